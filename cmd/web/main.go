@@ -1,11 +1,24 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	// the time zones of the reports don't depend on the zone database of the host
+	_ "time/tzdata"
 
 	"github.com/burakkarasel/Theatre-API/internal/api"
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/job"
+	"github.com/burakkarasel/Theatre-API/internal/notify"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/recommend"
 	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -13,6 +26,9 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// shutdownTimeout is how long the server waits for its requests and their background work when it is stopped
+const shutdownTimeout = time.Minute
+
 func main() {
 	// first i load the env variables
 	config, err := util.LoadConfig(".")
@@ -30,8 +46,13 @@ func main() {
 
 	log.Println("connected to DB")
 
-	// then i run my migrations
-	runDBMigration("file://internal/db/migration", config.DBSource)
+	// then i run my migrations, the existing sales are backfilled with the currency of the app
+	currency := config.Currency
+	if currency == "" {
+		currency = util.DefaultCurrency
+	}
+
+	runDBMigration("file://internal/db/migration", migrationSource(config.DBSource, currency))
 
 	// then i create a new store to create a new server
 	store := db.NewStore(conn)
@@ -47,11 +68,64 @@ func main() {
 
 	log.Println("created a new server instance")
 
-	err = server.Start(config.ServerAddress)
+	// then i start the background jobs, the notifications are only logged until a real notifier is wired
+	if config.WatchlistJobInterval > 0 {
+		watchlistJob := job.NewWatchlistJob(store, notify.NewLogNotifier(log.Default()))
+		go watchlistJob.Run(context.Background(), config.WatchlistJobInterval)
 
-	if err != nil {
-		log.Fatal("cannot start server:", err)
+		log.Println("started the watchlist job")
 	}
+
+	if config.RecommendationPrecompute {
+		recommendationJob := job.NewRecommendationJob(store, recommend.NewEngine(store))
+		go recommendationJob.Run(context.Background(), config.RecommendationPrecomputeAt)
+
+		log.Println("started the recommendation job")
+	}
+
+	if config.ChartsRefreshInterval > 0 {
+		chartsJob := job.NewChartsJob(store)
+		go chartsJob.Run(context.Background(), config.ChartsRefreshInterval)
+
+		log.Println("started the charts job")
+	}
+
+	// the renewals are only logged until a real payment provider is wired
+	if config.MembershipRenewalInterval > 0 {
+		membershipsJob := job.NewMembershipsJob(store, payment.NewLogGateway(log.Default()))
+		go membershipsJob.Run(context.Background(), config.MembershipRenewalInterval)
+
+		log.Println("started the memberships job")
+	}
+
+	if config.PrivateBookingRefundInterval > 0 {
+		privateBookingsJob := job.NewPrivateBookingsJob(store, payment.NewLogGateway(log.Default()))
+		go privateBookingsJob.Run(context.Background(), config.PrivateBookingRefundInterval)
+
+		log.Println("started the private bookings job")
+	}
+
+	go func() {
+		if err := server.Start(config.ServerAddress); err != nil {
+			log.Fatal("cannot start server:", err)
+		}
+	}()
+
+	// at last i wait for a stop signal, so the emails that are being sent aren't dropped on a restart
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("shutting down the server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Fatal("cannot shut down server:", err)
+	}
+
+	log.Println("server stopped")
 }
 
 // runDBMigration runs the migrations at the start of the program
@@ -67,3 +141,17 @@ func runDBMigration(migrationURL string, dbSource string) {
 
 	log.Println("db migrated succesfully")
 }
+
+// migrationSource sets the theatre.currency setting of the migration connection to the currency of the app
+func migrationSource(dbSource string, currency string) string {
+	u, err := url.Parse(dbSource)
+	if err != nil {
+		log.Fatal("cannot parse db source:", err)
+	}
+
+	q := u.Query()
+	q.Set("options", strings.TrimSpace(q.Get("options")+" -c theatre.currency="+currency))
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
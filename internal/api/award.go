@@ -0,0 +1,163 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// awardKindOther is the kind of the awards that aren't counted apart
+const awardKindOther = "other"
+
+var (
+	ErrAwardWithoutNominee = errors.New("award must be given to a person or a movie")
+	ErrNomineeNotFound     = errors.New("awarded person or movie not found")
+)
+
+// CreateAwardRequest holds the json data of the request, the kind of an award is other unless it is given.
+// Only the won awards of the oscar kind are counted as the oscars of the directors
+type CreateAwardRequest struct {
+	Name     string `json:"name" binding:"required,min=3"`
+	Kind     string `json:"kind" binding:"omitempty,oneof=oscar golden_globe bafta other"`
+	Year     int32  `json:"year" binding:"required,min=1900,max=2100"`
+	Category string `json:"category" binding:"required,min=3"`
+	Winner   bool   `json:"winner"`
+	PersonID int64  `json:"person_id" binding:"omitempty,min=1"`
+	MovieID  int64  `json:"movie_id" binding:"omitempty,min=1"`
+}
+
+// createAward records a nomination or a win, oscars of the directors are refreshed with it
+func (server *Server) createAward(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreateAwardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.PersonID == 0 && req.MovieID == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrAwardWithoutNominee))
+		return
+	}
+
+	if req.Kind == "" {
+		req.Kind = awardKindOther
+	}
+
+	arg := db.CreateAwardParams{
+		Name:     req.Name,
+		Kind:     req.Kind,
+		Year:     sql.NullInt32{Int32: req.Year, Valid: true},
+		Category: req.Category,
+		Winner:   req.Winner,
+		PersonID: sql.NullInt64{Int64: req.PersonID, Valid: req.PersonID != 0},
+		MovieID:  sql.NullInt64{Int64: req.MovieID, Valid: req.MovieID != 0},
+	}
+
+	award, err := server.store.CreateAwardTx(ctx, arg)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code.Name() == "foreign_key_violation" {
+				ctx.JSON(http.StatusNotFound, errorResponse(ErrNomineeNotFound))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, award)
+}
+
+// ListAwardsRequest holds query values of the request
+type ListAwardsRequest struct {
+	Year     int32  `form:"year" binding:"required,min=1900,max=2100"`
+	Cursor   string `form:"cursor" binding:"omitempty,max=256"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// awardsCursor is the kind of the cursors of the awards list
+const awardsCursor = "awards"
+
+// listAwards returns a page of the awards of the given year that starts after the given cursor
+func (server *Server) listAwards(ctx *gin.Context) {
+	// first i check for bindings
+	var req ListAwardsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	afterID, err := server.decodeCursor(awardsCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// one more award tells if there is a next page
+	arg := db.ListAwardsByYearParams{
+		Year:    sql.NullInt32{Int32: req.Year, Valid: true},
+		AfterID: afterID,
+		Limit:   req.PageSize + 1,
+	}
+
+	awards, err := server.store.ListAwardsByYear(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// awards are ordered by their names and categories, the page starts after the position of the last award
+	awards, next := trimCursorPage(server, awardsCursor, awards, req.PageSize, func(a db.Award) int64 { return a.ID })
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, awards, next))
+}
+
+// GetAwardRequest holds the uri data of the request
+type GetAwardRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// deleteAward deletes an award, oscars of the directors are refreshed with it
+func (server *Server) deleteAward(ctx *gin.Context) {
+	// first i check for the bindings
+	var req GetAwardRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.DeleteAwardTx(ctx, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateAwardAPI tests createAward handler
+func TestCreateAwardAPI(t *testing.T) {
+	award := randomAward()
+	staff := randomStaff(t)
+
+	body := gin.H{
+		"name":      award.Name,
+		"kind":      award.Kind,
+		"year":      award.Year.Int32,
+		"category":  award.Category,
+		"winner":    award.Winner,
+		"person_id": award.PersonID.Int64,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAwardParams{
+					Name:     award.Name,
+					Kind:     award.Kind,
+					Year:     award.Year,
+					Category: award.Category,
+					Winner:   award.Winner,
+					PersonID: award.PersonID,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAwardTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(award, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got db.Award
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, award, got)
+			},
+		},
+		{
+			name: "Other Kind By Default",
+			body: gin.H{
+				"name":      "Sundance Film Festival",
+				"year":      award.Year.Int32,
+				"category":  award.Category,
+				"person_id": award.PersonID.Int64,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAwardParams{
+					Name:     "Sundance Film Festival",
+					Kind:     awardKindOther,
+					Year:     award.Year,
+					Category: award.Category,
+					PersonID: award.PersonID,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAwardTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(award, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Kind",
+			body: gin.H{
+				"name":      award.Name,
+				"kind":      "academy",
+				"year":      award.Year.Int32,
+				"category":  award.Category,
+				"person_id": award.PersonID.Int64,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAwardTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "No Nominee",
+			body: gin.H{
+				"name":     award.Name,
+				"year":     award.Year.Int32,
+				"category": award.Category,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAwardTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Invalid Year",
+			body: gin.H{
+				"name":      award.Name,
+				"year":      1200,
+				"category":  award.Category,
+				"person_id": award.PersonID.Int64,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAwardTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Nominee Not Found",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAwardTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Award{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAwardTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Award{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/awards", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListAwardsAPI tests listAwards handler
+func TestListAwardsAPI(t *testing.T) {
+	var awards []db.Award
+	for i := 0; i < 6; i++ {
+		a := randomAward()
+		a.Year = sql.NullInt32{Int32: 2010, Valid: true}
+		awards = append(awards, a)
+	}
+
+	testCases := []struct {
+		name  string
+		query string
+		// cursor is encoded by the test server when it is not zero
+		cursor        int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?year=2010&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAwardsByYearParams{
+					Year:  sql.NullInt32{Int32: 2010, Valid: true},
+					Limit: 6,
+				}
+				store.EXPECT().ListAwardsByYear(gomock.Any(), gomock.Eq(arg)).Times(1).Return(awards, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				// the extra award is left for the next page
+				var got ListResponse[db.Award]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, awards[:5], got.Items)
+				require.NotEmpty(t, got.NextCursor)
+				require.Contains(t, got.Next, "cursor=")
+				require.Contains(t, got.Next, "year=2010")
+			},
+		},
+		{
+			name:   "Last Page",
+			query:  "?year=2010&page_size=5",
+			cursor: awards[4].ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAwardsByYearParams{
+					Year:    sql.NullInt32{Int32: 2010, Valid: true},
+					AfterID: awards[4].ID,
+					Limit:   6,
+				}
+				store.EXPECT().ListAwardsByYear(gomock.Any(), gomock.Eq(arg)).Times(1).Return(awards[5:], nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[db.Award]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 1)
+				require.Empty(t, got.Next)
+			},
+		},
+		{
+			name:  "Forged Cursor",
+			query: "?year=2010&page_size=5&cursor=eyJrIjoiYXdhcmRzIiwiaWQiOjF9.c2lnbmF0dXJl",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAwardsByYear(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "No Year",
+			query: "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAwardsByYear(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Internal Server Error",
+			query: "?year=2010&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAwardsByYear(gomock.Any(), gomock.Any()).Times(1).Return([]db.Award{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/awards%s", tt.query)
+			if tt.cursor != 0 {
+				url += "&cursor=" + server.encodeCursor(awardsCursor, tt.cursor)
+			}
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestDeleteAwardAPI tests deleteAward handler
+func TestDeleteAwardAPI(t *testing.T) {
+	award := randomAward()
+	staff := randomStaff(t)
+
+	testCases := []struct {
+		name          string
+		awardID       int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			awardID: award.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteAwardTx(gomock.Any(), gomock.Eq(award.ID)).Times(1).Return(award, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:    "Award Not Found",
+			awardID: award.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteAwardTx(gomock.Any(), gomock.Eq(award.ID)).Times(1).Return(db.Award{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/awards/%d", tt.awardID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// randomAward creates a random won award of a random person
+func randomAward() db.Award {
+	return db.Award{
+		ID:       util.RandomInt(1, 1000),
+		Name:     "Academy Awards",
+		Kind:     "oscar",
+		Year:     sql.NullInt32{Int32: int32(util.RandomInt(1950, 2020)), Valid: true},
+		Category: util.RandomName(),
+		Winner:   true,
+		PersonID: sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+	}
+}
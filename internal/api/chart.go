@@ -0,0 +1,168 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultChartSize is the count of the movies in a chart if the limit isn't given
+const defaultChartSize = 10
+
+// defaultChartWindow is the window of the box-office chart if it isn't given
+const defaultChartWindow = "7d"
+
+// chartWindowDays holds the count of the days of the box-office windows
+var chartWindowDays = map[string]int{
+	"1d":  1,
+	"7d":  7,
+	"30d": 30,
+}
+
+// BoxOfficeRequest holds query values of the request
+type BoxOfficeRequest struct {
+	Window string `form:"window" binding:"omitempty,oneof=1d 7d 30d"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// TrendingRequest holds query values of the request
+type TrendingRequest struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// ChartEntryResponse holds a ranked movie with its sales in the window and in the window before it
+type ChartEntryResponse struct {
+	Rank            int        `json:"rank"`
+	Movie           db.Movie   `json:"movie"`
+	Tickets         int64      `json:"tickets"`
+	Revenue         util.Money `json:"revenue"`
+	PreviousTickets int64      `json:"previous_tickets"`
+	PreviousRevenue util.Money `json:"previous_revenue"`
+	TicketsDelta    int64      `json:"tickets_delta"`
+	RevenueDelta    util.Money `json:"revenue_delta"`
+}
+
+// getBoxOffice ranks the movies by their revenue in the window and compares them to the window before it,
+// the windows are whole UTC days and the current one includes today
+func (server *Server) getBoxOffice(ctx *gin.Context) {
+	// first i check for the bindings
+	var req BoxOfficeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Window == "" {
+		req.Window = defaultChartWindow
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultChartSize
+	}
+
+	currentFrom, previousFrom := chartWindow(time.Now(), chartWindowDays[req.Window])
+
+	arg := db.ListBoxOfficeParams{
+		CurrentFrom:  currentFrom,
+		PreviousFrom: previousFrom,
+		Limit:        req.Limit,
+	}
+
+	rows, err := server.store.ListBoxOffice(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result := make([]ChartEntryResponse, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, server.newChartEntry(len(result)+1, r.MovieID, r.Tickets, r.Revenue, r.PreviousTickets, r.PreviousRevenue))
+	}
+
+	server.writeChart(ctx, result)
+}
+
+// getTrending ranks the movies by the growth of their ticket sales in the last 7 days over the 7 days before them
+func (server *Server) getTrending(ctx *gin.Context) {
+	// first i check for the bindings
+	var req TrendingRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultChartSize
+	}
+
+	rows, err := server.store.ListTrendingMovies(ctx, req.Limit)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result := make([]ChartEntryResponse, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, server.newChartEntry(len(result)+1, r.MovieID, r.Tickets, r.Revenue, r.PreviousTickets, r.PreviousRevenue))
+	}
+
+	server.writeChart(ctx, result)
+}
+
+// writeChart loads the movies of the entries and writes the chart
+func (server *Server) writeChart(ctx *gin.Context, result []ChartEntryResponse) {
+	movies, err := server.loadMovies(ctx, collectIDs(result, func(e ChartEntryResponse) int64 { return e.Movie.ID }))
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	for i := range result {
+		m, ok := movies[result[i].Movie.ID]
+
+		if !ok {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ErrMissingMovie))
+			return
+		}
+
+		result[i].Movie = m
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, ""))
+}
+
+// newChartEntry creates a chart entry with the deltas of the sales
+// the revenues are in the currency of the app
+func (server *Server) newChartEntry(rank int, movieID, tickets, revenue, previousTickets, previousRevenue int64) ChartEntryResponse {
+	return ChartEntryResponse{
+		Rank:            rank,
+		Movie:           db.Movie{ID: movieID},
+		Tickets:         tickets,
+		Revenue:         server.money(revenue),
+		PreviousTickets: previousTickets,
+		PreviousRevenue: server.money(previousRevenue),
+		TicketsDelta:    tickets - previousTickets,
+		RevenueDelta:    server.money(revenue - previousRevenue),
+	}
+}
+
+// chartWindow returns the first days of the current and the previous windows of given days,
+// the current window ends with the UTC day of now
+func chartWindow(now time.Time, days int) (time.Time, time.Time) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	currentFrom := today.AddDate(0, 0, 1-days)
+
+	return currentFrom, currentFrom.AddDate(0, 0, -days)
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetBoxOfficeAPI tests getBoxOffice handler
+func TestGetBoxOfficeAPI(t *testing.T) {
+	m1 := randomMovie().Movie
+	m2 := randomMovie().Movie
+	m2.ID = m1.ID + 1
+
+	rows := []db.ListBoxOfficeRow{
+		{MovieID: m2.ID, Tickets: 30, Revenue: 900, PreviousTickets: 10, PreviousRevenue: 300},
+		{MovieID: m1.ID, Tickets: 5, Revenue: 150, PreviousTickets: 20, PreviousRevenue: 600},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBoxOffice(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListBoxOfficeParams) ([]db.ListBoxOfficeRow, error) {
+						require.Equal(t, int32(defaultChartSize), arg.Limit)
+						require.Equal(t, 7*24*time.Hour, arg.CurrentFrom.Sub(arg.PreviousFrom))
+						return rows, nil
+					})
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq([]int64{m2.ID, m1.ID})).Times(1).Return([]db.Movie{m1, m2}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				got := requireBodyChart(t, w)
+				require.Len(t, got, 2)
+				require.Equal(t, 1, got[0].Rank)
+				require.Equal(t, m2, got[0].Movie)
+				require.Equal(t, int64(20), got[0].TicketsDelta)
+				require.Equal(t, util.NewMoney(600, util.DefaultCurrency), got[0].RevenueDelta)
+				require.Equal(t, 2, got[1].Rank)
+				require.Equal(t, m1, got[1].Movie)
+				require.Equal(t, int64(-15), got[1].TicketsDelta)
+				require.Equal(t, util.NewMoney(-450, util.DefaultCurrency), got[1].RevenueDelta)
+			},
+		},
+		{
+			name:  "Window",
+			query: "?window=30d&limit=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBoxOffice(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListBoxOfficeParams) ([]db.ListBoxOfficeRow, error) {
+						require.Equal(t, int32(5), arg.Limit)
+						require.Equal(t, 30*24*time.Hour, arg.CurrentFrom.Sub(arg.PreviousFrom))
+						return []db.ListBoxOfficeRow{}, nil
+					})
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Empty(t, requireBodyChart(t, w))
+			},
+		},
+		{
+			name:  "Invalid Window",
+			query: "?window=2w",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBoxOffice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Missing Movie",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBoxOffice(gomock.Any(), gomock.Any()).Times(1).Return(rows, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{m1}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBoxOffice(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListBoxOfficeRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/charts/box-office"+tt.query, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestGetTrendingAPI tests getTrending handler
+func TestGetTrendingAPI(t *testing.T) {
+	m := randomMovie().Movie
+
+	rows := []db.MovieTrending{
+		{MovieID: m.ID, Tickets: 12, Revenue: 360, PreviousTickets: 2, PreviousRevenue: 60, RefreshedAt: time.Now()},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTrendingMovies(gomock.Any(), gomock.Eq(int32(defaultChartSize))).Times(1).Return(rows, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq([]int64{m.ID})).Times(1).Return([]db.Movie{m}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				got := requireBodyChart(t, w)
+				require.Len(t, got, 1)
+				require.Equal(t, m, got[0].Movie)
+				require.Equal(t, int64(10), got[0].TicketsDelta)
+				require.Equal(t, util.NewMoney(300, util.DefaultCurrency), got[0].RevenueDelta)
+			},
+		},
+		{
+			name:  "Invalid Limit",
+			query: "?limit=100",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTrendingMovies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTrendingMovies(gomock.Any(), gomock.Any()).Times(1).Return([]db.MovieTrending{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/charts/trending"+tt.query, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestChartWindow tests chartWindow
+func TestChartWindow(t *testing.T) {
+	now := time.Date(2030, 3, 1, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+
+	currentFrom, previousFrom := chartWindow(now, 1)
+	require.Equal(t, time.Date(2030, 3, 2, 0, 0, 0, 0, time.UTC), currentFrom)
+	require.Equal(t, time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC), previousFrom)
+
+	currentFrom, previousFrom = chartWindow(now, 7)
+	require.Equal(t, time.Date(2030, 2, 24, 0, 0, 0, 0, time.UTC), currentFrom)
+	require.Equal(t, time.Date(2030, 2, 17, 0, 0, 0, 0, time.UTC), previousFrom)
+}
+
+// requireBodyChart reads the chart entries of the response
+func requireBodyChart(t *testing.T, w *httptest.ResponseRecorder) []ChartEntryResponse {
+	data, err := ioutil.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	var got ListResponse[ChartEntryResponse]
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+
+	return got.Items
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+// ConcessionLineRequest holds a single line of a concession order
+type ConcessionLineRequest struct {
+	ItemID   int64 `json:"item_id" binding:"required,min=1"`
+	Quantity int32 `json:"quantity" binding:"required,min=1,max=20"`
+}
+
+// CreateConcessionItemRequest holds the json data of the request
+type CreateConcessionItemRequest struct {
+	Name     string     `json:"name" binding:"required,min=3"`
+	Category string     `json:"category" binding:"required,oneof=popcorn drink snack combo"`
+	Price    util.Money `json:"price"`
+	Stock    int32      `json:"stock" binding:"min=0"`
+}
+
+// createConcessionItem adds a new item to the concessions catalog
+func (server *Server) createConcessionItem(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreateConcessionItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the catalog is priced in the currency of the app
+	if !server.requireMoney(ctx, req.Price) {
+		return
+	}
+
+	if req.Price.IsZero() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrZeroAmount))
+		return
+	}
+
+	arg := db.CreateConcessionItemParams{
+		Name:     req.Name,
+		Category: req.Category,
+		Price:    req.Price.Amount,
+		Stock:    req.Stock,
+	}
+
+	item, err := server.store.CreateConcessionItem(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, server.newConcessionItemResponse(item))
+}
+
+// listConcessionItems returns the whole concessions catalog
+func (server *Server) listConcessionItems(ctx *gin.Context) {
+	items, err := server.store.ListConcessionItems(ctx)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	result := make([]ConcessionItemResponse, 0, len(items))
+	for _, item := range items {
+		result = append(result, server.newConcessionItemResponse(item))
+	}
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, ""))
+}
+
+// ConcessionItemURIRequest holds the uri data of the concession item requests
+type ConcessionItemURIRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// AddConcessionStockRequest holds the json data of the request
+type AddConcessionStockRequest struct {
+	Amount int32 `json:"amount" binding:"required,min=1"`
+}
+
+// addConcessionStock restocks a concession item
+func (server *Server) addConcessionStock(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri ConcessionItemURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req AddConcessionStockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.AddConcessionStockParams{
+		ID:     uri.ID,
+		Amount: req.Amount,
+	}
+
+	item, err := server.store.AddConcessionStock(ctx, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, server.newConcessionItemResponse(item))
+}
+
+// OrderConcessionsRequest holds the json data of the request
+type OrderConcessionsRequest struct {
+	Concessions []ConcessionLineRequest `json:"concessions" binding:"required,min=1,dive"`
+}
+
+// orderConcessions orders concessions separately for pickup at the screening of the given ticket
+func (server *Server) orderConcessions(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetTicketRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req OrderConcessionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// then i get the ticket that the order will be picked up with
+	t, err := server.store.GetTicket(ctx, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// if ticket owner and authenticated user doesnt match i return 401 and the error
+	if t.TicketOwner != authPayload.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorizedAction))
+		return
+	}
+
+	arg := db.OrderConcessionsTxParams{
+		TicketID:    t.ID,
+		OrderOwner:  t.TicketOwner,
+		Concessions: newConcessionLines(req.Concessions),
+	}
+
+	result, err := server.store.OrderConcessionsTx(ctx, arg)
+
+	if err != nil {
+		writeConcessionError(ctx, err)
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newConcessionOrderResponse(result, t.Currency))
+}
+
+// newConcessionLines converts the request lines into DB lines
+func newConcessionLines(lines []ConcessionLineRequest) []db.ConcessionLine {
+	result := make([]db.ConcessionLine, 0, len(lines))
+	for _, l := range lines {
+		result = append(result, db.ConcessionLine{ItemID: l.ItemID, Quantity: l.Quantity})
+	}
+	return result
+}
+
+// writeConcessionError writes the response for the errors of concession transactions, the ticket purchase is one of them
+func writeConcessionError(ctx *gin.Context, err error) {
+	switch err {
+	case sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case db.ErrOutOfStock, db.ErrScreeningBooked, db.ErrScreeningFull:
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
@@ -0,0 +1,347 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateConcessionItemAPI tests createConcessionItem handler
+func TestCreateConcessionItemAPI(t *testing.T) {
+	staff := randomStaff(t)
+	item := randomConcessionItem()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name":     item.Name,
+				"category": item.Category,
+				"price":    util.NewMoney(item.Price, util.DefaultCurrency),
+				"stock":    item.Stock,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateConcessionItemParams{
+					Name:     item.Name,
+					Category: item.Category,
+					Price:    item.Price,
+					Stock:    item.Stock,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateConcessionItem(gomock.Any(), gomock.Eq(arg)).Times(1).Return(item, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Category",
+			body: gin.H{
+				"name":     item.Name,
+				"category": "pizza",
+				"price":    util.NewMoney(item.Price, util.DefaultCurrency),
+				"stock":    item.Stock,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateConcessionItem(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Currency Mismatch",
+			body: gin.H{
+				"name":     item.Name,
+				"category": item.Category,
+				"price":    util.NewMoney(item.Price, "EUR"),
+				"stock":    item.Stock,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateConcessionItem(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			body: gin.H{
+				"name":     item.Name,
+				"category": item.Category,
+				"price":    util.NewMoney(item.Price, util.DefaultCurrency),
+				"stock":    item.Stock,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateConcessionItem(gomock.Any(), gomock.Any()).Times(1).Return(db.ConcessionItem{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/concessions", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListConcessionItemsAPI tests listConcessionItems handler
+func TestListConcessionItemsAPI(t *testing.T) {
+	items := []db.ConcessionItem{randomConcessionItem(), randomConcessionItem()}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListConcessionItems(gomock.Any()).Times(1).Return(items, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[ConcessionItemResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, len(items))
+				for i, item := range items {
+					require.Equal(t, item.ID, got.Items[i].ID)
+					require.Equal(t, util.NewMoney(item.Price, util.DefaultCurrency), got.Items[i].Price)
+				}
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListConcessionItems(gomock.Any()).Times(1).Return([]db.ConcessionItem{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/concessions", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestOrderConcessionsAPI tests orderConcessions handler
+func TestOrderConcessionsAPI(t *testing.T) {
+	ticket, _ := randomTicket(t)
+	order := randomConcessionOrder(ticket)
+	line := order.Items[0]
+
+	body := gin.H{
+		"concessions": []gin.H{{"item_id": line.ItemID, "quantity": line.Quantity}},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: ticket.TicketOwner,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.OrderConcessionsTxParams{
+					TicketID:    ticket.ID,
+					OrderOwner:  ticket.TicketOwner,
+					Concessions: []db.ConcessionLine{{ItemID: line.ItemID, Quantity: line.Quantity}},
+				}
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
+				store.EXPECT().OrderConcessionsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(order, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ConcessionOrderResponse
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, *newConcessionOrderResponse(order, ticket.Currency), got)
+			},
+		},
+		{
+			name:     "Empty Order",
+			username: ticket.TicketOwner,
+			body:     gin.H{"concessions": []gin.H{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().OrderConcessionsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Ticket Belongs To Other User",
+			username: util.RandomName(),
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
+				store.EXPECT().OrderConcessionsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, w.Code)
+			},
+		},
+		{
+			name:     "Item Not Found",
+			username: ticket.TicketOwner,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
+				store.EXPECT().OrderConcessionsTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ConcessionOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Out Of Stock",
+			username: ticket.TicketOwner,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
+				store.EXPECT().OrderConcessionsTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ConcessionOrderTxResult{}, db.ErrOutOfStock)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/tickets/%d/concessions", ticket.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// randomConcessionItem creates a random concession item
+func randomConcessionItem() db.ConcessionItem {
+	return db.ConcessionItem{
+		ID:       util.RandomInt(1, 1000),
+		Name:     util.RandomName(),
+		Category: "popcorn",
+		Price:    util.RandomInt(5, 20),
+		Stock:    int32(util.RandomInt(10, 100)),
+	}
+}
+
+// randomConcessionOrder creates a random concession order with a single item for given ticket
+func randomConcessionOrder(ticket db.Ticket) db.ConcessionOrderTxResult {
+	item := randomConcessionItem()
+	quantity := int32(util.RandomInt(1, 3))
+	order := db.ConcessionOrder{
+		ID:         util.RandomInt(1, 1000),
+		TicketID:   ticket.ID,
+		OrderOwner: ticket.TicketOwner,
+		Total:      item.Price * int64(quantity),
+	}
+
+	return db.ConcessionOrderTxResult{
+		Order: order,
+		Items: []db.ConcessionOrderItem{
+			{
+				ID:        util.RandomInt(1, 1000),
+				OrderID:   order.ID,
+				ItemID:    item.ID,
+				Name:      item.Name,
+				Quantity:  quantity,
+				UnitPrice: item.Price,
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrPersonNotFound  = errors.New("person not found")
+	ErrDuplicateCredit = errors.New("person is already credited with this role")
+)
+
+// AddMovieCreditRequest holds the json data of the request
+type AddMovieCreditRequest struct {
+	PersonID     int64  `json:"person_id" binding:"required,min=1"`
+	Role         string `json:"role" binding:"required,oneof=director actor writer composer"`
+	BillingOrder int32  `json:"billing_order" binding:"min=0"`
+}
+
+// addMovieCredit credits a person in a movie with the given role
+func (server *Server) addMovieCredit(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetMovieRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req AddMovieCreditRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// then i make sure the movie is still served
+	m, err := server.store.GetMovie(ctx, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if m.DeletedAt.Valid {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrMovieDeleted))
+		return
+	}
+
+	arg := db.CreateMovieCreditParams{
+		MovieID:      m.ID,
+		PersonID:     req.PersonID,
+		Role:         req.Role,
+		BillingOrder: req.BillingOrder,
+	}
+
+	credit, err := server.store.CreateMovieCredit(ctx, arg)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation":
+				ctx.JSON(http.StatusNotFound, errorResponse(ErrPersonNotFound))
+				return
+			case "unique_violation":
+				ctx.JSON(http.StatusConflict, errorResponse(ErrDuplicateCredit))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, credit)
+}
+
+// DeleteMovieCreditRequest holds the uri data of the request
+type DeleteMovieCreditRequest struct {
+	ID       int64 `uri:"id" binding:"required,min=1"`
+	CreditID int64 `uri:"credit_id" binding:"required,min=1"`
+}
+
+// deleteMovieCredit removes a credit from a movie
+func (server *Server) deleteMovieCredit(ctx *gin.Context) {
+	// first i check for the bindings
+	var req DeleteMovieCreditRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteMovieCreditParams{
+		ID:      req.CreditID,
+		MovieID: req.ID,
+	}
+
+	_, err := server.store.DeleteMovieCredit(ctx, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAddMovieCreditAPI tests addMovieCredit handler
+func TestAddMovieCreditAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	person := randomPerson()
+	staff := randomStaff(t)
+	credit := db.MovieCredit{
+		ID:           util.RandomInt(1, 1000),
+		MovieID:      movie.ID,
+		PersonID:     person.ID,
+		Role:         "actor",
+		BillingOrder: 1,
+	}
+
+	body := gin.H{
+		"person_id":     person.ID,
+		"role":          "actor",
+		"billing_order": 1,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateMovieCreditParams{
+					MovieID:      movie.ID,
+					PersonID:     person.ID,
+					Role:         "actor",
+					BillingOrder: 1,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().CreateMovieCredit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(credit, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Role",
+			body: gin.H{
+				"person_id": person.ID,
+				"role":      "stunt",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMovieCredit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Movie Deleted",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				deleted := movie
+				deleted.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(deleted, nil)
+				store.EXPECT().CreateMovieCredit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Person Not Found",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().CreateMovieCredit(gomock.Any(), gomock.Any()).Times(1).Return(db.MovieCredit{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Duplicate Credit",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().CreateMovieCredit(gomock.Any(), gomock.Any()).Times(1).Return(db.MovieCredit{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/movies/%d/credits", movie.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestDeleteMovieCreditAPI tests deleteMovieCredit handler
+func TestDeleteMovieCreditAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	creditID := util.RandomInt(1, 1000)
+	staff := randomStaff(t)
+
+	testCases := []struct {
+		name          string
+		creditID      int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			creditID: creditID,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DeleteMovieCreditParams{ID: creditID, MovieID: movie.ID}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteMovieCredit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.MovieCredit{ID: creditID, MovieID: movie.ID}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Bindings Error",
+			creditID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteMovieCredit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Credit Not Found",
+			creditID: creditID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteMovieCredit(gomock.Any(), gomock.Any()).Times(1).Return(db.MovieCredit{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/movies/%d/credits/%d", movie.ID, tt.creditID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
@@ -2,17 +2,20 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 )
 
-// CreateDirectorRequest holds createDirector request's json data
+var ErrDirectorHasMovies = errors.New("director still has movies")
+
+// CreateDirectorRequest holds createDirector request's json data, oscars are counted from the awards
 type CreateDirectorRequest struct {
 	FirstName string `json:"first_name" binding:"required,min=3"`
 	LastName  string `json:"last_name" binding:"required,min=3"`
-	Oscars    int64  `json:"oscars" binding:"required,min=0"`
 }
 
 // createDirector creates a new director in DB
@@ -25,14 +28,13 @@ func (server *Server) createDirector(ctx *gin.Context) {
 	}
 
 	// second i create args for database operation
-	arg := db.CreateDirectorParams{
+	arg := db.CreateDirectorTxParams{
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
-		Oscars:    req.Oscars,
 	}
 
-	// third i make the db operation
-	d, err := server.store.CreateDirector(ctx, arg)
+	// third i make the db operation, the director is created with its person
+	d, err := server.store.CreateDirectorTx(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -84,25 +86,28 @@ func (server *Server) getDirector(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, d)
 }
 
-// ListDirectorsRequest holds query values of the request
-type ListDirectorsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
-}
+// directorsCursor is the kind of the cursors of the directors list
+const directorsCursor = "directors"
 
-// listDirectors returns with given size and page id of directors
+// listDirectors returns a page of directors that starts after the given cursor
 func (server *Server) listDirectors(ctx *gin.Context) {
 	// first i check for bindings
-	var req ListDirectorsRequest
+	var req CursorPageRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	// second i create the params for DB operation
+	afterID, err := server.decodeCursor(directorsCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// second i create the params for DB operation, one more director tells if there is a next page
 	arg := db.ListDirectorsParams{
-		Offset: (req.PageID - 1) * req.PageSize,
-		Limit:  req.PageSize,
+		AfterID: afterID,
+		Limit:   req.PageSize + 1,
 	}
 
 	// third i get the directors from DB
@@ -113,11 +118,162 @@ func (server *Server) listDirectors(ctx *gin.Context) {
 		return
 	}
 
+	directors, next := trimCursorPage(server, directorsCursor, directors, req.PageSize, func(d db.Director) int64 { return d.ID })
+
 	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
 	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
 
 	// if no error occurs i return status ok and the directors i got from the DB
-	ctx.JSON(http.StatusOK, directors)
+	ctx.JSON(http.StatusOK, newListResponse(ctx, directors, next))
+}
+
+// UpdateDirectorRequest holds the json data of the request, only the given fields are updated
+type UpdateDirectorRequest struct {
+	FirstName *string `json:"first_name" binding:"omitempty,min=3"`
+	LastName  *string `json:"last_name" binding:"omitempty,min=3"`
+}
+
+// updateDirector updates the given fields of a director
+func (server *Server) updateDirector(ctx *gin.Context) {
+	// first i check the bindings
+	var uri GetDirectorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req UpdateDirectorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.FirstName == nil && req.LastName == nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrEmptyUpdate))
+		return
+	}
+
+	// then i create the params, null fields keep their current values
+	arg := db.UpdateDirectorParams{ID: uri.ID}
+
+	if req.FirstName != nil {
+		arg.FirstName = sql.NullString{String: *req.FirstName, Valid: true}
+	}
+	if req.LastName != nil {
+		arg.LastName = sql.NullString{String: *req.LastName, Valid: true}
+	}
+
+	d, err := server.store.UpdateDirectorTx(ctx, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, d)
+}
+
+// deleteDirector deletes a director, directors with movies can't be deleted
+func (server *Server) deleteDirector(ctx *gin.Context) {
+	// first i check the bindings
+	var req GetDirectorRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.DeleteDirector(ctx, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		// movies reference their director, even the deleted ones since their tickets are kept
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code.Name() == "foreign_key_violation" {
+				ctx.JSON(http.StatusConflict, errorResponse(ErrDirectorHasMovies))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+// directorMoviesCursor is the kind of the cursors of the movies list of a director
+const directorMoviesCursor = "director_movies"
+
+// listDirectorMovies returns a page of the movies of a director, newest first, that starts after the given cursor
+func (server *Server) listDirectorMovies(ctx *gin.Context) {
+	// first i check for bindings
+	var uri GetDirectorRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req CursorPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	beforeID, err := server.decodeCursor(directorMoviesCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// then i make sure the director exists, so an unknown director isn't an empty page
+	_, err = server.store.GetDirector(ctx, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ListMoviesByDirectorParams{
+		DirectorID: uri.ID,
+		BeforeID:   sql.NullInt64{Int64: beforeID, Valid: beforeID != 0},
+		Limit:      req.PageSize + 1,
+	}
+
+	movies, err := server.store.ListMoviesByDirector(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	movies, next := trimCursorPage(server, directorMoviesCursor, movies, req.PageSize, func(m db.Movie) int64 { return m.ID })
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, movies, next))
 }
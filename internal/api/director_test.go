@@ -9,12 +9,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
 	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -106,15 +108,13 @@ func TestCreateDirectorAPI(t *testing.T) {
 			body: gin.H{
 				"first_name": director.FirstName,
 				"last_name":  director.LastName,
-				"oscars":     director.Oscars,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateDirectorParams{
+				arg := db.CreateDirectorTxParams{
 					FirstName: director.FirstName,
 					LastName:  director.LastName,
-					Oscars:    director.Oscars,
 				}
-				store.EXPECT().CreateDirector(gomock.Any(), gomock.Eq(arg)).Times(1).Return(director, nil)
+				store.EXPECT().CreateDirectorTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(director, nil)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, w.Code)
@@ -126,10 +126,9 @@ func TestCreateDirectorAPI(t *testing.T) {
 			body: gin.H{
 				"first_name": "a",
 				"last_name":  director.LastName,
-				"oscars":     director.Oscars,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateDirector(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateDirectorTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -140,38 +139,9 @@ func TestCreateDirectorAPI(t *testing.T) {
 			body: gin.H{
 				"first_name": director.FirstName,
 				"last_name":  "a",
-				"oscars":     director.Oscars,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateDirector(gomock.Any(), gomock.Any()).Times(0)
-			},
-			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, w.Code)
-			},
-		},
-		{
-			name: "Invalid Oscars",
-			body: gin.H{
-				"first_name": director.FirstName,
-				"last_name":  director.LastName,
-				"oscars":     -3,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateDirector(gomock.Any(), gomock.Any()).Times(0)
-			},
-			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, w.Code)
-			},
-		},
-		{
-			name: "Invalid Oscars",
-			body: gin.H{
-				"first_name": director.FirstName,
-				"last_name":  director.LastName,
-				"oscars":     -3,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateDirector(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateDirectorTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -182,15 +152,13 @@ func TestCreateDirectorAPI(t *testing.T) {
 			body: gin.H{
 				"first_name": director.FirstName,
 				"last_name":  director.LastName,
-				"oscars":     director.Oscars,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateDirectorParams{
+				arg := db.CreateDirectorTxParams{
 					FirstName: director.FirstName,
 					LastName:  director.LastName,
-					Oscars:    director.Oscars,
 				}
-				store.EXPECT().CreateDirector(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Director{}, sql.ErrConnDone)
+				store.EXPECT().CreateDirectorTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Director{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, w.Code)
@@ -224,79 +192,432 @@ func TestCreateDirectorAPI(t *testing.T) {
 // TestListDirectorsAPI tests listDirectors handler
 func TestListDirectorsAPI(t *testing.T) {
 	var directors []db.Director
-	for i := 0; i < 5; i++ {
+	for i := 0; i < 6; i++ {
 		directors = append(directors, randomDirector())
 	}
 
 	testCases := []struct {
-		name          string
+		name string
+		// cursor is encoded by the test server when it is not zero
+		cursor        int64
 		query         string
 		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+		checkResponse func(t *testing.T, server *Server, w *httptest.ResponseRecorder)
 	}{
 		{
 			name:  "OK",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListDirectorsParams{
-					Offset: 0,
-					Limit:  5,
+					AfterID: 0,
+					Limit:   6,
 				}
 				store.EXPECT().ListDirectors(gomock.Any(), gomock.Eq(arg)).Times(1).Return(directors, nil)
 			},
-			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+			checkResponse: func(t *testing.T, server *Server, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[db.Director]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 5)
+
+				// the next page starts after the last director of this page
+				afterID, err := server.decodeCursor(directorsCursor, got.NextCursor)
+				require.NoError(t, err)
+				require.Equal(t, directors[4].ID, afterID)
+				require.Contains(t, got.Next, "cursor=")
+			},
+		},
+		{
+			name:   "Next Page",
+			cursor: directors[4].ID,
+			query:  "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListDirectorsParams{
+					AfterID: directors[4].ID,
+					Limit:   6,
+				}
+				store.EXPECT().ListDirectors(gomock.Any(), gomock.Eq(arg)).Times(1).Return(directors[5:], nil)
+			},
+			checkResponse: func(t *testing.T, server *Server, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[db.Director]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 1)
+				require.Empty(t, got.NextCursor)
+				require.Empty(t, got.Next)
 			},
 		},
 		{
-			name:  "No Page ID",
-			query: "?page_id=&page_size=5",
+			name:  "Forged Cursor",
+			query: "?page_size=5&cursor=eyJrIjoiZGlyZWN0b3JzIiwiaWQiOjF9.c2lnbmF0dXJl",
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListDirectors(gomock.Any(), gomock.Any()).Times(0)
 			},
-			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+			checkResponse: func(t *testing.T, server *Server, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
 			},
 		},
 		{
 			name:  "No Page Size",
-			query: "?page_id=1&page_size=",
+			query: "?page_size=",
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListDirectors(gomock.Any(), gomock.Any()).Times(0)
 			},
-			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+			checkResponse: func(t *testing.T, server *Server, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
 			},
 		},
 		{
-			name:  "Invalid Page ID",
-			query: "?page_id=-3&page_size=5",
+			name:  "Invalid Page Size",
+			query: "?page_size=51",
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListDirectors(gomock.Any(), gomock.Any()).Times(0)
 			},
+			checkResponse: func(t *testing.T, server *Server, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Internal Server Error",
+			query: "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListDirectorsParams{
+					AfterID: 0,
+					Limit:   6,
+				}
+				store.EXPECT().ListDirectors(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Director{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, server *Server, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/directors%s", tt.query)
+			if tt.cursor != 0 {
+				url += "&cursor=" + server.encodeCursor(directorsCursor, tt.cursor)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, server, w)
+		})
+	}
+}
+
+// TestUpdateDirectorAPI tests updateDirector handler
+func TestUpdateDirectorAPI(t *testing.T) {
+	director := randomDirector()
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	newName := util.RandomName()
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			body:     gin.H{"last_name": newName},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateDirectorParams{
+					ID:       director.ID,
+					LastName: sql.NullString{String: newName, Valid: true},
+				}
+				updated := director
+				updated.LastName = newName
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateDirectorTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				updated := director
+				updated.LastName = newName
+				requireBodyMatch(t, w.Body, updated)
+			},
+		},
+		{
+			name:     "Not Staff",
+			username: user.Username,
+			body:     gin.H{"last_name": newName},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateDirectorTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Empty Update",
+			username: staff.Username,
+			body:     gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateDirectorTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Oscars Are Derived",
+			username: staff.Username,
+			body:     gin.H{"oscars": 3},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateDirectorTx(gomock.Any(), gomock.Any()).Times(0)
+			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
 			},
 		},
+		{
+			name:     "Director Not Found",
+			username: staff.Username,
+			body:     gin.H{"last_name": newName},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateDirectorTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Director{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/directors/%d", director.ID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestDeleteDirectorAPI tests deleteDirector handler
+func TestDeleteDirectorAPI(t *testing.T) {
+	director := randomDirector()
+	staff := randomStaff(t)
+
+	testCases := []struct {
+		name          string
+		directorID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			directorID: director.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteDirector(gomock.Any(), gomock.Eq(director.ID)).Times(1).Return(director, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:       "Bindings Error",
+			directorID: -3,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteDirector(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:       "Director Not Found",
+			directorID: director.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteDirector(gomock.Any(), gomock.Eq(director.ID)).Times(1).Return(db.Director{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:       "Director Has Movies",
+			directorID: director.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteDirector(gomock.Any(), gomock.Eq(director.ID)).Times(1).Return(db.Director{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:       "Internal Server Error",
+			directorID: director.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteDirector(gomock.Any(), gomock.Eq(director.ID)).Times(1).Return(db.Director{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/directors/%d", tt.directorID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListDirectorMoviesAPI tests listDirectorMovies handler
+func TestListDirectorMoviesAPI(t *testing.T) {
+	director := randomDirector()
+	var movies []db.Movie
+	for i := 0; i < 5; i++ {
+		m := randomMovie().Movie
+		m.DirectorID = director.ID
+		movies = append(movies, m)
+	}
+
+	testCases := []struct {
+		name   string
+		cursor int64
+		// cursorKind is the kind of the movies list of a director if it is empty
+		cursorKind    string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			cursor: 1001,
+			query:  "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListMoviesByDirectorParams{
+					DirectorID: director.ID,
+					BeforeID:   sql.NullInt64{Int64: 1001, Valid: true},
+					Limit:      6,
+				}
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Eq(director.ID)).Times(1).Return(director, nil)
+				store.EXPECT().ListMoviesByDirector(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movies, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[db.Movie]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, movies, got.Items)
+				require.Empty(t, got.NextCursor)
+			},
+		},
 		{
 			name:  "Invalid Page Size",
-			query: "?page_id=-3&page_size=5",
+			query: "?page_size=51",
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListDirectors(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByDirector(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:       "Cursor Of Another List",
+			cursor:     director.ID,
+			cursorKind: directorsCursor,
+			query:      "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByDirector(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
 			},
 		},
+		{
+			name:  "Director Not Found",
+			query: "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Eq(director.ID)).Times(1).Return(db.Director{}, sql.ErrNoRows)
+				store.EXPECT().ListMoviesByDirector(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
 		{
 			name:  "Internal Server Error",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.ListDirectorsParams{
-					Offset: 0,
-					Limit:  5,
-				}
-				store.EXPECT().ListDirectors(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Director{}, sql.ErrConnDone)
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Eq(director.ID)).Times(1).Return(director, nil)
+				store.EXPECT().ListMoviesByDirector(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, w.Code)
@@ -315,7 +636,14 @@ func TestListDirectorsAPI(t *testing.T) {
 			server := newTestServer(t, store)
 			w := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/directors%s", tt.query)
+			url := fmt.Sprintf("/directors/%d/movies%s", director.ID, tt.query)
+			if tt.cursor != 0 {
+				kind := tt.cursorKind
+				if kind == "" {
+					kind = directorMoviesCursor
+				}
+				url += "&cursor=" + server.encodeCursor(kind, tt.cursor)
+			}
 
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
@@ -333,6 +661,7 @@ func randomDirector() db.Director {
 		FirstName: util.RandomName(),
 		LastName:  util.RandomName(),
 		Oscars:    util.RandomInt(1, 10),
+		PersonID:  util.RandomInt(1, 10000),
 	}
 }
 
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateGenreAPI tests createGenre handler
+func TestCreateGenreAPI(t *testing.T) {
+	genre := db.Genre{ID: util.RandomInt(1, 100), Name: util.RandomName()}
+	staff := randomStaff(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": genre.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateGenre(gomock.Any(), gomock.Eq(genre.Name)).Times(1).Return(genre, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Name",
+			body: gin.H{"name": "a"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Genre Exists",
+			body: gin.H{"name": genre.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateGenre(gomock.Any(), gomock.Eq(genre.Name)).Times(1).Return(db.Genre{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/genres", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListGenresAPI tests listGenres handler
+func TestListGenresAPI(t *testing.T) {
+	genres := []db.Genre{
+		{ID: 1, Name: "Drama"},
+		{ID: 2, Name: "Western"},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGenres(gomock.Any()).Times(1).Return(genres, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[db.Genre]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, genres, got.Items)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGenres(gomock.Any()).Times(1).Return([]db.Genre{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/genres", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
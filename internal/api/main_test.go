@@ -5,9 +5,11 @@ import (
 	"testing"
 	"time"
 
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
 	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,6 +20,11 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		AccessTokenDuration: time.Minute,
 	}
 
+	// the passwords of the users aren't changed unless the test expects it, so the tokens aren't revoked
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().GetPasswordChangedAt(gomock.Any(), gomock.Any()).AnyTimes().Return(time.Time{}, nil)
+	}
+
 	server, err := NewServer(config, store)
 	require.NoError(t, err)
 	require.NotEmpty(t, server)
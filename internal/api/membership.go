@@ -0,0 +1,646 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/membership"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrMembershipPlanMissing   = errors.New("membership plan doesn't exist")
+	ErrMembershipPlanExists    = errors.New("membership plan with the same name already exists")
+	ErrMembershipMissing       = errors.New("user has no membership")
+	ErrMembershipExists        = errors.New("user already has a membership")
+	ErrMembershipNotActive     = errors.New("membership is not active")
+	ErrMembershipNotPaused     = errors.New("membership is not paused")
+	ErrMembershipPlanUnchanged = errors.New("membership is already on the plan")
+	ErrPlanChangeConflict      = errors.New("more seats are used than the allowance of the plan for the period")
+)
+
+// the statuses of a membership, a past due membership couldn't be renewed and has no allowance until it is paid
+const (
+	membershipActive  = "active"
+	membershipPaused  = "paused"
+	membershipPastDue = "past_due"
+)
+
+// the kinds of the payments of a membership
+const (
+	membershipPaymentSubscription = "subscription"
+	membershipPaymentProration    = "proration"
+	membershipPaymentRefund       = "refund"
+)
+
+// MembershipPlanResponse holds a membership plan with its price for a month
+type MembershipPlanResponse struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Allowance int32      `json:"allowance"`
+	Price     util.Money `json:"price"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// newMembershipPlanResponse creates the response of a membership plan
+func newMembershipPlanResponse(plan db.MembershipPlan) MembershipPlanResponse {
+	return MembershipPlanResponse{
+		ID:        plan.ID,
+		Name:      plan.Name,
+		Allowance: plan.Allowance,
+		Price:     util.NewMoney(plan.Price, plan.Currency),
+		CreatedAt: plan.CreatedAt,
+	}
+}
+
+// MembershipDetails holds a membership with the seats left of the allowance of its period
+type MembershipDetails struct {
+	ID                int64        `json:"id"`
+	PlanID            int64        `json:"plan_id"`
+	Status            string       `json:"status"`
+	PeriodStart       time.Time    `json:"period_start"`
+	PeriodEnd         time.Time    `json:"period_end"`
+	Allowance         int32        `json:"allowance"`
+	Used              int32        `json:"used"`
+	Remaining         int32        `json:"remaining"`
+	CancelAtPeriodEnd bool         `json:"cancel_at_period_end"`
+	PausedAt          sql.NullTime `json:"paused_at"`
+	CancelledAt       sql.NullTime `json:"cancelled_at"`
+	CreatedAt         time.Time    `json:"created_at"`
+}
+
+// newMembershipDetails creates the details of a membership
+func newMembershipDetails(m db.Membership) MembershipDetails {
+	return MembershipDetails{
+		ID:                m.ID,
+		PlanID:            m.PlanID,
+		Status:            m.Status,
+		PeriodStart:       m.PeriodStart,
+		PeriodEnd:         m.PeriodEnd,
+		Allowance:         m.Allowance,
+		Used:              m.Used,
+		Remaining:         m.Allowance - m.Used,
+		CancelAtPeriodEnd: m.CancelAtPeriodEnd,
+		PausedAt:          m.PausedAt,
+		CancelledAt:       m.CancelledAt,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+// MembershipPaymentResponse holds a charge or a refund of a membership, the refunds have negative amounts.
+// A pending refund isn't given back by the payment gateway yet, it is retried by the memberships job
+type MembershipPaymentResponse struct {
+	ID        int64      `json:"id"`
+	Kind      string     `json:"kind"`
+	Amount    util.Money `json:"amount"`
+	Reference string     `json:"reference,omitempty"`
+	Pending   bool       `json:"pending,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// newMembershipPaymentResponse creates the response of a payment of a membership
+func newMembershipPaymentResponse(p db.MembershipPayment) MembershipPaymentResponse {
+	return MembershipPaymentResponse{
+		ID:        p.ID,
+		Kind:      p.Kind,
+		Amount:    util.NewMoney(p.Amount, p.Currency),
+		Reference: p.Reference.String,
+		Pending:   !p.Reference.Valid,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+// MembershipResponse holds a membership with its plan, the payment is given if the request is charged
+// and the refunds are given if it is refunded
+type MembershipResponse struct {
+	Membership MembershipDetails           `json:"membership"`
+	Plan       MembershipPlanResponse      `json:"plan"`
+	Payment    *MembershipPaymentResponse  `json:"payment,omitempty"`
+	Refunds    []MembershipPaymentResponse `json:"refunds,omitempty"`
+}
+
+// newMembershipResponse creates the response of a membership and its payments
+func newMembershipResponse(m db.Membership, plan db.MembershipPlan, p *db.MembershipPayment, refunds ...db.MembershipPayment) MembershipResponse {
+	res := MembershipResponse{Membership: newMembershipDetails(m), Plan: newMembershipPlanResponse(plan)}
+	if p != nil {
+		payment := newMembershipPaymentResponse(*p)
+		res.Payment = &payment
+	}
+
+	for _, r := range refunds {
+		res.Refunds = append(res.Refunds, newMembershipPaymentResponse(r))
+	}
+
+	return res
+}
+
+// CreateMembershipPlanRequest holds the json data of the request, the price is charged for every month
+type CreateMembershipPlanRequest struct {
+	Name      string     `json:"name" binding:"required,max=64"`
+	Allowance int32      `json:"allowance" binding:"required,min=1,max=100"`
+	Price     util.Money `json:"price"`
+}
+
+// createMembershipPlan creates a membership plan in the currency of the app
+func (server *Server) createMembershipPlan(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreateMembershipPlanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.requireMoney(ctx, req.Price) {
+		return
+	}
+
+	plan, err := server.store.CreateMembershipPlan(ctx, db.CreateMembershipPlanParams{
+		Name:      req.Name,
+		Allowance: req.Allowance,
+		Price:     req.Price.Amount,
+		Currency:  req.Price.Currency,
+	})
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrMembershipPlanExists))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newMembershipPlanResponse(plan))
+}
+
+// listMembershipPlans returns the membership plans from the cheapest
+func (server *Server) listMembershipPlans(ctx *gin.Context) {
+	plans, err := server.store.ListMembershipPlans(ctx)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result := make([]MembershipPlanResponse, 0, len(plans))
+	for _, plan := range plans {
+		result = append(result, newMembershipPlanResponse(plan))
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, ""))
+}
+
+// MembershipPlanRequest holds the json data of the subscription and the plan change requests
+type MembershipPlanRequest struct {
+	PlanID int64 `json:"plan_id" binding:"required,min=1"`
+}
+
+// subscribe creates a membership of the authenticated user, the first period starts now and is charged in advance
+func (server *Server) subscribe(ctx *gin.Context) {
+	// first i check for the bindings
+	var req MembershipPlanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	plan, ok := server.requireMembershipPlan(ctx, req.PlanID)
+	if !ok {
+		return
+	}
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// a user can't have two memberships, the user is checked before it is charged
+	_, err := server.store.GetLiveMembership(ctx, authPayload.Username)
+
+	if err == nil {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrMembershipExists))
+		return
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	period := membership.NewPeriod(time.Now())
+
+	charge, ok := server.chargeMembership(ctx, payment.Charge{
+		Username:    authPayload.Username,
+		Amount:      util.NewMoney(plan.Price, plan.Currency),
+		Description: fmt.Sprintf("%s membership until %s", plan.Name, period.End.Format(holidayLayout)),
+	}, membershipPaymentSubscription)
+	if !ok {
+		return
+	}
+
+	result, err := server.store.CreateMembershipTx(ctx, db.CreateMembershipTxParams{
+		CreateMembershipParams: db.CreateMembershipParams{
+			Username:    authPayload.Username,
+			PlanID:      plan.ID,
+			PeriodStart: period.Start,
+			PeriodEnd:   period.End,
+			Allowance:   plan.Allowance,
+		},
+		Payment: charge,
+	})
+
+	if err != nil {
+		// the user is refunded if the membership isn't created
+		server.refundFailedCharge(ctx, authPayload.Username, charge)
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrMembershipExists))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newMembershipResponse(result.Membership, plan, result.Payment))
+}
+
+// getMembership returns the membership of the authenticated user with its plan
+func (server *Server) getMembership(ctx *gin.Context) {
+	m, ok := server.requireMembership(ctx)
+	if !ok {
+		return
+	}
+
+	plan, ok := server.requireMembershipPlan(ctx, m.PlanID)
+	if !ok {
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newMembershipResponse(m, plan, nil))
+}
+
+// changeMembershipPlan moves the membership of the authenticated user to another plan for the rest of the period.
+// The difference of the prices for the rest of the period is charged or refunded and the allowance is prorated,
+// the next periods are charged with the price of the new plan. An upgrade is charged before the plan is changed,
+// a downgrade is refunded once the plan is changed so a failed change is never refunded
+func (server *Server) changeMembershipPlan(ctx *gin.Context) {
+	// first i check for the bindings
+	var req MembershipPlanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	m, ok := server.requireMembership(ctx)
+	if !ok {
+		return
+	}
+
+	if m.Status != membershipActive {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrMembershipNotActive))
+		return
+	}
+
+	if m.PlanID == req.PlanID {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrMembershipPlanUnchanged))
+		return
+	}
+
+	from, ok := server.requireMembershipPlan(ctx, m.PlanID)
+	if !ok {
+		return
+	}
+
+	to, ok := server.requireMembershipPlan(ctx, req.PlanID)
+	if !ok {
+		return
+	}
+
+	period := membership.Period{Start: m.PeriodStart, End: m.PeriodEnd}
+	change, err := period.ChangePlan(newPlan(from), newPlan(to), m.Allowance, m.Used, time.Now())
+
+	if err != nil {
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	var p *db.MembershipPaymentParams
+	var refund *db.MembershipRefundParams
+	switch {
+	case change.Charge.Amount > 0:
+		p, ok = server.chargeMembership(ctx, payment.Charge{
+			Username:    m.Username,
+			Amount:      change.Charge,
+			Description: fmt.Sprintf("%s membership until %s", to.Name, period.End.Format(holidayLayout)),
+		}, membershipPaymentProration)
+		if !ok {
+			return
+		}
+	case change.Charge.Amount < 0:
+		refund = &db.MembershipRefundParams{Amount: -change.Charge.Amount, Currency: change.Charge.Currency}
+	}
+
+	result, err := server.store.ChangeMembershipPlanTx(ctx, db.ChangeMembershipPlanTxParams{
+		ChangeMembershipPlanParams: db.ChangeMembershipPlanParams{
+			PlanID:    to.ID,
+			Allowance: change.Allowance,
+			ID:        m.ID,
+		},
+		Payment: p,
+		Refund:  refund,
+	})
+
+	if err != nil {
+		server.refundFailedCharge(ctx, m.Username, p)
+
+		// the membership is paused or its seats are used meanwhile
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrPlanChangeConflict))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	refunds := server.giveBackRefunds(ctx, m.Username, result.Refunds)
+
+	ctx.JSON(http.StatusOK, newMembershipResponse(result.Membership, to, result.Payment, refunds...))
+}
+
+// pauseMembership pauses the membership of the authenticated user, its allowance can't be used and
+// it isn't renewed while it is paused
+func (server *Server) pauseMembership(ctx *gin.Context) {
+	m, ok := server.requireMembership(ctx)
+	if !ok {
+		return
+	}
+
+	m, err := server.store.PauseMembership(ctx, db.PauseMembershipParams{PausedAt: time.Now(), ID: m.ID})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrMembershipNotActive))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writeMembership(ctx, m)
+}
+
+// resumeMembership resumes the paused membership of the authenticated user, the paused time is added to its period
+func (server *Server) resumeMembership(ctx *gin.Context) {
+	m, ok := server.requireMembership(ctx)
+	if !ok {
+		return
+	}
+
+	if m.Status != membershipPaused {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrMembershipNotPaused))
+		return
+	}
+
+	period := membership.Period{Start: m.PeriodStart, End: m.PeriodEnd}.Resume(m.PausedAt.Time, time.Now())
+
+	m, err := server.store.ResumeMembership(ctx, db.ResumeMembershipParams{PeriodEnd: period.End, ID: m.ID})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrMembershipNotPaused))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writeMembership(ctx, m)
+}
+
+// CancelMembershipRequest holds the query of the request
+type CancelMembershipRequest struct {
+	// Immediately ends the membership now and refunds the rest of the period,
+	// otherwise an active membership is cancelled at the end of its period
+	Immediately bool `form:"immediately"`
+}
+
+// cancelMembership cancels the membership of the authenticated user. The paused and past due memberships
+// are cancelled immediately, a paused membership is refunded for the rest of its period it hasn't used.
+// The refund is given back once the membership is cancelled
+func (server *Server) cancelMembership(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CancelMembershipRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	m, ok := server.requireMembership(ctx)
+	if !ok {
+		return
+	}
+
+	if m.Status == membershipActive && !req.Immediately {
+		m, err := server.store.CancelMembershipAtPeriodEnd(ctx, m.ID)
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusConflict, errorResponse(ErrMembershipNotActive))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		server.writeMembership(ctx, m)
+		return
+	}
+
+	plan, ok := server.requireMembershipPlan(ctx, m.PlanID)
+	if !ok {
+		return
+	}
+
+	// a past due period isn't paid so it isn't refunded, a paused period is refunded from the time it is paused
+	var refund *db.MembershipRefundParams
+	if m.Status != membershipPastDue {
+		at := time.Now()
+		if m.Status == membershipPaused {
+			at = m.PausedAt.Time
+		}
+
+		period := membership.Period{Start: m.PeriodStart, End: m.PeriodEnd}
+		amount := period.Prorate(util.NewMoney(plan.Price, plan.Currency), at)
+
+		if !amount.IsZero() {
+			refund = &db.MembershipRefundParams{Amount: amount.Amount, Currency: amount.Currency}
+		}
+	}
+
+	result, err := server.store.CancelMembershipTx(ctx, db.CancelMembershipTxParams{ID: m.ID, Refund: refund})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrMembershipMissing))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	refunds := server.giveBackRefunds(ctx, m.Username, result.Refunds)
+
+	ctx.JSON(http.StatusOK, newMembershipResponse(result.Membership, plan, result.Payment, refunds...))
+}
+
+// requireMembership gets the membership of the authenticated user that isn't cancelled,
+// it writes the error response and returns false if there is none
+func (server *Server) requireMembership(ctx *gin.Context) (db.Membership, bool) {
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	m, err := server.store.GetLiveMembership(ctx, authPayload.Username)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrMembershipMissing))
+			return db.Membership{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Membership{}, false
+	}
+
+	return m, true
+}
+
+// requireMembershipPlan gets a membership plan, it writes the error response and returns false if it doesn't exist
+func (server *Server) requireMembershipPlan(ctx *gin.Context, id int64) (db.MembershipPlan, bool) {
+	plan, err := server.store.GetMembershipPlan(ctx, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrMembershipPlanMissing))
+			return db.MembershipPlan{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.MembershipPlan{}, false
+	}
+
+	return plan, true
+}
+
+// writeMembership writes the response of a membership with its plan
+func (server *Server) writeMembership(ctx *gin.Context, m db.Membership) {
+	plan, ok := server.requireMembershipPlan(ctx, m.PlanID)
+	if !ok {
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newMembershipResponse(m, plan, nil))
+}
+
+// chargeMembership takes a payment of a membership through the payment gateway, nothing is charged for a zero amount.
+// It writes the error response and returns false if the payment fails, a declined payment is 402
+func (server *Server) chargeMembership(ctx *gin.Context, c payment.Charge, kind string) (*db.MembershipPaymentParams, bool) {
+	if c.Amount.IsZero() {
+		return nil, true
+	}
+
+	receipt, err := server.payments.Charge(ctx, c)
+
+	if err != nil {
+		if err == payment.ErrDeclined {
+			ctx.JSON(http.StatusPaymentRequired, errorResponse(err))
+			return nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+
+	return &db.MembershipPaymentParams{
+		Kind:      kind,
+		Amount:    receipt.Amount.Amount,
+		Currency:  receipt.Amount.Currency,
+		Reference: receipt.Reference,
+	}, true
+}
+
+// giveBackRefunds gives back the pending refunds of a membership through the payment gateway once the change
+// they are given for is saved. A failed refund is logged and stays pending, the memberships job retries it
+func (server *Server) giveBackRefunds(ctx *gin.Context, username string, refunds []db.MembershipPayment) []db.MembershipPayment {
+	result := make([]db.MembershipPayment, 0, len(refunds))
+	for _, p := range refunds {
+		done, err := membership.Refund(ctx, server.store, server.payments, username, p)
+		if err != nil {
+			log.Printf("membership refund %d of %s is pending: %v", p.ID, username, err)
+		}
+		result = append(result, done)
+	}
+
+	return result
+}
+
+// refundFailedCharge gives a charge back if the change it is charged for isn't saved.
+// A failed refund is logged so it can be refunded by hand
+func (server *Server) refundFailedCharge(ctx *gin.Context, username string, p *db.MembershipPaymentParams) {
+	if p == nil {
+		return
+	}
+
+	_, err := server.payments.Refund(ctx, payment.Refund{
+		Username:    username,
+		Amount:      util.NewMoney(p.Amount, p.Currency),
+		Description: "membership change failed",
+		Reference:   p.Reference,
+	})
+
+	if err != nil {
+		log.Printf("cannot refund %s of %s: %v", p.Reference, username, err)
+	}
+}
+
+// newPlan creates the plan of a membership period from a membership plan
+func newPlan(plan db.MembershipPlan) membership.Plan {
+	return membership.Plan{Allowance: plan.Allowance, Price: util.NewMoney(plan.Price, plan.Currency)}
+}
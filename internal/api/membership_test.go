@@ -0,0 +1,837 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingGateway is a payment gateway that keeps the payments in memory for the tests
+type recordingGateway struct {
+	charges []payment.Charge
+	refunds []payment.Refund
+	err     error
+}
+
+// Charge records the charge or returns the error of the gateway
+func (g *recordingGateway) Charge(ctx context.Context, c payment.Charge) (payment.Receipt, error) {
+	if g.err != nil {
+		return payment.Receipt{}, g.err
+	}
+	g.charges = append(g.charges, c)
+	return payment.Receipt{Reference: "ch_1", Amount: c.Amount}, nil
+}
+
+// Refund records the refund or returns the error of the gateway
+func (g *recordingGateway) Refund(ctx context.Context, r payment.Refund) (payment.Receipt, error) {
+	if g.err != nil {
+		return payment.Receipt{}, g.err
+	}
+	g.refunds = append(g.refunds, r)
+	return payment.Receipt{Reference: "re_1", Amount: r.Amount}, nil
+}
+
+// TestCreateMembershipPlanAPI tests createMembershipPlan handler
+func TestCreateMembershipPlanAPI(t *testing.T) {
+	staff := randomStaff(t)
+	plan := randomMembershipPlan()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": plan.Name, "allowance": plan.Allowance, "price": util.NewMoney(plan.Price, plan.Currency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateMembershipPlanParams{Name: plan.Name, Allowance: plan.Allowance, Price: plan.Price, Currency: plan.Currency}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateMembershipPlan(gomock.Any(), gomock.Eq(arg)).Times(1).Return(plan, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got MembershipPlanResponse
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, newMembershipPlanResponse(plan), got)
+			},
+		},
+		{
+			name: "Other Currency",
+			body: gin.H{"name": plan.Name, "allowance": plan.Allowance, "price": util.NewMoney(plan.Price, "EUR")},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateMembershipPlan(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Zero Allowance",
+			body: gin.H{"name": plan.Name, "allowance": 0, "price": util.NewMoney(plan.Price, plan.Currency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateMembershipPlan(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Duplicate Name",
+			body: gin.H{"name": plan.Name, "allowance": plan.Allowance, "price": util.NewMoney(plan.Price, plan.Currency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateMembershipPlan(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.MembershipPlan{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/membership-plans", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListMembershipPlansAPI tests listMembershipPlans handler
+func TestListMembershipPlansAPI(t *testing.T) {
+	p1 := randomMembershipPlan()
+	p2 := randomMembershipPlan()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMembershipPlans(gomock.Any()).Times(1).Return([]db.MembershipPlan{p1, p2}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[MembershipPlanResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, []MembershipPlanResponse{newMembershipPlanResponse(p1), newMembershipPlanResponse(p2)}, got.Items)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMembershipPlans(gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/membership-plans", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestSubscribeAPI tests subscribe handler
+func TestSubscribeAPI(t *testing.T) {
+	_, user := randomUser(t)
+	plan := randomMembershipPlan()
+	m := randomMembership(user.Username, plan)
+	charge := db.MembershipPayment{ID: 1, MembershipID: m.ID, Kind: membershipPaymentSubscription, Amount: plan.Price, Currency: plan.Currency, Reference: sql.NullString{String: "ch_1", Valid: true}}
+
+	testCases := []struct {
+		name          string
+		gatewayErr    error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.Membership{}, sql.ErrNoRows)
+				store.EXPECT().CreateMembershipTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateMembershipTxParams) (db.MembershipTxResult, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, plan.ID, arg.PlanID)
+						require.Equal(t, plan.Allowance, arg.Allowance)
+						require.WithinDuration(t, time.Now(), arg.PeriodStart, time.Second)
+						require.Equal(t, arg.PeriodStart.AddDate(0, 1, 0), arg.PeriodEnd)
+						require.Equal(t, &db.MembershipPaymentParams{Kind: membershipPaymentSubscription, Amount: plan.Price, Currency: plan.Currency, Reference: "ch_1"}, arg.Payment)
+						return db.MembershipTxResult{Membership: m, Payment: &charge}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireMembershipBodyMatch(t, w.Body, newMembershipResponse(m, plan, &charge))
+				require.Len(t, gateway.charges, 1)
+				require.Equal(t, util.NewMoney(plan.Price, plan.Currency), gateway.charges[0].Amount)
+			},
+		},
+		{
+			name: "Already Member",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(m, nil)
+				store.EXPECT().CreateMembershipTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+				require.Empty(t, gateway.charges)
+			},
+		},
+		{
+			name:       "Declined",
+			gatewayErr: payment.ErrDeclined,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.Membership{}, sql.ErrNoRows)
+				store.EXPECT().CreateMembershipTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusPaymentRequired, w.Code)
+			},
+		},
+		{
+			name: "Plan Not Found",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(db.MembershipPlan{}, sql.ErrNoRows)
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Concurrent Subscription",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.Membership{}, sql.ErrNoRows)
+				store.EXPECT().CreateMembershipTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.MembershipTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "ch_1", gateway.refunds[0].Reference)
+				require.Equal(t, util.NewMoney(plan.Price, plan.Currency), gateway.refunds[0].Amount)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			gateway := &recordingGateway{err: tt.gatewayErr}
+			server.payments = gateway
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"plan_id": plan.ID})
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/me/membership", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w, gateway)
+		})
+	}
+}
+
+// TestGetMembershipAPI tests getMembership handler
+func TestGetMembershipAPI(t *testing.T) {
+	_, user := randomUser(t)
+	plan := randomMembershipPlan()
+	m := randomMembership(user.Username, plan)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(m, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireMembershipBodyMatch(t, w.Body, newMembershipResponse(m, plan, nil))
+			},
+		},
+		{
+			name: "Not Found",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.Membership{}, sql.ErrNoRows)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/me/membership", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestChangeMembershipPlanAPI tests changeMembershipPlan handler
+func TestChangeMembershipPlanAPI(t *testing.T) {
+	_, user := randomUser(t)
+	small := db.MembershipPlan{ID: 1, Name: "2 movies", Allowance: 2, Price: 1000, Currency: util.DefaultCurrency}
+	large := db.MembershipPlan{ID: 2, Name: "8 movies", Allowance: 8, Price: 4000, Currency: util.DefaultCurrency}
+
+	onSmall := randomMembership(user.Username, small)
+	onLarge := randomMembership(user.Username, large)
+	onLarge.Used = 1
+
+	paused := onSmall
+	paused.Status = membershipPaused
+	paused.PausedAt = sql.NullTime{Time: onSmall.PeriodStart, Valid: true}
+
+	testCases := []struct {
+		name          string
+		planID        int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway)
+	}{
+		{
+			name:   "Upgrade",
+			planID: large.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(onSmall, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(small.ID)).Times(1).Return(small, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(large.ID)).Times(1).Return(large, nil)
+				store.EXPECT().ChangeMembershipPlanTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ChangeMembershipPlanTxParams) (db.MembershipTxResult, error) {
+						require.Equal(t, large.ID, arg.PlanID)
+						require.Equal(t, onSmall.ID, arg.ID)
+						require.Greater(t, arg.Allowance, small.Allowance)
+						require.Equal(t, membershipPaymentProration, arg.Payment.Kind)
+						require.Positive(t, arg.Payment.Amount)
+						require.Less(t, arg.Payment.Amount, large.Price-small.Price)
+						return db.MembershipTxResult{Membership: onLarge}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Len(t, gateway.charges, 1)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name:   "Downgrade",
+			planID: small.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(onLarge, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(large.ID)).Times(1).Return(large, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(small.ID)).Times(1).Return(small, nil)
+				store.EXPECT().ChangeMembershipPlanTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ChangeMembershipPlanTxParams) (db.MembershipTxResult, error) {
+						require.Nil(t, arg.Payment)
+						require.Positive(t, arg.Refund.Amount)
+						require.Less(t, arg.Refund.Amount, large.Price-small.Price)
+						require.GreaterOrEqual(t, arg.Allowance, onLarge.Used)
+						return db.MembershipTxResult{Membership: onSmall, Refunds: []db.MembershipPayment{pendingRefund(onLarge, arg.Refund)}}, nil
+					})
+				expectRefundGivenBack(store)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Empty(t, gateway.charges)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "ch_0", gateway.refunds[0].Reference)
+
+				res := decodeMembershipResponse(t, w)
+				require.Nil(t, res.Payment)
+				require.Len(t, res.Refunds, 1)
+				require.Equal(t, "re_1", res.Refunds[0].Reference)
+				require.False(t, res.Refunds[0].Pending)
+			},
+		},
+		{
+			name:   "Downgrade Fails",
+			planID: small.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(onLarge, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(large.ID)).Times(1).Return(large, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(small.ID)).Times(1).Return(small, nil)
+				store.EXPECT().ChangeMembershipPlanTx(gomock.Any(), gomock.Any()).Times(1).Return(db.MembershipTxResult{}, sql.ErrConnDone)
+				store.EXPECT().CompleteMembershipRefund(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				// the plan isn't changed so nothing is given back
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name:   "Same Plan",
+			planID: small.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(onSmall, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:   "Paused",
+			planID: large.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(paused, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:   "Changed Meanwhile",
+			planID: large.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(onSmall, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(small.ID)).Times(1).Return(small, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(large.ID)).Times(1).Return(large, nil)
+				store.EXPECT().ChangeMembershipPlanTx(gomock.Any(), gomock.Any()).Times(1).Return(db.MembershipTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+				require.Len(t, gateway.charges, 1)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, gateway.charges[0].Amount, gateway.refunds[0].Amount)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			gateway := &recordingGateway{}
+			server.payments = gateway
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"plan_id": tt.planID})
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPut, "/me/membership", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w, gateway)
+		})
+	}
+}
+
+// TestPauseResumeMembershipAPI tests pauseMembership and resumeMembership handlers
+func TestPauseResumeMembershipAPI(t *testing.T) {
+	_, user := randomUser(t)
+	plan := randomMembershipPlan()
+	m := randomMembership(user.Username, plan)
+
+	paused := m
+	paused.Status = membershipPaused
+	paused.PausedAt = sql.NullTime{Time: time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second), Valid: true}
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Pause",
+			url:  "/me/membership/pause",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(m, nil)
+				store.EXPECT().PauseMembership(gomock.Any(), gomock.Any()).Times(1).Return(paused, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireMembershipBodyMatch(t, w.Body, newMembershipResponse(paused, plan, nil))
+			},
+		},
+		{
+			name: "Pause Not Active",
+			url:  "/me/membership/pause",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(paused, nil)
+				store.EXPECT().PauseMembership(gomock.Any(), gomock.Any()).Times(1).Return(db.Membership{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Resume",
+			url:  "/me/membership/resume",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(paused, nil)
+				store.EXPECT().ResumeMembership(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ResumeMembershipParams) (db.Membership, error) {
+						require.Equal(t, paused.ID, arg.ID)
+						require.WithinDuration(t, paused.PeriodEnd.Add(48*time.Hour), arg.PeriodEnd, 2*time.Second)
+						return m, nil
+					})
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Resume Not Paused",
+			url:  "/me/membership/resume",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(m, nil)
+				store.EXPECT().ResumeMembership(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, tt.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestCancelMembershipAPI tests cancelMembership handler
+func TestCancelMembershipAPI(t *testing.T) {
+	_, user := randomUser(t)
+	plan := randomMembershipPlan()
+	m := randomMembership(user.Username, plan)
+	atPeriodEnd := m
+	atPeriodEnd.CancelAtPeriodEnd = true
+
+	pastDue := m
+	pastDue.Status = membershipPastDue
+
+	testCases := []struct {
+		name          string
+		query         string
+		gatewayErr    error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway)
+	}{
+		{
+			name: "At Period End",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(m, nil)
+				store.EXPECT().CancelMembershipAtPeriodEnd(gomock.Any(), gomock.Eq(m.ID)).Times(1).Return(atPeriodEnd, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().CancelMembershipTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireMembershipBodyMatch(t, w.Body, newMembershipResponse(atPeriodEnd, plan, nil))
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name:  "Immediately",
+			query: "?immediately=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(m, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().CancelMembershipTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CancelMembershipTxParams) (db.MembershipTxResult, error) {
+						require.Equal(t, m.ID, arg.ID)
+						require.Positive(t, arg.Refund.Amount)
+						require.Less(t, arg.Refund.Amount, plan.Price)
+						return db.MembershipTxResult{Membership: m, Refunds: []db.MembershipPayment{pendingRefund(m, arg.Refund)}}, nil
+					})
+				expectRefundGivenBack(store)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "ch_0", gateway.refunds[0].Reference)
+				require.Equal(t, "membership-refund-2", gateway.refunds[0].IdempotencyKey)
+			},
+		},
+		{
+			name:       "Refund Pending",
+			query:      "?immediately=true",
+			gatewayErr: errors.New("gateway is down"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(m, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().CancelMembershipTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CancelMembershipTxParams) (db.MembershipTxResult, error) {
+						return db.MembershipTxResult{Membership: m, Refunds: []db.MembershipPayment{pendingRefund(m, arg.Refund)}}, nil
+					})
+				store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(refundedCharge(), nil)
+				store.EXPECT().CompleteMembershipRefund(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				// the membership is cancelled and the refund is left to the memberships job
+				require.Equal(t, http.StatusOK, w.Code)
+
+				res := decodeMembershipResponse(t, w)
+				require.Len(t, res.Refunds, 1)
+				require.True(t, res.Refunds[0].Pending)
+				require.Empty(t, res.Refunds[0].Reference)
+			},
+		},
+		{
+			name:  "Cancel Fails",
+			query: "?immediately=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(m, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().CancelMembershipTx(gomock.Any(), gomock.Any()).Times(1).Return(db.MembershipTxResult{}, sql.ErrConnDone)
+				store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name: "Past Due",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(pastDue, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().CancelMembershipTx(gomock.Any(), gomock.Eq(db.CancelMembershipTxParams{ID: m.ID})).Times(1).
+					Return(db.MembershipTxResult{Membership: pastDue}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name: "Not Found",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLiveMembership(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.Membership{}, sql.ErrNoRows)
+				store.EXPECT().CancelMembershipAtPeriodEnd(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			gateway := &recordingGateway{err: tt.gatewayErr}
+			server.payments = gateway
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodDelete, "/me/membership"+tt.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w, gateway)
+		})
+	}
+}
+
+// randomMembershipPlan creates a random membership plan
+func randomMembershipPlan() db.MembershipPlan {
+	return db.MembershipPlan{
+		ID:        util.RandomInt(1, 1000),
+		Name:      util.RandomString(8),
+		Allowance: int32(util.RandomInt(1, 8)),
+		Price:     util.RandomInt(500, 5000),
+		Currency:  util.DefaultCurrency,
+	}
+}
+
+// randomMembership creates an active membership of a user on a plan that is in the middle of its period
+func randomMembership(username string, plan db.MembershipPlan) db.Membership {
+	start := time.Now().AddDate(0, 0, -10).UTC().Truncate(time.Second)
+
+	return db.Membership{
+		ID:          util.RandomInt(1, 1000),
+		Username:    username,
+		PlanID:      plan.ID,
+		Status:      membershipActive,
+		PeriodStart: start,
+		PeriodEnd:   start.AddDate(0, 1, 0),
+		Allowance:   plan.Allowance,
+		CreatedAt:   start,
+	}
+}
+
+// refundedCharge is the charge the refunds of the membership tests give back
+func refundedCharge() db.MembershipPayment {
+	return db.MembershipPayment{ID: 1, Kind: membershipPaymentSubscription, Reference: sql.NullString{String: "ch_0", Valid: true}}
+}
+
+// pendingRefund creates the pending refund a membership transaction records for given refund
+func pendingRefund(m db.Membership, arg *db.MembershipRefundParams) db.MembershipPayment {
+	return db.MembershipPayment{
+		ID:           2,
+		MembershipID: m.ID,
+		Kind:         membershipPaymentRefund,
+		Amount:       -arg.Amount,
+		Currency:     arg.Currency,
+		RefundOf:     sql.NullInt64{Int64: 1, Valid: true},
+	}
+}
+
+// expectRefundGivenBack expects the pending refund of pendingRefund to be given back and completed
+func expectRefundGivenBack(store *mockdb.MockStore) {
+	store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(refundedCharge(), nil)
+	store.EXPECT().CompleteMembershipRefund(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ interface{}, arg db.CompleteMembershipRefundParams) (db.MembershipPayment, error) {
+			return db.MembershipPayment{ID: arg.ID, Kind: membershipPaymentRefund, Reference: arg.Reference}, nil
+		})
+}
+
+// decodeMembershipResponse decodes the membership response of the body
+func decodeMembershipResponse(t *testing.T, w *httptest.ResponseRecorder) MembershipResponse {
+	var res MembershipResponse
+	err := json.Unmarshal(w.Body.Bytes(), &res)
+	require.NoError(t, err)
+	return res
+}
+
+// requireMembershipBodyMatch checks for a given body and response's body
+func requireMembershipBodyMatch(t *testing.T, body *bytes.Buffer, res MembershipResponse) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var got MembershipResponse
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Equal(t, res, got)
+}
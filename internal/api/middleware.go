@@ -1,10 +1,12 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"net/http"
 	"strings"
 
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
 	"github.com/burakkarasel/Theatre-API/internal/token"
 	"github.com/gin-gonic/gin"
 )
@@ -15,14 +17,21 @@ const (
 	authorizationPayloadKey      = "authorization_payload"
 )
 
+const (
+	accessLevelCustomer int16 = 1
+	accessLevelStaff    int16 = 2
+)
+
 var (
 	ErrNoAuthorizationHeader      = errors.New("authorization header is not provided")
 	ErrInvalidAuthorizationHeader = errors.New("invalid authorization header")
 	ErrInvalidAuthorizationType   = errors.New("invalid authorization type")
+	ErrStaffOnly                  = errors.New("only staff members can access this route")
+	ErrSessionRevoked             = errors.New("session is revoked, please log in again")
 )
 
 // authMiddleware implements authentication middleware to protect routes
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, store db.Store) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// first we check authorizationHeaderKey
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
@@ -61,8 +70,55 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
+		// then we check if the password is changed after the token is issued, the tokens before a reset are revoked
+		changedAt, err := store.GetPasswordChangedAt(ctx, payload.Username)
+
+		if err != nil {
+			// if the user doesn't exist anymore the token is useless
+			if err == sql.ErrNoRows {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if payload.IssuedAt.Before(changedAt) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ErrSessionRevoked))
+			return
+		}
+
 		// finally we put payload into context and move forward to the route
 		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
 	}
 }
+
+// staffMiddleware lets only staff members through, it must run after authMiddleware
+func staffMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// first we take the payload that authMiddleware put into context
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		// then we get the user of the token to check its access level
+		u, err := store.GetUser(ctx, authPayload.Username)
+
+		if err != nil {
+			// if the user doesn't exist anymore the token is useless
+			if err == sql.ErrNoRows {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		// if the user is not a staff member we return forbidden
+		if u.AccessLevel < accessLevelStaff {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ErrStaffOnly))
+			return
+		}
+
+		ctx.Next()
+	}
+}
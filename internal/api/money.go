@@ -0,0 +1,256 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrNegativeAmount = errors.New("amount can't be negative")
+	ErrZeroAmount     = errors.New("amount must be greater than zero")
+)
+
+// money creates a money with given minor units in the currency of the app
+func (server *Server) money(amount int64) util.Money {
+	return util.NewMoney(amount, server.currency)
+}
+
+// requireMoney checks that the amounts of a request are in the currency of the app and are not negative,
+// it writes the error response and returns false if one of them isn't
+func (server *Server) requireMoney(ctx *gin.Context, amounts ...util.Money) bool {
+	for _, m := range amounts {
+		if m.Currency != server.currency {
+			ctx.JSON(http.StatusBadRequest, errorResponse(util.ErrCurrencyMismatch))
+			return false
+		}
+		if m.Amount < 0 {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrNegativeAmount))
+			return false
+		}
+	}
+
+	return true
+}
+
+// TicketResponse holds a ticket with its amounts in the currency it is sold in.
+// Net is the price of the seats without the tax, Total is the sum of Net and Tax
+type TicketResponse struct {
+	ID            int64         `json:"id"`
+	MovieID       int64         `json:"movie_id"`
+	TicketOwner   string        `json:"ticket_owner"`
+	Child         int16         `json:"child"`
+	Adult         int16         `json:"adult"`
+	ScreeningID   sql.NullInt64 `json:"screening_id"`
+	PaymentMethod string        `json:"payment_method"`
+	Net           util.Money    `json:"net"`
+	Discount      util.Money    `json:"discount"`
+	Surcharge     util.Money    `json:"surcharge"`
+	Tax           util.Money    `json:"tax"`
+	Total         util.Money    `json:"total"`
+	CreatedAt     time.Time     `json:"created_at"`
+	CheckedInAt   sql.NullTime  `json:"checked_in_at"`
+}
+
+// newTicketResponse creates the response of a ticket
+func newTicketResponse(t db.Ticket) TicketResponse {
+	return TicketResponse{
+		ID:            t.ID,
+		MovieID:       t.MovieID,
+		TicketOwner:   t.TicketOwner,
+		Child:         t.Child,
+		Adult:         t.Adult,
+		PaymentMethod: t.PaymentMethod,
+		Net:           util.NewMoney(t.Total-t.Tax, t.Currency),
+		Discount:      util.NewMoney(t.Discount, t.Currency),
+		Surcharge:     util.NewMoney(t.Surcharge, t.Currency),
+		Tax:           util.NewMoney(t.Tax, t.Currency),
+		Total:         util.NewMoney(t.Total, t.Currency),
+		ScreeningID:   t.ScreeningID,
+		CreatedAt:     t.CreatedAt,
+		CheckedInAt:   t.CheckedInAt,
+	}
+}
+
+// ConcessionItemResponse holds a concession item with its price
+type ConcessionItemResponse struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Category  string     `json:"category"`
+	Price     util.Money `json:"price"`
+	Stock     int32      `json:"stock"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// newConcessionItemResponse creates the response of a concession item, the catalog is priced in the currency of the app
+func (server *Server) newConcessionItemResponse(item db.ConcessionItem) ConcessionItemResponse {
+	return ConcessionItemResponse{
+		ID:        item.ID,
+		Name:      item.Name,
+		Category:  item.Category,
+		Price:     server.money(item.Price),
+		Stock:     item.Stock,
+		CreatedAt: item.CreatedAt,
+	}
+}
+
+// ConcessionOrderSummary holds a concession order without its items
+type ConcessionOrderSummary struct {
+	ID         int64      `json:"id"`
+	TicketID   int64      `json:"ticket_id"`
+	OrderOwner string     `json:"order_owner"`
+	Total      util.Money `json:"total"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ConcessionOrderLineResponse holds an item of a concession order with the price it is sold for
+type ConcessionOrderLineResponse struct {
+	ID        int64      `json:"id"`
+	ItemID    int64      `json:"item_id"`
+	Name      string     `json:"name"`
+	Quantity  int32      `json:"quantity"`
+	UnitPrice util.Money `json:"unit_price"`
+}
+
+// ConcessionOrderResponse holds a concession order and its items
+type ConcessionOrderResponse struct {
+	Order ConcessionOrderSummary        `json:"order"`
+	Items []ConcessionOrderLineResponse `json:"items"`
+}
+
+// newConcessionOrderResponse creates the response of a concession order, the order is in the currency of its ticket
+func newConcessionOrderResponse(result db.ConcessionOrderTxResult, currency string) *ConcessionOrderResponse {
+	r := &ConcessionOrderResponse{
+		Order: ConcessionOrderSummary{
+			ID:         result.Order.ID,
+			TicketID:   result.Order.TicketID,
+			OrderOwner: result.Order.OrderOwner,
+			Total:      util.NewMoney(result.Order.Total, currency),
+			CreatedAt:  result.Order.CreatedAt,
+		},
+		Items: make([]ConcessionOrderLineResponse, 0, len(result.Items)),
+	}
+
+	for _, item := range result.Items {
+		r.Items = append(r.Items, ConcessionOrderLineResponse{
+			ID:        item.ID,
+			ItemID:    item.ItemID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: util.NewMoney(item.UnitPrice, currency),
+		})
+	}
+
+	return r
+}
+
+// PosSaleDetails holds a box office sale with its total in the currency it is sold in
+type PosSaleDetails struct {
+	ID            int64      `json:"id"`
+	ShiftID       int64      `json:"shift_id"`
+	MovieID       int64      `json:"movie_id"`
+	GuestName     string     `json:"guest_name"`
+	PaymentMethod string     `json:"payment_method"`
+	TicketCode    string     `json:"ticket_code"`
+	Child         int16      `json:"child"`
+	Adult         int16      `json:"adult"`
+	Net           util.Money `json:"net"`
+	Tax           util.Money `json:"tax"`
+	Total         util.Money `json:"total"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// newPosSaleDetails creates the details of a box office sale
+func newPosSaleDetails(sale db.PosSale) PosSaleDetails {
+	return PosSaleDetails{
+		ID:            sale.ID,
+		ShiftID:       sale.ShiftID,
+		MovieID:       sale.MovieID,
+		GuestName:     sale.GuestName,
+		PaymentMethod: sale.PaymentMethod,
+		TicketCode:    sale.TicketCode,
+		Child:         sale.Child,
+		Adult:         sale.Adult,
+		Net:           util.NewMoney(sale.Total-sale.Tax, sale.Currency),
+		Tax:           util.NewMoney(sale.Tax, sale.Currency),
+		Total:         util.NewMoney(sale.Total, sale.Currency),
+		CreatedAt:     sale.CreatedAt,
+	}
+}
+
+// CashShiftResponse holds a cash drawer shift, the counted cash is only given once the shift is closed
+type CashShiftResponse struct {
+	ID          int64        `json:"id"`
+	Cashier     string       `json:"cashier"`
+	OpeningCash util.Money   `json:"opening_cash"`
+	CountedCash *util.Money  `json:"counted_cash"`
+	OpenedAt    time.Time    `json:"opened_at"`
+	ClosedAt    sql.NullTime `json:"closed_at"`
+}
+
+// newCashShiftResponse creates the response of a shift, the cash drawer is in the currency of the app
+func (server *Server) newCashShiftResponse(shift db.CashShift) CashShiftResponse {
+	r := CashShiftResponse{
+		ID:          shift.ID,
+		Cashier:     shift.Cashier,
+		OpeningCash: server.money(shift.OpeningCash),
+		OpenedAt:    shift.OpenedAt,
+		ClosedAt:    shift.ClosedAt,
+	}
+
+	if shift.CountedCash.Valid {
+		counted := server.money(shift.CountedCash.Int64)
+		r.CountedCash = &counted
+	}
+
+	return r
+}
+
+// ScreeningFormatResponse holds a screening format with its surcharge for every seat
+type ScreeningFormatResponse struct {
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	Surcharge util.Money `json:"surcharge"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// newScreeningFormatResponse creates the response of a screening format
+func (server *Server) newScreeningFormatResponse(f db.ScreeningFormat) ScreeningFormatResponse {
+	return ScreeningFormatResponse{
+		Code:      f.Code,
+		Name:      f.Name,
+		Surcharge: server.money(f.Surcharge),
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+// VenueDetails holds a venue with its net prices and tax rate, the tax rate is in basis points so 1800 is 18%
+type VenueDetails struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Address    string     `json:"address"`
+	TimeZone   string     `json:"time_zone"`
+	AdultPrice util.Money `json:"adult_price"`
+	ChildPrice util.Money `json:"child_price"`
+	TaxRate    int32      `json:"tax_rate"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// newVenueDetails creates the details of a venue
+func (server *Server) newVenueDetails(v db.Venue) VenueDetails {
+	return VenueDetails{
+		ID:         v.ID,
+		Name:       v.Name,
+		Address:    v.Address,
+		TimeZone:   v.TimeZone,
+		AdultPrice: server.money(v.AdultPrice),
+		ChildPrice: server.money(v.ChildPrice),
+		TaxRate:    v.TaxRate,
+		CreatedAt:  v.CreatedAt,
+	}
+}
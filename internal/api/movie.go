@@ -2,12 +2,36 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 )
 
+var (
+	ErrMovieDeleted    = errors.New("movie is deleted")
+	ErrEmptyUpdate     = errors.New("at least one field must be given to update")
+	ErrGenreNotFound   = errors.New("genre not found")
+	ErrRestricted      = errors.New("child tickets can't be sold for restricted movies")
+	ErrMissingDirector = errors.New("director of the movie is missing")
+)
+
+// dateLayout is the layout of the dates in requests
+const dateLayout = "2006-01-02"
+
+// defaultCertification is given to the movies that are not rated yet
+const defaultCertification = "NR"
+
+// restrictedCertifications can't be watched by children
+var restrictedCertifications = map[string]bool{
+	"R":     true,
+	"NC-17": true,
+}
+
 // CreateMovieRequest holds request json data
 type CreateMovieRequest struct {
 	Title      string `json:"title" binding:"required,min=3"`
@@ -15,6 +39,14 @@ type CreateMovieRequest struct {
 	Summary    string `json:"summary" binding:"required,min=10"`
 	Rating     int16  `json:"rating" binding:"required,min=1"`
 	DirectorID int64  `json:"director_id" binding:"required,min=1"`
+	// Certification is NR if it is not given
+	Certification string   `json:"certification" binding:"omitempty,oneof=G PG PG-13 R NC-17 NR"`
+	Tags          []string `json:"tags" binding:"omitempty,max=10,dive,min=2,max=32"`
+	GenreIDs      []int64  `json:"genre_ids" binding:"omitempty,max=5,dive,min=1"`
+	// ReleaseDate is today if it is not given
+	ReleaseDate string `json:"release_date" binding:"omitempty,datetime=2006-01-02"`
+	// Runtime is in minutes, the movie can't be scheduled until it is given
+	Runtime int32 `json:"runtime" binding:"omitempty,min=1,max=1000"`
 }
 
 // createMovie creates a new movie in DB
@@ -26,21 +58,36 @@ func (server *Server) createMovie(ctx *gin.Context) {
 		return
 	}
 
+	if req.Certification == "" {
+		req.Certification = defaultCertification
+	}
+
+	if req.Tags == nil {
+		req.Tags = []string{}
+	}
+
 	// otherwise i create new create movie params
-	arg := db.CreateMovieParams{
-		Title:      req.Title,
-		Rating:     req.Rating,
-		DirectorID: req.DirectorID,
-		Summary:    req.Summary,
-		Poster:     req.Poster,
+	arg := db.CreateMovieTxParams{
+		CreateMovieParams: db.CreateMovieParams{
+			Title:         req.Title,
+			Rating:        req.Rating,
+			DirectorID:    req.DirectorID,
+			Summary:       req.Summary,
+			Poster:        req.Poster,
+			Certification: req.Certification,
+			Tags:          req.Tags,
+			ReleaseDate:   newNullDate(req.ReleaseDate),
+			Runtime:       req.Runtime,
+		},
+		GenreIDs: req.GenreIDs,
 	}
 
-	// insert the movie into DB
-	m, err := server.store.CreateMovie(ctx, arg)
+	// insert the movie into DB, its genres and director credit are created in the same transaction
+	m, err := server.store.CreateMovieTx(ctx, arg)
 
-	// if error occurs i return 500 and the error
+	// if the director or a genre doesn't exist i return 404, otherwise 500 and the error
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeMovieError(ctx, err)
 		return
 	}
 
@@ -60,8 +107,11 @@ type GetMovieRequest struct {
 
 // GetMovieResponse holds the data of the response
 type GetMovieResponse struct {
-	Movie    db.Movie    `json:"movie"`
-	Director db.Director `json:"director"`
+	Movie    db.Movie                 `json:"movie"`
+	Director db.Director              `json:"director"`
+	Credits  []db.ListMovieCreditsRow `json:"credits,omitempty"`
+	Genres   []db.Genre               `json:"genres,omitempty"`
+	Reviews  *ReviewStats             `json:"reviews,omitempty"`
 }
 
 // getMovie finds the movie for given ID
@@ -88,6 +138,12 @@ func (server *Server) getMovie(ctx *gin.Context) {
 		return
 	}
 
+	// soft deleted movies are not served anymore
+	if m.DeletedAt.Valid {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrMovieDeleted))
+		return
+	}
+
 	d, err := server.store.GetDirector(ctx, m.DirectorID)
 
 	if err != nil {
@@ -95,30 +151,99 @@ func (server *Server) getMovie(ctx *gin.Context) {
 		return
 	}
 
+	// then i get the full cast and crew in billing order
+	credits, err := server.store.ListMovieCredits(ctx, m.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	genres, err := server.store.ListMovieGenres(ctx, m.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// users' ratings are given next to the rating of the staff
+	stats, err := server.store.GetMovieReviewStats(ctx, m.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
 	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
 
 	// otherwise i return OK and the movie from the DB
-	ctx.JSON(http.StatusOK, GetMovieResponse{Movie: m, Director: d})
+	ctx.JSON(http.StatusOK, GetMovieResponse{Movie: m, Director: d, Credits: credits, Genres: genres, Reviews: newReviewStats(stats)})
 }
 
-// ListMovieRequest holds query data of the request
+// ListMoviesRequest holds query data of the request, the cursor is only valid with the sort and the order it was given for
 type ListMoviesRequest struct {
-	Count int32 `form:"count" binding:"required,min=1,max=8"`
+	Cursor   string `form:"cursor" binding:"omitempty,max=256"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=20"`
+	// Sort is the newest first if it is not given, Order defaults to asc for titles and desc for the others
+	Sort          string `form:"sort" binding:"omitempty,oneof=rating title release_date"`
+	Order         string `form:"order" binding:"omitempty,oneof=asc desc"`
+	DirectorID    int64  `form:"director_id" binding:"omitempty,min=1"`
+	MinRating     int16  `form:"min_rating" binding:"omitempty,min=1,max=10"`
+	MaxRating     int16  `form:"max_rating" binding:"omitempty,min=1,max=10,gtefield=MinRating"`
+	Genre         string `form:"genre" binding:"omitempty,max=32"`
+	Tag           string `form:"tag" binding:"omitempty,max=32"`
+	Certification string `form:"certification" binding:"omitempty,oneof=G PG PG-13 R NC-17 NR"`
 }
 
-// listMovies directly returns all the movies from DB there can max 8 movies be so it doesnt take any value
+// moviesCursor is the kind of the cursors of the movies list, the sort and the order are appended to it
+const moviesCursor = "movies"
+
+// listMovies returns a page of the movies that match the given filters in the given order
 func (server *Server) listMovies(ctx *gin.Context) {
+	// first i check for the bindings
 	var req ListMoviesRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	sortDesc := req.Order == "desc" || (req.Order == "" && req.Sort != "title")
+	kind := moviesCursor + ":" + req.Sort + ":" + strconv.FormatBool(sortDesc)
+
+	afterID, err := server.decodeCursor(kind, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// empty filters are not applied
+	filter := db.CountMoviesParams{
+		Genre:         sql.NullString{String: req.Genre, Valid: req.Genre != ""},
+		Tag:           sql.NullString{String: req.Tag, Valid: req.Tag != ""},
+		Certification: sql.NullString{String: req.Certification, Valid: req.Certification != ""},
+		DirectorID:    sql.NullInt64{Int64: req.DirectorID, Valid: req.DirectorID != 0},
+		MinRating:     sql.NullInt16{Int16: req.MinRating, Valid: req.MinRating != 0},
+		MaxRating:     sql.NullInt16{Int16: req.MaxRating, Valid: req.MaxRating != 0},
+	}
+
+	arg := db.ListMoviesParams{
+		Genre:         filter.Genre,
+		Tag:           filter.Tag,
+		Certification: filter.Certification,
+		DirectorID:    filter.DirectorID,
+		MinRating:     filter.MinRating,
+		MaxRating:     filter.MaxRating,
+		AfterID:       afterID,
+		SortBy:        req.Sort,
+		SortDesc:      sortDesc,
+		Limit:         req.PageSize + 1,
+	}
+
 	// i get the movies from DB
-	movies, err := server.store.ListMovies(ctx, req.Count)
+	movies, err := server.store.ListMovies(ctx, arg)
 
 	// if any error occurs i return 500 and the error
 	if err != nil {
@@ -126,24 +251,201 @@ func (server *Server) listMovies(ctx *gin.Context) {
 		return
 	}
 
-	var res []GetMovieResponse
+	// then i count all the matching movies for the total
+	total, err := server.store.CountMovies(ctx, filter)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the movies are paged after the sort column and the ID of the last movie, so the pages don't shift when movies are added
+	movies, next := trimCursorPage(server, kind, movies, req.PageSize, func(m db.Movie) int64 { return m.ID })
+
+	// the directors of the page are fetched at once instead of one query per movie
+	directors, err := server.loadDirectors(ctx, movies)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
 
+	var items = []GetMovieResponse{}
 	for _, m := range movies {
-		d, err := server.store.GetDirector(ctx, m.DirectorID)
+		d, ok := directors[m.DirectorID]
 
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if !ok {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ErrMissingDirector))
 			return
 		}
 
-		res = append(res, GetMovieResponse{Movie: m, Director: d})
+		items = append(items, GetMovieResponse{Movie: m, Director: d})
 	}
 
+	// the next link keeps the filters and the order of the current request
+	res := newListResponse(ctx, items, next)
+	res.Total = &total
+
 	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
 	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
 
-	// otherwise i return OK and the movies i got from the DB
+	// otherwise i return OK and the page of movies i got from the DB
 	ctx.JSON(http.StatusOK, res)
 }
+
+// loadDirectors fetches the directors of the given movies in a single query and maps them by their IDs
+func (server *Server) loadDirectors(ctx *gin.Context, movies []db.Movie) (map[int64]db.Director, error) {
+	result := make(map[int64]db.Director)
+	if len(movies) == 0 {
+		return result, nil
+	}
+
+	directors, err := server.store.ListDirectorsByIDs(ctx, collectIDs(movies, func(m db.Movie) int64 { return m.DirectorID }))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range directors {
+		result[d.ID] = d
+	}
+
+	return result, nil
+}
+
+// UpdateMovieRequest holds the json data of the request, only the given fields are updated
+type UpdateMovieRequest struct {
+	Title   *string `json:"title" binding:"omitempty,min=3"`
+	Poster  *string `json:"poster" binding:"omitempty,min=10"`
+	Summary *string `json:"summary" binding:"omitempty,min=10"`
+	Rating  *int16  `json:"rating" binding:"omitempty,min=1"`
+	// Tags and GenreIDs replace the current ones when they are given
+	Certification *string   `json:"certification" binding:"omitempty,oneof=G PG PG-13 R NC-17 NR"`
+	Tags          *[]string `json:"tags" binding:"omitempty,max=10,dive,min=2,max=32"`
+	GenreIDs      *[]int64  `json:"genre_ids" binding:"omitempty,max=5,dive,min=1"`
+	ReleaseDate   *string   `json:"release_date" binding:"omitempty,datetime=2006-01-02"`
+	Runtime       *int32    `json:"runtime" binding:"omitempty,min=1,max=1000"`
+}
+
+// updateMovie updates the given fields of a movie
+func (server *Server) updateMovie(ctx *gin.Context) {
+	// first i check the bindings
+	var uri GetMovieRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req UpdateMovieRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Title == nil && req.Poster == nil && req.Summary == nil && req.Rating == nil &&
+		req.Certification == nil && req.Tags == nil && req.GenreIDs == nil && req.ReleaseDate == nil && req.Runtime == nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrEmptyUpdate))
+		return
+	}
+
+	// then i create the params, null fields keep their current values
+	arg := db.UpdateMovieTxParams{UpdateMovieParams: db.UpdateMovieParams{ID: uri.ID}}
+
+	if req.Title != nil {
+		arg.Title = sql.NullString{String: *req.Title, Valid: true}
+	}
+	if req.Poster != nil {
+		arg.Poster = sql.NullString{String: *req.Poster, Valid: true}
+	}
+	if req.Summary != nil {
+		arg.Summary = sql.NullString{String: *req.Summary, Valid: true}
+	}
+	if req.Rating != nil {
+		arg.Rating = sql.NullInt16{Int16: *req.Rating, Valid: true}
+	}
+	if req.Certification != nil {
+		arg.Certification = sql.NullString{String: *req.Certification, Valid: true}
+	}
+	if req.ReleaseDate != nil {
+		arg.ReleaseDate = newNullDate(*req.ReleaseDate)
+	}
+	if req.Runtime != nil {
+		arg.Runtime = sql.NullInt32{Int32: *req.Runtime, Valid: true}
+	}
+	// an empty list clears the tags or genres since it isn't nil
+	if req.Tags != nil {
+		arg.Tags = *req.Tags
+	}
+	if req.GenreIDs != nil {
+		arg.GenreIDs = *req.GenreIDs
+	}
+
+	// deleted movies can't be updated either so they are not found as well
+	m, err := server.store.UpdateMovieTx(ctx, arg)
+
+	if err != nil {
+		writeMovieError(ctx, err)
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, m)
+}
+
+// deleteMovie soft deletes a movie, so the tickets that reference it stay valid
+func (server *Server) deleteMovie(ctx *gin.Context) {
+	// first i check the bindings
+	var req GetMovieRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.SoftDeleteMovie(ctx, req.ID)
+
+	if err != nil {
+		// if no rows are updated the movie doesn't exist or it is already deleted
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+// newNullDate converts a date that is already validated by the bindings, an empty date is null
+func newNullDate(date string) sql.NullTime {
+	t, err := time.Parse(dateLayout, date)
+	return sql.NullTime{Time: t, Valid: err == nil}
+}
+
+// writeMovieError writes the response for the errors of movie transactions
+func writeMovieError(ctx *gin.Context, err error) {
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	// genres are referenced by their IDs so an unknown genre violates the foreign key
+	if pqErr, ok := err.(*pq.Error); ok {
+		if pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrGenreNotFound))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
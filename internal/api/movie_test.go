@@ -9,12 +9,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
 	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,27 +31,101 @@ func TestCreateMovieAPI(t *testing.T) {
 	}{
 		{
 			name: "OK",
+			body: gin.H{
+				"title":         movie.Movie.Title,
+				"summary":       movie.Movie.Summary,
+				"poster":        movie.Movie.Poster,
+				"director_id":   movie.Movie.DirectorID,
+				"rating":        movie.Movie.Rating,
+				"certification": movie.Movie.Certification,
+				"tags":          movie.Movie.Tags,
+				"genre_ids":     []int64{movie.Genres[0].ID},
+				"runtime":       movie.Movie.Runtime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateMovieTxParams{
+					CreateMovieParams: db.CreateMovieParams{
+						Title:         movie.Movie.Title,
+						Summary:       movie.Movie.Summary,
+						Poster:        movie.Movie.Poster,
+						Rating:        movie.Movie.Rating,
+						DirectorID:    movie.Movie.DirectorID,
+						Certification: movie.Movie.Certification,
+						Tags:          movie.Movie.Tags,
+						Runtime:       movie.Movie.Runtime,
+					},
+					GenreIDs: []int64{movie.Genres[0].ID},
+				}
+
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movie.Movie, nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireBodyMatchCreateMovie(t, w.Body, movie.Movie)
+			},
+		},
+		{
+			name: "Release Date",
+			body: gin.H{
+				"title":        movie.Movie.Title,
+				"summary":      movie.Movie.Summary,
+				"poster":       movie.Movie.Poster,
+				"director_id":  movie.Movie.DirectorID,
+				"rating":       movie.Movie.Rating,
+				"release_date": "2022-09-30",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateMovieTxParams{
+					CreateMovieParams: db.CreateMovieParams{
+						Title:         movie.Movie.Title,
+						Summary:       movie.Movie.Summary,
+						Poster:        movie.Movie.Poster,
+						Rating:        movie.Movie.Rating,
+						DirectorID:    movie.Movie.DirectorID,
+						Certification: defaultCertification,
+						Tags:          []string{},
+						ReleaseDate:   sql.NullTime{Time: time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+					},
+				}
+
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movie.Movie, nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Release Date",
+			body: gin.H{
+				"title":        movie.Movie.Title,
+				"summary":      movie.Movie.Summary,
+				"poster":       movie.Movie.Poster,
+				"director_id":  movie.Movie.DirectorID,
+				"rating":       movie.Movie.Rating,
+				"release_date": "30/09/2022",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Invalid Runtime",
 			body: gin.H{
 				"title":       movie.Movie.Title,
 				"summary":     movie.Movie.Summary,
 				"poster":      movie.Movie.Poster,
 				"director_id": movie.Movie.DirectorID,
 				"rating":      movie.Movie.Rating,
+				"runtime":     -90,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateMovieParams{
-					Title:      movie.Movie.Title,
-					Summary:    movie.Movie.Summary,
-					Poster:     movie.Movie.Poster,
-					Rating:     movie.Movie.Rating,
-					DirectorID: movie.Movie.DirectorID,
-				}
-
-				store.EXPECT().CreateMovie(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movie.Movie, nil)
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, w.Code)
-				requireBodyMatchCreateMovie(t, w.Body, movie.Movie)
+				require.Equal(t, http.StatusBadRequest, w.Code)
 			},
 		},
 		{
@@ -62,7 +138,7 @@ func TestCreateMovieAPI(t *testing.T) {
 				"rating":      movie.Movie.Rating,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -78,7 +154,7 @@ func TestCreateMovieAPI(t *testing.T) {
 				"rating":      movie.Movie.Rating,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -94,7 +170,7 @@ func TestCreateMovieAPI(t *testing.T) {
 				"rating":      movie.Movie.Rating,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -110,7 +186,7 @@ func TestCreateMovieAPI(t *testing.T) {
 				"rating":      movie.Movie.Rating,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -126,7 +202,7 @@ func TestCreateMovieAPI(t *testing.T) {
 				"rating":      -3,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -134,6 +210,38 @@ func TestCreateMovieAPI(t *testing.T) {
 		},
 		{
 			name: "Internal Server Error",
+			body: gin.H{
+				"title":         movie.Movie.Title,
+				"summary":       movie.Movie.Summary,
+				"poster":        movie.Movie.Poster,
+				"director_id":   movie.Movie.DirectorID,
+				"rating":        movie.Movie.Rating,
+				"certification": movie.Movie.Certification,
+				"tags":          movie.Movie.Tags,
+				"genre_ids":     []int64{movie.Genres[0].ID},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateMovieTxParams{
+					CreateMovieParams: db.CreateMovieParams{
+						Title:         movie.Movie.Title,
+						Summary:       movie.Movie.Summary,
+						Poster:        movie.Movie.Poster,
+						Rating:        movie.Movie.Rating,
+						DirectorID:    movie.Movie.DirectorID,
+						Certification: movie.Movie.Certification,
+						Tags:          movie.Movie.Tags,
+					},
+					GenreIDs: []int64{movie.Genres[0].ID},
+				}
+
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Movie{}, sql.ErrConnDone)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+		{
+			name: "Default Certification",
 			body: gin.H{
 				"title":       movie.Movie.Title,
 				"summary":     movie.Movie.Summary,
@@ -142,18 +250,72 @@ func TestCreateMovieAPI(t *testing.T) {
 				"rating":      movie.Movie.Rating,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateMovieParams{
-					Title:      movie.Movie.Title,
-					Summary:    movie.Movie.Summary,
-					Poster:     movie.Movie.Poster,
-					Rating:     movie.Movie.Rating,
-					DirectorID: movie.Movie.DirectorID,
+				arg := db.CreateMovieTxParams{
+					CreateMovieParams: db.CreateMovieParams{
+						Title:         movie.Movie.Title,
+						Summary:       movie.Movie.Summary,
+						Poster:        movie.Movie.Poster,
+						Rating:        movie.Movie.Rating,
+						DirectorID:    movie.Movie.DirectorID,
+						Certification: "NR",
+						Tags:          []string{},
+					},
 				}
 
-				store.EXPECT().CreateMovie(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Movie{}, sql.ErrConnDone)
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movie.Movie, nil)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, w.Code)
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Certification",
+			body: gin.H{
+				"title":         movie.Movie.Title,
+				"summary":       movie.Movie.Summary,
+				"poster":        movie.Movie.Poster,
+				"director_id":   movie.Movie.DirectorID,
+				"rating":        movie.Movie.Rating,
+				"certification": "X",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Genre Not Found",
+			body: gin.H{
+				"title":       movie.Movie.Title,
+				"summary":     movie.Movie.Summary,
+				"poster":      movie.Movie.Poster,
+				"director_id": movie.Movie.DirectorID,
+				"rating":      movie.Movie.Rating,
+				"genre_ids":   []int64{movie.Genres[0].ID},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Movie{}, &pq.Error{Code: "23503"})
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Director Not Found",
+			body: gin.H{
+				"title":       movie.Movie.Title,
+				"summary":     movie.Movie.Summary,
+				"poster":      movie.Movie.Poster,
+				"director_id": movie.Movie.DirectorID,
+				"rating":      movie.Movie.Rating,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateMovieTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
 			},
 		},
 	}
@@ -186,6 +348,8 @@ func TestCreateMovieAPI(t *testing.T) {
 // TestGetMovieAPI tests getMovie handler
 func TestGetMovieAPI(t *testing.T) {
 	movie := randomMovie()
+	stats := db.GetMovieReviewStatsRow{Count: 3, Average: 4, ThreeStars: 1, FourStars: 1, FiveStars: 1}
+	movie.Reviews = newReviewStats(stats)
 	testCases := []struct {
 		name           string
 		movieID        int64
@@ -198,6 +362,9 @@ func TestGetMovieAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(movie.Movie, nil)
 				store.EXPECT().GetDirector(gomock.Any(), gomock.Eq(movie.Movie.DirectorID)).Times(1).Return(movie.Director, nil)
+				store.EXPECT().ListMovieCredits(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(movie.Credits, nil)
+				store.EXPECT().ListMovieGenres(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(movie.Genres, nil)
+				store.EXPECT().GetMovieReviewStats(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(stats, nil)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, w.Code)
@@ -226,6 +393,19 @@ func TestGetMovieAPI(t *testing.T) {
 				require.Equal(t, http.StatusNotFound, w.Code)
 			},
 		},
+		{
+			name:    "Movie Deleted",
+			movieID: movie.Movie.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				deleted := movie.Movie
+				deleted.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(deleted, nil)
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
 		{
 			name:    "Movie Internal Error",
 			movieID: movie.Movie.ID,
@@ -248,6 +428,32 @@ func TestGetMovieAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, w.Code)
 			},
 		},
+		{
+			name:    "Credits Internal Error",
+			movieID: movie.Movie.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(movie.Movie, nil)
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Eq(movie.Movie.DirectorID)).Times(1).Return(movie.Director, nil)
+				store.EXPECT().ListMovieCredits(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return([]db.ListMovieCreditsRow{}, sql.ErrConnDone)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+		{
+			name:    "Reviews Internal Error",
+			movieID: movie.Movie.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(movie.Movie, nil)
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Eq(movie.Movie.DirectorID)).Times(1).Return(movie.Director, nil)
+				store.EXPECT().ListMovieCredits(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(movie.Credits, nil)
+				store.EXPECT().ListMovieGenres(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(movie.Genres, nil)
+				store.EXPECT().GetMovieReviewStats(gomock.Any(), gomock.Eq(movie.Movie.ID)).Times(1).Return(db.GetMovieReviewStatsRow{}, sql.ErrConnDone)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
 	}
 
 	for _, tt := range testCases {
@@ -284,33 +490,184 @@ func TestListMoviesAPI(t *testing.T) {
 		directors = append(directors, m.Director)
 	}
 
+	directorIDs := make([]int64, 0, n)
+	for _, m := range movies {
+		directorIDs = append(directorIDs, m.DirectorID)
+	}
+
+	// a full page of movies from only 3 directors
+	var page []db.Movie
+	for i := 0; i < 20; i++ {
+		m := movies[i%3]
+		m.ID = int64(i + 1)
+		page = append(page, m)
+	}
+
+	// defaultArg lists the newest movies first without any filters, one more movie tells if there is a next page
+	defaultArg := db.ListMoviesParams{SortDesc: true, Limit: int32(n) + 1}
+
+	// the extra movie of a full page is by a director of the page
+	extra := movies[0]
+	extra.ID = movies[n-1].ID + 1
+
 	testCases := []struct {
-		name           string
-		query          string
+		name  string
+		query string
+		// cursor is encoded by the test server for the default sort when it is not zero
+		cursor         int64
 		buildStubs     func(store *mockdb.MockStore)
 		checkResponses func(t *testing.T, w *httptest.ResponseRecorder)
 	}{
 		{
 			name:  "OK",
-			query: "?count=5",
+			query: "?page_size=5",
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(int32(n))).Times(1).Return(movies, nil)
-				for i := 0; i < n; i++ {
-					store.EXPECT().GetDirector(gomock.Any(), gomock.Eq(movies[i].DirectorID)).Times(1).Return(directors[i], nil)
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(defaultArg)).Times(1).Return(append(movies, extra), nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Eq(db.CountMoviesParams{})).Times(1).Return(int64(12), nil)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Eq(directorIDs)).Times(1).Return(directors, nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[GetMovieResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, n)
+				require.Equal(t, int64(12), *got.Total)
+				require.NotEmpty(t, got.NextCursor)
+				require.Contains(t, got.Next, "cursor=")
+			},
+		},
+		{
+			name:   "Last Page",
+			query:  "?page_size=5",
+			cursor: movies[n-1].ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := defaultArg
+				arg.AfterID = movies[n-1].ID
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movies[:2], nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Eq(db.CountMoviesParams{})).Times(1).Return(int64(12), nil)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Eq(directorIDs[:2])).Times(1).Return(directors[:2], nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[GetMovieResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 2)
+				require.Empty(t, got.Next)
+			},
+		},
+		{
+			name:  "Filters",
+			query: "?page_size=5&genre=drama&tag=classic&certification=PG-13&director_id=7&min_rating=6&max_rating=9",
+			buildStubs: func(store *mockdb.MockStore) {
+				filter := db.CountMoviesParams{
+					Genre:         sql.NullString{String: "drama", Valid: true},
+					Tag:           sql.NullString{String: "classic", Valid: true},
+					Certification: sql.NullString{String: "PG-13", Valid: true},
+					DirectorID:    sql.NullInt64{Int64: 7, Valid: true},
+					MinRating:     sql.NullInt16{Int16: 6, Valid: true},
+					MaxRating:     sql.NullInt16{Int16: 9, Valid: true},
 				}
+				arg := defaultArg
+				arg.Genre = filter.Genre
+				arg.Tag = filter.Tag
+				arg.Certification = filter.Certification
+				arg.DirectorID = filter.DirectorID
+				arg.MinRating = filter.MinRating
+				arg.MaxRating = filter.MaxRating
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movies[:1], nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Eq(filter)).Times(1).Return(int64(1), nil)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Eq(directorIDs[:1])).Times(1).Return(directors[:1], nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:  "Sort By Title",
+			query: "?page_size=5&sort=title",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := defaultArg
+				arg.SortBy = "title"
+				arg.SortDesc = false
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Movie{}, nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, w.Code)
 			},
 		},
 		{
-			name:  "Invalid Count",
-			query: "?count=-3",
+			name:  "Sort By Release Date Ascending",
+			query: "?page_size=5&sort=release_date&order=asc",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := defaultArg
+				arg.SortBy = "release_date"
+				arg.SortDesc = false
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Movie{}, nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			// the cursor of the default sort can't be used to page the movies by their ratings
+			name:   "Cursor Of Other Sort",
+			query:  "?page_size=5&sort=rating",
+			cursor: movies[n-1].ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListMovies(gomock.Any(), gomock.Any()).Times(0)
-				for i := 0; i < n; i++ {
-					store.EXPECT().GetDirector(gomock.Any(), gomock.Any()).Times(0)
-				}
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Invalid Sort",
+			query: "?page_size=5&sort=director",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Invalid Rating Range",
+			query: "?page_size=5&min_rating=8&max_rating=6",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Invalid Certification",
+			query: "?page_size=5&certification=X",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Invalid Page Size",
+			query: "?page_size=50",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -318,12 +675,23 @@ func TestListMoviesAPI(t *testing.T) {
 		},
 		{
 			name:  "Movie Internal Error",
-			query: "?count=5",
+			query: "?page_size=5",
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(int32(n))).Times(1).Return([]db.Movie{}, sql.ErrConnDone)
-				for i := 0; i < n; i++ {
-					store.EXPECT().GetDirector(gomock.Any(), gomock.Any()).Times(0)
-				}
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(defaultArg)).Times(1).Return([]db.Movie{}, sql.ErrConnDone)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+		{
+			name:  "Count Internal Error",
+			query: "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(defaultArg)).Times(1).Return(movies, nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), sql.ErrConnDone)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, w.Code)
@@ -331,17 +699,56 @@ func TestListMoviesAPI(t *testing.T) {
 		},
 		{
 			name:  "Director Internal Error",
-			query: "?count=5",
+			query: "?page_size=5",
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(int32(n))).Times(1).Return(movies, nil)
-				for i := 0; i < 1; i++ {
-					store.EXPECT().GetDirector(gomock.Any(), gomock.Eq(movies[i].DirectorID)).Times(1).Return(db.Director{}, sql.ErrConnDone)
-				}
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(defaultArg)).Times(1).Return(movies, nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Any()).Times(1).Return(int64(n), nil)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Eq(directorIDs)).Times(1).Return([]db.Director{}, sql.ErrConnDone)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+		{
+			name:  "Missing Director",
+			query: "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(defaultArg)).Times(1).Return(movies, nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Any()).Times(1).Return(int64(n), nil)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Eq(directorIDs)).Times(1).Return(directors[1:], nil)
 			},
 			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, w.Code)
 			},
 		},
+		{
+			// the mock fails on any other call, so a page costs 3 queries however many movies it has
+			name:  "Constant Queries",
+			query: "?page_size=20",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := defaultArg
+				arg.Limit = 21
+				store.EXPECT().ListMovies(gomock.Any(), gomock.Eq(arg)).Times(1).Return(page, nil)
+				store.EXPECT().CountMovies(gomock.Any(), gomock.Any()).Times(1).Return(int64(20), nil)
+				store.EXPECT().ListDirectorsByIDs(gomock.Any(), gomock.Eq(directorIDs[:3])).Times(1).Return(directors[:3], nil)
+				store.EXPECT().GetDirector(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[GetMovieResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 20)
+
+				for i, m := range got.Items {
+					require.Equal(t, directors[i%3], m.Director)
+				}
+			},
+		},
 	}
 
 	for _, tt := range testCases {
@@ -356,6 +763,9 @@ func TestListMoviesAPI(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			url := fmt.Sprintf("/movies%s", tt.query)
+			if tt.cursor != 0 {
+				url += "&cursor=" + server.encodeCursor(moviesCursor+"::true", tt.cursor)
+			}
 
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
@@ -367,6 +777,247 @@ func TestListMoviesAPI(t *testing.T) {
 	}
 }
 
+// TestUpdateMovieAPI tests updateMovie handler
+func TestUpdateMovieAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	newTitle := util.RandomName()
+
+	testCases := []struct {
+		name           string
+		username       string
+		body           gin.H
+		buildStubs     func(store *mockdb.MockStore)
+		checkResponses func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			body:     gin.H{"title": newTitle},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateMovieTxParams{
+					UpdateMovieParams: db.UpdateMovieParams{
+						ID:    movie.ID,
+						Title: sql.NullString{String: newTitle, Valid: true},
+					},
+				}
+				updated := movie
+				updated.Title = newTitle
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateMovieTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				updated := movie
+				updated.Title = newTitle
+				requireBodyMatchCreateMovie(t, w.Body, updated)
+			},
+		},
+		{
+			name:     "Replace Genres And Tags",
+			username: staff.Username,
+			body:     gin.H{"certification": "R", "tags": []string{}, "genre_ids": []int64{3, 4}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateMovieTxParams{
+					UpdateMovieParams: db.UpdateMovieParams{
+						ID:            movie.ID,
+						Certification: sql.NullString{String: "R", Valid: true},
+						Tags:          []string{},
+					},
+					GenreIDs: []int64{3, 4},
+				}
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateMovieTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movie, nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Runtime",
+			username: staff.Username,
+			body:     gin.H{"runtime": 128},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateMovieTxParams{
+					UpdateMovieParams: db.UpdateMovieParams{
+						ID:      movie.ID,
+						Runtime: sql.NullInt32{Int32: 128, Valid: true},
+					},
+				}
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateMovieTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movie, nil)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Not Staff",
+			username: user.Username,
+			body:     gin.H{"title": newTitle},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateMovieTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Empty Update",
+			username: staff.Username,
+			body:     gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateMovieTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Invalid Summary",
+			username: staff.Username,
+			body:     gin.H{"summary": "asd"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateMovieTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Movie Not Found",
+			username: staff.Username,
+			body:     gin.H{"title": newTitle},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpdateMovieTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/movies/%d", movie.ID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponses(t, w)
+		})
+	}
+}
+
+// TestDeleteMovieAPI tests deleteMovie handler
+func TestDeleteMovieAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	staff := randomStaff(t)
+
+	testCases := []struct {
+		name           string
+		movieID        int64
+		buildStubs     func(store *mockdb.MockStore)
+		checkResponses func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			movieID: movie.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				deleted := movie
+				deleted.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().SoftDeleteMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(deleted, nil)
+				store.EXPECT().DeleteMovie(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:    "Invalid ID",
+			movieID: -3,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().SoftDeleteMovie(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:    "Movie Not Found",
+			movieID: movie.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().SoftDeleteMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:    "Internal Error",
+			movieID: movie.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().SoftDeleteMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(db.Movie{}, sql.ErrConnDone)
+			},
+			checkResponses: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/movies/%d", tt.movieID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponses(t, w)
+		})
+	}
+}
+
 // randomMovie creates a random movie
 func randomMovie() GetMovieResponse {
 	d := randomDirector()
@@ -378,12 +1029,32 @@ func randomMovie() GetMovieResponse {
 			Rating:     int16(util.RandomInt(5, 10)),
 			ID:         util.RandomInt(1, 1000),
 			DirectorID: d.ID,
+			// PG-13 lets the children in, restricted cases set their own certification
+			Certification: "PG-13",
+			Tags:          []string{util.RandomName()},
+			Runtime:       int32(util.RandomInt(80, 180)),
 		},
 		Director: db.Director{
 			FirstName: d.FirstName,
 			LastName:  d.LastName,
 			ID:        d.ID,
 			Oscars:    d.Oscars,
+			PersonID:  d.PersonID,
+		},
+		Genres: []db.Genre{
+			{
+				ID:   util.RandomInt(1, 20),
+				Name: util.RandomName(),
+			},
+		},
+		Credits: []db.ListMovieCreditsRow{
+			{
+				ID:        util.RandomInt(1, 1000),
+				PersonID:  d.PersonID,
+				FirstName: d.FirstName,
+				LastName:  d.LastName,
+				Role:      "director",
+			},
 		},
 	}
 }
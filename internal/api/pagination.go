@@ -0,0 +1,141 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ListResponse is the envelope of every list route
+type ListResponse[T any] struct {
+	Items []T `json:"items"`
+	// Total is only given by the routes that count the matching items
+	Total *int64 `json:"total,omitempty"`
+	// NextCursor is given by the cursor paginated routes, both are empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
+
+// CursorPageRequest holds the query values of the cursor paginated routes, the first page has no cursor
+type CursorPageRequest struct {
+	Cursor   string `form:"cursor" binding:"omitempty,max=256"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=50"`
+}
+
+// cursor is the position of the last item of a page, its kind keeps it from being used on another route
+type cursor struct {
+	Kind string `json:"k"`
+	ID   int64  `json:"id"`
+}
+
+// encodeCursor creates an opaque cursor for the given route and ID, it is signed so it can't be forged
+func (server *Server) encodeCursor(kind string, id int64) string {
+	payload, _ := json.Marshal(cursor{Kind: kind, ID: id})
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(server.signCursor(payload))
+}
+
+// decodeCursor verifies the given cursor and returns its ID, an empty cursor is the start of the list
+func (server *Server) decodeCursor(kind, token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return 0, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, server.signCursor(payload)) {
+		return 0, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.Kind != kind || c.ID < 1 {
+		return 0, ErrInvalidCursor
+	}
+
+	return c.ID, nil
+}
+
+// signCursor signs the payload of a cursor with the cursor key of the server
+func (server *Server) signCursor(payload []byte) []byte {
+	mac := hmac.New(sha256.New, server.cursorKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// newCursorKey returns the key the cursors are signed with. The token key isn't used as it is,
+// so signing a cursor never signs anything with the key of the access tokens
+func newCursorKey(config util.Config) []byte {
+	if config.CursorKey != "" {
+		return []byte(config.CursorKey)
+	}
+
+	mac := hmac.New(sha256.New, []byte(config.TokenSymmetricKey))
+	mac.Write([]byte("cursor"))
+	return mac.Sum(nil)
+}
+
+// trimCursorPage trims the extra item that is fetched to know if there is a next page,
+// it returns the items of the page and the cursor of the next page
+func trimCursorPage[T any](server *Server, kind string, items []T, pageSize int32, id func(T) int64) ([]T, string) {
+	if len(items) <= int(pageSize) {
+		return items, ""
+	}
+
+	items = items[:pageSize]
+	return items, server.encodeCursor(kind, id(items[pageSize-1]))
+}
+
+// newListResponse creates the envelope of a list, the next cursor is empty on the last page
+func newListResponse[T any](ctx *gin.Context, items []T, next string) ListResponse[T] {
+	res := ListResponse[T]{Items: items}
+	if res.Items == nil {
+		res.Items = []T{}
+	}
+
+	if next != "" {
+		res.NextCursor = next
+		res.Next = nextLink(ctx, "cursor", next)
+	}
+
+	return res
+}
+
+// collectIDs returns the distinct IDs of the given items so the related rows of a page can be fetched at once
+func collectIDs[T any](items []T, id func(T) int64) []int64 {
+	seen := make(map[int64]bool, len(items))
+	ids := make([]int64, 0, len(items))
+
+	for _, item := range items {
+		if v := id(item); !seen[v] {
+			seen[v] = true
+			ids = append(ids, v)
+		}
+	}
+
+	return ids
+}
+
+// nextLink returns the link of the current request with the given query value replaced
+func nextLink(ctx *gin.Context, key, value string) string {
+	query := ctx.Request.URL.Query()
+	query.Set(key, value)
+	return ctx.Request.URL.Path + "?" + query.Encode()
+}
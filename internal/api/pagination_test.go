@@ -0,0 +1,64 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCursor tests encodeCursor and decodeCursor
+func TestCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+	id := util.RandomInt(1, 1000)
+
+	// a cursor is decoded to its ID on its own list
+	c := server.encodeCursor(directorsCursor, id)
+	got, err := server.decodeCursor(directorsCursor, c)
+	require.NoError(t, err)
+	require.Equal(t, id, got)
+
+	// an empty cursor is the start of the list
+	got, err = server.decodeCursor(directorsCursor, "")
+	require.NoError(t, err)
+	require.Zero(t, got)
+
+	// a cursor can't be used on another list
+	_, err = server.decodeCursor(ticketsCursor, c)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	// a cursor of another server isn't accepted
+	other := newTestServer(t, mockdb.NewMockStore(ctrl))
+	_, err = other.decodeCursor(directorsCursor, c)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	// the payload of a cursor can't be changed without its signature
+	parts := strings.Split(c, ".")
+	forged := strings.Split(server.encodeCursor(directorsCursor, id+1), ".")[0] + "." + parts[1]
+	_, err = server.decodeCursor(directorsCursor, forged)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	for _, invalid := range []string{"abc", "a.b.c", "!!!." + parts[1], parts[0] + ".!!!"} {
+		_, err = server.decodeCursor(directorsCursor, invalid)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	}
+}
+
+// TestNewCursorKey tests that the cursors aren't signed with the token key
+func TestNewCursorKey(t *testing.T) {
+	tokenKey := util.RandomString(32)
+
+	derived := newCursorKey(util.Config{TokenSymmetricKey: tokenKey})
+	require.NotEqual(t, []byte(tokenKey), derived)
+	require.Equal(t, derived, newCursorKey(util.Config{TokenSymmetricKey: tokenKey}))
+
+	// the cursor key of the config is used as it is, so it can be rotated on its own
+	cursorKey := util.RandomString(32)
+	require.Equal(t, []byte(cursorKey), newCursorKey(util.Config{TokenSymmetricKey: tokenKey, CursorKey: cursorKey}))
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePersonRequest holds createPerson request's json data
+type CreatePersonRequest struct {
+	FirstName string `json:"first_name" binding:"required,min=2"`
+	LastName  string `json:"last_name" binding:"required,min=2"`
+}
+
+// createPerson creates a new person who can be credited in movies
+func (server *Server) createPerson(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreatePersonRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreatePersonParams{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	}
+
+	p, err := server.store.CreatePerson(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, p)
+}
+
+// GetPersonRequest holds the uri data of the request
+type GetPersonRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getPerson finds the person for given ID
+func (server *Server) getPerson(ctx *gin.Context) {
+	// first i check for the bindings
+	var req GetPersonRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	p, err := server.store.GetPerson(ctx, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, p)
+}
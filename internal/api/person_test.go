@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreatePersonAPI tests createPerson handler
+func TestCreatePersonAPI(t *testing.T) {
+	person := randomPerson()
+	staff := randomStaff(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"first_name": person.FirstName,
+				"last_name":  person.LastName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreatePersonParams{
+					FirstName: person.FirstName,
+					LastName:  person.LastName,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreatePerson(gomock.Any(), gomock.Eq(arg)).Times(1).Return(person, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireBodyMatchPerson(t, w.Body, person)
+			},
+		},
+		{
+			name: "No Last Name",
+			body: gin.H{
+				"first_name": person.FirstName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreatePerson(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			body: gin.H{
+				"first_name": person.FirstName,
+				"last_name":  person.LastName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreatePerson(gomock.Any(), gomock.Any()).Times(1).Return(db.Person{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/people", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestGetPersonAPI tests getPerson handler
+func TestGetPersonAPI(t *testing.T) {
+	person := randomPerson()
+
+	testCases := []struct {
+		name          string
+		personID      int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			personID: person.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPerson(gomock.Any(), gomock.Eq(person.ID)).Times(1).Return(person, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireBodyMatchPerson(t, w.Body, person)
+			},
+		},
+		{
+			name:     "Bindings Error",
+			personID: -3,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPerson(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Person Not Found",
+			personID: person.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPerson(gomock.Any(), gomock.Eq(person.ID)).Times(1).Return(db.Person{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Internal Server Error",
+			personID: person.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPerson(gomock.Any(), gomock.Eq(person.ID)).Times(1).Return(db.Person{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/people/%d", tt.personID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// randomPerson creates a random person
+func randomPerson() db.Person {
+	return db.Person{
+		ID:        util.RandomInt(1, 10000),
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+	}
+}
+
+// requireBodyMatchPerson checks for a given body and response's body
+func requireBodyMatchPerson(t *testing.T, body *bytes.Buffer, person db.Person) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var got db.Person
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Equal(t, person, got)
+}
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+const (
+	paymentMethodCash = "cash"
+	paymentMethodCard = "card"
+)
+
+var (
+	ErrNoOpenShift      = errors.New("cashier has no open shift")
+	ErrShiftAlreadyOpen = errors.New("cashier already has an open shift")
+	ErrShiftClosed      = errors.New("shift is already closed")
+	ErrNotShiftCashier  = errors.New("authenticated user and shift cashier doesn't match")
+)
+
+// OpenShiftRequest holds the json data of the request
+type OpenShiftRequest struct {
+	OpeningCash util.Money `json:"opening_cash"`
+}
+
+// openShift opens a new cash drawer shift for the authenticated cashier
+func (server *Server) openShift(ctx *gin.Context) {
+	// first i check for the bindings
+	var req OpenShiftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the cash drawer is in the currency of the app
+	if !server.requireMoney(ctx, req.OpeningCash) {
+		return
+	}
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.OpenCashShiftParams{
+		Cashier:     authPayload.Username,
+		OpeningCash: req.OpeningCash.Amount,
+	}
+
+	shift, err := server.store.OpenCashShift(ctx, arg)
+
+	// a cashier can only have one open shift at a time
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code.Name() == "unique_violation" {
+				ctx.JSON(http.StatusConflict, errorResponse(ErrShiftAlreadyOpen))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, server.newCashShiftResponse(shift))
+}
+
+// CreatePosSaleRequest holds the json data of the request
+type CreatePosSaleRequest struct {
+	MovieID       int64      `json:"movie_id" binding:"required,min=1"`
+	Total         util.Money `json:"total"`
+	Child         int16      `json:"child" binding:"min=0"`
+	Adult         int16      `json:"adult" binding:"min=0"`
+	PaymentMethod string     `json:"payment_method" binding:"required,oneof=cash card"`
+	GuestName     string     `json:"guest_name" binding:"max=64"`
+}
+
+// PosSaleResponse holds the data of a box office sale response
+type PosSaleResponse struct {
+	Sale  PosSaleDetails `json:"sale"`
+	Movie db.Movie       `json:"movie"`
+}
+
+// createPosSale sells a ticket at the box office to a walk-in customer without an account
+func (server *Server) createPosSale(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreatePosSaleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// then i check that i am not selling a ticket for no one
+	if req.Adult == 0 && req.Child == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidTicket))
+		return
+	}
+
+	// the box office sells in the currency of the app
+	if !server.requireMoney(ctx, req.Total) {
+		return
+	}
+
+	if req.Total.IsZero() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrZeroAmount))
+		return
+	}
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// every sale is recorded into the cashier's open shift
+	shift, err := server.store.GetOpenCashShift(ctx, authPayload.Username)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrNoOpenShift))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// then i get the movie of the sale and check for error
+	m, err := server.store.GetMovie(ctx, req.MovieID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if m.DeletedAt.Valid {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrMovieDeleted))
+		return
+	}
+
+	// children can't be let in to restricted movies
+	if req.Child > 0 && restrictedCertifications[m.Certification] {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrRestricted))
+		return
+	}
+
+	// then i generate the code that gets printed on the ticket
+	code, err := util.NewTicketCode()
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the total of the box office includes the tax of the tax rate of the config
+	_, tax := req.Total.SplitTax(server.taxRate)
+
+	arg := db.CreatePosSaleParams{
+		ShiftID:       shift.ID,
+		MovieID:       req.MovieID,
+		GuestName:     req.GuestName,
+		PaymentMethod: req.PaymentMethod,
+		TicketCode:    code,
+		Child:         req.Child,
+		Adult:         req.Adult,
+		Total:         req.Total.Amount,
+		Currency:      req.Total.Currency,
+		Tax:           tax.Amount,
+	}
+
+	sale, err := server.store.CreatePosSale(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, PosSaleResponse{Sale: newPosSaleDetails(sale), Movie: m})
+}
+
+// GetPosSaleRequest holds the uri data of the request
+type GetPosSaleRequest struct {
+	Code string `uri:"code" binding:"required,alphanum"`
+}
+
+// getPosSale finds a box office sale by its printed ticket code
+func (server *Server) getPosSale(ctx *gin.Context) {
+	// first i check for the bindings
+	var req GetPosSaleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sale, err := server.store.GetPosSaleByCode(ctx, req.Code)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	m, err := server.store.GetMovie(ctx, sale.MovieID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, PosSaleResponse{Sale: newPosSaleDetails(sale), Movie: m})
+}
+
+// ShiftRequest holds the uri data of the shift requests
+type ShiftRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// CloseShiftRequest holds the json data of the request
+type CloseShiftRequest struct {
+	CountedCash util.Money `json:"counted_cash"`
+}
+
+// ShiftReport holds the cash drawer reconciliation of a shift
+type ShiftReport struct {
+	Shift        CashShiftResponse `json:"shift"`
+	CashSales    int64             `json:"cash_sales"`
+	CashTotal    util.Money        `json:"cash_total"`
+	CardSales    int64             `json:"card_sales"`
+	CardTotal    util.Money        `json:"card_total"`
+	ExpectedCash util.Money        `json:"expected_cash"`
+	Discrepancy  util.Money        `json:"discrepancy"`
+}
+
+// closeShift closes the cashier's shift with the counted cash and returns the reconciliation report
+func (server *Server) closeShift(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri ShiftRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req CloseShiftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.requireMoney(ctx, req.CountedCash) {
+		return
+	}
+
+	shift, ok := server.getOwnShift(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	if shift.ClosedAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrShiftClosed))
+		return
+	}
+
+	arg := db.CloseCashShiftParams{
+		ID:          shift.ID,
+		CountedCash: sql.NullInt64{Int64: req.CountedCash.Amount, Valid: true},
+	}
+
+	shift, err := server.store.CloseCashShift(ctx, arg)
+
+	if err != nil {
+		// if no rows are updated the shift got closed in the meantime
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrShiftClosed))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writeShiftReport(ctx, shift)
+}
+
+// getShiftReport returns the reconciliation report of a shift
+func (server *Server) getShiftReport(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri ShiftRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shift, ok := server.getOwnShift(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	server.writeShiftReport(ctx, shift)
+}
+
+// getOwnShift gets the shift for given ID and checks that it belongs to the authenticated cashier,
+// it writes the error response itself and returns false if anything goes wrong
+func (server *Server) getOwnShift(ctx *gin.Context, id int64) (db.CashShift, bool) {
+	shift, err := server.store.GetCashShift(ctx, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return shift, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return shift, false
+	}
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if shift.Cashier != authPayload.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrNotShiftCashier))
+		return shift, false
+	}
+
+	return shift, true
+}
+
+// writeShiftReport sums up the sales of the shift and writes the report as response
+func (server *Server) writeShiftReport(ctx *gin.Context, shift db.CashShift) {
+	rows, err := server.store.SummarizeCashShift(ctx, shift.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	report := server.newShiftReport(shift, rows)
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// newShiftReport reconciles the cash drawer, discrepancy is only set once the cash is counted
+func (server *Server) newShiftReport(shift db.CashShift, rows []db.SummarizeCashShiftRow) ShiftReport {
+	var cashTotal, cardTotal, discrepancy int64
+	report := ShiftReport{Shift: server.newCashShiftResponse(shift)}
+
+	for _, r := range rows {
+		switch r.PaymentMethod {
+		case paymentMethodCash:
+			report.CashSales = r.Sales
+			cashTotal = r.Total
+		case paymentMethodCard:
+			report.CardSales = r.Sales
+			cardTotal = r.Total
+		}
+	}
+
+	expectedCash := shift.OpeningCash + cashTotal
+
+	if shift.CountedCash.Valid {
+		discrepancy = shift.CountedCash.Int64 - expectedCash
+	}
+
+	report.CashTotal = server.money(cashTotal)
+	report.CardTotal = server.money(cardTotal)
+	report.ExpectedCash = server.money(expectedCash)
+	report.Discrepancy = server.money(discrepancy)
+
+	return report
+}
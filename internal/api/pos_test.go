@@ -0,0 +1,381 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenShiftAPI tests openShift handler
+func TestOpenShiftAPI(t *testing.T) {
+	staff := randomStaff(t)
+	shift := randomCashShift(staff)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"opening_cash": util.NewMoney(shift.OpeningCash, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.OpenCashShiftParams{
+					Cashier:     staff.Username,
+					OpeningCash: shift.OpeningCash,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().OpenCashShift(gomock.Any(), gomock.Eq(arg)).Times(1).Return(shift, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Opening Cash",
+			body: gin.H{"opening_cash": util.NewMoney(-1, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().OpenCashShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Shift Already Open",
+			body: gin.H{"opening_cash": util.NewMoney(shift.OpeningCash, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().OpenCashShift(gomock.Any(), gomock.Any()).Times(1).Return(db.CashShift{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/pos/shifts", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestCreatePosSaleAPI tests createPosSale handler
+func TestCreatePosSaleAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	shift := randomCashShift(staff)
+	movie := randomMovie().Movie
+
+	body := gin.H{
+		"movie_id":       movie.ID,
+		"child":          1,
+		"adult":          2,
+		"total":          util.NewMoney(90, util.DefaultCurrency),
+		"payment_method": "cash",
+		"guest_name":     "walk in",
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetOpenCashShift(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(shift, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().CreatePosSale(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePosSaleParams) (db.PosSale, error) {
+						require.Equal(t, shift.ID, arg.ShiftID)
+						require.Equal(t, "cash", arg.PaymentMethod)
+						require.Equal(t, util.DefaultCurrency, arg.Currency)
+						// 90 includes 18% of tax on a net of 76
+						require.Equal(t, int64(14), arg.Tax)
+						require.Len(t, arg.TicketCode, 10)
+						return db.PosSale{ID: 1, ShiftID: arg.ShiftID, MovieID: arg.MovieID, TicketCode: arg.TicketCode, Total: arg.Total, Tax: arg.Tax, Currency: arg.Currency}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got PosSaleResponse
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.NotEmpty(t, got.Sale.TicketCode)
+				require.Equal(t, util.NewMoney(76, util.DefaultCurrency), got.Sale.Net)
+				require.Equal(t, util.NewMoney(14, util.DefaultCurrency), got.Sale.Tax)
+				require.Equal(t, movie.ID, got.Movie.ID)
+			},
+		},
+		{
+			name:     "Restricted Movie",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				restricted := movie
+				restricted.Certification = "R"
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetOpenCashShift(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(shift, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(restricted, nil)
+				store.EXPECT().CreatePosSale(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Not Staff",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetOpenCashShift(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePosSale(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Invalid Payment Method",
+			username: staff.Username,
+			body: gin.H{
+				"movie_id":       movie.ID,
+				"adult":          2,
+				"total":          util.NewMoney(90, util.DefaultCurrency),
+				"payment_method": "cheque",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetOpenCashShift(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePosSale(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "No Open Shift",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetOpenCashShift(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(db.CashShift{}, sql.ErrNoRows)
+				store.EXPECT().CreatePosSale(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Movie Not Found",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetOpenCashShift(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(shift, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+				store.EXPECT().CreatePosSale(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.taxRate = 1800
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/pos/sales", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestCloseShiftAPI tests closeShift handler
+func TestCloseShiftAPI(t *testing.T) {
+	staff := randomStaff(t)
+	otherStaff := randomStaff(t)
+	shift := randomCashShift(staff)
+
+	closedShift := shift
+	closedShift.CountedCash = sql.NullInt64{Int64: shift.OpeningCash + 100, Valid: true}
+	closedShift.ClosedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	summary := []db.SummarizeCashShiftRow{
+		{PaymentMethod: "card", Sales: 2, Total: 200},
+		{PaymentMethod: "cash", Sales: 3, Total: 90},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CloseCashShiftParams{
+					ID:          shift.ID,
+					CountedCash: closedShift.CountedCash,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetCashShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				store.EXPECT().CloseCashShift(gomock.Any(), gomock.Eq(arg)).Times(1).Return(closedShift, nil)
+				store.EXPECT().SummarizeCashShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(summary, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ShiftReport
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, int64(3), got.CashSales)
+				require.Equal(t, util.NewMoney(200, util.DefaultCurrency), got.CardTotal)
+				require.Equal(t, util.NewMoney(shift.OpeningCash+90, util.DefaultCurrency), got.ExpectedCash)
+				require.Equal(t, util.NewMoney(10, util.DefaultCurrency), got.Discrepancy)
+			},
+		},
+		{
+			name:     "Other Cashier",
+			username: otherStaff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(otherStaff.Username)).Times(1).Return(otherStaff, nil)
+				store.EXPECT().GetCashShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				store.EXPECT().CloseCashShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, w.Code)
+			},
+		},
+		{
+			name:     "Already Closed",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetCashShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(closedShift, nil)
+				store.EXPECT().CloseCashShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:     "Shift Not Found",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetCashShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(db.CashShift{}, sql.ErrNoRows)
+				store.EXPECT().CloseCashShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"counted_cash": util.NewMoney(closedShift.CountedCash.Int64, util.DefaultCurrency)})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/pos/shifts/%d/close", shift.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// randomCashShift creates a random open cash shift for given cashier
+func randomCashShift(cashier db.User) db.CashShift {
+	return db.CashShift{
+		ID:          util.RandomInt(1, 1000),
+		Cashier:     cashier.Username,
+		OpeningCash: util.RandomInt(0, 500),
+		OpenedAt:    time.Now(),
+	}
+}
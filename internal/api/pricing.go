@@ -0,0 +1,371 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/pricing"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrInvalidPricingRule = errors.New("pricing rule is missing the fields of its kind")
+	ErrInvalidRuleWindow  = errors.New("valid_until must be after valid_from")
+	ErrPricingRuleMissing = errors.New("pricing rule doesn't exist")
+	ErrScreeningNotPriced = errors.New("screening has no auditorium to price its seats")
+)
+
+// the base prices of the seats of a venue if they are not given
+const (
+	defaultAdultPrice = 1000
+	defaultChildPrice = 700
+)
+
+// holidayLayout is the layout of the dates of the holiday rules
+const holidayLayout = "2006-01-02"
+
+const pricingRulesCursor = "pricing_rules"
+
+// CreatePricingRuleRequest holds the json data of the request, a rule without a venue applies to every venue
+type CreatePricingRuleRequest struct {
+	VenueID int64  `json:"venue_id" binding:"omitempty,min=1"`
+	Name    string `json:"name" binding:"required,max=64"`
+	Kind    string `json:"kind" binding:"required,oneof=matinee weekday holiday occupancy"`
+	// Percent is added to the price of the seats, a negative percent is a discount
+	Percent int32 `json:"percent" binding:"required,min=-100,max=500"`
+	// StartsBefore is the local time of the day the matinee screenings start before
+	StartsBefore string `json:"starts_before" binding:"omitempty,datetime=15:04"`
+	// Weekdays are the days of the weekday rules, Sunday is 0
+	Weekdays []int32 `json:"weekdays" binding:"omitempty,max=7,unique,dive,min=0,max=6"`
+	Holiday  string  `json:"holiday" binding:"omitempty,datetime=2006-01-02"`
+	// MinOccupancy is the percent of the sold seats that triggers an occupancy rule
+	MinOccupancy int32      `json:"min_occupancy" binding:"omitempty,min=1,max=100"`
+	ValidFrom    *time.Time `json:"valid_from"`
+	ValidUntil   *time.Time `json:"valid_until"`
+}
+
+// createPricingRule creates a pricing rule, it applies to the prices quoted after it is created
+func (server *Server) createPricingRule(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreatePricingRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreatePricingRuleParams{
+		VenueID:  sql.NullInt64{Int64: req.VenueID, Valid: req.VenueID != 0},
+		Name:     req.Name,
+		Kind:     req.Kind,
+		Percent:  req.Percent,
+		Weekdays: []int32{},
+	}
+
+	// then i check that the rule has the fields of its kind
+	switch req.Kind {
+	case pricing.RuleMatinee:
+		if req.StartsBefore == "" {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidPricingRule))
+			return
+		}
+		// a matinee before midnight lasts the whole day
+		startsBefore, _ := time.Parse(clockLayout, req.StartsBefore)
+		minutes := int32(startsBefore.Hour()*60 + startsBefore.Minute())
+		if minutes == 0 {
+			minutes = minutesPerDay
+		}
+		arg.StartsBefore = sql.NullInt32{Int32: minutes, Valid: true}
+	case pricing.RuleWeekday:
+		if len(req.Weekdays) == 0 {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidPricingRule))
+			return
+		}
+		arg.Weekdays = req.Weekdays
+	case pricing.RuleHoliday:
+		if req.Holiday == "" {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidPricingRule))
+			return
+		}
+		holiday, _ := time.Parse(holidayLayout, req.Holiday)
+		arg.Holiday = sql.NullTime{Time: holiday, Valid: true}
+	case pricing.RuleOccupancy:
+		if req.MinOccupancy == 0 {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidPricingRule))
+			return
+		}
+		arg.MinOccupancy = sql.NullInt32{Int32: req.MinOccupancy, Valid: true}
+	}
+
+	if req.ValidFrom != nil {
+		arg.ValidFrom = sql.NullTime{Time: *req.ValidFrom, Valid: true}
+	}
+	if req.ValidUntil != nil {
+		if req.ValidFrom != nil && !req.ValidUntil.After(*req.ValidFrom) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidRuleWindow))
+			return
+		}
+		arg.ValidUntil = sql.NullTime{Time: *req.ValidUntil, Valid: true}
+	}
+
+	// a rule of a venue needs the venue
+	if req.VenueID != 0 {
+		if _, ok := server.requireVenue(ctx, req.VenueID); !ok {
+			return
+		}
+	}
+
+	rule, err := server.store.CreatePricingRule(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// listPricingRules returns a page of the pricing rules that starts after the given cursor
+func (server *Server) listPricingRules(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CursorPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	afterID, err := server.decodeCursor(pricingRulesCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rules, err := server.store.ListPricingRules(ctx, db.ListPricingRulesParams{AfterID: afterID, Limit: req.PageSize + 1})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rules, next := trimCursorPage(server, pricingRulesCursor, rules, req.PageSize, func(r db.PricingRule) int64 { return r.ID })
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, rules, next))
+}
+
+// PricingRuleURIRequest holds the uri data of the request
+type PricingRuleURIRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// deletePricingRule deletes a pricing rule, the tickets sold with it keep their prices
+func (server *Server) deletePricingRule(ctx *gin.Context) {
+	// first i check for the bindings
+	var req PricingRuleURIRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.DeletePricingRule(ctx, req.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrPricingRuleMissing))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+// ScreeningPriceURIRequest holds the uri data of the request
+type ScreeningPriceURIRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// ScreeningPriceRequest holds the query values of the request, a single adult seat is priced if they are not given
+type ScreeningPriceRequest struct {
+	Adult *int16 `form:"adult" binding:"omitempty,min=0,max=50"`
+	Child int16  `form:"child" binding:"min=0,max=50"`
+}
+
+// getScreeningPrice quotes the seats of a published screening with the pricing rules of its venue
+func (server *Server) getScreeningPrice(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri ScreeningPriceURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ScreeningPriceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	adult := int16(1)
+	if req.Adult != nil {
+		adult = *req.Adult
+	}
+
+	if adult == 0 && req.Child == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidTicket))
+		return
+	}
+
+	screening, err := server.store.GetScreening(ctx, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the screenings that are not published or are booked privately are not on the listings either
+	if !screening.PublishedAt.Valid || screening.PrivateBookingID.Valid {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	if !screening.AuditoriumID.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrScreeningNotPriced))
+		return
+	}
+
+	b, ok := server.quoteScreening(ctx, screening, adult, req.Child)
+	if !ok {
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, b)
+}
+
+// quoteScreening prices the seats of a screening in an auditorium with the base prices and the rules of its venue.
+// It writes the error response and returns false if anything the price depends on can't be loaded
+func (server *Server) quoteScreening(ctx *gin.Context, screening db.Screening, adult, child int16) (pricing.Breakdown, bool) {
+	a, err := server.store.GetAuditorium(ctx, screening.AuditoriumID.Int64)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return pricing.Breakdown{}, false
+	}
+
+	v, err := server.store.GetVenue(ctx, a.VenueID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return pricing.Breakdown{}, false
+	}
+
+	loc, err := time.LoadLocation(v.TimeZone)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return pricing.Breakdown{}, false
+	}
+
+	f, err := server.store.GetScreeningFormat(ctx, screening.Format)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return pricing.Breakdown{}, false
+	}
+
+	sold, err := server.store.CountScreeningSeats(ctx, sql.NullInt64{Int64: screening.ID, Valid: true})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return pricing.Breakdown{}, false
+	}
+
+	// the seats can't be quoted if the auditorium doesn't have them left, the purchase checks it again while the screening is locked
+	if sold+int64(adult)+int64(child) > int64(a.Seats) {
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrScreeningFull))
+		return pricing.Breakdown{}, false
+	}
+
+	rules, err := server.store.ListVenuePricingRules(ctx, sql.NullInt64{Int64: v.ID, Valid: true})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return pricing.Breakdown{}, false
+	}
+
+	req := pricing.Request{
+		StartsAt:   screening.StartsAt,
+		Location:   loc,
+		Adult:      adult,
+		Child:      child,
+		Currency:   server.currency,
+		TaxRate:    v.TaxRate,
+		AdultPrice: v.AdultPrice,
+		ChildPrice: v.ChildPrice,
+		FormatName: f.Name,
+		Surcharge:  f.Surcharge,
+		Seats:      a.Seats,
+		Sold:       sold,
+		Rules:      make([]pricing.Rule, 0, len(rules)),
+	}
+
+	for _, r := range rules {
+		req.Rules = append(req.Rules, newPricingRule(r))
+	}
+
+	return server.pricer.Quote(req), true
+}
+
+// newPricingRule converts a pricing rule of the DB to a rule of the pricing engine
+func newPricingRule(r db.PricingRule) pricing.Rule {
+	rule := pricing.Rule{
+		ID:           r.ID,
+		Name:         r.Name,
+		Kind:         r.Kind,
+		Percent:      r.Percent,
+		StartsBefore: r.StartsBefore.Int32,
+		Weekdays:     make([]time.Weekday, 0, len(r.Weekdays)),
+		Date:         r.Holiday.Time,
+		MinOccupancy: r.MinOccupancy.Int32,
+	}
+
+	for _, d := range r.Weekdays {
+		rule.Weekdays = append(rule.Weekdays, time.Weekday(d))
+	}
+
+	if r.ValidFrom.Valid {
+		rule.ValidFrom = &r.ValidFrom.Time
+	}
+	if r.ValidUntil.Valid {
+		rule.ValidUntil = &r.ValidUntil.Time
+	}
+
+	return rule
+}
@@ -0,0 +1,412 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/pricing"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreatePricingRuleAPI tests createPricingRule handler
+func TestCreatePricingRuleAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	venue := randomVenue()
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK Matinee",
+			username: staff.Username,
+			body:     gin.H{"venue_id": venue.ID, "name": "Matinee", "kind": "matinee", "percent": -20, "starts_before": "13:00"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreatePricingRuleParams{
+					VenueID:      sql.NullInt64{Int64: venue.ID, Valid: true},
+					Name:         "Matinee",
+					Kind:         pricing.RuleMatinee,
+					Percent:      -20,
+					StartsBefore: sql.NullInt32{Int32: 13 * 60, Valid: true},
+					Weekdays:     []int32{},
+				}
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().CreatePricingRule(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PricingRule{ID: 1, Name: "Matinee"}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "OK Every Venue",
+			username: staff.Username,
+			body:     gin.H{"name": "Cheap Tuesday", "kind": "weekday", "percent": -30, "weekdays": []int{2}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreatePricingRuleParams{
+					Name:     "Cheap Tuesday",
+					Kind:     pricing.RuleWeekday,
+					Percent:  -30,
+					Weekdays: []int32{2},
+				}
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePricingRule(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PricingRule{ID: 1}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Missing Fields Of Kind",
+			username: staff.Username,
+			body:     gin.H{"name": "Holiday", "kind": "holiday", "percent": 25},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreatePricingRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Invalid Weekday",
+			username: staff.Username,
+			body:     gin.H{"name": "Cheap Day", "kind": "weekday", "percent": -30, "weekdays": []int{7}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreatePricingRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Invalid Window",
+			username: staff.Username,
+			body: gin.H{
+				"name":          "Surge",
+				"kind":          "occupancy",
+				"percent":       15,
+				"min_occupancy": 80,
+				"valid_from":    time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+				"valid_until":   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreatePricingRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Venue Not Found",
+			username: staff.Username,
+			body:     gin.H{"venue_id": venue.ID, "name": "Surge", "kind": "occupancy", "percent": 15, "min_occupancy": 80},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(db.Venue{}, sql.ErrNoRows)
+				store.EXPECT().CreatePricingRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Not Staff",
+			username: user.Username,
+			body:     gin.H{"name": "Surge", "kind": "occupancy", "percent": 15, "min_occupancy": 80},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreatePricingRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/pricing-rules", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestDeletePricingRuleAPI tests deletePricingRule handler
+func TestDeletePricingRuleAPI(t *testing.T) {
+	staff := randomStaff(t)
+	id := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		id            int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   id,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeletePricingRule(gomock.Any(), gomock.Eq(id)).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Not Found",
+			id:   id,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeletePricingRule(gomock.Any(), gomock.Eq(id)).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Invalid ID",
+			id:   0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeletePricingRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/pricing-rules/%d", tt.id), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestGetScreeningPriceAPI tests getScreeningPrice handler
+func TestGetScreeningPriceAPI(t *testing.T) {
+	venue := randomVenue()
+	auditorium := randomAuditorium(venue)
+	screening := randomScreening(randomMovie().Movie)
+	screening.PublishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	screening.AuditoriumID = sql.NullInt64{Int64: auditorium.ID, Valid: true}
+
+	// the rule matches every day so the price doesn't depend on the random start of the screening
+	everyDay := db.PricingRule{ID: 1, Name: "Launch Week", Kind: pricing.RuleWeekday, Percent: -10, Weekdays: []int32{0, 1, 2, 3, 4, 5, 6}}
+
+	buildQuoteStubs := func(store *mockdb.MockStore, s db.Screening) {
+		store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(s.ID)).Times(1).Return(s, nil)
+		store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+		store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+		store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Eq(s.Format)).Times(1).
+			Return(db.ScreeningFormat{Code: s.Format, Name: "IMAX", Surcharge: 500}, nil)
+		store.EXPECT().CountScreeningSeats(gomock.Any(), gomock.Eq(sql.NullInt64{Int64: s.ID, Valid: true})).Times(1).Return(int64(10), nil)
+		store.EXPECT().ListVenuePricingRules(gomock.Any(), gomock.Eq(sql.NullInt64{Int64: venue.ID, Valid: true})).Times(1).
+			Return([]db.PricingRule{everyDay}, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		id            int64
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			id:    screening.ID,
+			query: "?adult=2&child=1",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildQuoteStubs(store, screening)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got pricing.Breakdown
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+
+				// 2 x 1000 + 700, 10 percent off and 3 x 500 surcharge
+				require.Len(t, got.Lines, 4)
+				require.Equal(t, everyDay.ID, got.Lines[2].RuleID)
+				require.Equal(t, util.NewMoney(-270, util.DefaultCurrency), got.Lines[2].Amount)
+				require.Equal(t, util.NewMoney(1500, util.DefaultCurrency), got.Surcharge)
+				require.Equal(t, util.NewMoney(2700-270+1500, util.DefaultCurrency), got.Total)
+			},
+		},
+		{
+			name:  "Single Adult By Default",
+			id:    screening.ID,
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildQuoteStubs(store, screening)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				var got pricing.Breakdown
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				require.Equal(t, util.NewMoney(1000-100+500, util.DefaultCurrency), got.Total)
+			},
+		},
+		{
+			name:  "Not Enough Seats Left",
+			id:    screening.ID,
+			query: "?adult=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(screening, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Any()).Times(1).Return(db.ScreeningFormat{}, nil)
+				store.EXPECT().CountScreeningSeats(gomock.Any(), gomock.Any()).Times(1).Return(int64(auditorium.Seats-1), nil)
+				store.EXPECT().ListVenuePricingRules(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:  "No Seats",
+			id:    screening.ID,
+			query: "?adult=0",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Not Published",
+			id:    screening.ID,
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				unpublished := screening
+				unpublished.PublishedAt = sql.NullTime{}
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(unpublished, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:  "No Auditorium",
+			id:    screening.ID,
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				legacy := screening
+				legacy.AuditoriumID = sql.NullInt64{}
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(legacy, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:  "Screening Not Found",
+			id:    screening.ID,
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(db.Screening{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:  "Rules Internal Error",
+			id:    screening.ID,
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(screening, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Any()).Times(1).Return(db.ScreeningFormat{}, nil)
+				store.EXPECT().CountScreeningSeats(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().ListVenuePricingRules(gomock.Any(), gomock.Any()).Times(1).Return([]db.PricingRule{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/screenings/%d/price%s", tt.id, tt.query), nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
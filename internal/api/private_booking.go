@@ -0,0 +1,844 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/booking"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrPrivateBookingMissing = errors.New("private booking doesn't exist")
+	ErrScreeningNotBookable  = errors.New("screening has no auditorium to be booked")
+	ErrTooManyGuests         = errors.New("guests can't be more than the seats of the auditorium")
+	ErrOrganizerOnly         = errors.New("only the organizer of the booking can do this action")
+	ErrBookingNotQuotable    = errors.New("booking can't be quoted after its quote is accepted")
+	ErrBookingNotQuoted      = errors.New("booking has no quote to accept")
+	ErrQuoteExpired          = errors.New("quote of the booking is expired")
+	ErrBookingNotAccepted    = errors.New("quote of the booking must be accepted before it is confirmed")
+	ErrBookingNotCancellable = errors.New("booking can't be cancelled in its status")
+	ErrBookingChanged        = errors.New("booking is changed meanwhile")
+	ErrInvoiceMissing        = errors.New("booking has no invoice")
+	ErrInvoiceNotPayable     = errors.New("invoice is already paid or voided")
+)
+
+// the statuses of a private booking. A booking is requested by its organizer, quoted by the venue staff,
+// accepted by its organizer with the deposit and confirmed by a manager of the venue
+const (
+	bookingRequested = "requested"
+	bookingQuoted    = "quoted"
+	bookingAccepted  = "accepted"
+	bookingRejected  = "rejected"
+	bookingCancelled = "cancelled"
+)
+
+// privateBookingsCursor is the kind of the cursors of the private booking lists
+const privateBookingsCursor = "private_bookings"
+
+// defaultQuoteValidDays is the count of the days a quote can be accepted in if it isn't given
+const defaultQuoteValidDays = 14
+
+// QuoteResponse holds the quote of a private booking, the balance of the total is invoiced after the booking is confirmed
+type QuoteResponse struct {
+	Net              util.Money `json:"net"`
+	Tax              util.Money `json:"tax"`
+	Total            util.Money `json:"total"`
+	Deposit          util.Money `json:"deposit"`
+	PaymentTermsDays int32      `json:"payment_terms_days"`
+	QuotedBy         string     `json:"quoted_by"`
+	QuotedAt         time.Time  `json:"quoted_at"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+}
+
+// InvoiceResponse holds the invoice of a confirmed private booking, the balance is the total without the deposit
+type InvoiceResponse struct {
+	Number           string       `json:"number"`
+	Net              util.Money   `json:"net"`
+	Tax              util.Money   `json:"tax"`
+	Total            util.Money   `json:"total"`
+	Deposit          util.Money   `json:"deposit"`
+	Balance          util.Money   `json:"balance"`
+	IssuedAt         time.Time    `json:"issued_at"`
+	DueAt            time.Time    `json:"due_at"`
+	PaidAt           sql.NullTime `json:"paid_at"`
+	PaymentReference string       `json:"payment_reference,omitempty"`
+	VoidedAt         sql.NullTime `json:"voided_at"`
+}
+
+// newInvoiceResponse creates the response of an invoice
+func newInvoiceResponse(i db.PrivateBookingInvoice) InvoiceResponse {
+	return InvoiceResponse{
+		Number:           i.Number,
+		Net:              util.NewMoney(i.Net, i.Currency),
+		Tax:              util.NewMoney(i.Tax, i.Currency),
+		Total:            util.NewMoney(i.Total, i.Currency),
+		Deposit:          util.NewMoney(i.Deposit, i.Currency),
+		Balance:          util.NewMoney(i.Balance, i.Currency),
+		IssuedAt:         i.IssuedAt,
+		DueAt:            i.DueAt,
+		PaidAt:           i.PaidAt,
+		PaymentReference: i.PaymentReference.String,
+		VoidedAt:         i.VoidedAt,
+	}
+}
+
+// PrivateBookingResponse holds a private booking, the quote is given after the booking is quoted
+// and the invoice is only given by the confirmation
+type PrivateBookingResponse struct {
+	ID            int64            `json:"id"`
+	Organizer     string           `json:"organizer"`
+	Organization  string           `json:"organization"`
+	ContactEmail  string           `json:"contact_email"`
+	ScreeningID   int64            `json:"screening_id"`
+	VenueID       int64            `json:"venue_id"`
+	Guests        int32            `json:"guests"`
+	Notes         string           `json:"notes"`
+	Status        string           `json:"status"`
+	Quote         *QuoteResponse   `json:"quote,omitempty"`
+	AcceptedAt    sql.NullTime     `json:"accepted_at"`
+	ApprovedBy    string           `json:"approved_by,omitempty"`
+	ConfirmedAt   sql.NullTime     `json:"confirmed_at"`
+	CancelledAt   sql.NullTime     `json:"cancelled_at"`
+	CancelReason  string           `json:"cancel_reason,omitempty"`
+	RefundPending bool             `json:"refund_pending,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	Invoice       *InvoiceResponse `json:"invoice,omitempty"`
+}
+
+// newPrivateBookingResponse creates the response of a private booking and its invoice
+func newPrivateBookingResponse(b db.PrivateBooking, invoice *db.PrivateBookingInvoice) PrivateBookingResponse {
+	res := PrivateBookingResponse{
+		ID:            b.ID,
+		Organizer:     b.Organizer,
+		Organization:  b.Organization,
+		ContactEmail:  b.ContactEmail,
+		ScreeningID:   b.ScreeningID,
+		VenueID:       b.VenueID,
+		Guests:        b.Guests,
+		Notes:         b.Notes,
+		Status:        b.Status,
+		AcceptedAt:    b.AcceptedAt,
+		ApprovedBy:    b.ApprovedBy.String,
+		ConfirmedAt:   b.ConfirmedAt,
+		CancelledAt:   b.CancelledAt,
+		CancelReason:  b.CancelReason,
+		CreatedAt:     b.CreatedAt,
+		RefundPending: booking.RefundPending(b),
+	}
+
+	if b.QuotedAt.Valid {
+		q := quoteOf(b)
+		res.Quote = &QuoteResponse{
+			Net:              q.Net,
+			Tax:              q.Tax,
+			Total:            q.Total,
+			Deposit:          q.Deposit,
+			PaymentTermsDays: q.TermsDays,
+			QuotedBy:         b.QuotedBy.String,
+			QuotedAt:         b.QuotedAt.Time,
+			ExpiresAt:        q.ExpiresAt,
+		}
+	}
+
+	if invoice != nil {
+		i := newInvoiceResponse(*invoice)
+		res.Invoice = &i
+	}
+
+	return res
+}
+
+// CreatePrivateBookingRequest holds the json data of the request, the whole auditorium of the screening is booked
+type CreatePrivateBookingRequest struct {
+	ScreeningID  int64  `json:"screening_id" binding:"required,min=1"`
+	Organization string `json:"organization" binding:"required,max=128"`
+	ContactEmail string `json:"contact_email" binding:"required,email"`
+	Guests       int32  `json:"guests" binding:"required,min=1"`
+	Notes        string `json:"notes" binding:"max=1000"`
+}
+
+// createPrivateBooking requests a private booking of an upcoming screening for the authenticated user,
+// the staff of the venue of the screening quotes it
+func (server *Server) createPrivateBooking(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreatePrivateBookingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	screening, err := server.store.GetScreening(ctx, req.ScreeningID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !screening.AuditoriumID.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrScreeningNotBookable))
+		return
+	}
+
+	if !screening.StartsAt.After(time.Now()) {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrScreeningInPast))
+		return
+	}
+
+	if screening.PrivateBookingID.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrScreeningBooked))
+		return
+	}
+
+	auditorium, err := server.store.GetAuditorium(ctx, screening.AuditoriumID.Int64)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Guests > auditorium.Seats {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrTooManyGuests))
+		return
+	}
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	b, err := server.store.CreatePrivateBooking(ctx, db.CreatePrivateBookingParams{
+		Organizer:    authPayload.Username,
+		Organization: req.Organization,
+		ContactEmail: req.ContactEmail,
+		ScreeningID:  screening.ID,
+		VenueID:      auditorium.VenueID,
+		Guests:       req.Guests,
+		Notes:        req.Notes,
+	})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writePrivateBooking(ctx, b, nil)
+}
+
+// GetPrivateBookingRequest holds the uri of the private booking routes
+type GetPrivateBookingRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getPrivateBooking returns a private booking to its organizer or the staff of its venue
+func (server *Server) getPrivateBooking(ctx *gin.Context) {
+	b, ok := server.requirePrivateBooking(ctx)
+	if !ok || !server.requireBookingAccess(ctx, b) {
+		return
+	}
+
+	server.writePrivateBooking(ctx, b, nil)
+}
+
+// listMyPrivateBookings returns a page of the private bookings of the authenticated user
+func (server *Server) listMyPrivateBookings(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CursorPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	afterID, err := server.decodeCursor(privateBookingsCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	bookings, err := server.store.ListOrganizerPrivateBookings(ctx, db.ListOrganizerPrivateBookingsParams{
+		Organizer: authPayload.Username,
+		AfterID:   afterID,
+		Limit:     req.PageSize + 1,
+	})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writePrivateBookings(ctx, bookings, req.PageSize)
+}
+
+// ListVenuePrivateBookingsRequest holds the query of the request
+type ListVenuePrivateBookingsRequest struct {
+	CursorPageRequest
+	Status string `form:"status" binding:"omitempty,oneof=requested quoted accepted confirmed rejected cancelled"`
+}
+
+// listVenuePrivateBookings returns a page of the private bookings of a venue with the given status,
+// only the staff of the venue can see them
+func (server *Server) listVenuePrivateBookings(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetVenueRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ListVenuePrivateBookingsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	afterID, err := server.decodeCursor(privateBookingsCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	v, ok := server.requireVenue(ctx, uri.ID)
+	if !ok || !server.requireVenueRole(ctx, v.ID, venueRoleStaff) {
+		return
+	}
+
+	bookings, err := server.store.ListVenuePrivateBookings(ctx, db.ListVenuePrivateBookingsParams{
+		VenueID: v.ID,
+		Status:  sql.NullString{String: req.Status, Valid: req.Status != ""},
+		AfterID: afterID,
+		Limit:   req.PageSize + 1,
+	})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writePrivateBookings(ctx, bookings, req.PageSize)
+}
+
+// QuotePrivateBookingRequest holds the json data of the request, the tax of the venue is added to the net price
+type QuotePrivateBookingRequest struct {
+	Net     util.Money `json:"net"`
+	Deposit util.Money `json:"deposit"`
+	// PaymentTermsDays is the count of the days the balance of the invoice is due after the booking is confirmed
+	PaymentTermsDays int32 `json:"payment_terms_days" binding:"min=0,max=90"`
+	// ValidDays is the count of the days the quote can be accepted in, it is 14 if it isn't given
+	ValidDays int `json:"valid_days" binding:"min=0,max=60"`
+}
+
+// quotePrivateBooking prices a private booking, the staff of its venue can quote it again until its quote is accepted
+func (server *Server) quotePrivateBooking(ctx *gin.Context) {
+	// first i check for the bindings
+	var req QuotePrivateBookingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.requireMoney(ctx, req.Net, req.Deposit) {
+		return
+	}
+
+	if req.ValidDays == 0 {
+		req.ValidDays = defaultQuoteValidDays
+	}
+
+	b, ok := server.requirePrivateBooking(ctx)
+	if !ok || !server.requireVenueRole(ctx, b.VenueID, venueRoleStaff) {
+		return
+	}
+
+	if b.Status != bookingRequested && b.Status != bookingQuoted {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrBookingNotQuotable))
+		return
+	}
+
+	v, ok := server.requireVenue(ctx, b.VenueID)
+	if !ok {
+		return
+	}
+
+	q, err := booking.NewQuote(req.Net, v.TaxRate, req.Deposit, req.PaymentTermsDays, time.Duration(req.ValidDays)*24*time.Hour, time.Now())
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	b, err = server.store.QuotePrivateBooking(ctx, db.QuotePrivateBookingParams{
+		Net:              sql.NullInt64{Int64: q.Net.Amount, Valid: true},
+		Tax:              sql.NullInt64{Int64: q.Tax.Amount, Valid: true},
+		Total:            sql.NullInt64{Int64: q.Total.Amount, Valid: true},
+		Deposit:          sql.NullInt64{Int64: q.Deposit.Amount, Valid: true},
+		Currency:         sql.NullString{String: q.Net.Currency, Valid: true},
+		PaymentTermsDays: sql.NullInt32{Int32: q.TermsDays, Valid: true},
+		QuotedBy:         sql.NullString{String: authPayload.Username, Valid: true},
+		QuoteExpiresAt:   sql.NullTime{Time: q.ExpiresAt, Valid: true},
+		ID:               b.ID,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrBookingNotQuotable))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writePrivateBooking(ctx, b, nil)
+}
+
+// acceptPrivateBooking accepts the quote of a private booking for its organizer and charges the deposit of the quote,
+// the deposit is refunded if the quote is changed meanwhile. The concurrent accepts of a quote get the same charge,
+// the one that isn't saved returns the accepted booking and doesn't refund the charge
+func (server *Server) acceptPrivateBooking(ctx *gin.Context) {
+	b, ok := server.requirePrivateBooking(ctx)
+	if !ok || !server.requireOrganizer(ctx, b) {
+		return
+	}
+
+	if b.Status != bookingQuoted {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrBookingNotQuoted))
+		return
+	}
+
+	q := quoteOf(b)
+	if !q.ExpiresAt.After(time.Now()) {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrQuoteExpired))
+		return
+	}
+
+	// a quote is charged once however many times it is accepted, a new quote is charged again
+	var reference sql.NullString
+	if !q.Deposit.IsZero() {
+		receipt, err := server.payments.Charge(ctx, payment.Charge{
+			Username:       b.Organizer,
+			Amount:         q.Deposit,
+			Description:    fmt.Sprintf("deposit of private booking %d of %s", b.ID, b.Organization),
+			IdempotencyKey: fmt.Sprintf("private-booking-%d-%d", b.ID, b.QuotedAt.Time.Unix()),
+		})
+
+		if err != nil {
+			if err == payment.ErrDeclined {
+				ctx.JSON(http.StatusPaymentRequired, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		reference = sql.NullString{String: receipt.Reference, Valid: true}
+	}
+
+	accepted, err := server.store.AcceptPrivateBooking(ctx, db.AcceptPrivateBookingParams{
+		DepositReference: reference,
+		ID:               b.ID,
+		QuotedAt:         b.QuotedAt,
+	})
+
+	if err != nil {
+		// the charge is the deposit of the booking if the quote is accepted by another request with the same key
+		current, getErr := server.store.GetPrivateBooking(ctx, b.ID)
+		if getErr != nil {
+			log.Printf("deposit %s of private booking %d isn't refunded, the booking can't be checked: %v", reference.String, b.ID, getErr)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if reference.Valid && current.DepositReference == reference {
+			server.writePrivateBooking(ctx, current, nil)
+			return
+		}
+
+		server.refundPayment(ctx, b.Organizer, q.Deposit, reference, "private booking quote changed")
+
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrBookingChanged))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writePrivateBooking(ctx, accepted, nil)
+}
+
+// confirmPrivateBooking is the approval of an accepted private booking by a manager of its venue,
+// all the seats of its screening are blocked and its invoice is issued with the payment terms of its quote
+func (server *Server) confirmPrivateBooking(ctx *gin.Context) {
+	b, ok := server.requirePrivateBooking(ctx)
+	if !ok || !server.requireVenueRole(ctx, b.VenueID, venueRoleManager) {
+		return
+	}
+
+	if b.Status != bookingAccepted {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrBookingNotAccepted))
+		return
+	}
+
+	invoice := quoteOf(b).Invoice(b.ID, time.Now())
+
+	// here i take the payload from the context
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.ConfirmPrivateBookingTx(ctx, db.ConfirmPrivateBookingTxParams{
+		ConfirmPrivateBookingParams: db.ConfirmPrivateBookingParams{
+			ApprovedBy: sql.NullString{String: authPayload.Username, Valid: true},
+			ID:         b.ID,
+		},
+		Invoice: db.CreatePrivateBookingInvoiceParams{
+			Number:   invoice.Number,
+			Net:      invoice.Net.Amount,
+			Tax:      invoice.Tax.Amount,
+			Total:    invoice.Total.Amount,
+			Deposit:  invoice.Deposit.Amount,
+			Balance:  invoice.Balance.Amount,
+			Currency: invoice.Total.Currency,
+			IssuedAt: invoice.IssuedAt,
+			DueAt:    invoice.DueAt,
+		},
+	})
+
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusConflict, errorResponse(ErrBookingNotAccepted))
+		case db.ErrScreeningBooked, db.ErrScreeningSold:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	server.writePrivateBooking(ctx, result.Booking, result.Invoice)
+}
+
+// RejectPrivateBookingRequest holds the json data of the request
+type RejectPrivateBookingRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// rejectPrivateBooking rejects a private booking before it is confirmed, only the staff of its venue can reject it.
+// The deposit of an accepted booking is refunded
+func (server *Server) rejectPrivateBooking(ctx *gin.Context) {
+	// first i check for the bindings
+	var req RejectPrivateBookingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	b, ok := server.requirePrivateBooking(ctx)
+	if !ok || !server.requireVenueRole(ctx, b.VenueID, venueRoleStaff) {
+		return
+	}
+
+	if b.Status != bookingRequested && b.Status != bookingQuoted && b.Status != bookingAccepted {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrBookingNotCancellable))
+		return
+	}
+
+	server.closePrivateBooking(ctx, b, bookingRejected, req.Reason)
+}
+
+// CancelPrivateBookingRequest holds the query of the request
+type CancelPrivateBookingRequest struct {
+	Reason string `form:"reason" binding:"max=500"`
+}
+
+// cancelPrivateBooking cancels a private booking for its organizer. The deposit is refunded until the booking
+// is confirmed, a confirmed booking releases its screening and its invoice is voided unless it is paid
+func (server *Server) cancelPrivateBooking(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CancelPrivateBookingRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	b, ok := server.requirePrivateBooking(ctx)
+	if !ok || !server.requireOrganizer(ctx, b) {
+		return
+	}
+
+	if b.Status == bookingRejected || b.Status == bookingCancelled {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrBookingNotCancellable))
+		return
+	}
+
+	server.closePrivateBooking(ctx, b, bookingCancelled, req.Reason)
+}
+
+// getPrivateBookingInvoice returns the invoice of a confirmed private booking to its organizer or the staff of its venue
+func (server *Server) getPrivateBookingInvoice(ctx *gin.Context) {
+	b, ok := server.requirePrivateBooking(ctx)
+	if !ok || !server.requireBookingAccess(ctx, b) {
+		return
+	}
+
+	invoice, ok := server.requireInvoice(ctx, b.ID)
+	if !ok {
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newInvoiceResponse(invoice))
+}
+
+// payPrivateBookingInvoice charges the balance of the invoice of a private booking from its organizer,
+// the payment is refunded if the invoice is paid or voided meanwhile. The concurrent payments of an invoice get
+// the same charge, the one that isn't saved returns the paid invoice and doesn't refund the charge
+func (server *Server) payPrivateBookingInvoice(ctx *gin.Context) {
+	b, ok := server.requirePrivateBooking(ctx)
+	if !ok || !server.requireOrganizer(ctx, b) {
+		return
+	}
+
+	invoice, ok := server.requireInvoice(ctx, b.ID)
+	if !ok {
+		return
+	}
+
+	if invoice.PaidAt.Valid || invoice.VoidedAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrInvoiceNotPayable))
+		return
+	}
+
+	// the deposit can be the whole total, then the invoice is settled without a charge
+	var reference sql.NullString
+	if invoice.Balance > 0 {
+		receipt, err := server.payments.Charge(ctx, payment.Charge{
+			Username:       b.Organizer,
+			Amount:         util.NewMoney(invoice.Balance, invoice.Currency),
+			Description:    fmt.Sprintf("invoice %s of %s", invoice.Number, b.Organization),
+			IdempotencyKey: "invoice-" + invoice.Number,
+		})
+
+		if err != nil {
+			if err == payment.ErrDeclined {
+				ctx.JSON(http.StatusPaymentRequired, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		reference = sql.NullString{String: receipt.Reference, Valid: true}
+	}
+
+	paid, err := server.store.PayPrivateBookingInvoice(ctx, db.PayPrivateBookingInvoiceParams{
+		PaymentReference: reference,
+		BookingID:        b.ID,
+	})
+
+	if err != nil {
+		// the charge is the payment of the invoice if it is paid by another request with the same key
+		current, getErr := server.store.GetPrivateBookingInvoice(ctx, b.ID)
+		if getErr != nil {
+			log.Printf("payment %s of invoice %s isn't refunded, the invoice can't be checked: %v", reference.String, invoice.Number, getErr)
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if reference.Valid && current.PaymentReference == reference {
+			paid, err = current, nil
+		} else {
+			server.refundPayment(ctx, b.Organizer, util.NewMoney(invoice.Balance, invoice.Currency), reference, "invoice payment failed")
+		}
+	}
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrInvoiceNotPayable))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newInvoiceResponse(paid))
+}
+
+// closePrivateBooking rejects or cancels a private booking and writes the response, the deposit of an accepted
+// booking is refunded after its status is changed. A confirmed booking keeps its deposit
+func (server *Server) closePrivateBooking(ctx *gin.Context, b db.PrivateBooking, status, reason string) {
+	result, err := server.store.CancelPrivateBookingTx(ctx, db.CancelPrivateBookingParams{
+		Status:         status,
+		CancelReason:   reason,
+		ID:             b.ID,
+		PreviousStatus: b.Status,
+	})
+
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusConflict, errorResponse(ErrBookingChanged))
+		case db.ErrInvoicePaid:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	// then i refund the deposit, a failed refund stays pending and it is given back by the private bookings job
+	if booking.RefundPending(result.Booking) {
+		refunded, err := booking.RefundDeposit(ctx, server.store, server.payments, result.Booking)
+		if err != nil {
+			log.Printf("deposit of private booking %d is pending to be refunded: %v", b.ID, err)
+		}
+		result.Booking = refunded
+	}
+
+	server.writePrivateBooking(ctx, result.Booking, result.Invoice)
+}
+
+// refundPayment gives a charge back if the change it is charged for isn't saved, nothing is refunded without a reference.
+// A failed refund is logged so it can be refunded by hand
+func (server *Server) refundPayment(ctx *gin.Context, username string, amount util.Money, reference sql.NullString, description string) {
+	if !reference.Valid {
+		return
+	}
+
+	_, err := server.payments.Refund(ctx, payment.Refund{
+		Username:    username,
+		Amount:      amount,
+		Description: description,
+		Reference:   reference.String,
+	})
+
+	if err != nil {
+		log.Printf("cannot refund %s of %s: %v", reference.String, username, err)
+	}
+}
+
+// requirePrivateBooking gets the private booking of the uri, it writes the error response and returns false
+// if it can't be got
+func (server *Server) requirePrivateBooking(ctx *gin.Context) (db.PrivateBooking, bool) {
+	var uri GetPrivateBookingRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.PrivateBooking{}, false
+	}
+
+	b, err := server.store.GetPrivateBooking(ctx, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrPrivateBookingMissing))
+			return db.PrivateBooking{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.PrivateBooking{}, false
+	}
+
+	return b, true
+}
+
+// requireOrganizer checks that the authenticated user is the organizer of a booking,
+// it writes the error response and returns false if the user isn't
+func (server *Server) requireOrganizer(ctx *gin.Context, b db.PrivateBooking) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if b.Organizer != authPayload.Username {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrOrganizerOnly))
+		return false
+	}
+
+	return true
+}
+
+// requireBookingAccess checks that the authenticated user is the organizer of a booking or a staff member of its venue,
+// it writes the error response and returns false if the user is neither
+func (server *Server) requireBookingAccess(ctx *gin.Context, b db.PrivateBooking) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if b.Organizer == authPayload.Username {
+		return true
+	}
+
+	return server.requireVenueRole(ctx, b.VenueID, venueRoleStaff)
+}
+
+// requireInvoice gets the invoice of a booking, it writes the error response and returns false if it can't be got
+func (server *Server) requireInvoice(ctx *gin.Context, bookingID int64) (db.PrivateBookingInvoice, bool) {
+	invoice, err := server.store.GetPrivateBookingInvoice(ctx, bookingID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrInvoiceMissing))
+			return invoice, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return invoice, false
+	}
+
+	return invoice, true
+}
+
+// writePrivateBooking writes the response of a private booking and its invoice
+func (server *Server) writePrivateBooking(ctx *gin.Context, b db.PrivateBooking, invoice *db.PrivateBookingInvoice) {
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newPrivateBookingResponse(b, invoice))
+}
+
+// writePrivateBookings writes a page of private bookings, one more booking than the page size tells if there is a next page
+func (server *Server) writePrivateBookings(ctx *gin.Context, bookings []db.PrivateBooking, pageSize int32) {
+	bookings, next := trimCursorPage(server, privateBookingsCursor, bookings, pageSize, func(b db.PrivateBooking) int64 { return b.ID })
+
+	result := make([]PrivateBookingResponse, 0, len(bookings))
+	for _, b := range bookings {
+		result = append(result, newPrivateBookingResponse(b, nil))
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, next))
+}
+
+// quoteOf returns the quote of a quoted booking
+func quoteOf(b db.PrivateBooking) booking.Quote {
+	return booking.Quote{
+		Net:       util.NewMoney(b.Net.Int64, b.Currency.String),
+		Tax:       util.NewMoney(b.Tax.Int64, b.Currency.String),
+		Total:     util.NewMoney(b.Total.Int64, b.Currency.String),
+		Deposit:   util.NewMoney(b.Deposit.Int64, b.Currency.String),
+		TermsDays: b.PaymentTermsDays.Int32,
+		ExpiresAt: b.QuoteExpiresAt.Time,
+	}
+}
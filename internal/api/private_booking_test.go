@@ -0,0 +1,1149 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/booking"
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreatePrivateBookingAPI tests createPrivateBooking handler
+func TestCreatePrivateBookingAPI(t *testing.T) {
+	_, user := randomUser(t)
+	venue := randomVenue()
+	auditorium := randomAuditorium(venue)
+	screening := randomScreening(randomMovie().Movie)
+	screening.AuditoriumID = sql.NullInt64{Int64: auditorium.ID, Valid: true}
+	b := randomPrivateBooking(user.Username, screening, venue)
+
+	body := gin.H{
+		"screening_id":  screening.ID,
+		"organization":  b.Organization,
+		"contact_email": b.ContactEmail,
+		"guests":        b.Guests,
+		"notes":         b.Notes,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreatePrivateBookingParams{
+					Organizer:    user.Username,
+					Organization: b.Organization,
+					ContactEmail: b.ContactEmail,
+					ScreeningID:  screening.ID,
+					VenueID:      venue.ID,
+					Guests:       b.Guests,
+					Notes:        b.Notes,
+				}
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(screening, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().CreatePrivateBooking(gomock.Any(), gomock.Eq(arg)).Times(1).Return(b, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requirePrivateBookingBodyMatch(t, w.Body, newPrivateBookingResponse(b, nil))
+			},
+		},
+		{
+			name: "Too Many Guests",
+			body: gin.H{
+				"screening_id":  screening.ID,
+				"organization":  b.Organization,
+				"contact_email": b.ContactEmail,
+				"guests":        auditorium.Seats + 1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(screening, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().CreatePrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Already Booked",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				booked := screening
+				booked.PrivateBookingID = sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}
+
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(booked, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "No Auditorium",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				legacy := screening
+				legacy.AuditoriumID = sql.NullInt64{}
+
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(legacy, nil)
+				store.EXPECT().CreatePrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Screening Not Found",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(db.Screening{}, sql.ErrNoRows)
+				store.EXPECT().CreatePrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Invalid Email",
+			body: gin.H{
+				"screening_id":  screening.ID,
+				"organization":  b.Organization,
+				"contact_email": "school",
+				"guests":        b.Guests,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/private-bookings", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestGetPrivateBookingAPI tests getPrivateBooking handler
+func TestGetPrivateBookingAPI(t *testing.T) {
+	_, organizer := randomUser(t)
+	_, other := randomUser(t)
+	venue := randomVenue()
+	b := randomPrivateBooking(organizer.Username, randomScreening(randomMovie().Movie), venue)
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Organizer",
+			username: organizer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requirePrivateBookingBodyMatch(t, w.Body, newPrivateBookingResponse(b, nil))
+			},
+		},
+		{
+			name:     "Venue Staff",
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(other, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(db.GetVenueStaffParams{Username: other.Username, VenueID: venue.ID})).Times(1).
+					Return(db.VenueStaff{Username: other.Username, VenueID: venue.ID, Role: venueRoleStaff}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Forbidden",
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(other, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Any()).Times(1).Return(db.VenueStaff{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Not Found",
+			username: organizer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(db.PrivateBooking{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/private-bookings/%d", b.ID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListVenuePrivateBookingsAPI tests listVenuePrivateBookings handler
+func TestListVenuePrivateBookingsAPI(t *testing.T) {
+	staff := randomStaff(t)
+	venue := randomVenue()
+	screening := randomScreening(randomMovie().Movie)
+
+	bookings := make([]db.PrivateBooking, 3)
+	for i := range bookings {
+		bookings[i] = randomPrivateBooking(util.RandomName(), screening, venue)
+		bookings[i].ID = int64(i + 1)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "page_size=2&status=requested",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListVenuePrivateBookingsParams{
+					VenueID: venue.ID,
+					Status:  sql.NullString{String: bookingRequested, Valid: true},
+					Limit:   3,
+				}
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ListVenuePrivateBookings(gomock.Any(), gomock.Eq(arg)).Times(1).Return(bookings, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[PrivateBookingResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 2)
+				require.NotEmpty(t, got.NextCursor)
+			},
+		},
+		{
+			name:  "Invalid Status",
+			query: "page_size=2&status=paid",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListVenuePrivateBookings(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/venues/%d/private-bookings?%s", venue.ID, tt.query), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestQuotePrivateBookingAPI tests quotePrivateBooking handler
+func TestQuotePrivateBookingAPI(t *testing.T) {
+	staff := randomStaff(t)
+	venue := randomVenue()
+	venue.TaxRate = 1800
+	b := randomPrivateBooking(util.RandomName(), randomScreening(randomMovie().Movie), venue)
+	quoted := quotedPrivateBooking(b)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"net": util.NewMoney(100000, util.DefaultCurrency), "deposit": util.NewMoney(20000, util.DefaultCurrency), "payment_terms_days": 30},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().QuotePrivateBooking(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.QuotePrivateBookingParams) (db.PrivateBooking, error) {
+						require.Equal(t, b.ID, arg.ID)
+						require.Equal(t, int64(100000), arg.Net.Int64)
+						require.Equal(t, int64(18000), arg.Tax.Int64)
+						require.Equal(t, int64(118000), arg.Total.Int64)
+						require.Equal(t, int64(20000), arg.Deposit.Int64)
+						require.Equal(t, int32(30), arg.PaymentTermsDays.Int32)
+						require.Equal(t, staff.Username, arg.QuotedBy.String)
+						require.WithinDuration(t, time.Now().AddDate(0, 0, defaultQuoteValidDays), arg.QuoteExpiresAt.Time, time.Second)
+						return quoted, nil
+					})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requirePrivateBookingBodyMatch(t, w.Body, newPrivateBookingResponse(quoted, nil))
+			},
+		},
+		{
+			name: "Deposit Too Large",
+			body: gin.H{"net": util.NewMoney(1000, util.DefaultCurrency), "deposit": util.NewMoney(5000, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().QuotePrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Already Accepted",
+			body: gin.H{"net": util.NewMoney(100000, util.DefaultCurrency), "deposit": util.NewMoney(0, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				accepted := quoted
+				accepted.Status = bookingAccepted
+
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(accepted, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().QuotePrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Other Currency",
+			body: gin.H{"net": util.NewMoney(100000, "EUR"), "deposit": util.NewMoney(0, "EUR")},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Long Payment Terms",
+			body: gin.H{"net": util.NewMoney(100000, util.DefaultCurrency), "deposit": util.NewMoney(0, util.DefaultCurrency), "payment_terms_days": 365},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/private-bookings/%d/quote", b.ID), bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestAcceptPrivateBookingAPI tests acceptPrivateBooking handler
+func TestAcceptPrivateBookingAPI(t *testing.T) {
+	_, organizer := randomUser(t)
+	venue := randomVenue()
+	quoted := quotedPrivateBooking(randomPrivateBooking(organizer.Username, randomScreening(randomMovie().Movie), venue))
+
+	accepted := quoted
+	accepted.Status = bookingAccepted
+	accepted.DepositReference = sql.NullString{String: "ch_1", Valid: true}
+	accepted.AcceptedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+	acceptArg := db.AcceptPrivateBookingParams{DepositReference: accepted.DepositReference, ID: quoted.ID, QuotedAt: quoted.QuotedAt}
+
+	testCases := []struct {
+		name          string
+		username      string
+		booking       db.PrivateBooking
+		gatewayErr    error
+		buildStubs    func(store *mockdb.MockStore, b db.PrivateBooking)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway)
+	}{
+		{
+			name:     "OK",
+			username: organizer.Username,
+			booking:  quoted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Eq(acceptArg)).Times(1).Return(accepted, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requirePrivateBookingBodyMatch(t, w.Body, newPrivateBookingResponse(accepted, nil))
+				require.Len(t, gateway.charges, 1)
+				require.Equal(t, util.NewMoney(quoted.Deposit.Int64, quoted.Currency.String), gateway.charges[0].Amount)
+				require.Equal(t, fmt.Sprintf("private-booking-%d-%d", quoted.ID, quoted.QuotedAt.Time.Unix()), gateway.charges[0].IdempotencyKey)
+			},
+		},
+		{
+			name:     "No Deposit",
+			username: organizer.Username,
+			booking: func() db.PrivateBooking {
+				free := quoted
+				free.Deposit = sql.NullInt64{Int64: 0, Valid: true}
+				return free
+			}(),
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				arg := db.AcceptPrivateBookingParams{ID: b.ID, QuotedAt: b.QuotedAt}
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accepted, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Empty(t, gateway.charges)
+			},
+		},
+		{
+			name:     "Expired",
+			username: organizer.Username,
+			booking: func() db.PrivateBooking {
+				expired := quoted
+				expired.QuoteExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+				return expired
+			}(),
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+				require.Empty(t, gateway.charges)
+			},
+		},
+		{
+			name:     "Quote Changed",
+			username: organizer.Username,
+			booking:  quoted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				requoted := b
+				requoted.QuotedAt = sql.NullTime{Time: b.QuotedAt.Time.Add(time.Hour), Valid: true}
+
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Eq(acceptArg)).Times(1).Return(db.PrivateBooking{}, sql.ErrNoRows)
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(requoted, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "ch_1", gateway.refunds[0].Reference)
+			},
+		},
+		{
+			name:     "Accepted By Concurrent Request",
+			username: organizer.Username,
+			booking:  quoted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Eq(acceptArg)).Times(1).Return(db.PrivateBooking{}, sql.ErrNoRows)
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(accepted, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requirePrivateBookingBodyMatch(t, w.Body, newPrivateBookingResponse(accepted, nil))
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name:     "Recheck Fails",
+			username: organizer.Username,
+			booking:  quoted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Eq(acceptArg)).Times(1).Return(db.PrivateBooking{}, sql.ErrConnDone)
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(db.PrivateBooking{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name:       "Declined",
+			username:   organizer.Username,
+			booking:    quoted,
+			gatewayErr: payment.ErrDeclined,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusPaymentRequired, w.Code)
+			},
+		},
+		{
+			name:     "Not Quoted",
+			username: organizer.Username,
+			booking:  accepted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:     "Not Organizer",
+			username: util.RandomName(),
+			booking:  quoted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().AcceptPrivateBooking(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+				require.Empty(t, gateway.charges)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store, tt.booking)
+
+			server := newTestServer(t, store)
+			gateway := &recordingGateway{err: tt.gatewayErr}
+			server.payments = gateway
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/private-bookings/%d/accept", tt.booking.ID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w, gateway)
+		})
+	}
+}
+
+// TestConfirmPrivateBookingAPI tests confirmPrivateBooking handler
+func TestConfirmPrivateBookingAPI(t *testing.T) {
+	_, manager := randomUser(t)
+	venue := randomVenue()
+	managerArg := db.GetVenueStaffParams{Username: manager.Username, VenueID: venue.ID}
+
+	accepted := quotedPrivateBooking(randomPrivateBooking(util.RandomName(), randomScreening(randomMovie().Movie), venue))
+	accepted.Status = bookingAccepted
+
+	confirmed := accepted
+	confirmed.Status = db.PrivateBookingConfirmed
+	confirmed.ApprovedBy = sql.NullString{String: manager.Username, Valid: true}
+	confirmed.ConfirmedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+	invoice := randomPrivateBookingInvoice(confirmed)
+
+	testCases := []struct {
+		name          string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: venueRoleManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(accepted.ID)).Times(1).Return(accepted, nil)
+				store.EXPECT().ConfirmPrivateBookingTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ConfirmPrivateBookingTxParams) (db.PrivateBookingTxResult, error) {
+						require.Equal(t, accepted.ID, arg.ID)
+						require.Equal(t, manager.Username, arg.ApprovedBy.String)
+						require.Equal(t, booking.InvoiceNumber(accepted.ID, arg.Invoice.IssuedAt), arg.Invoice.Number)
+						require.Equal(t, accepted.Total.Int64, arg.Invoice.Total)
+						require.Equal(t, accepted.Total.Int64-accepted.Deposit.Int64, arg.Invoice.Balance)
+						require.Equal(t, arg.Invoice.IssuedAt.AddDate(0, 0, int(accepted.PaymentTermsDays.Int32)), arg.Invoice.DueAt)
+						return db.PrivateBookingTxResult{Booking: confirmed, Invoice: &invoice}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requirePrivateBookingBodyMatch(t, w.Body, newPrivateBookingResponse(confirmed, &invoice))
+			},
+		},
+		{
+			name: "Seats Sold",
+			role: venueRoleManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(accepted.ID)).Times(1).Return(accepted, nil)
+				store.EXPECT().ConfirmPrivateBookingTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PrivateBookingTxResult{}, db.ErrScreeningSold)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Booked By Another",
+			role: venueRoleManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(accepted.ID)).Times(1).Return(accepted, nil)
+				store.EXPECT().ConfirmPrivateBookingTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PrivateBookingTxResult{}, db.ErrScreeningBooked)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Staff Can't Approve",
+			role: venueRoleStaff,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(accepted.ID)).Times(1).Return(accepted, nil)
+				store.EXPECT().ConfirmPrivateBookingTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name: "Not Accepted",
+			role: venueRoleManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				quoted := accepted
+				quoted.Status = bookingQuoted
+
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(accepted.ID)).Times(1).Return(quoted, nil)
+				store.EXPECT().ConfirmPrivateBookingTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(manager.Username)).Times(1).Return(manager, nil)
+			store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(managerArg)).Times(1).
+				Return(db.VenueStaff{Username: manager.Username, VenueID: venue.ID, Role: tt.role}, nil)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/private-bookings/%d/confirm", accepted.ID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, manager.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestCancelPrivateBookingAPI tests cancelPrivateBooking and rejectPrivateBooking handlers
+func TestCancelPrivateBookingAPI(t *testing.T) {
+	_, organizer := randomUser(t)
+	staff := randomStaff(t)
+	venue := randomVenue()
+
+	quoted := quotedPrivateBooking(randomPrivateBooking(organizer.Username, randomScreening(randomMovie().Movie), venue))
+
+	accepted := quoted
+	accepted.Status = bookingAccepted
+	accepted.DepositReference = sql.NullString{String: "ch_1", Valid: true}
+
+	confirmed := accepted
+	confirmed.Status = db.PrivateBookingConfirmed
+
+	closed := accepted
+	closed.Status = bookingCancelled
+	closed.CancelReason = "trip moved"
+	closed.CancelledAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+	refunded := closed
+	refunded.DepositRefundReference = sql.NullString{String: "re_1", Valid: true}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		username      string
+		booking       db.PrivateBooking
+		gatewayErr    error
+		buildStubs    func(store *mockdb.MockStore, b db.PrivateBooking)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway)
+	}{
+		{
+			name:     "Cancel Accepted",
+			method:   http.MethodDelete,
+			url:      "/private-bookings/%d?reason=trip+moved",
+			username: organizer.Username,
+			booking:  accepted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				arg := db.CancelPrivateBookingParams{
+					Status:         bookingCancelled,
+					CancelReason:   "trip moved",
+					ID:             b.ID,
+					PreviousStatus: bookingAccepted,
+				}
+				refundArg := db.CompletePrivateBookingRefundParams{ID: b.ID, DepositRefundReference: refunded.DepositRefundReference}
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().CancelPrivateBookingTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PrivateBookingTxResult{Booking: closed}, nil)
+				store.EXPECT().CompletePrivateBookingRefund(gomock.Any(), gomock.Eq(refundArg)).Times(1).Return(refunded, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requirePrivateBookingBodyMatch(t, w.Body, newPrivateBookingResponse(refunded, nil))
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "ch_1", gateway.refunds[0].Reference)
+				require.Equal(t, util.NewMoney(accepted.Deposit.Int64, accepted.Currency.String), gateway.refunds[0].Amount)
+				require.Equal(t, fmt.Sprintf("private-booking-refund-%d", accepted.ID), gateway.refunds[0].IdempotencyKey)
+			},
+		},
+		{
+			name:       "Refund Pending",
+			method:     http.MethodDelete,
+			url:        "/private-bookings/%d",
+			username:   organizer.Username,
+			booking:    accepted,
+			gatewayErr: errors.New("gateway is down"),
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().CancelPrivateBookingTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PrivateBookingTxResult{Booking: closed}, nil)
+				store.EXPECT().CompletePrivateBookingRefund(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				res := newPrivateBookingResponse(closed, nil)
+				require.True(t, res.RefundPending)
+				requirePrivateBookingBodyMatch(t, w.Body, res)
+			},
+		},
+		{
+			name:     "Cancel Confirmed Keeps Deposit",
+			method:   http.MethodDelete,
+			url:      "/private-bookings/%d",
+			username: organizer.Username,
+			booking:  confirmed,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				arg := db.CancelPrivateBookingParams{Status: bookingCancelled, ID: b.ID, PreviousStatus: db.PrivateBookingConfirmed}
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().CancelPrivateBookingTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PrivateBookingTxResult{Booking: b}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name:     "Invoice Paid",
+			method:   http.MethodDelete,
+			url:      "/private-bookings/%d",
+			username: organizer.Username,
+			booking:  confirmed,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().CancelPrivateBookingTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PrivateBookingTxResult{}, db.ErrInvoicePaid)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:     "Not Organizer",
+			method:   http.MethodDelete,
+			url:      "/private-bookings/%d",
+			username: staff.Username,
+			booking:  quoted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().CancelPrivateBookingTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Reject Quoted",
+			method:   http.MethodPost,
+			url:      "/private-bookings/%d/reject",
+			username: staff.Username,
+			booking:  quoted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				arg := db.CancelPrivateBookingParams{Status: bookingRejected, CancelReason: "auditorium closed", ID: b.ID, PreviousStatus: bookingQuoted}
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CancelPrivateBookingTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PrivateBookingTxResult{Booking: b}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name:     "Reject Confirmed",
+			method:   http.MethodPost,
+			url:      "/private-bookings/%d/reject",
+			username: staff.Username,
+			booking:  confirmed,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CancelPrivateBookingTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:     "Changed Meanwhile",
+			method:   http.MethodPost,
+			url:      "/private-bookings/%d/reject",
+			username: staff.Username,
+			booking:  accepted,
+			buildStubs: func(store *mockdb.MockStore, b db.PrivateBooking) {
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(b.ID)).Times(1).Return(b, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CancelPrivateBookingTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PrivateBookingTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store, tt.booking)
+
+			server := newTestServer(t, store)
+			gateway := &recordingGateway{err: tt.gatewayErr}
+			server.payments = gateway
+			w := httptest.NewRecorder()
+
+			var body *bytes.Buffer
+			if tt.method == http.MethodPost {
+				data, err := json.Marshal(gin.H{"reason": "auditorium closed"})
+				require.NoError(t, err)
+				body = bytes.NewBuffer(data)
+			} else {
+				body = bytes.NewBuffer(nil)
+			}
+
+			req, err := http.NewRequest(tt.method, fmt.Sprintf(tt.url, tt.booking.ID), body)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w, gateway)
+		})
+	}
+}
+
+// TestPayPrivateBookingInvoiceAPI tests payPrivateBookingInvoice handler
+func TestPayPrivateBookingInvoiceAPI(t *testing.T) {
+	_, organizer := randomUser(t)
+	confirmed := quotedPrivateBooking(randomPrivateBooking(organizer.Username, randomScreening(randomMovie().Movie), randomVenue()))
+	confirmed.Status = db.PrivateBookingConfirmed
+	invoice := randomPrivateBookingInvoice(confirmed)
+
+	paid := invoice
+	paid.PaidAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+	paid.PaymentReference = sql.NullString{String: "ch_1", Valid: true}
+
+	testCases := []struct {
+		name          string
+		gatewayErr    error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.PayPrivateBookingInvoiceParams{PaymentReference: paid.PaymentReference, BookingID: confirmed.ID}
+				store.EXPECT().GetPrivateBookingInvoice(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(invoice, nil)
+				store.EXPECT().PayPrivateBookingInvoice(gomock.Any(), gomock.Eq(arg)).Times(1).Return(paid, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got InvoiceResponse
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, newInvoiceResponse(paid), got)
+
+				require.Len(t, gateway.charges, 1)
+				require.Equal(t, util.NewMoney(invoice.Balance, invoice.Currency), gateway.charges[0].Amount)
+				require.Equal(t, "invoice-"+invoice.Number, gateway.charges[0].IdempotencyKey)
+			},
+		},
+		{
+			name: "Already Paid",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBookingInvoice(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(paid, nil)
+				store.EXPECT().PayPrivateBookingInvoice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+				require.Empty(t, gateway.charges)
+			},
+		},
+		{
+			name: "Voided Meanwhile",
+			buildStubs: func(store *mockdb.MockStore) {
+				voided := invoice
+				voided.VoidedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+				store.EXPECT().GetPrivateBookingInvoice(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(invoice, nil)
+				store.EXPECT().PayPrivateBookingInvoice(gomock.Any(), gomock.Any()).Times(1).Return(db.PrivateBookingInvoice{}, sql.ErrNoRows)
+				store.EXPECT().GetPrivateBookingInvoice(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(voided, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusConflict, w.Code)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "ch_1", gateway.refunds[0].Reference)
+			},
+		},
+		{
+			name: "Paid By Concurrent Request",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBookingInvoice(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(invoice, nil)
+				store.EXPECT().PayPrivateBookingInvoice(gomock.Any(), gomock.Any()).Times(1).Return(db.PrivateBookingInvoice{}, sql.ErrNoRows)
+				store.EXPECT().GetPrivateBookingInvoice(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(paid, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name:       "Declined",
+			gatewayErr: payment.ErrDeclined,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBookingInvoice(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(invoice, nil)
+				store.EXPECT().PayPrivateBookingInvoice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusPaymentRequired, w.Code)
+			},
+		},
+		{
+			name: "No Invoice",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPrivateBookingInvoice(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(db.PrivateBookingInvoice{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, gateway *recordingGateway) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(confirmed.ID)).Times(1).Return(confirmed, nil)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			gateway := &recordingGateway{err: tt.gatewayErr}
+			server.payments = gateway
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/private-bookings/%d/invoice/pay", confirmed.ID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, organizer.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w, gateway)
+		})
+	}
+}
+
+// randomPrivateBooking creates a random requested booking of a screening in a venue
+func randomPrivateBooking(organizer string, screening db.Screening, venue db.Venue) db.PrivateBooking {
+	return db.PrivateBooking{
+		ID:           util.RandomInt(1, 1000),
+		Organizer:    organizer,
+		Organization: util.RandomString(12),
+		ContactEmail: util.RandomEmail(),
+		ScreeningID:  screening.ID,
+		VenueID:      venue.ID,
+		Guests:       int32(util.RandomInt(20, 50)),
+		Notes:        util.RandomString(20),
+		Status:       bookingRequested,
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// quotedPrivateBooking returns given booking with a quote of a week
+func quotedPrivateBooking(b db.PrivateBooking) db.PrivateBooking {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	b.Status = bookingQuoted
+	b.Net = sql.NullInt64{Int64: 100000, Valid: true}
+	b.Tax = sql.NullInt64{Int64: 18000, Valid: true}
+	b.Total = sql.NullInt64{Int64: 118000, Valid: true}
+	b.Deposit = sql.NullInt64{Int64: 20000, Valid: true}
+	b.Currency = sql.NullString{String: util.DefaultCurrency, Valid: true}
+	b.PaymentTermsDays = sql.NullInt32{Int32: 30, Valid: true}
+	b.QuotedBy = sql.NullString{String: util.RandomName(), Valid: true}
+	b.QuotedAt = sql.NullTime{Time: now, Valid: true}
+	b.QuoteExpiresAt = sql.NullTime{Time: now.AddDate(0, 0, 7), Valid: true}
+
+	return b
+}
+
+// randomPrivateBookingInvoice creates the unpaid invoice of a quoted booking
+func randomPrivateBookingInvoice(b db.PrivateBooking) db.PrivateBookingInvoice {
+	issuedAt := time.Now().UTC().Truncate(time.Second)
+
+	return db.PrivateBookingInvoice{
+		ID:        util.RandomInt(1, 1000),
+		BookingID: b.ID,
+		Number:    booking.InvoiceNumber(b.ID, issuedAt),
+		Net:       b.Net.Int64,
+		Tax:       b.Tax.Int64,
+		Total:     b.Total.Int64,
+		Deposit:   b.Deposit.Int64,
+		Balance:   b.Total.Int64 - b.Deposit.Int64,
+		Currency:  b.Currency.String,
+		IssuedAt:  issuedAt,
+		DueAt:     issuedAt.AddDate(0, 0, int(b.PaymentTermsDays.Int32)),
+	}
+}
+
+// requirePrivateBookingBodyMatch checks that the body of the response is given booking
+func requirePrivateBookingBodyMatch(t *testing.T, body *bytes.Buffer, res PrivateBookingResponse) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var got PrivateBookingResponse
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Equal(t, res, got)
+}
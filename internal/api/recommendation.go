@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultRecommendationCount is the count of the recommendations if the limit isn't given
+const defaultRecommendationCount = 10
+
+// recommendationMaxAge is the age after which the precomputed recommendations are computed again per request,
+// it is longer than a day so a late nightly precompute doesn't fall back to it
+const recommendationMaxAge = 36 * time.Hour
+
+// ListRecommendationsRequest holds query values of the request
+type ListRecommendationsRequest struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// RecommendationResponse holds a recommended movie with its score and the reasons of the score
+type RecommendationResponse struct {
+	Movie   db.Movie `json:"movie"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// listRecommendations suggests now-showing movies to the authenticated user by the tickets of the user
+// and of the other buyers, the precomputed recommendations are used if they are fresh
+func (server *Server) listRecommendations(ctx *gin.Context) {
+	// first i check for the bindings
+	var req ListRecommendationsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultRecommendationCount
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.ListUserRecommendationsParams{
+		Username:      authPayload.Username,
+		ComputedAfter: time.Now().Add(-recommendationMaxAge),
+		Limit:         req.Limit,
+	}
+
+	stored, err := server.store.ListUserRecommendations(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result := make([]RecommendationResponse, 0, len(stored))
+	for _, r := range stored {
+		result = append(result, RecommendationResponse{Movie: db.Movie{ID: r.MovieID}, Score: r.Score, Reasons: r.Reasons})
+	}
+
+	// then i compute them if the user isn't precomputed yet
+	if len(result) == 0 {
+		recommendations, err := server.recommender.Compute(ctx, authPayload.Username, int(req.Limit))
+
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		for _, r := range recommendations {
+			result = append(result, RecommendationResponse{Movie: db.Movie{ID: r.MovieID}, Score: r.Score, Reasons: r.Reasons})
+		}
+	}
+
+	movies, err := server.loadMovies(ctx, collectIDs(result, func(r RecommendationResponse) int64 { return r.Movie.ID }))
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	for i := range result {
+		m, ok := movies[result[i].Movie.ID]
+
+		if !ok {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ErrMissingMovie))
+			return
+		}
+
+		result[i].Movie = m
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, ""))
+}
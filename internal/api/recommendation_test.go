@@ -0,0 +1,149 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/recommend"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListRecommendationsAPI tests listRecommendations handler
+func TestListRecommendationsAPI(t *testing.T) {
+	_, user := randomUser(t)
+	m1 := randomMovie().Movie
+	m2 := randomMovie().Movie
+	m2.ID = m1.ID + 1
+
+	stored := []db.UserRecommendation{
+		{Username: user.Username, MovieID: m2.ID, Score: 0.5, Reasons: []string{recommend.ReasonGenre}},
+		{Username: user.Username, MovieID: m1.ID, Score: 0.1, Reasons: []string{recommend.ReasonCoPurchase}},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Precomputed",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUserRecommendations(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListUserRecommendationsParams) ([]db.UserRecommendation, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, int32(defaultRecommendationCount), arg.Limit)
+						require.WithinDuration(t, time.Now().Add(-recommendationMaxAge), arg.ComputedAfter, time.Second)
+						return stored, nil
+					})
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq([]int64{m2.ID, m1.ID})).Times(1).Return([]db.Movie{m1, m2}, nil)
+				store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				got := requireBodyRecommendations(t, w)
+				require.Len(t, got, 2)
+				require.Equal(t, m2, got[0].Movie)
+				require.Equal(t, []string{recommend.ReasonGenre}, got[0].Reasons)
+				require.Equal(t, m1, got[1].Movie)
+			},
+		},
+		{
+			name:  "Computed",
+			query: "?limit=1",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUserRecommendations(gomock.Any(), gomock.Any()).Times(1).Return([]db.UserRecommendation{}, nil)
+				store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(1).Return([]db.Movie{m1, m2}, nil)
+				store.EXPECT().ListUserPurchases(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return([]db.ListUserPurchasesRow{}, nil)
+				store.EXPECT().ListGenresOfMovies(gomock.Any(), gomock.Any()).Times(1).Return([]db.MovieGenre{}, nil)
+
+				// the movie with the better rating comes first without any history
+				best := m1
+				if m2.Rating > m1.Rating {
+					best = m2
+				}
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq([]int64{best.ID})).Times(1).Return([]db.Movie{best}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				got := requireBodyRecommendations(t, w)
+				require.Len(t, got, 1)
+				require.Equal(t, []string{recommend.ReasonTopRated}, got[0].Reasons)
+			},
+		},
+		{
+			name:  "Invalid Limit",
+			query: "?limit=100",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUserRecommendations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Missing Movie",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUserRecommendations(gomock.Any(), gomock.Any()).Times(1).Return(stored, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{m1}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUserRecommendations(gomock.Any(), gomock.Any()).Times(1).Return([]db.UserRecommendation{}, sql.ErrConnDone)
+				store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/me/recommendations"+tt.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// requireBodyRecommendations decodes the recommendations in the response's body
+func requireBodyRecommendations(t *testing.T, w *httptest.ResponseRecorder) []RecommendationResponse {
+	data, err := ioutil.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	var got ListResponse[RecommendationResponse]
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+
+	return got.Items
+}
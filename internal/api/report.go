@@ -0,0 +1,263 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/report"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrInvalidTimeZone  = errors.New("invalid time zone")
+	ErrInvalidDateRange = errors.New("to must not be before from and the range must not exceed 366 days")
+)
+
+// reportDateLayout is the layout of the dates of the reports
+const reportDateLayout = "2006-01-02"
+
+// maxReportDays is the longest date range of a report
+const maxReportDays = 366
+
+// salesReportHeader holds the column names of the exported sales reports
+var salesReportHeader = []interface{}{
+	"day", "movie_id", "title", "screening_id", "ticket_type", "payment_method", "channel",
+	"quantity", "currency", "gross", "discount", "tax", "net",
+}
+
+// SalesReportRequest holds query values of the request, the dates are inclusive days of the time zone
+type SalesReportRequest struct {
+	From     string `form:"from" binding:"required,datetime=2006-01-02"`
+	To       string `form:"to" binding:"required,datetime=2006-01-02"`
+	TimeZone string `form:"tz"`
+	Format   string `form:"format" binding:"omitempty,oneof=json csv xlsx"`
+}
+
+// SalesReportRow holds the sales of a day for a movie, screening, ticket type and payment method,
+// net is the gross without the discount and the tax
+type SalesReportRow struct {
+	Day           string     `json:"day"`
+	MovieID       int64      `json:"movie_id"`
+	Title         string     `json:"title"`
+	ScreeningID   *int64     `json:"screening_id"`
+	TicketType    string     `json:"ticket_type"`
+	PaymentMethod string     `json:"payment_method"`
+	Channel       string     `json:"channel"`
+	Quantity      int64      `json:"quantity"`
+	Gross         util.Money `json:"gross"`
+	Discount      util.Money `json:"discount"`
+	Tax           util.Money `json:"tax"`
+	Net           util.Money `json:"net"`
+}
+
+// getSalesReport returns the daily sales of the online tickets and the box office in a date range,
+// as JSON or as a streamed CSV or XLSX file for the finance team
+func (server *Server) getSalesReport(ctx *gin.Context) {
+	// first i check for the bindings
+	var req SalesReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.TimeZone == "" {
+		req.TimeZone = "UTC"
+	}
+
+	// Local is the zone of the server, the DB doesn't know it so it is invalid like the unknown zones
+	loc, err := time.LoadLocation(req.TimeZone)
+
+	if err != nil || loc == time.Local {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidTimeZone))
+		return
+	}
+
+	// then i turn the days of the time zone into a half open range of instants
+	from, _ := time.ParseInLocation(reportDateLayout, req.From, loc)
+	to, _ := time.ParseInLocation(reportDateLayout, req.To, loc)
+	to = to.AddDate(0, 0, 1)
+
+	if !to.After(from) || to.After(from.AddDate(0, 0, maxReportDays)) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidDateRange))
+		return
+	}
+
+	arg := db.ListDailySalesParams{
+		CreatedFrom: from,
+		CreatedTo:   to,
+		TimeZone:    loc.String(),
+	}
+
+	// the rows are written as they are read from the DB, so the response is only started with the first row
+	var w salesReportWriter
+	started := false
+	start := func() error {
+		started = true
+
+		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+		var err error
+		w, err = server.newSalesReportWriter(ctx, req)
+		return err
+	}
+
+	err = server.store.StreamDailySales(ctx, arg, func(r db.ListDailySalesRow) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return w.Write(newSalesReportRow(r))
+	})
+
+	// nothing is sent yet if the query fails before the first row, so the error can still be returned
+	if err != nil && !started {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// an empty report is started after the query
+	if err == nil && !started {
+		err = start()
+	}
+
+	if err == nil {
+		err = w.Close()
+	}
+
+	// the status is already sent if writing fails so the error is only recorded
+	if err != nil {
+		_ = ctx.Error(err)
+	}
+}
+
+// salesReportWriter writes the rows of a sales report to the response one by one
+type salesReportWriter interface {
+	Write(row SalesReportRow) error
+	// Close finishes the report
+	Close() error
+}
+
+// newSalesReportWriter sends the headers of the report in the requested format and returns its writer
+func (server *Server) newSalesReportWriter(ctx *gin.Context, req SalesReportRequest) (salesReportWriter, error) {
+	if req.Format == "" || req.Format == "json" {
+		ctx.Header("Content-Type", "application/json; charset=utf-8")
+		ctx.Status(http.StatusOK)
+		return newJSONSalesWriter(ctx.Writer)
+	}
+
+	filename := fmt.Sprintf("sales-%s-%s.%s", req.From, req.To, req.Format)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	var w report.Writer
+	var err error
+	if req.Format == "csv" {
+		ctx.Header("Content-Type", "text/csv; charset=utf-8")
+		w = report.NewCSVWriter(ctx.Writer)
+	} else {
+		ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w, err = report.NewXLSXWriter(ctx.Writer, "Sales")
+	}
+
+	ctx.Status(http.StatusOK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteRow(salesReportHeader...); err != nil {
+		return nil, err
+	}
+
+	return &fileSalesWriter{w: w}, nil
+}
+
+// jsonSalesWriter writes the rows in the envelope of the lists, a report has no next page
+type jsonSalesWriter struct {
+	w    io.Writer
+	rows int
+}
+
+// newJSONSalesWriter starts the envelope of the rows
+func newJSONSalesWriter(w io.Writer) (*jsonSalesWriter, error) {
+	if _, err := io.WriteString(w, `{"items":[`); err != nil {
+		return nil, err
+	}
+	return &jsonSalesWriter{w: w}, nil
+}
+
+// Write writes a row as an item of the envelope
+func (writer *jsonSalesWriter) Write(row SalesReportRow) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	if writer.rows > 0 {
+		data = append([]byte{','}, data...)
+	}
+	writer.rows++
+
+	_, err = writer.w.Write(data)
+	return err
+}
+
+// Close closes the envelope
+func (writer *jsonSalesWriter) Close() error {
+	_, err := io.WriteString(writer.w, "]}")
+	return err
+}
+
+// fileSalesWriter writes the rows as the rows of an exported file
+type fileSalesWriter struct {
+	w report.Writer
+}
+
+// Write writes a row of the file, the amounts are decimal numbers in the major units of their currency for the spreadsheets
+func (writer *fileSalesWriter) Write(r SalesReportRow) error {
+	screeningID := ""
+	if r.ScreeningID != nil {
+		screeningID = fmt.Sprint(*r.ScreeningID)
+	}
+
+	return writer.w.WriteRow(r.Day, r.MovieID, r.Title, screeningID, r.TicketType, r.PaymentMethod, r.Channel,
+		r.Quantity, r.Gross.Currency, report.Decimal(r.Gross.Decimal()), report.Decimal(r.Discount.Decimal()),
+		report.Decimal(r.Tax.Decimal()), report.Decimal(r.Net.Decimal()))
+}
+
+// Close flushes the rows and finishes the file
+func (writer *fileSalesWriter) Close() error {
+	return writer.w.Close()
+}
+
+// newSalesReportRow creates a sales report row from the DB row
+func newSalesReportRow(r db.ListDailySalesRow) SalesReportRow {
+	row := SalesReportRow{
+		Day:           r.Day.Format(reportDateLayout),
+		MovieID:       r.MovieID,
+		Title:         r.Title,
+		TicketType:    r.TicketType,
+		PaymentMethod: r.PaymentMethod,
+		Channel:       r.Channel,
+		Quantity:      r.Quantity,
+		Gross:         util.NewMoney(r.Gross, r.Currency),
+		Discount:      util.NewMoney(r.Discount, r.Currency),
+		Tax:           util.NewMoney(r.Tax, r.Currency),
+		Net:           util.NewMoney(r.Net, r.Currency),
+	}
+
+	if r.ScreeningID.Valid {
+		row.ScreeningID = &r.ScreeningID.Int64
+	}
+
+	return row
+}
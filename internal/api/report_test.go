@@ -0,0 +1,279 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetSalesReportAPI tests getSalesReport handler
+func TestGetSalesReportAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	m := randomMovie().Movie
+
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	require.NoError(t, err)
+
+	rows := []db.ListDailySalesRow{
+		{
+			Day: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), MovieID: m.ID, Title: "Heat, Director's Cut",
+			ScreeningID: sql.NullInt64{Int64: 7, Valid: true}, TicketType: "adult", PaymentMethod: "card", Channel: "online",
+			Currency: "USD", Quantity: 2, Gross: 240, Discount: 40, Tax: 20, Net: 180,
+		},
+		{
+			Day: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), MovieID: m.ID, Title: "Heat, Director's Cut",
+			TicketType: "child", PaymentMethod: "cash", Channel: "box_office",
+			Currency: "USD", Quantity: 1, Gross: 60, Net: 60,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "JSON",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-31&tz=Europe/Istanbul",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListDailySalesParams{
+					CreatedFrom: time.Date(2030, 1, 1, 0, 0, 0, 0, istanbul),
+					CreatedTo:   time.Date(2030, 2, 1, 0, 0, 0, 0, istanbul),
+					TimeZone:    "Europe/Istanbul",
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Eq(arg), gomock.Any()).Times(1).DoAndReturn(streamDailySales(rows, nil))
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[SalesReportRow]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 2)
+				require.Equal(t, "2030-01-01", got.Items[0].Day)
+				require.Equal(t, int64(7), *got.Items[0].ScreeningID)
+				require.Equal(t, util.NewMoney(180, "USD"), got.Items[0].Net)
+				require.Nil(t, got.Items[1].ScreeningID)
+			},
+		},
+		{
+			name:     "CSV",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-01&format=csv",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListDailySalesParams{
+					CreatedFrom: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+					CreatedTo:   time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+					TimeZone:    "UTC",
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Eq(arg), gomock.Any()).Times(1).DoAndReturn(streamDailySales(rows, nil))
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="sales-2030-01-01-2030-01-01.csv"`, w.Header().Get("Content-Disposition"))
+
+				want := "day,movie_id,title,screening_id,ticket_type,payment_method,channel,quantity,currency,gross,discount,tax,net\n" +
+					fmt.Sprintf("2030-01-01,%d,\"Heat, Director's Cut\",7,adult,card,online,2,USD,2.40,0.40,0.20,1.80\n", m.ID) +
+					fmt.Sprintf("2030-01-01,%d,\"Heat, Director's Cut\",,child,cash,box_office,1,USD,0.60,0.00,0.00,0.60\n", m.ID)
+				require.Equal(t, want, w.Body.String())
+			},
+		},
+		{
+			name:     "XLSX",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-01&format=xlsx",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(streamDailySales(rows, nil))
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+
+				data := w.Body.Bytes()
+				zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+				require.NoError(t, err)
+				require.Len(t, zr.File, 5)
+
+				sheet, err := zr.Open("xl/worksheets/sheet1.xml")
+				require.NoError(t, err)
+				defer sheet.Close()
+
+				content, err := ioutil.ReadAll(sheet)
+				require.NoError(t, err)
+
+				// the amounts are number cells so they can be summed in the spreadsheet
+				require.Contains(t, string(content), `<c r="J2"><v>2.40</v></c><c r="K2"><v>0.40</v></c><c r="L2"><v>0.20</v></c><c r="M2"><v>1.80</v></c>`)
+			},
+		},
+		{
+			name:     "Invalid Time Zone",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-31&tz=Mars/Olympus",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Local Time Zone",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-31&tz=Local",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Empty JSON",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-31",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(streamDailySales(nil, nil))
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				require.JSONEq(t, `{"items":[]}`, w.Body.String())
+			},
+		},
+		{
+			name:     "Reversed Range",
+			username: staff.Username,
+			query:    "?from=2030-01-31&to=2030-01-01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Range Too Long",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2031-01-02",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Invalid Format",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-31&format=pdf",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Not Staff",
+			username: user.Username,
+			query:    "?from=2030-01-01&to=2030-01-31",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Internal Error",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-31&format=csv",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(streamDailySales(nil, sql.ErrConnDone))
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+		{
+			name:     "Error After First Row",
+			username: staff.Username,
+			query:    "?from=2030-01-01&to=2030-01-31&format=csv",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().StreamDailySales(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(streamDailySales(rows[:1], sql.ErrConnDone))
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				// the file is already started so only the rows before the error are sent
+				require.Equal(t, http.StatusOK, w.Code)
+				require.NotContains(t, w.Body.String(), "box_office")
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/reports/sales"+tt.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// streamDailySales returns a stub of StreamDailySales that passes the given rows and returns err after them
+func streamDailySales(rows []db.ListDailySalesRow, err error) func(context.Context, db.ListDailySalesParams, func(db.ListDailySalesRow) error) error {
+	return func(_ context.Context, _ db.ListDailySalesParams, fn func(db.ListDailySalesRow) error) error {
+		for _, r := range rows {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,448 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var ErrDuplicateReview = errors.New("movie is already reviewed by the user")
+var ErrDuplicateReport = errors.New("review is already reported by the user")
+var ErrReportOwnReview = errors.New("cannot report own review")
+
+// reportThreshold is the count of the reports that sends an approved review back to the moderation queue
+const reportThreshold = 3
+
+// cursor kinds of the review lists
+const (
+	movieReviewsCursor = "movie_reviews"
+	reviewsCursor      = "reviews"
+)
+
+// ReviewStats holds the aggregate ratings of the approved reviews of a movie
+type ReviewStats struct {
+	Count   int64   `json:"count"`
+	Average float64 `json:"average"`
+	// Histogram holds the count of the reviews for each star from 1 to 5
+	Histogram map[int16]int64 `json:"histogram"`
+}
+
+// newReviewStats converts the DB stats into the response
+func newReviewStats(row db.GetMovieReviewStatsRow) *ReviewStats {
+	return &ReviewStats{
+		Count:   row.Count,
+		Average: row.Average,
+		Histogram: map[int16]int64{
+			1: row.OneStar,
+			2: row.TwoStars,
+			3: row.ThreeStars,
+			4: row.FourStars,
+			5: row.FiveStars,
+		},
+	}
+}
+
+// CreateReviewRequest holds the json data of the request
+type CreateReviewRequest struct {
+	Rating int16  `json:"rating" binding:"required,min=1,max=5"`
+	Body   string `json:"body" binding:"max=2000"`
+}
+
+// createReview rates and reviews a movie, the review is verified if the user has a checked in ticket for it.
+// The review waits for the moderators unless the automatic filter rejects it
+func (server *Server) createReview(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetMovieRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req CreateReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// then i make sure the movie is still served
+	if !server.requireServedMovie(ctx, uri.ID) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.CreateReviewTxParams{
+		CreateReviewParams: db.CreateReviewParams{
+			MovieID:  uri.ID,
+			Username: authPayload.Username,
+			Rating:   req.Rating,
+			Body:     req.Body,
+			Status:   db.ReviewPending,
+		},
+	}
+
+	if v := server.filter.Check(req.Body); v.Flagged {
+		arg.Status = db.ReviewRejected
+		arg.FilterReason = v.Reason
+	}
+
+	review, err := server.store.CreateReviewTx(ctx, arg)
+
+	if err != nil {
+		// a user can review a movie only once
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrDuplicateReview))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, review)
+}
+
+// listMovieReviews returns a page of the approved reviews of a movie, newest first
+func (server *Server) listMovieReviews(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetMovieRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req CursorPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	beforeID, err := server.decodeCursor(movieReviewsCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// then i make sure the movie is still served, so an unknown movie isn't an empty page
+	if !server.requireServedMovie(ctx, uri.ID) {
+		return
+	}
+
+	arg := db.ListMovieReviewsParams{
+		MovieID:  uri.ID,
+		BeforeID: sql.NullInt64{Int64: beforeID, Valid: beforeID != 0},
+		Limit:    req.PageSize + 1,
+	}
+
+	reviews, err := server.store.ListMovieReviews(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	reviews, next := trimCursorPage(server, movieReviewsCursor, reviews, req.PageSize, func(r db.Review) int64 { return r.ID })
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, reviews, next))
+}
+
+// GetReviewRequest holds the uri data of the review requests
+type GetReviewRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// deleteReview deletes a review of the authenticated user
+func (server *Server) deleteReview(ctx *gin.Context) {
+	// first i check for the bindings
+	var req GetReviewRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	review, err := server.store.GetReview(ctx, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// only the author can delete a review, staff rejects it instead
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if review.Username != authPayload.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorizedAction))
+		return
+	}
+
+	err = server.store.DeleteReview(ctx, review.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, nil)
+}
+
+// ModerateReviewRequest holds the json data of the request, a rejection needs a reason
+type ModerateReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending approved rejected"`
+	Reason string `json:"reason" binding:"required_if=Status rejected,max=500"`
+}
+
+// moderateReview approves or rejects a review, the decision is recorded in the audit trail
+func (server *Server) moderateReview(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetReviewRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ModerateReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.ModerateReviewTxParams{
+		ID:        uri.ID,
+		Moderator: authPayload.Username,
+		Status:    req.Status,
+		Reason:    req.Reason,
+	}
+
+	review, err := server.store.ModerateReviewTx(ctx, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, review)
+}
+
+// ListReviewsRequest holds query values of the request
+type ListReviewsRequest struct {
+	CursorPageRequest
+	Status string `form:"status" binding:"required,oneof=pending approved rejected"`
+}
+
+// listReviews returns a page of the reviews with the given status for the moderators, newest first.
+// The pending reviews are the moderation queue
+func (server *Server) listReviews(ctx *gin.Context) {
+	// first i check for the bindings
+	var req ListReviewsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	beforeID, err := server.decodeCursor(reviewsCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListReviewsByStatusParams{
+		Status:   req.Status,
+		BeforeID: sql.NullInt64{Int64: beforeID, Valid: beforeID != 0},
+		Limit:    req.PageSize + 1,
+	}
+
+	reviews, err := server.store.ListReviewsByStatus(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	reviews, next := trimCursorPage(server, reviewsCursor, reviews, req.PageSize, func(r db.Review) int64 { return r.ID })
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, reviews, next))
+}
+
+// ReportReviewRequest holds the json data of the request
+type ReportReviewRequest struct {
+	Reason string `json:"reason" binding:"required,oneof=spam offensive spoiler other"`
+	Note   string `json:"note" binding:"max=500"`
+}
+
+// reportReview reports an approved review of another user,
+// the review goes back to the moderation queue if it is reported too often
+func (server *Server) reportReview(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetReviewRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ReportReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	review, err := server.store.GetReview(ctx, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// only the public reviews can be reported, the others are already with the moderators
+	if review.Status != db.ReviewApproved {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if review.Username == authPayload.Username {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrReportOwnReview))
+		return
+	}
+
+	arg := db.ReportReviewTxParams{
+		CreateReviewReportParams: db.CreateReviewReportParams{
+			ReviewID: review.ID,
+			Reporter: authPayload.Username,
+			Reason:   req.Reason,
+			Note:     req.Note,
+		},
+		Threshold: reportThreshold,
+	}
+
+	result, err := server.store.ReportReviewTx(ctx, arg)
+
+	if err != nil {
+		// a user can report a review only once
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrDuplicateReport))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	// the reporter doesn't need to know the status of the review
+	ctx.JSON(http.StatusOK, result.Report)
+}
+
+// ReviewModerationResponse holds a review with its reports and the audit trail of its moderation
+type ReviewModerationResponse struct {
+	Review  db.Review            `json:"review"`
+	Reports []db.ReviewReport    `json:"reports"`
+	Events  []db.ModerationEvent `json:"events"`
+}
+
+// getReviewModeration returns a review with its reports and the decisions about it for the moderators
+func (server *Server) getReviewModeration(ctx *gin.Context) {
+	// first i check for the bindings
+	var req GetReviewRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	review, err := server.store.GetReview(ctx, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	reports, err := server.store.ListReviewReports(ctx, review.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	events, err := server.store.ListModerationEvents(ctx, review.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, ReviewModerationResponse{Review: review, Reports: reports, Events: events})
+}
+
+// requireServedMovie writes 404 and returns false if the movie doesn't exist or it is deleted
+func (server *Server) requireServedMovie(ctx *gin.Context, id int64) bool {
+	m, err := server.store.GetMovie(ctx, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if m.DeletedAt.Valid {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrMovieDeleted))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,811 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/moderation"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateReviewAPI tests createReview handler
+func TestCreateReviewAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	_, user := randomUser(t)
+	review := randomReview(movie, user.Username)
+	review.Status = db.ReviewPending
+
+	rejected := review
+	rejected.Body = "what a scam"
+	rejected.Status = db.ReviewRejected
+
+	deleted := movie
+	deleted.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"rating": review.Rating, "body": review.Body},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateReviewTxParams{
+					CreateReviewParams: db.CreateReviewParams{
+						MovieID:  movie.ID,
+						Username: user.Username,
+						Rating:   review.Rating,
+						Body:     review.Body,
+						Status:   db.ReviewPending,
+					},
+				}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().CreateReviewTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(review, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireBodyMatchReview(t, w.Body, review)
+			},
+		},
+		{
+			name: "Rejected By Filter",
+			body: gin.H{"rating": rejected.Rating, "body": rejected.Body},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateReviewTxParams{
+					CreateReviewParams: db.CreateReviewParams{
+						MovieID:  movie.ID,
+						Username: user.Username,
+						Rating:   rejected.Rating,
+						Body:     rejected.Body,
+						Status:   db.ReviewRejected,
+					},
+					FilterReason: `blocked word "scam"`,
+				}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().CreateReviewTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rejected, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireBodyMatchReview(t, w.Body, rejected)
+			},
+		},
+		{
+			name: "Invalid Rating",
+			body: gin.H{"rating": 6, "body": review.Body},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Movie Not Found",
+			body: gin.H{"rating": review.Rating},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+				store.EXPECT().CreateReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Movie Deleted",
+			body: gin.H{"rating": review.Rating},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(deleted, nil)
+				store.EXPECT().CreateReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Already Reviewed",
+			body: gin.H{"rating": review.Rating},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().CreateReviewTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Review{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			body: gin.H{"rating": review.Rating},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().CreateReviewTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Review{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.filter = moderation.NewWordlistFilter([]string{"scam"})
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/movies/%d/reviews", movie.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListMovieReviewsAPI tests listMovieReviews handler
+func TestListMovieReviewsAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	var reviews []db.Review
+	for i := 0; i < 3; i++ {
+		reviews = append(reviews, randomReview(movie, util.RandomName()))
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?page_size=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListMovieReviewsParams{MovieID: movie.ID, Limit: 3}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListMovieReviews(gomock.Any(), gomock.Eq(arg)).Times(1).Return(reviews, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[db.Review]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, reviews[:2], got.Items)
+				require.NotEmpty(t, got.NextCursor)
+			},
+		},
+		{
+			name:  "Invalid Cursor",
+			query: "?page_size=2&cursor=abc",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMovieReviews(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Movie Not Found",
+			query: "?page_size=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+				store.EXPECT().ListMovieReviews(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:  "Internal Error",
+			query: "?page_size=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListMovieReviews(gomock.Any(), gomock.Any()).Times(1).Return([]db.Review{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/movies/%d/reviews%s", movie.ID, tt.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestDeleteReviewAPI tests deleteReview handler
+func TestDeleteReviewAPI(t *testing.T) {
+	_, user := randomUser(t)
+	review := randomReview(randomMovie().Movie, user.Username)
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(review, nil)
+				store.EXPECT().DeleteReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Review Of Other User",
+			username: util.RandomName(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(review, nil)
+				store.EXPECT().DeleteReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, w.Code)
+			},
+		},
+		{
+			name:     "Not Found",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(db.Review{}, sql.ErrNoRows)
+				store.EXPECT().DeleteReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Internal Error",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(review, nil)
+				store.EXPECT().DeleteReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/reviews/%d", review.ID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestModerateReviewAPI tests moderateReview handler
+func TestModerateReviewAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	review := randomReview(randomMovie().Movie, user.Username)
+
+	rejected := review
+	rejected.Status = db.ReviewRejected
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			body:     gin.H{"status": "rejected", "reason": "insults"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ModerateReviewTxParams{
+					ID:        review.ID,
+					Moderator: staff.Username,
+					Status:    db.ReviewRejected,
+					Reason:    "insults",
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ModerateReviewTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rejected, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireBodyMatchReview(t, w.Body, rejected)
+			},
+		},
+		{
+			name:     "Approve Without Reason",
+			username: staff.Username,
+			body:     gin.H{"status": "approved"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ModerateReviewTxParams{
+					ID:        review.ID,
+					Moderator: staff.Username,
+					Status:    db.ReviewApproved,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ModerateReviewTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(review, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Reject Without Reason",
+			username: staff.Username,
+			body:     gin.H{"status": "rejected"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ModerateReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Not Staff",
+			username: user.Username,
+			body:     gin.H{"status": "approved"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ModerateReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Invalid Status",
+			username: staff.Username,
+			body:     gin.H{"status": "deleted"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ModerateReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Not Found",
+			username: staff.Username,
+			body:     gin.H{"status": "approved"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ModerateReviewTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Review{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/reviews/%d/status", review.ID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListReviewsAPI tests listReviews handler
+func TestListReviewsAPI(t *testing.T) {
+	staff := randomStaff(t)
+	review := randomReview(randomMovie().Movie, util.RandomName())
+	review.Status = db.ReviewPending
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?status=pending&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListReviewsByStatusParams{Status: db.ReviewPending, Limit: 6}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ListReviewsByStatus(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Review{review}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[db.Review]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, []db.Review{review}, got.Items)
+				require.Empty(t, got.NextCursor)
+			},
+		},
+		{
+			name:  "No Status",
+			query: "?page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ListReviewsByStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Internal Error",
+			query: "?status=pending&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().ListReviewsByStatus(gomock.Any(), gomock.Any()).Times(1).Return([]db.Review{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/reviews"+tt.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestReportReviewAPI tests reportReview handler
+func TestReportReviewAPI(t *testing.T) {
+	_, author := randomUser(t)
+	_, reporter := randomUser(t)
+	review := randomReview(randomMovie().Movie, author.Username)
+
+	pending := review
+	pending.Status = db.ReviewPending
+
+	report := db.ReviewReport{
+		ID:       util.RandomInt(1, 1000),
+		ReviewID: review.ID,
+		Reporter: reporter.Username,
+		Reason:   "spoiler",
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: reporter.Username,
+			body:     gin.H{"reason": "spoiler"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReportReviewTxParams{
+					CreateReviewReportParams: db.CreateReviewReportParams{
+						ReviewID: review.ID,
+						Reporter: reporter.Username,
+						Reason:   "spoiler",
+					},
+					Threshold: reportThreshold,
+				}
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(review, nil)
+				store.EXPECT().ReportReviewTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ReportReviewTxResult{Report: report, Review: pending}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got db.ReviewReport
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, report, got)
+			},
+		},
+		{
+			name:     "Invalid Reason",
+			username: reporter.Username,
+			body:     gin.H{"reason": "boring"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReportReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Own Review",
+			username: author.Username,
+			body:     gin.H{"reason": "spoiler"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(review, nil)
+				store.EXPECT().ReportReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Review Not Approved",
+			username: reporter.Username,
+			body:     gin.H{"reason": "spoiler"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().ReportReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Review Not Found",
+			username: reporter.Username,
+			body:     gin.H{"reason": "spoiler"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(db.Review{}, sql.ErrNoRows)
+				store.EXPECT().ReportReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Already Reported",
+			username: reporter.Username,
+			body:     gin.H{"reason": "spoiler"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(review, nil)
+				store.EXPECT().ReportReviewTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ReportReviewTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/reviews/%d/reports", review.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestGetReviewModerationAPI tests getReviewModeration handler
+func TestGetReviewModerationAPI(t *testing.T) {
+	staff := randomStaff(t)
+	review := randomReview(randomMovie().Movie, util.RandomName())
+
+	reports := []db.ReviewReport{
+		{ID: util.RandomInt(1, 1000), ReviewID: review.ID, Reporter: util.RandomName(), Reason: "offensive"},
+	}
+	events := []db.ModerationEvent{
+		{
+			ID:         util.RandomInt(1, 1000),
+			ReviewID:   review.ID,
+			Moderator:  sql.NullString{String: staff.Username, Valid: true},
+			FromStatus: db.ReviewPending,
+			ToStatus:   db.ReviewApproved,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(review, nil)
+				store.EXPECT().ListReviewReports(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(reports, nil)
+				store.EXPECT().ListModerationEvents(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(events, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ReviewModerationResponse
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, review, got.Review)
+				require.Equal(t, reports, got.Reports)
+				require.Equal(t, events, got.Events)
+			},
+		},
+		{
+			name: "Not Found",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(db.Review{}, sql.ErrNoRows)
+				store.EXPECT().ListReviewReports(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListModerationEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetReview(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(review, nil)
+				store.EXPECT().ListReviewReports(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return(reports, nil)
+				store.EXPECT().ListModerationEvents(gomock.Any(), gomock.Eq(review.ID)).Times(1).Return([]db.ModerationEvent{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/reviews/%d/moderation", review.ID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// randomReview creates a random review of the given user for the given movie
+func randomReview(movie db.Movie, username string) db.Review {
+	return db.Review{
+		ID:       util.RandomInt(1, 1000),
+		MovieID:  movie.ID,
+		Username: username,
+		Rating:   int16(util.RandomInt(1, 5)),
+		Body:     util.RandomString(20),
+		Verified: true,
+		Status:   db.ReviewApproved,
+	}
+}
+
+// requireBodyMatchReview checks for a given body and response's body
+func requireBodyMatchReview(t *testing.T, body *bytes.Buffer, review db.Review) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var got db.Review
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Equal(t, review, got)
+}
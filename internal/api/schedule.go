@@ -0,0 +1,235 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/schedule"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrRuntimeUnknown   = errors.New("runtime of the movie is unknown")
+	ErrScheduleTooLarge = errors.New("schedule creates too many screenings")
+)
+
+const (
+	defaultTrailerMinutes  = 15
+	defaultCleaningMinutes = 15
+	// maxScheduledScreenings limits the screenings a schedule creates with its repetitions
+	maxScheduledScreenings = 200
+)
+
+// GetAuditoriumRequest holds the uri data of the auditorium requests
+type GetAuditoriumRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// ScheduleSlotRequest holds a screening of the schedule request
+type ScheduleSlotRequest struct {
+	MovieID  int64     `json:"movie_id" binding:"required,min=1"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	// TrailerMinutes is 15 minutes if it is not given
+	TrailerMinutes *int32 `json:"trailer_minutes" binding:"omitempty,min=0,max=60"`
+	ScreeningAttributesRequest
+}
+
+// ScheduleRequest holds the json data of the request, the slots are repeated every week for RepeatWeeks weeks
+type ScheduleRequest struct {
+	Slots []ScheduleSlotRequest `json:"slots" binding:"required,min=1,max=20,dive"`
+	// RepeatWeeks is 1 if it is not given, so the slots are scheduled once
+	RepeatWeeks int  `json:"repeat_weeks" binding:"omitempty,min=1,max=26"`
+	DryRun      bool `json:"dry_run"`
+}
+
+// ScheduleResponse holds the screenings of a schedule, in a dry run they are not created and the conflicts are listed
+type ScheduleResponse struct {
+	Screenings []db.Screening         `json:"screenings"`
+	Conflicts  []db.ScreeningConflict `json:"conflicts"`
+	DryRun     bool                   `json:"dry_run"`
+}
+
+// scheduleAuditorium creates the unpublished screenings of an auditorium and repeats them weekly.
+// The weeks are added on the wall clock of the venue, either all of the screenings are created or none of them
+func (server *Server) scheduleAuditorium(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetAuditoriumRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.RepeatWeeks == 0 {
+		req.RepeatWeeks = 1
+	}
+
+	if len(req.Slots)*req.RepeatWeeks > maxScheduledScreenings {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrScheduleTooLarge))
+		return
+	}
+
+	now := time.Now()
+	for _, s := range req.Slots {
+		if !s.StartsAt.After(now) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrScreeningInPast))
+			return
+		}
+	}
+
+	// then i check the role of the user in the venue of the auditorium
+	a, err := server.store.GetAuditorium(ctx, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.requireVenueRole(ctx, a.VenueID, venueRoleStaff) {
+		return
+	}
+
+	v, err := server.store.GetVenue(ctx, a.VenueID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the time zone is validated when the venue is created
+	loc, err := time.LoadLocation(v.TimeZone)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// then i check the formats and the movies of the slots at once
+	formats := make([]string, 0, len(req.Slots))
+	for _, s := range req.Slots {
+		if s.Format != "" {
+			formats = append(formats, s.Format)
+		}
+	}
+
+	if !server.requireScreeningFormats(ctx, formats...) {
+		return
+	}
+
+	movies, err := server.loadMovies(ctx, collectIDs(req.Slots, func(s ScheduleSlotRequest) int64 { return s.MovieID }))
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	screenings := make([]db.CreateScreeningParams, 0, len(req.Slots)*req.RepeatWeeks)
+	for _, s := range req.Slots {
+		m, ok := movies[s.MovieID]
+		if !ok {
+			ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+			return
+		}
+
+		if !checkSchedulableMovie(ctx, m) {
+			return
+		}
+
+		trailerMinutes := int32(defaultTrailerMinutes)
+		if s.TrailerMinutes != nil {
+			trailerMinutes = *s.TrailerMinutes
+		}
+
+		for _, startsAt := range schedule.Weekly(s.StartsAt, loc, req.RepeatWeeks) {
+			slot := schedule.NewSlot(startsAt, trailerMinutes, m.Runtime, a.CleaningMinutes)
+
+			arg := db.CreateScreeningParams{
+				MovieID:        m.ID,
+				StartsAt:       slot.StartsAt,
+				TrailerMinutes: trailerMinutes,
+				EndsAt:         slot.EndsAt,
+				BlockedUntil:   slot.BlockedUntil,
+			}
+			s.setAttributes(&arg)
+
+			screenings = append(screenings, arg)
+		}
+	}
+
+	result, err := server.store.ScheduleScreeningsTx(ctx, db.ScheduleScreeningsTxParams{
+		AuditoriumID: a.ID,
+		Screenings:   screenings,
+		DryRun:       req.DryRun,
+	})
+
+	if err != nil {
+		if err == db.ErrScheduleConflict {
+			ctx.JSON(http.StatusConflict, scheduleConflictResponse(err, result.Conflicts))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, ScheduleResponse{
+		Screenings: result.Screenings,
+		Conflicts:  result.Conflicts,
+		DryRun:     req.DryRun,
+	})
+}
+
+// requireSchedulableMovie gets the movie and writes the response if it can't be scheduled
+func (server *Server) requireSchedulableMovie(ctx *gin.Context, id int64) (db.Movie, bool) {
+	m, err := server.store.GetMovie(ctx, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.Movie{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Movie{}, false
+	}
+
+	return m, checkSchedulableMovie(ctx, m)
+}
+
+// checkSchedulableMovie writes the response if the movie is deleted or its runtime is unknown
+func checkSchedulableMovie(ctx *gin.Context, m db.Movie) bool {
+	if m.DeletedAt.Valid {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrMovieDeleted))
+		return false
+	}
+
+	if m.Runtime == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrRuntimeUnknown))
+		return false
+	}
+
+	return true
+}
+
+// scheduleConflictResponse returns the error with the conflicts that caused it
+func scheduleConflictResponse(err error, conflicts []db.ScreeningConflict) gin.H {
+	return gin.H{
+		"error":     err.Error(),
+		"conflicts": conflicts,
+	}
+}
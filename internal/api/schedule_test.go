@@ -0,0 +1,298 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScheduleAuditoriumAPI tests scheduleAuditorium handler
+func TestScheduleAuditoriumAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	venue := randomVenue()
+	auditorium := randomAuditorium(venue)
+	movie := randomMovie().Movie
+	screening := randomScreening(movie)
+	startsAt := screening.StartsAt
+
+	body := gin.H{
+		"slots":        []gin.H{{"movie_id": movie.ID, "starts_at": startsAt}},
+		"repeat_weeks": 3,
+	}
+	venueStaffArg := db.GetVenueStaffParams{Username: user.Username, VenueID: venue.ID}
+
+	// the screening is repeated on the same weekday for 3 weeks, Istanbul has no daylight saving time
+	screenings := make([]db.CreateScreeningParams, 0, 3)
+	for i := 0; i < 3; i++ {
+		screenings = append(screenings, db.CreateScreeningParams{
+			MovieID:        movie.ID,
+			StartsAt:       startsAt.AddDate(0, 0, 7*i),
+			TrailerMinutes: defaultTrailerMinutes,
+			EndsAt:         screening.EndsAt.AddDate(0, 0, 7*i),
+			BlockedUntil:   screening.BlockedUntil.AddDate(0, 0, 7*i),
+			Format:         defaultScreeningFormat,
+			AudioLanguage:  defaultAudioLanguage,
+		})
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ScheduleScreeningsTxParams{AuditoriumID: auditorium.ID, Screenings: screenings}
+
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq([]int64{movie.ID})).Times(1).Return([]db.Movie{movie}, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.ScheduleScreeningsTxResult{Screenings: []db.Screening{screening, screening, screening}}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				resp := requireBodySchedule(t, w)
+				require.Len(t, resp.Screenings, 3)
+				require.False(t, resp.DryRun)
+			},
+		},
+		{
+			name:     "Dry Run",
+			username: user.Username,
+			body: gin.H{
+				"slots":        []gin.H{{"movie_id": movie.ID, "starts_at": startsAt}},
+				"repeat_weeks": 3,
+				"dry_run":      true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ScheduleScreeningsTxParams{AuditoriumID: auditorium.ID, Screenings: screenings, DryRun: true}
+				otherIndex := 0
+				result := db.ScheduleScreeningsTxResult{
+					Conflicts: []db.ScreeningConflict{{Index: 2, Screening: screenings[2], OtherIndex: &otherIndex}},
+				}
+
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(venueStaffArg)).Times(1).
+					Return(db.VenueStaff{Username: user.Username, VenueID: venue.ID, Role: venueRoleStaff}, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{movie}, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				resp := requireBodySchedule(t, w)
+				require.True(t, resp.DryRun)
+				require.Len(t, resp.Conflicts, 1)
+				require.Equal(t, 2, resp.Conflicts[0].Index)
+			},
+		},
+		{
+			name:     "Conflict",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				existing := randomScreening(movie)
+				result := db.ScheduleScreeningsTxResult{
+					Conflicts: []db.ScreeningConflict{{Index: 1, Screening: screenings[1], Existing: &existing}},
+				}
+
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{movie}, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(1).Return(result, db.ErrScheduleConflict)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+
+				conflicts := requireBodyConflicts(t, w)
+				require.Len(t, conflicts, 1)
+				require.Equal(t, 1, conflicts[0].Index)
+			},
+		},
+		{
+			name:     "Not Venue Staff",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(venueStaffArg)).Times(1).Return(db.VenueStaff{}, sql.ErrNoRows)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Movie Not Found",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{}, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Runtime Unknown",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				unknown := movie
+				unknown.Runtime = 0
+
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{unknown}, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Starts In Past",
+			username: staff.Username,
+			body:     gin.H{"slots": []gin.H{{"movie_id": movie.ID, "starts_at": time.Now().Add(-time.Hour)}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Too Many Weeks",
+			username: staff.Username,
+			body:     gin.H{"slots": []gin.H{{"movie_id": movie.ID, "starts_at": startsAt}}, "repeat_weeks": 27},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "No Slots",
+			username: staff.Username,
+			body:     gin.H{"slots": []gin.H{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Auditorium Not Found",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(db.Auditorium{}, sql.ErrNoRows)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Internal Error",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{movie}, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ScheduleScreeningsTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/auditoriums/%d/schedule", auditorium.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// requireBodySchedule reads the schedule of the response
+func requireBodySchedule(t *testing.T, w *httptest.ResponseRecorder) ScheduleResponse {
+	data, err := ioutil.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	var got ScheduleResponse
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+
+	return got
+}
+
+// requireBodyConflicts reads the conflicts of the error response
+func requireBodyConflicts(t *testing.T, w *httptest.ResponseRecorder) []db.ScreeningConflict {
+	data, err := ioutil.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	var got struct {
+		Error     string                 `json:"error"`
+		Conflicts []db.ScreeningConflict `json:"conflicts"`
+	}
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.NotEmpty(t, got.Error)
+
+	return got.Conflicts
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/schedule"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrScreeningInPast    = errors.New("screening must start in the future")
+	ErrScreeningPublished = errors.New("screening is already published")
+)
+
+// CreateScreeningRequest holds the json data of the request
+type CreateScreeningRequest struct {
+	MovieID      int64     `json:"movie_id" binding:"required,min=1"`
+	AuditoriumID int64     `json:"auditorium_id" binding:"required,min=1"`
+	StartsAt     time.Time `json:"starts_at" binding:"required"`
+	// TrailerMinutes is 15 minutes if it is not given
+	TrailerMinutes *int32 `json:"trailer_minutes" binding:"omitempty,min=0,max=60"`
+	ScreeningAttributesRequest
+}
+
+// createScreening creates an unpublished screening of a movie in an auditorium, it isn't on sale until it is published.
+// Only the staff of the auditorium's venue can create it, and it can't overlap another screening of the auditorium
+func (server *Server) createScreening(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreateScreeningRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !req.StartsAt.After(time.Now()) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrScreeningInPast))
+		return
+	}
+
+	// then i check the role of the user in the venue of the auditorium
+	a, err := server.store.GetAuditorium(ctx, req.AuditoriumID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.requireVenueRole(ctx, a.VenueID, venueRoleStaff) {
+		return
+	}
+
+	// then i make sure the movie is still served and its runtime is known
+	m, ok := server.requireSchedulableMovie(ctx, req.MovieID)
+	if !ok {
+		return
+	}
+
+	s := db.CreateScreeningParams{MovieID: m.ID}
+	req.setAttributes(&s)
+
+	// only the given formats are checked, the default format always exists
+	if req.Format != "" && !server.requireScreeningFormats(ctx, req.Format) {
+		return
+	}
+
+	s.TrailerMinutes = defaultTrailerMinutes
+	if req.TrailerMinutes != nil {
+		s.TrailerMinutes = *req.TrailerMinutes
+	}
+
+	slot := schedule.NewSlot(req.StartsAt, s.TrailerMinutes, m.Runtime, a.CleaningMinutes)
+	s.StartsAt = slot.StartsAt
+	s.EndsAt = slot.EndsAt
+	s.BlockedUntil = slot.BlockedUntil
+
+	arg := db.ScheduleScreeningsTxParams{
+		AuditoriumID: a.ID,
+		Screenings:   []db.CreateScreeningParams{s},
+	}
+
+	result, err := server.store.ScheduleScreeningsTx(ctx, arg)
+
+	if err != nil {
+		if err == db.ErrScheduleConflict {
+			ctx.JSON(http.StatusConflict, scheduleConflictResponse(err, result.Conflicts))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	screening := result.Screenings[0]
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, screening)
+}
+
+// GetScreeningRequest holds the uri data of the screening requests
+type GetScreeningRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// publishScreening puts a screening on sale, the watchers of its movie are notified by the watchlist job.
+// Only the staff of the screening's venue can publish it, the screenings without an auditorium need the global staff
+func (server *Server) publishScreening(ctx *gin.Context) {
+	// first i check for the bindings
+	var req GetScreeningRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	s, err := server.store.GetScreening(ctx, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// there is no venue with id 0, so only the global staff passes for the screenings without an auditorium
+	var venueID int64
+	if s.AuditoriumID.Valid {
+		a, err := server.store.GetAuditorium(ctx, s.AuditoriumID.Int64)
+
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		venueID = a.VenueID
+	}
+
+	if !server.requireVenueRole(ctx, venueID, venueRoleStaff) {
+		return
+	}
+
+	if s.PublishedAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrScreeningPublished))
+		return
+	}
+
+	s, err = server.store.PublishScreening(ctx, s.ID)
+
+	if err != nil {
+		// if another publish won the race there are no rows to update
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrScreeningPublished))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, s)
+}
+
+// listMovieScreenings returns the upcoming screenings of a movie that are on sale and match the given filters
+func (server *Server) listMovieScreenings(ctx *gin.Context) {
+	// first i check for the bindings
+	var req GetMovieRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var filter ScreeningFilterRequest
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.requireServedMovie(ctx, req.ID) {
+		return
+	}
+
+	screenings, err := server.store.ListMovieScreenings(ctx, db.ListMovieScreeningsParams{
+		MovieID:          req.ID,
+		Format:           sql.NullString{String: filter.Format, Valid: filter.Format != ""},
+		AudioLanguage:    sql.NullString{String: filter.AudioLanguage, Valid: filter.AudioLanguage != ""},
+		SubtitleLanguage: sql.NullString{String: filter.SubtitleLanguage, Valid: filter.SubtitleLanguage != ""},
+		Subtitled:        newNullBool(filter.Subtitled),
+		Dubbed:           newNullBool(filter.Dubbed),
+		AudioDescription: newNullBool(filter.AudioDescription),
+		Relaxed:          newNullBool(filter.Relaxed),
+	})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, screenings, ""))
+}
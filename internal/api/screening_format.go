@@ -0,0 +1,167 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrUnknownFormat = errors.New("screening format doesn't exist")
+
+const (
+	defaultScreeningFormat = "2d"
+	defaultAudioLanguage   = "en"
+)
+
+// ScreeningAttributesRequest holds the attributes of a screening, it is a 2D screening in english if they are not given
+type ScreeningAttributesRequest struct {
+	Format string `json:"format" binding:"omitempty,max=16"`
+	// the languages are ISO 639-1 codes, a screening has subtitles only if SubtitleLanguage is given
+	AudioLanguage    string `json:"audio_language" binding:"omitempty,len=2,lowercase,alpha"`
+	SubtitleLanguage string `json:"subtitle_language" binding:"omitempty,len=2,lowercase,alpha"`
+	Dubbed           bool   `json:"dubbed"`
+	AudioDescription bool   `json:"audio_description"`
+	// Relaxed screenings are sensory-friendly
+	Relaxed bool `json:"relaxed"`
+}
+
+// setAttributes sets the attributes of the screening params with their defaults
+func (req ScreeningAttributesRequest) setAttributes(arg *db.CreateScreeningParams) {
+	arg.Format = req.Format
+	if arg.Format == "" {
+		arg.Format = defaultScreeningFormat
+	}
+
+	arg.AudioLanguage = req.AudioLanguage
+	if arg.AudioLanguage == "" {
+		arg.AudioLanguage = defaultAudioLanguage
+	}
+
+	arg.SubtitleLanguage = sql.NullString{String: req.SubtitleLanguage, Valid: req.SubtitleLanguage != ""}
+	arg.Dubbed = req.Dubbed
+	arg.AudioDescription = req.AudioDescription
+	arg.Relaxed = req.Relaxed
+}
+
+// ScreeningFilterRequest holds the query values that filter the screening listings, empty filters are not applied
+type ScreeningFilterRequest struct {
+	Format           string `form:"format" binding:"omitempty,max=16"`
+	AudioLanguage    string `form:"audio_language" binding:"omitempty,len=2"`
+	SubtitleLanguage string `form:"subtitle_language" binding:"omitempty,len=2"`
+	Subtitled        *bool  `form:"subtitled"`
+	Dubbed           *bool  `form:"dubbed"`
+	AudioDescription *bool  `form:"audio_description"`
+	Relaxed          *bool  `form:"relaxed"`
+}
+
+// newNullBool converts an optional query value, a missing value is null
+func newNullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+// requireScreeningFormats checks that the formats exist and writes the response if one of them doesn't
+func (server *Server) requireScreeningFormats(ctx *gin.Context, codes ...string) bool {
+	if len(codes) == 0 {
+		return true
+	}
+
+	formats, err := server.store.ListScreeningFormats(ctx)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	known := make(map[string]bool, len(formats))
+	for _, f := range formats {
+		known[f.Code] = true
+	}
+
+	for _, code := range codes {
+		if !known[code] {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrUnknownFormat))
+			return false
+		}
+	}
+
+	return true
+}
+
+// listScreeningFormats returns the screening formats with their surcharges, cheapest first
+func (server *Server) listScreeningFormats(ctx *gin.Context) {
+	formats, err := server.store.ListScreeningFormats(ctx)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	result := make([]ScreeningFormatResponse, 0, len(formats))
+	for _, f := range formats {
+		result = append(result, server.newScreeningFormatResponse(f))
+	}
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, ""))
+}
+
+// ScreeningFormatURIRequest holds the uri data of the request
+type ScreeningFormatURIRequest struct {
+	Code string `uri:"code" binding:"required,max=16,lowercase"`
+}
+
+// SetScreeningFormatRequest holds the json data of the request
+type SetScreeningFormatRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Surcharge is added to the net price of every seat, it applies to the tickets sold after it is changed
+	Surcharge util.Money `json:"surcharge"`
+}
+
+// setScreeningFormat creates a screening format or changes its name and surcharge
+func (server *Server) setScreeningFormat(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri ScreeningFormatURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req SetScreeningFormatRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.requireMoney(ctx, req.Surcharge) {
+		return
+	}
+
+	f, err := server.store.UpsertScreeningFormat(ctx, db.UpsertScreeningFormatParams{
+		Code:      uri.Code,
+		Name:      req.Name,
+		Surcharge: req.Surcharge.Amount,
+	})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, server.newScreeningFormatResponse(f))
+}
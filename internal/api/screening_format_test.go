@@ -0,0 +1,213 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListScreeningFormatsAPI tests listScreeningFormats handler
+func TestListScreeningFormatsAPI(t *testing.T) {
+	formats := []db.ScreeningFormat{
+		{Code: "2d", Name: "2D"},
+		{Code: "imax", Name: "IMAX", Surcharge: 500},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListScreeningFormats(gomock.Any()).Times(1).Return(formats, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[ScreeningFormatResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, len(formats))
+				for i, f := range formats {
+					require.Equal(t, f.Code, got.Items[i].Code)
+					require.Equal(t, util.NewMoney(f.Surcharge, util.DefaultCurrency), got.Items[i].Surcharge)
+				}
+			},
+		},
+		{
+			name: "Internal Server Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListScreeningFormats(gomock.Any()).Times(1).Return([]db.ScreeningFormat{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/screening-formats", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestSetScreeningFormatAPI tests setScreeningFormat handler
+func TestSetScreeningFormatAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	format := db.ScreeningFormat{Code: "4dx", Name: "4DX", Surcharge: 900}
+
+	testCases := []struct {
+		name          string
+		username      string
+		code          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			code:     format.Code,
+			body:     gin.H{"name": format.Name, "surcharge": util.NewMoney(format.Surcharge, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertScreeningFormatParams{Code: format.Code, Name: format.Name, Surcharge: format.Surcharge}
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpsertScreeningFormat(gomock.Any(), gomock.Eq(arg)).Times(1).Return(format, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ScreeningFormatResponse
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, format.Code, got.Code)
+				require.Equal(t, util.NewMoney(format.Surcharge, util.DefaultCurrency), got.Surcharge)
+			},
+		},
+		{
+			name:     "Not Staff",
+			username: user.Username,
+			code:     format.Code,
+			body:     gin.H{"name": format.Name, "surcharge": util.NewMoney(format.Surcharge, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpsertScreeningFormat(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Negative Surcharge",
+			username: staff.Username,
+			code:     format.Code,
+			body:     gin.H{"name": format.Name, "surcharge": util.NewMoney(-100, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpsertScreeningFormat(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Currency Mismatch",
+			username: staff.Username,
+			code:     format.Code,
+			body:     gin.H{"name": format.Name, "surcharge": util.NewMoney(format.Surcharge, "JPY")},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpsertScreeningFormat(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Uppercase Code",
+			username: staff.Username,
+			code:     "IMAX",
+			body:     gin.H{"name": format.Name, "surcharge": util.NewMoney(format.Surcharge, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpsertScreeningFormat(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Internal Error",
+			username: staff.Username,
+			code:     format.Code,
+			body:     gin.H{"name": format.Name, "surcharge": util.NewMoney(format.Surcharge, util.DefaultCurrency)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().UpsertScreeningFormat(gomock.Any(), gomock.Any()).Times(1).Return(db.ScreeningFormat{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPut, "/screening-formats/"+tt.code, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+			tt.checkResponse(t, w)
+		})
+	}
+}
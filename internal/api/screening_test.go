@@ -0,0 +1,586 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateScreeningAPI tests createScreening handler
+func TestCreateScreeningAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	venue := randomVenue()
+	auditorium := randomAuditorium(venue)
+	movie := randomMovie().Movie
+	screening := randomScreening(movie)
+	screening.AuditoriumID = sql.NullInt64{Int64: auditorium.ID, Valid: true}
+
+	body := gin.H{"movie_id": movie.ID, "auditorium_id": auditorium.ID, "starts_at": screening.StartsAt}
+	venueStaffArg := db.GetVenueStaffParams{Username: user.Username, VenueID: venue.ID}
+	scheduleArg := db.ScheduleScreeningsTxParams{
+		AuditoriumID: auditorium.ID,
+		Screenings: []db.CreateScreeningParams{
+			{
+				MovieID:        movie.ID,
+				StartsAt:       screening.StartsAt,
+				TrailerMinutes: defaultTrailerMinutes,
+				EndsAt:         screening.EndsAt,
+				BlockedUntil:   screening.BlockedUntil,
+				Format:         defaultScreeningFormat,
+				AudioLanguage:  defaultAudioLanguage,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Eq(scheduleArg)).Times(1).
+					Return(db.ScheduleScreeningsTxResult{Screenings: []db.Screening{screening}}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireBodyMatchScreening(t, w.Body, screening)
+			},
+		},
+		{
+			name:     "Trailer Minutes",
+			username: staff.Username,
+			body:     gin.H{"movie_id": movie.ID, "auditorium_id": auditorium.ID, "starts_at": screening.StartsAt, "trailer_minutes": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				// without trailers the screening ends and blocks the auditorium earlier
+				arg := db.ScheduleScreeningsTxParams{
+					AuditoriumID: auditorium.ID,
+					Screenings: []db.CreateScreeningParams{
+						{
+							MovieID:       movie.ID,
+							StartsAt:      screening.StartsAt,
+							EndsAt:        screening.EndsAt.Add(-defaultTrailerMinutes * time.Minute),
+							BlockedUntil:  screening.BlockedUntil.Add(-defaultTrailerMinutes * time.Minute),
+							Format:        defaultScreeningFormat,
+							AudioLanguage: defaultAudioLanguage,
+						},
+					},
+				}
+
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.ScheduleScreeningsTxResult{Screenings: []db.Screening{screening}}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Conflict",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				existing := randomScreening(movie)
+				result := db.ScheduleScreeningsTxResult{
+					Conflicts: []db.ScreeningConflict{{Index: 0, Screening: scheduleArg.Screenings[0], Existing: &existing}},
+				}
+
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Eq(scheduleArg)).Times(1).Return(result, db.ErrScheduleConflict)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+
+				conflicts := requireBodyConflicts(t, w)
+				require.Len(t, conflicts, 1)
+				require.NotNil(t, conflicts[0].Existing)
+			},
+		},
+		{
+			name:     "Attributes",
+			username: staff.Username,
+			body: gin.H{
+				"movie_id":          movie.ID,
+				"auditorium_id":     auditorium.ID,
+				"starts_at":         screening.StartsAt,
+				"format":            "imax",
+				"audio_language":    "tr",
+				"subtitle_language": "en",
+				"dubbed":            true,
+				"relaxed":           true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := scheduleArg
+				arg.Screenings = []db.CreateScreeningParams{scheduleArg.Screenings[0]}
+				arg.Screenings[0].Format = "imax"
+				arg.Screenings[0].AudioLanguage = "tr"
+				arg.Screenings[0].SubtitleLanguage = sql.NullString{String: "en", Valid: true}
+				arg.Screenings[0].Dubbed = true
+				arg.Screenings[0].Relaxed = true
+
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListScreeningFormats(gomock.Any()).Times(1).
+					Return([]db.ScreeningFormat{{Code: "2d"}, {Code: "imax", Surcharge: 500}}, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.ScheduleScreeningsTxResult{Screenings: []db.Screening{screening}}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Unknown Format",
+			username: staff.Username,
+			body:     gin.H{"movie_id": movie.ID, "auditorium_id": auditorium.ID, "starts_at": screening.StartsAt, "format": "4dx"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListScreeningFormats(gomock.Any()).Times(1).Return([]db.ScreeningFormat{{Code: "2d"}}, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Invalid Audio Language",
+			username: staff.Username,
+			body:     gin.H{"movie_id": movie.ID, "auditorium_id": auditorium.ID, "starts_at": screening.StartsAt, "audio_language": "english"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Runtime Unknown",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				unknown := movie
+				unknown.Runtime = 0
+
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(unknown, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Venue Staff",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(venueStaffArg)).Times(1).
+					Return(db.VenueStaff{Username: user.Username, VenueID: venue.ID, Role: venueRoleStaff}, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ScheduleScreeningsTxResult{Screenings: []db.Screening{screening}}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Not Venue Staff",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(venueStaffArg)).Times(1).Return(db.VenueStaff{}, sql.ErrNoRows)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Starts In Past",
+			username: staff.Username,
+			body:     gin.H{"movie_id": movie.ID, "auditorium_id": auditorium.ID, "starts_at": time.Now().Add(-time.Hour)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "No Auditorium",
+			username: staff.Username,
+			body:     gin.H{"movie_id": movie.ID, "starts_at": screening.StartsAt},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Auditorium Not Found",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(db.Auditorium{}, sql.ErrNoRows)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Movie Not Found",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Internal Error",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ScheduleScreeningsTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ScheduleScreeningsTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/screenings", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestPublishScreeningAPI tests publishScreening handler
+func TestPublishScreeningAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	venue := randomVenue()
+	auditorium := randomAuditorium(venue)
+	screening := randomScreening(randomMovie().Movie)
+	screening.AuditoriumID = sql.NullInt64{Int64: auditorium.ID, Valid: true}
+
+	published := screening
+	published.PublishedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+	// the screenings created before the venues have no auditorium
+	legacy := randomScreening(randomMovie().Movie)
+	legacy.ID = screening.ID
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(screening, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().PublishScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(published, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireBodyMatchScreening(t, w.Body, published)
+			},
+		},
+		{
+			name:     "Venue Staff",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(screening, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(db.GetVenueStaffParams{Username: user.Username, VenueID: venue.ID})).Times(1).
+					Return(db.VenueStaff{Username: user.Username, VenueID: venue.ID, Role: venueRoleStaff}, nil)
+				store.EXPECT().PublishScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(published, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Legacy Screening Needs Global Staff",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(legacy, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(db.GetVenueStaffParams{Username: user.Username})).Times(1).Return(db.VenueStaff{}, sql.ErrNoRows)
+				store.EXPECT().PublishScreening(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Not Found",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(db.Screening{}, sql.ErrNoRows)
+				store.EXPECT().PublishScreening(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Already Published",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(published, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().PublishScreening(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:     "Concurrent Publish",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(screening, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().PublishScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(db.Screening{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/screenings/%d/publish", screening.ID)
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListMovieScreeningsAPI tests listMovieScreenings handler
+func TestListMovieScreeningsAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	screenings := []db.Screening{randomScreening(movie), randomScreening(movie)}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListMovieScreeningsParams{MovieID: movie.ID}
+
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListMovieScreenings(gomock.Any(), gomock.Eq(arg)).Times(1).Return(screenings, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[db.Screening]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, screenings, got.Items)
+			},
+		},
+		{
+			name:  "Filters",
+			query: "?format=imax&audio_language=en&subtitled=true&relaxed=false",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListMovieScreeningsParams{
+					MovieID:       movie.ID,
+					Format:        sql.NullString{String: "imax", Valid: true},
+					AudioLanguage: sql.NullString{String: "en", Valid: true},
+					Subtitled:     sql.NullBool{Bool: true, Valid: true},
+					Relaxed:       sql.NullBool{Bool: false, Valid: true},
+				}
+
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListMovieScreenings(gomock.Any(), gomock.Eq(arg)).Times(1).Return(screenings, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:  "Invalid Filter",
+			query: "?dubbed=maybe",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMovieScreenings(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Movie Not Found",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+				store.EXPECT().ListMovieScreenings(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListMovieScreenings(gomock.Any(), gomock.Any()).Times(1).Return([]db.Screening{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/movies/%d/screenings%s", movie.ID, tt.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// randomScreening creates a random unpublished screening of given movie
+func randomScreening(movie db.Movie) db.Screening {
+	startsAt := time.Now().Add(time.Duration(util.RandomInt(1, 100)) * time.Hour).UTC().Truncate(time.Second)
+	endsAt := startsAt.Add(time.Duration(defaultTrailerMinutes+movie.Runtime) * time.Minute)
+
+	return db.Screening{
+		ID:             util.RandomInt(1, 1000),
+		MovieID:        movie.ID,
+		StartsAt:       startsAt,
+		TrailerMinutes: defaultTrailerMinutes,
+		EndsAt:         endsAt,
+		BlockedUntil:   endsAt.Add(defaultCleaningMinutes * time.Minute),
+		Format:         defaultScreeningFormat,
+		AudioLanguage:  defaultAudioLanguage,
+	}
+}
+
+// requireBodyMatchScreening checks for a given body and response's body
+func requireBodyMatchScreening(t *testing.T, body *bytes.Buffer, screening db.Screening) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var got db.Screening
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Equal(t, screening, got)
+}
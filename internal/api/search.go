@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// default counts are used when the count of the results isn't given
+const (
+	defaultSearchCount       = 10
+	defaultAutocompleteCount = 5
+)
+
+// SearchMoviesRequest holds query values of the request
+type SearchMoviesRequest struct {
+	Q     string `form:"q" binding:"required,min=2,max=100"`
+	Count int32  `form:"count" binding:"omitempty,min=1,max=20"`
+}
+
+// searchMovies searches the movies by their title, summary, director and cast, best matches come first
+func (server *Server) searchMovies(ctx *gin.Context) {
+	// first i check for the bindings
+	var req SearchMoviesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Count == 0 {
+		req.Count = defaultSearchCount
+	}
+
+	arg := db.SearchMoviesParams{
+		Q:     req.Q,
+		Count: req.Count,
+	}
+
+	// results come with their rank and a highlighted snippet of the summary
+	results, err := server.store.SearchMovies(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, results)
+}
+
+// AutocompleteMoviesRequest holds query values of the request
+type AutocompleteMoviesRequest struct {
+	Q     string `form:"q" binding:"required,min=1,max=50"`
+	Count int32  `form:"count" binding:"omitempty,min=1,max=20"`
+}
+
+// autocompleteMovies suggests movie titles while the user is typing, prefix matches come first
+func (server *Server) autocompleteMovies(ctx *gin.Context) {
+	// first i check for the bindings
+	var req AutocompleteMoviesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Count == 0 {
+		req.Count = defaultAutocompleteCount
+	}
+
+	arg := db.AutocompleteMoviesParams{
+		Prefix: req.Q,
+		Count:  req.Count,
+	}
+
+	suggestions, err := server.store.AutocompleteMovies(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, suggestions)
+}
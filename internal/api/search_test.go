@@ -0,0 +1,204 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchMoviesAPI tests searchMovies handler
+func TestSearchMoviesAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	results := []db.SearchMoviesRow{
+		{
+			ID:            movie.ID,
+			Title:         movie.Title,
+			Poster:        movie.Poster,
+			Certification: movie.Certification,
+			Rank:          0.75,
+			Snippet:       "<b>" + movie.Title + "</b>",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"q": {movie.Title}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SearchMoviesParams{Q: movie.Title, Count: defaultSearchCount}
+				store.EXPECT().SearchMovies(gomock.Any(), gomock.Eq(arg)).Times(1).Return(results, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got []db.SearchMoviesRow
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, results, got)
+			},
+		},
+		{
+			name:  "Custom Count",
+			query: url.Values{"q": {movie.Title}, "count": {"3"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SearchMoviesParams{Q: movie.Title, Count: 3}
+				store.EXPECT().SearchMovies(gomock.Any(), gomock.Eq(arg)).Times(1).Return(results, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:  "Missing Query",
+			query: url.Values{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchMovies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Query Too Short",
+			query: url.Values{"q": {"a"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchMovies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Invalid Count",
+			query: url.Values{"q": {movie.Title}, "count": {"100"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchMovies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Internal Error",
+			query: url.Values{"q": {movie.Title}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchMovies(gomock.Any(), gomock.Any()).Times(1).Return([]db.SearchMoviesRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/movies/search?"+tt.query.Encode(), nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestAutocompleteMoviesAPI tests autocompleteMovies handler
+func TestAutocompleteMoviesAPI(t *testing.T) {
+	movie := randomMovie().Movie
+	prefix := movie.Title[:3]
+	suggestions := []db.AutocompleteMoviesRow{{ID: movie.ID, Title: movie.Title}}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"q": {prefix}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.AutocompleteMoviesParams{Prefix: prefix, Count: defaultAutocompleteCount}
+				store.EXPECT().AutocompleteMovies(gomock.Any(), gomock.Eq(arg)).Times(1).Return(suggestions, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got []db.AutocompleteMoviesRow
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, suggestions, got)
+			},
+		},
+		{
+			name:  "Query Too Long",
+			query: url.Values{"q": {util.RandomString(51)}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AutocompleteMovies(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:  "Internal Error",
+			query: url.Values{"q": {prefix}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AutocompleteMovies(gomock.Any(), gomock.Any()).Times(1).Return([]db.AutocompleteMoviesRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/movies/autocomplete?"+tt.query.Encode(), nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
@@ -1,9 +1,20 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"log"
+	"net"
+	"net/http"
+	"sync"
 
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/mail"
+	"github.com/burakkarasel/Theatre-API/internal/moderation"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/pricing"
+	"github.com/burakkarasel/Theatre-API/internal/ratelimit"
+	"github.com/burakkarasel/Theatre-API/internal/recommend"
 	"github.com/burakkarasel/Theatre-API/internal/token"
 	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/gin-gonic/gin"
@@ -13,13 +24,29 @@ var ErrMovieLimit = errors.New("cannot create more movies")
 var ErrInvalidTicket = errors.New("cannot create ticket for 0 adult and 0 child")
 var ErrInvalidPassword = errors.New("invalid password")
 var ErrCannoCreateTokenMaker = errors.New("cannot create token maker")
+var ErrCannotCreateFilter = errors.New("cannot create review filter")
+var ErrInvalidTaxRate = errors.New("tax rate must be between 0 and 10000 basis points")
 
 // Server serves HTTP requests for our theatre app service.
 type Server struct {
-	config     util.Config
-	store      db.Store
-	router     *gin.Engine
-	tokenMaker token.Maker
+	config      util.Config
+	store       db.Store
+	router      *gin.Engine
+	httpServer  *http.Server
+	tokenMaker  token.Maker
+	filter      moderation.Filter
+	recommender *recommend.Engine
+	pricer      *pricing.Engine
+	currency    string
+	taxRate     int32
+	payments    payment.Gateway
+	mailer      mail.Mailer
+	cursorKey   []byte
+	// the password reset requests are limited per email and per IP
+	resetsByEmail *ratelimit.Limiter
+	resetsByIP    *ratelimit.Limiter
+	// background holds the work that is done after the responses, like sending the emails
+	background sync.WaitGroup
 }
 
 // NewServer creates a new server instance with given store and sets up our routing
@@ -31,16 +58,105 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		return nil, ErrCannoCreateTokenMaker
 	}
 
-	server := &Server{config: config, store: store, tokenMaker: tokenMaker}
+	// the review filter can be switched the same way, it is a chain of a wordlist and a regex filter for now
+	regexFilter, err := moderation.NewRegexFilter(config.ModerationPatterns)
+
+	if err != nil {
+		return nil, ErrCannotCreateFilter
+	}
+
+	filter := moderation.NewChainFilter(moderation.NewWordlistFilter(config.ModerationWordlist), regexFilter)
+
+	// all the prices and the sales are in the currency of the config
+	currency := config.Currency
+	if currency == "" {
+		currency = util.DefaultCurrency
+	}
+
+	if !util.IsSupportedCurrency(currency) {
+		return nil, util.ErrUnsupportedCurrency
+	}
+
+	// the sales that aren't in a venue are taxed with the tax rate of the config
+	if config.TaxRate < 0 || config.TaxRate > 10000 {
+		return nil, ErrInvalidTaxRate
+	}
+
+	server := &Server{
+		config:      config,
+		store:       store,
+		tokenMaker:  tokenMaker,
+		filter:      filter,
+		recommender: recommend.NewEngine(store),
+		pricer:      pricing.NewEngine(pricing.SystemClock{}),
+		currency:    currency,
+		taxRate:     config.TaxRate,
+		cursorKey:   newCursorKey(config),
+		// the payments are only logged until a real payment provider is wired
+		payments: payment.NewLogGateway(log.Default()),
+	}
+
+	// the emails are written to a local file or kept in memory until a real mail provider is wired
+	if config.MailerFile != "" {
+		server.mailer = mail.NewFileMailer(config.MailerFile)
+	} else {
+		server.mailer = mail.NewMemoryMailer()
+	}
+
+	server.resetsByEmail, server.resetsByIP = newPasswordResetLimiters(config)
 
 	server.setRoutes()
+	server.httpServer = &http.Server{Handler: server.router}
 
 	return server, nil
 }
 
-// start runs the HTTP server on a specific port
+// runInBackground runs fn after the response is sent, Shutdown waits for it to finish
+func (server *Server) runInBackground(fn func()) {
+	server.background.Add(1)
+	go func() {
+		defer server.background.Done()
+		fn()
+	}()
+}
+
+// start runs the HTTP server on a specific port, it returns nil when the server is shut down
 func (server *Server) Start(port string) error {
-	return server.router.Run(port)
+	listener, err := net.Listen("tcp", port)
+
+	if err != nil {
+		return err
+	}
+
+	err = server.httpServer.Serve(listener)
+
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown stops the HTTP server after its active requests are served and then waits for the background work
+// of the requests, like the password reset emails. It gives up when ctx is done
+func (server *Server) Shutdown(ctx context.Context) error {
+	// the requests can't add background work once the HTTP server is stopped
+	if err := server.httpServer.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		server.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // setRoutes sets the routes for the server
@@ -51,24 +167,166 @@ func (server *Server) setRoutes() {
 	router.POST("/directors", server.createDirector)
 	router.GET("/directors/:id", server.getDirector)
 	router.GET("/directors", server.listDirectors)
+	router.GET("/directors/:id/movies", server.listDirectorMovies)
 
 	// movies
 	router.POST("/movies", server.createMovie)
 	router.GET("/movies", server.listMovies)
+	router.GET("/movies/search", server.searchMovies)
+	router.GET("/movies/autocomplete", server.autocompleteMovies)
 	router.GET("/movies/:id", server.getMovie)
+	router.GET("/movies/:id/reviews", server.listMovieReviews)
+	router.GET("/movies/:id/screenings", server.listMovieScreenings)
+
+	// genres
+	router.GET("/genres", server.listGenres)
+
+	// people
+	router.GET("/people/:id", server.getPerson)
+
+	// awards
+	router.GET("/awards", server.listAwards)
+
+	// concessions
+	router.GET("/concessions", server.listConcessionItems)
+
+	// venues
+	router.GET("/venues", server.listVenues)
+	router.GET("/venues/:id", server.getVenue)
+	router.GET("/venues/:id/screenings", server.listVenueScreenings)
+
+	// screenings
+	router.GET("/screenings/:id/price", server.getScreeningPrice)
+
+	// screening formats
+	router.GET("/screening-formats", server.listScreeningFormats)
+
+	// membership plans
+	router.GET("/membership-plans", server.listMembershipPlans)
+
+	// charts
+	router.GET("/charts/box-office", server.getBoxOffice)
+	router.GET("/charts/trending", server.getTrending)
 
 	// users
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
+	router.POST("/users/password/forgot", server.forgotPassword)
+	router.POST("/users/password/reset", server.resetPassword)
 
 	// middleware
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.store))
 
 	// tickets (protected)
 	authRoutes.POST("/tickets", server.createTicket)
 	authRoutes.GET("/tickets/:id", server.getTicket)
 	authRoutes.GET("/tickets", server.listTickets)
 	authRoutes.DELETE("/tickets/:id", server.deleteTicket)
+	authRoutes.POST("/tickets/:id/concessions", server.orderConcessions)
+
+	// reviews (protected)
+	authRoutes.POST("/movies/:id/reviews", server.createReview)
+	authRoutes.DELETE("/reviews/:id", server.deleteReview)
+	authRoutes.POST("/reviews/:id/reports", server.reportReview)
+
+	// watchlist (protected)
+	authRoutes.POST("/watchlist", server.addWatchlistItem)
+	authRoutes.GET("/watchlist", server.listWatchlist)
+	authRoutes.DELETE("/watchlist/:movie_id", server.removeWatchlistItem)
+
+	// recommendations (protected)
+	authRoutes.GET("/me/recommendations", server.listRecommendations)
+
+	// memberships (protected)
+	authRoutes.POST("/me/membership", server.subscribe)
+	authRoutes.GET("/me/membership", server.getMembership)
+	authRoutes.PUT("/me/membership", server.changeMembershipPlan)
+	authRoutes.DELETE("/me/membership", server.cancelMembership)
+	authRoutes.POST("/me/membership/pause", server.pauseMembership)
+	authRoutes.POST("/me/membership/resume", server.resumeMembership)
+
+	// private bookings (protected), the venue roles are checked by the handlers
+	authRoutes.POST("/private-bookings", server.createPrivateBooking)
+	authRoutes.GET("/private-bookings/:id", server.getPrivateBooking)
+	authRoutes.DELETE("/private-bookings/:id", server.cancelPrivateBooking)
+	authRoutes.POST("/private-bookings/:id/quote", server.quotePrivateBooking)
+	authRoutes.POST("/private-bookings/:id/accept", server.acceptPrivateBooking)
+	authRoutes.POST("/private-bookings/:id/confirm", server.confirmPrivateBooking)
+	authRoutes.POST("/private-bookings/:id/reject", server.rejectPrivateBooking)
+	authRoutes.GET("/private-bookings/:id/invoice", server.getPrivateBookingInvoice)
+	authRoutes.POST("/private-bookings/:id/invoice/pay", server.payPrivateBookingInvoice)
+	authRoutes.GET("/me/private-bookings", server.listMyPrivateBookings)
+	authRoutes.GET("/venues/:id/private-bookings", server.listVenuePrivateBookings)
+
+	// venues (venue staff), the roles are checked per venue by the handlers
+	authRoutes.POST("/venues/:id/auditoriums", server.createAuditorium)
+	authRoutes.GET("/venues/:id/staff", server.listVenueStaff)
+	authRoutes.PUT("/venues/:id/staff/:username", server.setVenueStaff)
+	authRoutes.DELETE("/venues/:id/staff/:username", server.removeVenueStaff)
+
+	// screenings (venue staff)
+	authRoutes.POST("/screenings", server.createScreening)
+	authRoutes.POST("/screenings/:id/publish", server.publishScreening)
+	authRoutes.POST("/auditoriums/:id/schedule", server.scheduleAuditorium)
+
+	// staff middleware
+	staffRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.store), staffMiddleware(server.store))
+
+	// point of sale (staff)
+	staffRoutes.POST("/pos/shifts", server.openShift)
+	staffRoutes.POST("/pos/shifts/:id/close", server.closeShift)
+	staffRoutes.GET("/pos/shifts/:id/report", server.getShiftReport)
+	staffRoutes.POST("/pos/sales", server.createPosSale)
+	staffRoutes.GET("/pos/sales/:code", server.getPosSale)
+
+	// venues (staff)
+	staffRoutes.POST("/venues", server.createVenue)
+
+	// screening formats (staff)
+	staffRoutes.PUT("/screening-formats/:code", server.setScreeningFormat)
+
+	// pricing rules (staff)
+	staffRoutes.POST("/pricing-rules", server.createPricingRule)
+	staffRoutes.GET("/pricing-rules", server.listPricingRules)
+	staffRoutes.DELETE("/pricing-rules/:id", server.deletePricingRule)
+
+	// membership plans (staff)
+	staffRoutes.POST("/membership-plans", server.createMembershipPlan)
+
+	// reports (staff)
+	staffRoutes.GET("/reports/sales", server.getSalesReport)
+
+	// tickets (staff)
+	staffRoutes.POST("/tickets/:id/check-in", server.checkInTicket)
+
+	// reviews (staff)
+	staffRoutes.GET("/reviews", server.listReviews)
+	staffRoutes.PATCH("/reviews/:id/status", server.moderateReview)
+	staffRoutes.GET("/reviews/:id/moderation", server.getReviewModeration)
+
+	// directors (staff)
+	staffRoutes.PATCH("/directors/:id", server.updateDirector)
+	staffRoutes.DELETE("/directors/:id", server.deleteDirector)
+
+	// movies (staff)
+	staffRoutes.PATCH("/movies/:id", server.updateMovie)
+	staffRoutes.DELETE("/movies/:id", server.deleteMovie)
+	staffRoutes.POST("/movies/:id/credits", server.addMovieCredit)
+	staffRoutes.DELETE("/movies/:id/credits/:credit_id", server.deleteMovieCredit)
+
+	// genres (staff)
+	staffRoutes.POST("/genres", server.createGenre)
+
+	// people (staff)
+	staffRoutes.POST("/people", server.createPerson)
+
+	// awards (staff)
+	staffRoutes.POST("/awards", server.createAward)
+	staffRoutes.DELETE("/awards/:id", server.deleteAward)
+
+	// concessions (staff)
+	staffRoutes.POST("/concessions", server.createConcessionItem)
+	staffRoutes.POST("/concessions/:id/stock", server.addConcessionStock)
 
 	server.router = router
 }
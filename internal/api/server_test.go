@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShutdown tests that Shutdown waits for the background work of the server
+func TestShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+
+	release := make(chan struct{})
+	finished := false
+	server.runInBackground(func() {
+		<-release
+		finished = true
+	})
+
+	// the work isn't done before the deadline, so Shutdown gives up
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, server.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, server.Shutdown(context.Background()))
+	require.True(t, finished)
+}
+
+// TestStartAfterShutdown tests that Start returns nil once the server is shut down
+func TestStartAfterShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+	require.NoError(t, server.Shutdown(context.Background()))
+	require.NoError(t, server.Start("127.0.0.1:0"))
+}
@@ -4,26 +4,44 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/pricing"
 	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/gin-gonic/gin"
 )
 
 var ErrUnauthorizedAction = errors.New("authenticated user and ticket owner doesn't match")
+var ErrMissingMovie = errors.New("movie of the ticket is missing")
+var ErrAlreadyCheckedIn = errors.New("ticket is already checked in")
+var ErrScreeningNotOnSale = errors.New("screening is not on sale")
+var ErrScreeningMismatch = errors.New("screening is not a screening of the movie")
+var ErrMissingTotal = errors.New("total is required for the tickets without a priced screening")
 
 // CreateTicketRequest holds the json data of the createTicket
 type CreateTicketRequest struct {
 	MovieID int64 `json:"movie_id" binding:"required,min=1"`
-	Total   int64 `json:"total" binding:"required,gt=0"`
-	Child   int16 `json:"child" binding:"min=0"`
-	Adult   int16 `json:"adult" binding:"min=0"`
+	// Total is the price of the seats in the currency of the app, the surcharge of the screening's format is added to it.
+	// It isn't needed for the screenings in an auditorium, their seats are priced by the pricing rules of the venue
+	Total *util.Money `json:"total"`
+	Child int16       `json:"child" binding:"min=0"`
+	Adult int16       `json:"adult" binding:"min=0"`
+	// ScreeningID is optional, the ticket is sold for a published screening that hasn't started yet
+	ScreeningID int64 `json:"screening_id" binding:"omitempty,min=1"`
+	// Concessions are optional, they are bought together with the ticket
+	Concessions []ConcessionLineRequest `json:"concessions" binding:"omitempty,max=10,dive"`
 }
 
 // CreateTicketResponse holds the data for createTicket response
 type CreateTicketResponse struct {
-	Ticket db.Ticket `json:"ticket"`
-	Movie  db.Movie  `json:"movie"`
+	Ticket      TicketResponse           `json:"ticket"`
+	Movie       db.Movie                 `json:"movie"`
+	Concessions *ConcessionOrderResponse `json:"concessions,omitempty"`
+	Price       *pricing.Breakdown       `json:"price,omitempty"`
+	// Membership is given if the seats are covered by the allowance of the membership of the user instead of a payment
+	Membership *MembershipDetails `json:"membership,omitempty"`
 }
 
 func (server *Server) createTicket(ctx *gin.Context) {
@@ -40,16 +58,26 @@ func (server *Server) createTicket(ctx *gin.Context) {
 		return
 	}
 
+	// the total of the request must be in the currency of the app
+	if req.Total != nil && !server.requireMoney(ctx, *req.Total) {
+		return
+	}
+
 	// here i take the payload from the context
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
 	// then i create args for the DB operation
 	arg := db.CreateTicketParams{
-		MovieID:     req.MovieID,
-		TicketOwner: authPayload.Username,
-		Total:       req.Total,
-		Child:       req.Child,
-		Adult:       req.Adult,
+		MovieID:       req.MovieID,
+		TicketOwner:   authPayload.Username,
+		Child:         req.Child,
+		Adult:         req.Adult,
+		Currency:      server.currency,
+		PaymentMethod: paymentMethodCard,
+	}
+
+	if req.Total != nil {
+		arg.Total = req.Total.Amount
 	}
 
 	// then i get the movie of the ticket and check for error
@@ -66,12 +94,98 @@ func (server *Server) createTicket(ctx *gin.Context) {
 		return
 	}
 
-	// then i create the ticket
-	t, err := server.store.CreateTicket(ctx, arg)
+	// tickets can't be sold for deleted movies
+	if m.DeletedAt.Valid {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrMovieDeleted))
+		return
+	}
+
+	// children can't be let in to restricted movies
+	if req.Child > 0 && restrictedCertifications[m.Certification] {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrRestricted))
+		return
+	}
 
-	// if any error occurs i return 500 and the error
+	// then i check the screening if the ticket is sold for one
+	var price *pricing.Breakdown
+	if req.ScreeningID != 0 {
+		screening, err := server.store.GetScreening(ctx, req.ScreeningID)
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if screening.MovieID != m.ID {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrScreeningMismatch))
+			return
+		}
+
+		if !screening.PublishedAt.Valid || !screening.StartsAt.After(time.Now()) {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrScreeningNotOnSale))
+			return
+		}
+
+		// all the seats of a privately booked screening are blocked for its booking
+		if screening.PrivateBookingID.Valid {
+			ctx.JSON(http.StatusConflict, errorResponse(db.ErrScreeningBooked))
+			return
+		}
+
+		arg.ScreeningID = sql.NullInt64{Int64: screening.ID, Valid: true}
+
+		if screening.AuditoriumID.Valid {
+			// the seats of a screening in an auditorium are priced by the rules of its venue, the total of the request is ignored
+			b, ok := server.quoteScreening(ctx, screening, req.Adult, req.Child)
+			if !ok {
+				return
+			}
+
+			arg.Total = b.Total.Amount
+			arg.Tax = b.Tax.Amount
+			arg.Surcharge = b.Surcharge.Amount
+			arg.Discount = b.Discount.Amount
+			price = &b
+		} else {
+			// the surcharge of the format is added for every seat
+			f, err := server.store.GetScreeningFormat(ctx, screening.Format)
+
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+
+			arg.Surcharge = f.Surcharge * (int64(req.Adult) + int64(req.Child))
+			arg.Total += arg.Surcharge
+		}
+	}
+
+	// the tickets that are not priced by the rules need the total of the request,
+	// the total includes the tax of the tax rate of the config
+	if price == nil {
+		if req.Total == nil || req.Total.Amount == 0 {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrMissingTotal))
+			return
+		}
+
+		_, tax := util.NewMoney(arg.Total, arg.Currency).SplitTax(server.taxRate)
+		arg.Tax = tax.Amount
+	}
+
+	// then i create the ticket and its concessions in a single transaction,
+	// the seats are covered by the membership of the user while its allowance lasts
+	result, err := server.store.PurchaseTicketTx(ctx, db.PurchaseTicketTxParams{
+		CreateTicketParams: arg,
+		Concessions:        newConcessionLines(req.Concessions),
+	})
+
+	// if any error occurs i return the error with its status
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeConcessionError(ctx, err)
 		return
 	}
 
@@ -81,7 +195,16 @@ func (server *Server) createTicket(ctx *gin.Context) {
 	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
 
 	// if no error occurs i return ok and create ticket response
-	ctx.JSON(http.StatusOK, CreateTicketResponse{Ticket: t, Movie: m})
+	res := CreateTicketResponse{Ticket: newTicketResponse(result.Ticket), Movie: m, Price: price}
+	if result.Concessions != nil {
+		res.Concessions = newConcessionOrderResponse(*result.Concessions, result.Ticket.Currency)
+	}
+	if result.Membership != nil {
+		m := newMembershipDetails(*result.Membership)
+		res.Membership = &m
+	}
+
+	ctx.JSON(http.StatusOK, res)
 }
 
 // GetTicketRequest holds uri data of the request
@@ -89,10 +212,11 @@ type GetTicketRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
-// GetTicketResponse holds the json data of the response
+// GetTicketResponse holds the json data of the response, the concessions are the items of all the orders of the ticket
 type GetTicketResponse struct {
-	Ticket db.Ticket `json:"ticket"`
-	Movie  db.Movie  `json:"movie"`
+	Ticket      TicketResponse                `json:"ticket"`
+	Movie       db.Movie                      `json:"movie"`
+	Concessions []ConcessionOrderLineResponse `json:"concessions"`
 }
 
 // getTicket takes ID and returns the relevant Ticket
@@ -136,38 +260,49 @@ func (server *Server) getTicket(ctx *gin.Context) {
 		return
 	}
 
+	// then i get the concessions ordered with the ticket
+	concessions, err := server.loadTicketConcessions(ctx, []db.Ticket{t})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
 	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
 
 	// if no error occurs i return OK and get ticket response
-	ctx.JSON(http.StatusOK, GetTicketResponse{Movie: m, Ticket: t})
+	ctx.JSON(http.StatusOK, GetTicketResponse{Movie: m, Ticket: newTicketResponse(t), Concessions: concessions[t.ID]})
 }
 
-// ListTicketRequest holds the query data of the request
-type ListTicketsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
-}
+// ticketsCursor is the kind of the cursors of the tickets list
+const ticketsCursor = "tickets"
 
-// listTickets returns the tickets for given query values
+// listTickets returns a page of the tickets of the user that starts after the given cursor
 func (server *Server) listTickets(ctx *gin.Context) {
 	// first i check for the bindings
-	var req ListTicketsRequest
+	var req CursorPageRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	afterID, err := server.decodeCursor(ticketsCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	// here i take the payload from the context
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	// then i create args to call DB func
+	// then i create args to call DB func, one more ticket tells if there is a next page
 	arg := db.ListTicketsParams{
 		TicketOwner: authPayload.Username,
-		Limit:       req.PageSize,
-		Offset:      (req.PageID - 1) * req.PageSize,
+		AfterID:     afterID,
+		Limit:       req.PageSize + 1,
 	}
 
 	tickets, err := server.store.ListTickets(ctx, arg)
@@ -184,19 +319,37 @@ func (server *Server) listTickets(ctx *gin.Context) {
 		return
 	}
 
-	// then i get each ticket's movie for the response
+	tickets, next := trimCursorPage(server, ticketsCursor, tickets, req.PageSize, func(t db.Ticket) int64 { return t.ID })
+
+	// then i get the movies of the tickets at once for the response
+	movies, err := server.loadTicketMovies(ctx, tickets)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// and the concessions of the tickets at once as well
+	concessions, err := server.loadTicketConcessions(ctx, tickets)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	var result = []GetTicketResponse{}
 	for _, t := range tickets {
-		m, err := server.store.GetMovie(ctx, t.MovieID)
+		m, ok := movies[t.MovieID]
 
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if !ok {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ErrMissingMovie))
 			return
 		}
 
 		var res = GetTicketResponse{
-			Movie:  m,
-			Ticket: t,
+			Movie:       m,
+			Ticket:      newTicketResponse(t),
+			Concessions: concessions[t.ID],
 		}
 
 		result = append(result, res)
@@ -208,7 +361,64 @@ func (server *Server) listTickets(ctx *gin.Context) {
 	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
 
 	// then i return the result with OK
-	ctx.JSON(http.StatusOK, result)
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, next))
+}
+
+// loadTicketMovies fetches the movies of the given tickets in a single query and maps them by their IDs
+func (server *Server) loadTicketMovies(ctx *gin.Context, tickets []db.Ticket) (map[int64]db.Movie, error) {
+	return server.loadMovies(ctx, collectIDs(tickets, func(t db.Ticket) int64 { return t.MovieID }))
+}
+
+// loadTicketConcessions fetches the concession items of the given tickets in a single query and maps them by
+// the IDs of their tickets, every ticket has a list even if it has no concessions
+func (server *Server) loadTicketConcessions(ctx *gin.Context, tickets []db.Ticket) (map[int64][]ConcessionOrderLineResponse, error) {
+	result := make(map[int64][]ConcessionOrderLineResponse, len(tickets))
+	currencies := make(map[int64]string, len(tickets))
+	for _, t := range tickets {
+		result[t.ID] = []ConcessionOrderLineResponse{}
+		currencies[t.ID] = t.Currency
+	}
+
+	if len(tickets) == 0 {
+		return result, nil
+	}
+
+	items, err := server.store.ListTicketConcessionItems(ctx, collectIDs(tickets, func(t db.Ticket) int64 { return t.ID }))
+	if err != nil {
+		return nil, err
+	}
+
+	// the orders are in the currency of their tickets
+	for _, item := range items {
+		result[item.TicketID] = append(result[item.TicketID], ConcessionOrderLineResponse{
+			ID:        item.ID,
+			ItemID:    item.ItemID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: util.NewMoney(item.UnitPrice, currencies[item.TicketID]),
+		})
+	}
+
+	return result, nil
+}
+
+// loadMovies fetches the movies with the given IDs in a single query and maps them by their IDs
+func (server *Server) loadMovies(ctx *gin.Context, ids []int64) (map[int64]db.Movie, error) {
+	result := make(map[int64]db.Movie)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	movies, err := server.store.ListMoviesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range movies {
+		result[m.ID] = m
+	}
+
+	return result, nil
 }
 
 // DeleteTicketRequest holds the uri data of the request
@@ -248,8 +458,8 @@ func (server *Server) deleteTicket(ctx *gin.Context) {
 		return
 	}
 
-	// then i delete the ticket
-	err = server.store.DeleteTicket(ctx, req.ID)
+	// then i delete the ticket, its concessions go back to the stock
+	err = server.store.DeleteTicketTx(ctx, req.ID)
 
 	// if any error occurs i check the error message
 	if err != nil {
@@ -271,3 +481,50 @@ func (server *Server) deleteTicket(ctx *gin.Context) {
 	// if no error occurs i return OK and no data
 	ctx.JSON(http.StatusOK, nil)
 }
+
+// checkInTicket checks in the ticket of a viewer at the entrance of the screening
+func (server *Server) checkInTicket(ctx *gin.Context) {
+	// first i check bindings
+	var req GetTicketRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	t, err := server.store.GetTicket(ctx, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// a ticket can be used only once
+	if t.CheckedInAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrAlreadyCheckedIn))
+		return
+	}
+
+	// the reviews of the owner for the movie become verified in the same transaction
+	t, err = server.store.CheckInTicketTx(ctx, t.ID)
+
+	if err != nil {
+		// if another check in won the race there are no rows to update
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrAlreadyCheckedIn))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newTicketResponse(t))
+}
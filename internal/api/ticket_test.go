@@ -13,6 +13,7 @@ import (
 
 	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/pricing"
 	"github.com/burakkarasel/Theatre-API/internal/token"
 	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/gin-gonic/gin"
@@ -23,10 +24,21 @@ import (
 // TestCreateTicketAPI tests createTicket handler
 func TestCreateTicketAPI(t *testing.T) {
 	ticket, movie := randomTicket(t)
+	concessions := randomConcessionOrder(ticket)
+	screening := randomScreening(movie)
+	screening.PublishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	member := randomMembership(ticket.TicketOwner, randomMembershipPlan())
+	member.Allowance, member.Used = 10, int32(ticket.Child+ticket.Adult)
+	covered := ticket
+	covered.Discount, covered.Total, covered.Tax = ticket.Total, 0, 0
+	covered.PaymentMethod = db.MembershipPaymentMethod
+	covered.MembershipID = sql.NullInt64{Int64: member.ID, Valid: true}
 
 	testCases := []struct {
 		name          string
 		body          gin.H
+		taxRate       int32
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
@@ -36,26 +48,483 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateTicketParams{
-					MovieID:     ticket.MovieID,
-					TicketOwner: ticket.TicketOwner,
-					Child:       ticket.Child,
-					Adult:       ticket.Adult,
-					Total:       ticket.Total,
+				arg := db.PurchaseTicketTxParams{
+					CreateTicketParams: db.CreateTicketParams{
+						MovieID:       ticket.MovieID,
+						TicketOwner:   ticket.TicketOwner,
+						Child:         ticket.Child,
+						Adult:         ticket.Adult,
+						Total:         ticket.Total,
+						Currency:      ticket.Currency,
+						PaymentMethod: paymentMethodCard,
+					},
+					Concessions: []db.ConcessionLine{},
+				}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseTicketTxResult{Ticket: ticket}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireTicketBodyMatch(t, w.Body, CreateTicketResponse{Movie: movie, Ticket: newTicketResponse(ticket)})
+			},
+		},
+		{
+			name: "Taxed By Config",
+			body: gin.H{
+				"child":    ticket.Child,
+				"adult":    ticket.Adult,
+				"total":    util.NewMoney(11800, ticket.Currency),
+				"movie_id": ticket.MovieID,
+			},
+			taxRate: 1800,
+			buildStubs: func(store *mockdb.MockStore) {
+				// 118.00 includes 18% of tax on a net of 100.00
+				arg := db.PurchaseTicketTxParams{
+					CreateTicketParams: db.CreateTicketParams{
+						MovieID:       ticket.MovieID,
+						TicketOwner:   ticket.TicketOwner,
+						Child:         ticket.Child,
+						Adult:         ticket.Adult,
+						Total:         11800,
+						Tax:           1800,
+						Currency:      ticket.Currency,
+						PaymentMethod: paymentMethodCard,
+					},
+					Concessions: []db.ConcessionLine{},
+				}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseTicketTxResult{Ticket: ticket}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Covered By Membership",
+			body: gin.H{
+				"child":    ticket.Child,
+				"adult":    ticket.Adult,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id": ticket.MovieID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PurchaseTicketTxResult{Ticket: covered, Membership: &member}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				details := newMembershipDetails(member)
+				requireTicketBodyMatch(t, w.Body, CreateTicketResponse{Movie: movie, Ticket: newTicketResponse(covered), Membership: &details})
+			},
+		},
+		{
+			name: "With Screening",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"total":        util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.PurchaseTicketTxParams{
+					CreateTicketParams: db.CreateTicketParams{
+						MovieID:       ticket.MovieID,
+						TicketOwner:   ticket.TicketOwner,
+						Child:         ticket.Child,
+						Adult:         ticket.Adult,
+						Total:         ticket.Total,
+						Currency:      ticket.Currency,
+						PaymentMethod: paymentMethodCard,
+						ScreeningID:   sql.NullInt64{Int64: screening.ID, Valid: true},
+					},
+					Concessions: []db.ConcessionLine{},
+				}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(screening, nil)
+				store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Eq(screening.Format)).Times(1).
+					Return(db.ScreeningFormat{Code: screening.Format}, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseTicketTxResult{Ticket: ticket}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Format Surcharge",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"total":        util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				imax := screening
+				imax.Format = "imax"
+				surcharge := 500 * (int64(ticket.Adult) + int64(ticket.Child))
+
+				arg := db.PurchaseTicketTxParams{
+					CreateTicketParams: db.CreateTicketParams{
+						MovieID:       ticket.MovieID,
+						TicketOwner:   ticket.TicketOwner,
+						Child:         ticket.Child,
+						Adult:         ticket.Adult,
+						Total:         ticket.Total + surcharge,
+						Currency:      ticket.Currency,
+						PaymentMethod: paymentMethodCard,
+						ScreeningID:   sql.NullInt64{Int64: screening.ID, Valid: true},
+						Surcharge:     surcharge,
+					},
+					Concessions: []db.ConcessionLine{},
+				}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(imax, nil)
+				store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Eq("imax")).Times(1).
+					Return(db.ScreeningFormat{Code: "imax", Name: "IMAX", Surcharge: 500}, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseTicketTxResult{Ticket: ticket}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Priced Screening",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				venue := randomVenue()
+				auditorium := randomAuditorium(venue)
+				priced := screening
+				priced.AuditoriumID = sql.NullInt64{Int64: auditorium.ID, Valid: true}
+				total := int64(ticket.Adult)*venue.AdultPrice + int64(ticket.Child)*venue.ChildPrice
+
+				arg := db.PurchaseTicketTxParams{
+					CreateTicketParams: db.CreateTicketParams{
+						MovieID:       ticket.MovieID,
+						TicketOwner:   ticket.TicketOwner,
+						Child:         ticket.Child,
+						Adult:         ticket.Adult,
+						Total:         total,
+						Currency:      ticket.Currency,
+						PaymentMethod: paymentMethodCard,
+						ScreeningID:   sql.NullInt64{Int64: screening.ID, Valid: true},
+					},
+					Concessions: []db.ConcessionLine{},
+				}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(priced, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Eq(screening.Format)).Times(1).
+					Return(db.ScreeningFormat{Code: screening.Format}, nil)
+				store.EXPECT().CountScreeningSeats(gomock.Any(), gomock.Eq(arg.ScreeningID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().ListVenuePricingRules(gomock.Any(), gomock.Eq(sql.NullInt64{Int64: venue.ID, Valid: true})).Times(1).
+					Return([]db.PricingRule{}, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseTicketTxResult{Ticket: ticket}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				var got CreateTicketResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				require.NotNil(t, got.Price)
+				require.NotEmpty(t, got.Price.Lines)
+			},
+		},
+		{
+			name: "Discounted Screening",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				venue := randomVenue()
+				auditorium := randomAuditorium(venue)
+				priced := screening
+				priced.AuditoriumID = sql.NullInt64{Int64: auditorium.ID, Valid: true}
+				everyDay := db.PricingRule{ID: 1, Name: "Launch Week", Kind: pricing.RuleWeekday, Percent: -10, Weekdays: []int32{0, 1, 2, 3, 4, 5, 6}}
+
+				// the prices of the venue are multiples of ten, so the discount isn't rounded
+				base := int64(ticket.Adult)*venue.AdultPrice + int64(ticket.Child)*venue.ChildPrice
+				discount := base / 10
+
+				arg := db.PurchaseTicketTxParams{
+					CreateTicketParams: db.CreateTicketParams{
+						MovieID:       ticket.MovieID,
+						TicketOwner:   ticket.TicketOwner,
+						Child:         ticket.Child,
+						Adult:         ticket.Adult,
+						Total:         base - discount,
+						Discount:      discount,
+						Currency:      ticket.Currency,
+						PaymentMethod: paymentMethodCard,
+						ScreeningID:   sql.NullInt64{Int64: screening.ID, Valid: true},
+					},
+					Concessions: []db.ConcessionLine{},
 				}
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Eq(arg)).Times(1).Return(ticket, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(priced, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Eq(screening.Format)).Times(1).
+					Return(db.ScreeningFormat{Code: screening.Format}, nil)
+				store.EXPECT().CountScreeningSeats(gomock.Any(), gomock.Eq(arg.ScreeningID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().ListVenuePricingRules(gomock.Any(), gomock.Eq(sql.NullInt64{Int64: venue.ID, Valid: true})).Times(1).
+					Return([]db.PricingRule{everyDay}, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseTicketTxResult{Ticket: ticket}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				var got CreateTicketResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				require.NotNil(t, got.Price)
+				require.NotZero(t, got.Price.Discount.Amount)
+			},
+		},
+		{
+			name: "Screening Full",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				venue := randomVenue()
+				auditorium := randomAuditorium(venue)
+				priced := screening
+				priced.AuditoriumID = sql.NullInt64{Int64: auditorium.ID, Valid: true}
+
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(priced, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Eq(screening.Format)).Times(1).
+					Return(db.ScreeningFormat{Code: screening.Format}, nil)
+				// every seat of the auditorium is sold already
+				store.EXPECT().CountScreeningSeats(gomock.Any(), gomock.Any()).Times(1).Return(int64(auditorium.Seats), nil)
+				store.EXPECT().ListVenuePricingRules(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Screening Sold Out Meanwhile",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				venue := randomVenue()
+				auditorium := randomAuditorium(venue)
+				priced := screening
+				priced.AuditoriumID = sql.NullInt64{Int64: auditorium.ID, Valid: true}
+
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(priced, nil)
+				store.EXPECT().GetAuditorium(gomock.Any(), gomock.Eq(auditorium.ID)).Times(1).Return(auditorium, nil)
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetScreeningFormat(gomock.Any(), gomock.Eq(screening.Format)).Times(1).
+					Return(db.ScreeningFormat{Code: screening.Format}, nil)
+				store.EXPECT().CountScreeningSeats(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().ListVenuePricingRules(gomock.Any(), gomock.Any()).Times(1).Return([]db.PricingRule{}, nil)
+				// the last seats are sold by another purchase before the screening is locked
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PurchaseTicketTxResult{}, db.ErrScreeningFull)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Missing Total",
+			body: gin.H{
+				"child":    ticket.Child,
+				"adult":    ticket.Adult,
+				"movie_id": ticket.MovieID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Screening Of Other Movie",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"total":        util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				other := screening
+				other.MovieID = movie.ID + 1
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(other, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Screening Not Published",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"total":        util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				unpublished := screening
+				unpublished.PublishedAt = sql.NullTime{}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(unpublished, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Screening Booked Privately",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"total":        util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				booked := screening
+				booked.PrivateBookingID = sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(booked, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name: "Screening Not Found",
+			body: gin.H{
+				"child":        ticket.Child,
+				"adult":        ticket.Adult,
+				"total":        util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":     ticket.MovieID,
+				"screening_id": screening.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().GetScreening(gomock.Any(), gomock.Eq(screening.ID)).Times(1).Return(db.Screening{}, sql.ErrNoRows)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Restricted Movie",
+			body: gin.H{
+				"child":    1,
+				"adult":    1,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id": ticket.MovieID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				restricted := movie
+				restricted.Certification = "NC-17"
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(restricted, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Restricted Movie Adults Only",
+			body: gin.H{
+				"adult":    2,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id": ticket.MovieID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				restricted := movie
+				restricted.Certification = "R"
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(restricted, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PurchaseTicketTxResult{Ticket: ticket}, nil)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, w.Code)
-				requireTicketBodyMatch(t, w.Body, CreateTicketResponse{Movie: movie, Ticket: ticket})
 			},
 		},
 		{
@@ -63,12 +532,12 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    -3,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -82,12 +551,12 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    -3,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -109,7 +578,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -120,7 +589,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": -3,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -128,7 +597,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, w.Code)
@@ -139,12 +608,12 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    0,
 				"adult":    0,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -158,12 +627,12 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(db.Movie{}, sql.ErrNoRows)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -177,12 +646,12 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(db.Movie{}, sql.ErrConnDone)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -196,40 +665,118 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateTicketParams{
-					MovieID:     ticket.MovieID,
-					TicketOwner: ticket.TicketOwner,
-					Child:       ticket.Child,
-					Adult:       ticket.Adult,
-					Total:       ticket.Total,
+				arg := db.PurchaseTicketTxParams{
+					CreateTicketParams: db.CreateTicketParams{
+						MovieID:       ticket.MovieID,
+						TicketOwner:   ticket.TicketOwner,
+						Child:         ticket.Child,
+						Adult:         ticket.Adult,
+						Total:         ticket.Total,
+						Currency:      ticket.Currency,
+						PaymentMethod: paymentMethodCard,
+					},
+					Concessions: []db.ConcessionLine{},
 				}
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Ticket{}, sql.ErrConnDone)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseTicketTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, w.Code)
 			},
 		},
+		{
+			name: "OK With Concessions",
+			body: gin.H{
+				"child":       ticket.Child,
+				"adult":       ticket.Adult,
+				"total":       util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":    ticket.MovieID,
+				"concessions": []gin.H{{"item_id": concessions.Items[0].ItemID, "quantity": concessions.Items[0].Quantity}},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.PurchaseTicketTxParams{
+					CreateTicketParams: db.CreateTicketParams{
+						MovieID:       ticket.MovieID,
+						TicketOwner:   ticket.TicketOwner,
+						Child:         ticket.Child,
+						Adult:         ticket.Adult,
+						Total:         ticket.Total,
+						Currency:      ticket.Currency,
+						PaymentMethod: paymentMethodCard,
+					},
+					Concessions: []db.ConcessionLine{{ItemID: concessions.Items[0].ItemID, Quantity: concessions.Items[0].Quantity}},
+				}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseTicketTxResult{Ticket: ticket, Concessions: &concessions}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireTicketBodyMatch(t, w.Body, CreateTicketResponse{Movie: movie, Ticket: newTicketResponse(ticket), Concessions: newConcessionOrderResponse(concessions, ticket.Currency)})
+			},
+		},
+		{
+			name: "Invalid Concession Quantity",
+			body: gin.H{
+				"child":       ticket.Child,
+				"adult":       ticket.Adult,
+				"total":       util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":    ticket.MovieID,
+				"concessions": []gin.H{{"item_id": concessions.Items[0].ItemID, "quantity": 0}},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Concession Out Of Stock",
+			body: gin.H{
+				"child":       ticket.Child,
+				"adult":       ticket.Adult,
+				"total":       util.NewMoney(ticket.Total, ticket.Currency),
+				"movie_id":    ticket.MovieID,
+				"concessions": []gin.H{{"item_id": concessions.Items[0].ItemID, "quantity": concessions.Items[0].Quantity}},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(ticket.MovieID)).Times(1).Return(movie, nil)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PurchaseTicketTxResult{}, db.ErrOutOfStock)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
 		{
 			name: "No Authorization",
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, w.Code)
@@ -240,7 +787,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -248,7 +795,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, w.Code)
@@ -259,7 +806,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -267,7 +814,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, w.Code)
@@ -278,7 +825,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			body: gin.H{
 				"child":    ticket.Child,
 				"adult":    ticket.Adult,
-				"total":    ticket.Total,
+				"total":    util.NewMoney(ticket.Total, ticket.Currency),
 				"movie_id": ticket.MovieID,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -286,7 +833,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().CreateTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PurchaseTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, w.Code)
@@ -303,6 +850,7 @@ func TestCreateTicketAPI(t *testing.T) {
 			tt.buildStubs(store)
 
 			server := newTestServer(t, store)
+			server.taxRate = tt.taxRate
 			w := httptest.NewRecorder()
 
 			url := "/tickets"
@@ -325,6 +873,10 @@ func TestCreateTicketAPI(t *testing.T) {
 // TestGetTicketAPI tests getTicket handler
 func TestGetTicketAPI(t *testing.T) {
 	ticket, movie := randomTicket(t)
+	concessions := []db.ListTicketConcessionItemsRow{
+		{TicketID: ticket.ID, ID: 1, OrderID: 1, ItemID: util.RandomInt(1, 1000), Name: "popcorn", Quantity: 2, UnitPrice: 450},
+	}
+
 	testCases := []struct {
 		name          string
 		ID            int64
@@ -338,12 +890,38 @@ func TestGetTicketAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
 				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListTicketConcessionItems(gomock.Any(), gomock.Eq([]int64{ticket.ID})).Times(1).Return(concessions, nil)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got GetTicketResponse
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Concessions, 1)
+				require.Equal(t, concessions[0].ItemID, got.Concessions[0].ItemID)
+				require.Equal(t, util.NewMoney(concessions[0].UnitPrice, ticket.Currency), got.Concessions[0].UnitPrice)
+			},
+		},
+		{
+			name: "Concessions Internal Server Error",
+			ID:   ticket.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().ListTicketConcessionItems(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
 			},
 		},
 		{
@@ -522,31 +1100,46 @@ func TestListTicketsAPI(t *testing.T) {
 	}{
 		{
 			name:  "OK",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, u.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListTicketsParams{
 					TicketOwner: u.Username,
-					Limit:       5,
-					Offset:      0,
+					AfterID:     0,
+					Limit:       6,
 				}
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Eq(arg)).Times(1).Return(tickets, nil)
-				for i := 0; i < n; i++ {
-					store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
-				}
+				// the tickets are all for the same movie so it is fetched once, the mock fails on any other call
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq([]int64{movie.ID})).Times(1).Return([]db.Movie{movie}, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
+				// the concessions of all the tickets are fetched at once as well
+				store.EXPECT().ListTicketConcessionItems(gomock.Any(), gomock.Any()).Times(1).
+					Return([]db.ListTicketConcessionItemsRow{{TicketID: tickets[0].ID, ID: 1, OrderID: 1, ItemID: 1, Name: "popcorn", Quantity: 1, UnitPrice: 450}}, nil)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got struct {
+					Items []GetTicketResponse `json:"items"`
+				}
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, n)
+				require.NotEmpty(t, got.Items[0].Concessions)
+				require.NotNil(t, got.Items[n-1].Concessions)
 			},
 		},
 		{
-			name:  "Invalid page ID",
-			query: "?page_id=&page_size=5",
+			name:  "Invalid cursor",
+			query: "?page_size=5&cursor=abc",
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, u.Username, time.Minute)
@@ -557,10 +1150,10 @@ func TestListTicketsAPI(t *testing.T) {
 		},
 		{
 			name:  "Invalid page size",
-			query: "?page_id=1&page_size=",
+			query: "?page_size=",
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, u.Username, time.Minute)
@@ -571,15 +1164,15 @@ func TestListTicketsAPI(t *testing.T) {
 		},
 		{
 			name:  "Ticket not found",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListTicketsParams{
 					TicketOwner: u.Username,
-					Limit:       5,
-					Offset:      0,
+					AfterID:     0,
+					Limit:       6,
 				}
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Ticket{}, sql.ErrNoRows)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, u.Username, time.Minute)
@@ -590,15 +1183,15 @@ func TestListTicketsAPI(t *testing.T) {
 		},
 		{
 			name:  "Ticket Internal Server Error",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListTicketsParams{
 					TicketOwner: u.Username,
-					Limit:       5,
-					Offset:      0,
+					AfterID:     0,
+					Limit:       6,
 				}
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Ticket{}, sql.ErrConnDone)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, u.Username, time.Minute)
@@ -609,15 +1202,15 @@ func TestListTicketsAPI(t *testing.T) {
 		},
 		{
 			name:  "Movie Internal Server Error",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListTicketsParams{
 					TicketOwner: u.Username,
-					Limit:       5,
-					Offset:      0,
+					AfterID:     0,
+					Limit:       6,
 				}
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Eq(arg)).Times(1).Return(tickets, nil)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, sql.ErrConnDone)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq([]int64{movie.ID})).Times(1).Return([]db.Movie{}, sql.ErrConnDone)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, u.Username, time.Minute)
@@ -628,13 +1221,13 @@ func TestListTicketsAPI(t *testing.T) {
 		},
 		{
 			name:  "No Authentication",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, w.Code)
@@ -642,13 +1235,13 @@ func TestListTicketsAPI(t *testing.T) {
 		},
 		{
 			name:  "Invalid Authentication Type",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, "asdasd", u.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, w.Code)
@@ -656,13 +1249,13 @@ func TestListTicketsAPI(t *testing.T) {
 		},
 		{
 			name:  "Invalid Authentication Format",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, "", u.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, w.Code)
@@ -670,13 +1263,13 @@ func TestListTicketsAPI(t *testing.T) {
 		},
 		{
 			name:  "Expired Token",
-			query: "?page_id=1&page_size=5",
+			query: "?page_size=5",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, u.Username, -time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListTickets(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, w.Code)
@@ -724,7 +1317,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(nil)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(nil)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -738,7 +1331,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   -5,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -752,7 +1345,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(db.Ticket{}, sql.ErrNoRows)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -766,7 +1359,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(sql.ErrNoRows)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(sql.ErrNoRows)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -780,7 +1373,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(db.Ticket{}, sql.ErrConnDone)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -794,7 +1387,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(sql.ErrConnDone)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(sql.ErrConnDone)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, time.Minute)
@@ -808,7 +1401,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 
@@ -822,7 +1415,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, "asdasd", ticket.TicketOwner, time.Minute)
@@ -836,7 +1429,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, "", ticket.TicketOwner, time.Minute)
@@ -850,7 +1443,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, "asdasd", time.Minute)
@@ -864,7 +1457,7 @@ func TestDeleteTicketAPI(t *testing.T) {
 			ID:   ticket.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetTicket(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().DeleteTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteTicketTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, validAuthorizationTypeBearer, ticket.TicketOwner, -time.Minute)
@@ -900,6 +1493,128 @@ func TestDeleteTicketAPI(t *testing.T) {
 	}
 }
 
+// TestCheckInTicketAPI tests checkInTicket handler
+func TestCheckInTicketAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	ticket, _ := randomTicket(t)
+
+	checkedIn := ticket
+	checkedIn.CheckedInAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
+				store.EXPECT().CheckInTicketTx(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(checkedIn, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got TicketResponse
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.True(t, got.CheckedInAt.Valid)
+				require.Equal(t, checkedIn.ID, got.ID)
+			},
+		},
+		{
+			name:     "Not Staff",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CheckInTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Ticket Not Found",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(db.Ticket{}, sql.ErrNoRows)
+				store.EXPECT().CheckInTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name:     "Already Checked In",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(checkedIn, nil)
+				store.EXPECT().CheckInTicketTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:     "Concurrent Check In",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
+				store.EXPECT().CheckInTicketTx(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(db.Ticket{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+		{
+			name:     "Internal Error",
+			username: staff.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().GetTicket(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(ticket, nil)
+				store.EXPECT().CheckInTicketTx(gomock.Any(), gomock.Eq(ticket.ID)).Times(1).Return(db.Ticket{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/tickets/%d/check-in", ticket.ID)
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
 // randomTicket creates a random ticket and movie and returns them
 func randomTicket(t *testing.T) (db.Ticket, db.Movie) {
 	_, u := randomUser(t)
@@ -912,6 +1627,7 @@ func randomTicket(t *testing.T) (db.Ticket, db.Movie) {
 		Child:       int16(util.RandomInt(1, 5)),
 		Adult:       int16(util.RandomInt(1, 5)),
 		Total:       util.RandomInt(0, 200),
+		Currency:    util.DefaultCurrency,
 	}, m.Movie
 }
 
@@ -935,5 +1651,6 @@ func randomTicketList(u db.User, m db.Movie) db.Ticket {
 		Child:       int16(util.RandomInt(1, 5)),
 		Adult:       int16(util.RandomInt(1, 5)),
 		Total:       util.RandomInt(0, 200),
+		Currency:    util.DefaultCurrency,
 	}
 }
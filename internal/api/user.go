@@ -1,17 +1,43 @@
 package api
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/mail"
+	"github.com/burakkarasel/Theatre-API/internal/ratelimit"
 	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var ErrInvalidResetToken = errors.New("reset token is invalid, used or expired")
+var ErrTooManyResets = errors.New("too many password reset requests, try again later")
+
+// defaultPasswordResetDuration is used if the config doesn't set how long a reset token lives
+const defaultPasswordResetDuration = time.Hour
+
+// the password reset requests are limited with these if the config doesn't set the limits
+const (
+	defaultPasswordResetEmailLimit = 3
+	defaultPasswordResetIPLimit    = 20
+	defaultPasswordResetWindow     = time.Hour
+)
+
+// passwordResetTimeout is how long a password reset can take after the response, with its email
+const passwordResetTimeout = 30 * time.Second
+
+// forgotPasswordMessage is returned whether the email belongs to a user or not, so the emails of the users can't be discovered
+const forgotPasswordMessage = "if the email belongs to a user, a password reset token is sent to it"
+
 // CreateUserRequest holds the json data of the request
 type CreateUserRequest struct {
 	Username string `json:"username" binding:"required,min=6,alphanum"`
@@ -49,7 +75,7 @@ func (server *Server) createUser(ctx *gin.Context) {
 		Username:       req.Username,
 		Email:          req.Email,
 		HashedPassword: hashedPassword,
-		AccessLevel:    1,
+		AccessLevel:    accessLevelCustomer,
 	}
 
 	u, err := server.store.CreateUser(ctx, arg)
@@ -149,6 +175,172 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// ForgotPasswordRequest holds the json data of the request
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// forgotPassword sends a single use password reset token to the email of the user. It responds before the token is
+// issued and the email is sent, so the response takes the same time whether the email belongs to a user or not
+func (server *Server) forgotPassword(ctx *gin.Context) {
+	// first i check for the bindings
+	var req ForgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// then i limit the requests, the emails are counted whether they belong to a user or not
+	if !server.resetsByIP.Allow(ctx.ClientIP()) || !server.resetsByEmail.Allow(strings.ToLower(req.Email)) {
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(ErrTooManyResets))
+		return
+	}
+
+	writeForgotPassword(ctx)
+
+	// the token is issued and sent after the response, the errors can only be logged
+	server.runInBackground(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), passwordResetTimeout)
+		defer cancel()
+
+		if err := server.sendPasswordReset(ctx, req.Email); err != nil {
+			log.Printf("cannot send password reset: %v", err)
+		}
+	})
+}
+
+// sendPasswordReset issues a new password reset token for the user of the email and sends it,
+// nothing is sent if the email doesn't belong to a user
+func (server *Server) sendPasswordReset(ctx context.Context, email string) error {
+	u, err := server.store.GetUserByEmail(ctx, email)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	// only the last token can be used, so i revoke the older ones
+	if err := server.store.RevokePasswordResets(ctx, u.Username); err != nil {
+		return err
+	}
+
+	resetToken, err := util.NewResetToken()
+
+	if err != nil {
+		return err
+	}
+
+	duration := server.config.PasswordResetDuration
+	if duration <= 0 {
+		duration = defaultPasswordResetDuration
+	}
+
+	// then i keep the hash of the token, the token itself is only sent to the user
+	reset, err := server.store.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		Username:  u.Username,
+		TokenHash: util.HashResetToken(resetToken),
+		ExpiresAt: time.Now().Add(duration),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	msg := mail.Message{
+		To:      u.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nuse the token below to reset your password, it expires at %s.\n\n%s\n\nIf you didn't ask for a password reset you can ignore this email.",
+			u.Username, reset.ExpiresAt.UTC().Format(time.RFC1123), resetToken),
+	}
+
+	if err := server.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("cannot send password reset email to %s: %w", u.Username, err)
+	}
+
+	return nil
+}
+
+// newPasswordResetLimiters creates the limiters of the password reset requests per email and per IP
+func newPasswordResetLimiters(config util.Config) (byEmail *ratelimit.Limiter, byIP *ratelimit.Limiter) {
+	emailLimit := config.PasswordResetEmailLimit
+	if emailLimit <= 0 {
+		emailLimit = defaultPasswordResetEmailLimit
+	}
+
+	ipLimit := config.PasswordResetIPLimit
+	if ipLimit <= 0 {
+		ipLimit = defaultPasswordResetIPLimit
+	}
+
+	window := config.PasswordResetWindow
+	if window <= 0 {
+		window = defaultPasswordResetWindow
+	}
+
+	return ratelimit.NewLimiter(emailLimit, window), ratelimit.NewLimiter(ipLimit, window)
+}
+
+// writeForgotPassword writes the same response for the existing and the missing emails
+func writeForgotPassword(ctx *gin.Context) {
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, gin.H{"message": forgotPasswordMessage})
+}
+
+// ResetPasswordRequest holds the json data of the request
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// resetPassword sets a new password with a reset token, the sessions of the user before the reset are revoked
+func (server *Server) resetPassword(ctx *gin.Context) {
+	// first i check for the bindings
+	var req ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// then i hash the new password
+	hashedPassword, err := util.HashPassword(req.Password)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the tokens issued before the change time aren't accepted anymore
+	_, err = server.store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		TokenHash:         util.HashResetToken(req.Token),
+		HashedPassword:    hashedPassword,
+		PasswordChangedAt: time.Now(),
+	})
+
+	if err != nil {
+		// if the token doesn't exist, is used or is expired i return 400
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidResetToken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	// finally i return OK, the user has to log in again with the new password
+	ctx.JSON(http.StatusOK, gin.H{"message": "password is reset, please log in again"})
+}
+
 // createUserResponse creates a user response without sensitive information
 func createUserResponse(user db.User) UserResponse {
 	return UserResponse{
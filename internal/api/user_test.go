@@ -2,16 +2,21 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/mail"
 	"github.com/burakkarasel/Theatre-API/internal/util"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -337,6 +342,379 @@ func TestLoginUserAPI(t *testing.T) {
 	}
 }
 
+// failingMailer is a mailer that can't send any email
+type failingMailer struct{}
+
+// Send implements mail.Mailer interface
+func (failingMailer) Send(ctx context.Context, m mail.Message) error {
+	return errors.New("mail server is down")
+}
+
+// TestForgotPasswordAPI tests forgotPassword handler
+func TestForgotPasswordAPI(t *testing.T) {
+	_, user := randomUser(t)
+
+	// the hash of the token that is kept in DB, it must match the token in the email
+	var tokenHash string
+
+	createReset := func(store *mockdb.MockStore) {
+		store.EXPECT().RevokePasswordResets(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(nil)
+		store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreatePasswordResetParams) (db.PasswordReset, error) {
+				tokenHash = arg.TokenHash
+				return db.PasswordReset{ID: 1, Username: arg.Username, TokenHash: arg.TokenHash, ExpiresAt: arg.ExpiresAt}, nil
+			})
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		mailer        mail.Mailer
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder, mailer mail.Mailer)
+	}{
+		{
+			name:   "OK",
+			body:   gin.H{"email": user.Email},
+			mailer: mail.NewMemoryMailer(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				createReset(store)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, mailer mail.Mailer) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireForgotPasswordMessage(t, w)
+
+				messages := mailer.(*mail.MemoryMailer).Messages()
+				require.Len(t, messages, 1)
+				require.Equal(t, user.Email, messages[0].To)
+
+				// the token itself is only in the email, DB keeps its hash
+				var found bool
+				for _, field := range strings.Fields(messages[0].Body) {
+					if util.HashResetToken(field) == tokenHash {
+						found = true
+					}
+				}
+				require.True(t, found)
+				require.NotContains(t, messages[0].Body, tokenHash)
+			},
+		},
+		{
+			name:   "Email Not Found",
+			body:   gin.H{"email": user.Email},
+			mailer: mail.NewMemoryMailer(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().RevokePasswordResets(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, mailer mail.Mailer) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireForgotPasswordMessage(t, w)
+				require.Empty(t, mailer.(*mail.MemoryMailer).Messages())
+			},
+		},
+		{
+			name:   "Mail Not Sent",
+			body:   gin.H{"email": user.Email},
+			mailer: failingMailer{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				createReset(store)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, mailer mail.Mailer) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireForgotPasswordMessage(t, w)
+			},
+		},
+		{
+			name:   "Invalid Email",
+			body:   gin.H{"email": "123.co"},
+			mailer: mail.NewMemoryMailer(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, mailer mail.Mailer) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:   "Internal Server Error",
+			body:   gin.H{"email": user.Email},
+			mailer: mail.NewMemoryMailer(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(db.User{}, sql.ErrConnDone)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, mailer mail.Mailer) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireForgotPasswordMessage(t, w)
+				require.Empty(t, mailer.(*mail.MemoryMailer).Messages())
+			},
+		},
+		{
+			name:   "Revoke Error",
+			body:   gin.H{"email": user.Email},
+			mailer: mail.NewMemoryMailer(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				store.EXPECT().RevokePasswordResets(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(sql.ErrConnDone)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, mailer mail.Mailer) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireForgotPasswordMessage(t, w)
+				require.Empty(t, mailer.(*mail.MemoryMailer).Messages())
+			},
+		},
+		{
+			name:   "Create Error",
+			body:   gin.H{"email": user.Email},
+			mailer: mail.NewMemoryMailer(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				store.EXPECT().RevokePasswordResets(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(nil)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(1).Return(db.PasswordReset{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder, mailer mail.Mailer) {
+				require.Equal(t, http.StatusOK, w.Code)
+				requireForgotPasswordMessage(t, w)
+				require.Empty(t, mailer.(*mail.MemoryMailer).Messages())
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.mailer = tt.mailer
+			w := httptest.NewRecorder()
+
+			url := "/users/password/forgot"
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+			// the reset is sent after the response, so i wait for it
+			server.background.Wait()
+
+			tt.checkResponse(t, w, tt.mailer)
+		})
+	}
+}
+
+// TestForgotPasswordRateLimit tests that the password reset requests are limited per email and per IP
+func TestForgotPasswordRateLimit(t *testing.T) {
+	_, user := randomUser(t)
+	_, other := randomUser(t)
+
+	testCases := []struct {
+		name       string
+		emailLimit int
+		ipLimit    int
+		emails     []string
+		buildStubs func(store *mockdb.MockStore)
+	}{
+		{
+			name:       "Email Limit",
+			emailLimit: 1,
+			ipLimit:    10,
+			emails:     []string{user.Email, user.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(db.User{}, sql.ErrNoRows)
+			},
+		},
+		{
+			name:       "IP Limit",
+			emailLimit: 10,
+			ipLimit:    1,
+			emails:     []string{user.Email, other.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(other.Email)).Times(0)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.mailer = mail.NewMemoryMailer()
+			server.config.PasswordResetEmailLimit = tt.emailLimit
+			server.config.PasswordResetIPLimit = tt.ipLimit
+			server.resetsByEmail, server.resetsByIP = newPasswordResetLimiters(server.config)
+
+			var codes []int
+			for _, email := range tt.emails {
+				data, err := json.Marshal(gin.H{"email": email})
+				require.NoError(t, err)
+
+				req, err := http.NewRequest(http.MethodPost, "/users/password/forgot", bytes.NewBuffer(data))
+				require.NoError(t, err)
+				req.RemoteAddr = "10.0.0.1:1234"
+
+				w := httptest.NewRecorder()
+				server.router.ServeHTTP(w, req)
+				codes = append(codes, w.Code)
+			}
+			server.background.Wait()
+
+			require.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+		})
+	}
+}
+
+// requireForgotPasswordMessage checks the response of forgotPassword, which is the same for every email
+func requireForgotPasswordMessage(t *testing.T, w *httptest.ResponseRecorder) {
+	var res map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &res)
+	require.NoError(t, err)
+	require.Equal(t, forgotPasswordMessage, res["message"])
+}
+
+// eqResetPasswordTxParamsMatcher holds the token and the password of the reset
+type eqResetPasswordTxParamsMatcher struct {
+	token    string
+	password string
+}
+
+// Matches method implements the Matcher interface
+func (e eqResetPasswordTxParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.ResetPasswordTxParams)
+	if !ok {
+		return false
+	}
+
+	if arg.TokenHash != util.HashResetToken(e.token) {
+		return false
+	}
+
+	if time.Since(arg.PasswordChangedAt) > time.Minute {
+		return false
+	}
+
+	return util.CompareHashedPassword(e.password, arg.HashedPassword) == nil
+}
+
+// String method implements Matcher internface
+func (e eqResetPasswordTxParamsMatcher) String() string {
+	return fmt.Sprintf("is a reset with token %v - %v", e.token, e.password)
+}
+
+// EqResetPasswordTxParams returns gomock.Matcher interface
+func EqResetPasswordTxParams(token, password string) gomock.Matcher {
+	return eqResetPasswordTxParamsMatcher{token, password}
+}
+
+// TestResetPasswordAPI tests resetPassword handler
+func TestResetPasswordAPI(t *testing.T) {
+	_, user := randomUser(t)
+	resetToken, err := util.NewResetToken()
+	require.NoError(t, err)
+	newPw := util.RandomString(10)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"token": resetToken, "password": newPw},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), EqResetPasswordTxParams(resetToken, newPw)).Times(1).Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Token",
+			body: gin.H{"token": resetToken, "password": newPw},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), EqResetPasswordTxParams(resetToken, newPw)).Times(1).Return(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+				require.Contains(t, w.Body.String(), ErrInvalidResetToken.Error())
+			},
+		},
+		{
+			name: "Missing Token",
+			body: gin.H{"password": newPw},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Invalid Password",
+			body: gin.H{"token": resetToken, "password": "asd"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Internal Server Error",
+			body: gin.H{"token": resetToken, "password": newPw},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := "/users/password/reset"
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
 // randomUser creates a random user and password
 func randomUser(t *testing.T) (string, db.User) {
 	pw := util.RandomString(8)
@@ -351,3 +729,10 @@ func randomUser(t *testing.T) (string, db.User) {
 		AccessLevel:    1,
 	}
 }
+
+// randomStaff creates a random staff member
+func randomStaff(t *testing.T) db.User {
+	_, u := randomUser(t)
+	u.AccessLevel = accessLevelStaff
+	return u
+}
@@ -0,0 +1,558 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrVenueExists      = errors.New("venue already exists")
+	ErrAuditoriumExists = errors.New("auditorium already exists in the venue")
+	ErrVenueStaffOnly   = errors.New("only the staff of the venue can do this action")
+	ErrVenueManagerOnly = errors.New("only the managers of the venue can do this action")
+	ErrNotVenueStaff    = errors.New("user is not a staff member of the venue")
+)
+
+// the roles of the venue staff, a manager can do everything a staff member can
+const (
+	venueRoleManager = "manager"
+	venueRoleStaff   = "staff"
+)
+
+const venuesCursor = "venues"
+
+// clockLayout is the layout of the opening hours
+const clockLayout = "15:04"
+
+// minutesPerDay is the count of the minutes in a day, a venue closing after midnight closes after it
+const minutesPerDay = 24 * 60
+
+// defaultVenueScreeningDays is the count of the days the venue screenings are listed for if it isn't given
+const defaultVenueScreeningDays = 7
+
+// OpeningHoursRequest holds the opening hours of a weekday, Sunday is 0.
+// A venue that closes at or before its opening time closes on the next day
+type OpeningHoursRequest struct {
+	Weekday int16  `json:"weekday" binding:"min=0,max=6"`
+	Opens   string `json:"opens" binding:"required,datetime=15:04"`
+	Closes  string `json:"closes" binding:"required,datetime=15:04"`
+}
+
+// OpeningHoursResponse holds the opening hours of a weekday
+type OpeningHoursResponse struct {
+	Weekday int16  `json:"weekday"`
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"`
+}
+
+// VenueResponse holds a venue with its opening hours, the auditoriums are only given by getVenue
+type VenueResponse struct {
+	Venue        VenueDetails           `json:"venue"`
+	OpeningHours []OpeningHoursResponse `json:"opening_hours"`
+	Auditoriums  []db.Auditorium        `json:"auditoriums,omitempty"`
+}
+
+// CreateVenueRequest holds the json data of the request, the weekdays without hours are closed
+type CreateVenueRequest struct {
+	Name         string                `json:"name" binding:"required,min=2"`
+	Address      string                `json:"address" binding:"required"`
+	TimeZone     string                `json:"time_zone" binding:"required"`
+	OpeningHours []OpeningHoursRequest `json:"opening_hours" binding:"omitempty,max=7,unique=Weekday,dive"`
+	// the net base prices of the seats are 10.00 and 7.00 if they are not given, the pricing rules adjust them
+	AdultPrice *util.Money `json:"adult_price"`
+	ChildPrice *util.Money `json:"child_price"`
+	// TaxRate is in basis points, 1800 is 18%. The tax is added to the net prices of the tickets
+	TaxRate int32 `json:"tax_rate" binding:"min=0,max=10000"`
+}
+
+// createVenue creates a venue with its opening hours
+func (server *Server) createVenue(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CreateVenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := time.LoadLocation(req.TimeZone); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidTimeZone))
+		return
+	}
+
+	arg := db.CreateVenueTxParams{
+		CreateVenueParams: db.CreateVenueParams{
+			Name:       req.Name,
+			Address:    req.Address,
+			TimeZone:   req.TimeZone,
+			AdultPrice: defaultAdultPrice,
+			ChildPrice: defaultChildPrice,
+			TaxRate:    req.TaxRate,
+		},
+		Hours: make([]db.CreateVenueHoursParams, 0, len(req.OpeningHours)),
+	}
+
+	// the prices must be in the currency of the app
+	if req.AdultPrice != nil {
+		if !server.requireMoney(ctx, *req.AdultPrice) {
+			return
+		}
+		arg.AdultPrice = req.AdultPrice.Amount
+	}
+	if req.ChildPrice != nil {
+		if !server.requireMoney(ctx, *req.ChildPrice) {
+			return
+		}
+		arg.ChildPrice = req.ChildPrice.Amount
+	}
+
+	for _, h := range req.OpeningHours {
+		opens, closes := parseOpeningHours(h.Opens, h.Closes)
+		arg.Hours = append(arg.Hours, db.CreateVenueHoursParams{Weekday: h.Weekday, Opens: opens, Closes: closes})
+	}
+
+	result, err := server.store.CreateVenueTx(ctx, arg)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code.Name() == "unique_violation" {
+				ctx.JSON(http.StatusConflict, errorResponse(ErrVenueExists))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, VenueResponse{Venue: server.newVenueDetails(result.Venue), OpeningHours: newOpeningHours(result.Hours)})
+}
+
+// listVenues returns the venues with their opening hours
+func (server *Server) listVenues(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CursorPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	afterID, err := server.decodeCursor(venuesCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	venues, err := server.store.ListVenues(ctx, db.ListVenuesParams{AfterID: afterID, Limit: req.PageSize + 1})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	venues, next := trimCursorPage(server, venuesCursor, venues, req.PageSize, func(v db.Venue) int64 { return v.ID })
+
+	// then i get the hours of the page in a single query
+	result := make([]VenueResponse, 0, len(venues))
+	if len(venues) > 0 {
+		hours, err := server.store.ListVenueHours(ctx, collectIDs(venues, func(v db.Venue) int64 { return v.ID }))
+
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		byVenue := make(map[int64][]db.VenueHour)
+		for _, h := range hours {
+			byVenue[h.VenueID] = append(byVenue[h.VenueID], h)
+		}
+
+		for _, v := range venues {
+			result = append(result, VenueResponse{Venue: server.newVenueDetails(v), OpeningHours: newOpeningHours(byVenue[v.ID])})
+		}
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, next))
+}
+
+// GetVenueRequest holds the uri data of the venue requests
+type GetVenueRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getVenue returns a venue with its opening hours and auditoriums
+func (server *Server) getVenue(ctx *gin.Context) {
+	// first i check for the bindings
+	var req GetVenueRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	v, ok := server.requireVenue(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	hours, err := server.store.ListVenueHours(ctx, []int64{v.ID})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	auditoriums, err := server.store.ListVenueAuditoriums(ctx, v.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, VenueResponse{Venue: server.newVenueDetails(v), OpeningHours: newOpeningHours(hours), Auditoriums: auditoriums})
+}
+
+// CreateAuditoriumRequest holds the json data of the request
+type CreateAuditoriumRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Seats int32  `json:"seats" binding:"required,min=1"`
+	// CleaningMinutes is the time the auditorium needs after every screening, it is 15 minutes if it is not given
+	CleaningMinutes *int32 `json:"cleaning_minutes" binding:"omitempty,min=0,max=120"`
+}
+
+// createAuditorium creates an auditorium in a venue, only the managers of the venue can create it
+func (server *Server) createAuditorium(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetVenueRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req CreateAuditoriumRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	v, ok := server.requireVenue(ctx, uri.ID)
+	if !ok || !server.requireVenueRole(ctx, v.ID, venueRoleManager) {
+		return
+	}
+
+	arg := db.CreateAuditoriumParams{
+		VenueID:         v.ID,
+		Name:            req.Name,
+		Seats:           req.Seats,
+		CleaningMinutes: defaultCleaningMinutes,
+	}
+	if req.CleaningMinutes != nil {
+		arg.CleaningMinutes = *req.CleaningMinutes
+	}
+
+	a, err := server.store.CreateAuditorium(ctx, arg)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code.Name() == "unique_violation" {
+				ctx.JSON(http.StatusConflict, errorResponse(ErrAuditoriumExists))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, a)
+}
+
+// ListVenueScreeningsRequest holds query values of the request
+type ListVenueScreeningsRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=30"`
+	ScreeningFilterRequest
+}
+
+// listVenueScreenings returns the upcoming screenings of a venue that are on sale in the next days and match the given filters
+func (server *Server) listVenueScreenings(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetVenueRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ListVenueScreeningsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Days == 0 {
+		req.Days = defaultVenueScreeningDays
+	}
+
+	v, ok := server.requireVenue(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	screenings, err := server.store.ListVenueScreenings(ctx, db.ListVenueScreeningsParams{
+		VenueID:          v.ID,
+		StartsBefore:     time.Now().AddDate(0, 0, req.Days),
+		Format:           sql.NullString{String: req.Format, Valid: req.Format != ""},
+		AudioLanguage:    sql.NullString{String: req.AudioLanguage, Valid: req.AudioLanguage != ""},
+		SubtitleLanguage: sql.NullString{String: req.SubtitleLanguage, Valid: req.SubtitleLanguage != ""},
+		Subtitled:        newNullBool(req.Subtitled),
+		Dubbed:           newNullBool(req.Dubbed),
+		AudioDescription: newNullBool(req.AudioDescription),
+		Relaxed:          newNullBool(req.Relaxed),
+	})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, screenings, ""))
+}
+
+// listVenueStaff returns the staff roles of a venue, only the managers of the venue can see them
+func (server *Server) listVenueStaff(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri GetVenueRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	v, ok := server.requireVenue(ctx, uri.ID)
+	if !ok || !server.requireVenueRole(ctx, v.ID, venueRoleManager) {
+		return
+	}
+
+	staff, err := server.store.ListVenueStaff(ctx, v.ID)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, staff, ""))
+}
+
+// VenueStaffRequest holds the uri data of the venue staff requests
+type VenueStaffRequest struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required"`
+}
+
+// SetVenueStaffRequest holds the json data of the request
+type SetVenueStaffRequest struct {
+	Role string `json:"role" binding:"required,oneof=manager staff"`
+}
+
+// setVenueStaff gives a user a role in a venue or changes the role, only the managers of the venue can set it
+func (server *Server) setVenueStaff(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri VenueStaffRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req SetVenueStaffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	v, ok := server.requireVenue(ctx, uri.ID)
+	if !ok || !server.requireVenueRole(ctx, v.ID, venueRoleManager) {
+		return
+	}
+
+	// then i make sure the user exists
+	if _, err := server.store.GetUser(ctx, uri.Username); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	staff, err := server.store.UpsertVenueStaff(ctx, db.UpsertVenueStaffParams{Username: uri.Username, VenueID: v.ID, Role: req.Role})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, staff)
+}
+
+// removeVenueStaff takes the role of a user in a venue, only the managers of the venue can take it
+func (server *Server) removeVenueStaff(ctx *gin.Context) {
+	// first i check for the bindings
+	var uri VenueStaffRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	v, ok := server.requireVenue(ctx, uri.ID)
+	if !ok || !server.requireVenueRole(ctx, v.ID, venueRoleManager) {
+		return
+	}
+
+	n, err := server.store.DeleteVenueStaff(ctx, db.DeleteVenueStaffParams{Username: uri.Username, VenueID: v.ID})
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if n == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrNotVenueStaff))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%s is not a staff member of the venue anymore", uri.Username)})
+}
+
+// requireVenue gets a venue, it writes the error response and returns false if the venue can't be got
+func (server *Server) requireVenue(ctx *gin.Context, id int64) (db.Venue, bool) {
+	v, err := server.store.GetVenue(ctx, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return v, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return v, false
+	}
+
+	return v, true
+}
+
+// requireVenueRole checks that the authenticated user has given role in a venue, the global staff has every role
+// in every venue. It writes the error response and returns false if the user doesn't have the role
+func (server *Server) requireVenueRole(ctx *gin.Context, venueID int64, role string) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	u, err := server.store.GetUser(ctx, authPayload.Username)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if u.AccessLevel >= accessLevelStaff {
+		return true
+	}
+
+	staff, err := server.store.GetVenueStaff(ctx, db.GetVenueStaffParams{Username: u.Username, VenueID: venueID})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(ErrVenueStaffOnly))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if role == venueRoleManager && staff.Role != venueRoleManager {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrVenueManagerOnly))
+		return false
+	}
+
+	return true
+}
+
+// parseOpeningHours turns the validated clock times into the minutes after midnight,
+// the closing time is moved to the next day if it isn't after the opening time
+func parseOpeningHours(opens, closes string) (int16, int16) {
+	o, _ := time.Parse(clockLayout, opens)
+	c, _ := time.Parse(clockLayout, closes)
+
+	openMinute := int16(o.Hour()*60 + o.Minute())
+	closeMinute := int16(c.Hour()*60 + c.Minute())
+
+	if closeMinute <= openMinute {
+		closeMinute += minutesPerDay
+	}
+
+	return openMinute, closeMinute
+}
+
+// newOpeningHours turns the stored hours into clock times
+func newOpeningHours(hours []db.VenueHour) []OpeningHoursResponse {
+	result := make([]OpeningHoursResponse, 0, len(hours))
+	for _, h := range hours {
+		result = append(result, OpeningHoursResponse{
+			Weekday: h.Weekday,
+			Opens:   formatClock(h.Opens),
+			Closes:  formatClock(h.Closes),
+		})
+	}
+
+	return result
+}
+
+// formatClock formats the minutes after midnight as a clock time
+func formatClock(minutes int16) string {
+	minutes %= minutesPerDay
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
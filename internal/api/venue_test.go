@@ -0,0 +1,766 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateVenueAPI tests createVenue handler
+func TestCreateVenueAPI(t *testing.T) {
+	staff := randomStaff(t)
+	venue := randomVenue()
+	venue.TaxRate = 1800
+	hours := []db.VenueHour{
+		{VenueID: venue.ID, Weekday: 5, Opens: 10 * 60, Closes: 25*60 + 30},
+		{VenueID: venue.ID, Weekday: 6, Opens: 9*60 + 15, Closes: 23 * 60},
+	}
+
+	body := gin.H{
+		"name":      venue.Name,
+		"address":   venue.Address,
+		"time_zone": venue.TimeZone,
+		"tax_rate":  venue.TaxRate,
+		"opening_hours": []gin.H{
+			{"weekday": 5, "opens": "10:00", "closes": "01:30"},
+			{"weekday": 6, "opens": "09:15", "closes": "23:00"},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateVenueTxParams{
+					CreateVenueParams: db.CreateVenueParams{Name: venue.Name, Address: venue.Address, TimeZone: venue.TimeZone, AdultPrice: defaultAdultPrice, ChildPrice: defaultChildPrice, TaxRate: venue.TaxRate},
+					Hours: []db.CreateVenueHoursParams{
+						{Weekday: 5, Opens: 10 * 60, Closes: 25*60 + 30},
+						{Weekday: 6, Opens: 9*60 + 15, Closes: 23 * 60},
+					},
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateVenueTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CreateVenueTxResult{Venue: venue, Hours: hours}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				got := requireBodyVenue(t, w)
+				requireVenueMatch(t, venue, got.Venue)
+				require.Equal(t, []OpeningHoursResponse{
+					{Weekday: 5, Opens: "10:00", Closes: "01:30"},
+					{Weekday: 6, Opens: "09:15", Closes: "23:00"},
+				}, got.OpeningHours)
+			},
+		},
+		{
+			name: "Invalid Time Zone",
+			body: gin.H{"name": venue.Name, "address": venue.Address, "time_zone": "Mars/Olympus"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateVenueTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Invalid Tax Rate",
+			body: gin.H{"name": venue.Name, "address": venue.Address, "time_zone": venue.TimeZone, "tax_rate": 10001},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateVenueTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Currency Mismatch",
+			body: gin.H{
+				"name":        venue.Name,
+				"address":     venue.Address,
+				"time_zone":   venue.TimeZone,
+				"adult_price": util.NewMoney(1200, "EUR"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateVenueTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Duplicate Weekday",
+			body: gin.H{
+				"name":      venue.Name,
+				"address":   venue.Address,
+				"time_zone": venue.TimeZone,
+				"opening_hours": []gin.H{
+					{"weekday": 5, "opens": "10:00", "closes": "23:00"},
+					{"weekday": 5, "opens": "11:00", "closes": "23:00"},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateVenueTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Invalid Clock",
+			body: gin.H{
+				"name":          venue.Name,
+				"address":       venue.Address,
+				"time_zone":     venue.TimeZone,
+				"opening_hours": []gin.H{{"weekday": 1, "opens": "25:00", "closes": "23:00"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateVenueTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Duplicate Name",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateVenueTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateVenueTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/venues", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListVenuesAPI tests listVenues handler
+func TestListVenuesAPI(t *testing.T) {
+	v1 := randomVenue()
+	v2 := randomVenue()
+	v2.ID = v1.ID + 1
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListVenues(gomock.Any(), gomock.Any()).Times(1).Return([]db.Venue{v1, v2}, nil)
+				store.EXPECT().ListVenueHours(gomock.Any(), gomock.Eq([]int64{v1.ID, v2.ID})).Times(1).
+					Return([]db.VenueHour{{VenueID: v2.ID, Weekday: 0, Opens: 600, Closes: 1380}}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[VenueResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 2)
+				requireVenueMatch(t, v1, got.Items[0].Venue)
+				require.Empty(t, got.Items[0].OpeningHours)
+				require.Equal(t, []OpeningHoursResponse{{Weekday: 0, Opens: "10:00", Closes: "23:00"}}, got.Items[1].OpeningHours)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListVenues(gomock.Any(), gomock.Any()).Times(1).Return([]db.Venue{}, sql.ErrConnDone)
+				store.EXPECT().ListVenueHours(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/venues?page_size=5", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestGetVenueAPI tests getVenue handler
+func TestGetVenueAPI(t *testing.T) {
+	venue := randomVenue()
+	auditoriums := []db.Auditorium{randomAuditorium(venue), randomAuditorium(venue)}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListVenueHours(gomock.Any(), gomock.Eq([]int64{venue.ID})).Times(1).Return([]db.VenueHour{}, nil)
+				store.EXPECT().ListVenueAuditoriums(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(auditoriums, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				got := requireBodyVenue(t, w)
+				requireVenueMatch(t, venue, got.Venue)
+				require.Equal(t, auditoriums, got.Auditoriums)
+			},
+		},
+		{
+			name: "Not Found",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(db.Venue{}, sql.ErrNoRows)
+				store.EXPECT().ListVenueAuditoriums(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/venues/%d", venue.ID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestCreateAuditoriumAPI tests createAuditorium handler
+func TestCreateAuditoriumAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, user := randomUser(t)
+	venue := randomVenue()
+	auditorium := randomAuditorium(venue)
+	body := gin.H{"name": auditorium.Name, "seats": auditorium.Seats}
+	venueStaffArg := db.GetVenueStaffParams{Username: user.Username, VenueID: venue.ID}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAuditoriumParams{
+					VenueID:         venue.ID,
+					Name:            auditorium.Name,
+					Seats:           auditorium.Seats,
+					CleaningMinutes: defaultCleaningMinutes,
+				}
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAuditorium(gomock.Any(), gomock.Eq(arg)).Times(1).Return(auditorium, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Venue Manager",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(venueStaffArg)).Times(1).
+					Return(db.VenueStaff{Username: user.Username, VenueID: venue.ID, Role: venueRoleManager}, nil)
+				store.EXPECT().CreateAuditorium(gomock.Any(), gomock.Any()).Times(1).Return(auditorium, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:     "Venue Staff Is Not Manager",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(venueStaffArg)).Times(1).
+					Return(db.VenueStaff{Username: user.Username, VenueID: venue.ID, Role: venueRoleStaff}, nil)
+				store.EXPECT().CreateAuditorium(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name:     "Invalid Seats",
+			username: staff.Username,
+			body:     gin.H{"name": auditorium.Name, "seats": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAuditorium(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name:     "Duplicate Name",
+			username: staff.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().CreateAuditorium(gomock.Any(), gomock.Any()).Times(1).Return(db.Auditorium{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/venues/%d/auditoriums", venue.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, tt.username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListVenueScreeningsAPI tests listVenueScreenings handler
+func TestListVenueScreeningsAPI(t *testing.T) {
+	venue := randomVenue()
+	screenings := []db.Screening{randomScreening(randomMovie().Movie)}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListVenueScreenings(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListVenueScreeningsParams) ([]db.Screening, error) {
+						require.Equal(t, venue.ID, arg.VenueID)
+						require.WithinDuration(t, time.Now().AddDate(0, 0, defaultVenueScreeningDays), arg.StartsBefore, time.Second)
+						return screenings, nil
+					})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:  "Filters",
+			query: "?format=3d&subtitle_language=tr&audio_description=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().ListVenueScreenings(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListVenueScreeningsParams) ([]db.Screening, error) {
+						require.Equal(t, sql.NullString{String: "3d", Valid: true}, arg.Format)
+						require.Equal(t, sql.NullString{String: "tr", Valid: true}, arg.SubtitleLanguage)
+						require.Equal(t, sql.NullBool{Bool: true, Valid: true}, arg.AudioDescription)
+						require.False(t, arg.Dubbed.Valid)
+						return screenings, nil
+					})
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:  "Invalid Days",
+			query: "?days=31",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Venue Not Found",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(db.Venue{}, sql.ErrNoRows)
+				store.EXPECT().ListVenueScreenings(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/venues/%d/screenings%s", venue.ID, tt.query)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestSetVenueStaffAPI tests setVenueStaff handler
+func TestSetVenueStaffAPI(t *testing.T) {
+	_, manager := randomUser(t)
+	_, member := randomUser(t)
+	venue := randomVenue()
+	managerArg := db.GetVenueStaffParams{Username: manager.Username, VenueID: venue.ID}
+	managerRole := db.VenueStaff{Username: manager.Username, VenueID: venue.ID, Role: venueRoleManager}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"role": venueRoleStaff},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertVenueStaffParams{Username: member.Username, VenueID: venue.ID, Role: venueRoleStaff}
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(manager.Username)).Times(1).Return(manager, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(managerArg)).Times(1).Return(managerRole, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(member.Username)).Times(1).Return(member, nil)
+				store.EXPECT().UpsertVenueStaff(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.VenueStaff{Username: member.Username, VenueID: venue.ID, Role: venueRoleStaff}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Role",
+			body: gin.H{"role": "owner"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpsertVenueStaff(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Not Manager",
+			body: gin.H{"role": venueRoleStaff},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(manager.Username)).Times(1).Return(manager, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(managerArg)).Times(1).Return(db.VenueStaff{}, sql.ErrNoRows)
+				store.EXPECT().UpsertVenueStaff(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			},
+		},
+		{
+			name: "User Not Found",
+			body: gin.H{"role": venueRoleStaff},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(manager.Username)).Times(1).Return(manager, nil)
+				store.EXPECT().GetVenueStaff(gomock.Any(), gomock.Eq(managerArg)).Times(1).Return(managerRole, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(member.Username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().UpsertVenueStaff(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/venues/%d/staff/%s", venue.ID, member.Username)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, manager.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestRemoveVenueStaffAPI tests removeVenueStaff handler
+func TestRemoveVenueStaffAPI(t *testing.T) {
+	staff := randomStaff(t)
+	_, member := randomUser(t)
+	venue := randomVenue()
+	arg := db.DeleteVenueStaffParams{Username: member.Username, VenueID: venue.ID}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteVenueStaff(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Not Venue Staff",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+				store.EXPECT().DeleteVenueStaff(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/venues/%d/staff/%s", venue.ID, member.Username)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListVenueStaffAPI tests listVenueStaff handler
+func TestListVenueStaffAPI(t *testing.T) {
+	staff := randomStaff(t)
+	venue := randomVenue()
+	members := []db.VenueStaff{{Username: util.RandomName(), VenueID: venue.ID, Role: venueRoleStaff}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetVenue(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(venue, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(staff.Username)).Times(1).Return(staff, nil)
+	store.EXPECT().ListVenueStaff(gomock.Any(), gomock.Eq(venue.ID)).Times(1).Return(members, nil)
+
+	server := newTestServer(t, store)
+	w := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/venues/%d/staff", venue.ID)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, staff.Username, time.Minute)
+
+	server.router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestParseOpeningHours tests parseOpeningHours
+func TestParseOpeningHours(t *testing.T) {
+	opens, closes := parseOpeningHours("10:00", "23:30")
+	require.Equal(t, int16(600), opens)
+	require.Equal(t, int16(1410), closes)
+
+	// the venue closes after midnight
+	opens, closes = parseOpeningHours("18:00", "02:00")
+	require.Equal(t, int16(1080), opens)
+	require.Equal(t, int16(1560), closes)
+	require.Equal(t, "02:00", formatClock(closes))
+
+	// the venue is open all day
+	opens, closes = parseOpeningHours("00:00", "00:00")
+	require.Equal(t, int16(0), opens)
+	require.Equal(t, int16(1440), closes)
+}
+
+// randomVenue creates a random venue
+func randomVenue() db.Venue {
+	return db.Venue{
+		ID:         util.RandomInt(1, 1000),
+		Name:       util.RandomName(),
+		Address:    util.RandomString(20),
+		TimeZone:   "Europe/Istanbul",
+		AdultPrice: defaultAdultPrice,
+		ChildPrice: defaultChildPrice,
+	}
+}
+
+// randomAuditorium creates a random auditorium of given venue
+func randomAuditorium(venue db.Venue) db.Auditorium {
+	return db.Auditorium{
+		ID:      util.RandomInt(1, 1000),
+		VenueID: venue.ID,
+		Name:    util.RandomName(),
+		Seats:   int32(util.RandomInt(50, 300)),
+		// CleaningMinutes is the default of the new auditoriums
+		CleaningMinutes: defaultCleaningMinutes,
+	}
+}
+
+// requireBodyVenue reads the venue of the response
+func requireBodyVenue(t *testing.T, w *httptest.ResponseRecorder) VenueResponse {
+	data, err := ioutil.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	var got VenueResponse
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+
+	return got
+}
+
+// requireVenueMatch checks that the details of a venue match given venue, the prices are in the currency of the app
+func requireVenueMatch(t *testing.T, venue db.Venue, got VenueDetails) {
+	require.Equal(t, venue.ID, got.ID)
+	require.Equal(t, venue.Name, got.Name)
+	require.Equal(t, venue.TimeZone, got.TimeZone)
+	require.Equal(t, util.NewMoney(venue.AdultPrice, util.DefaultCurrency), got.AdultPrice)
+	require.Equal(t, util.NewMoney(venue.ChildPrice, util.DefaultCurrency), got.ChildPrice)
+	require.Equal(t, venue.TaxRate, got.TaxRate)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrNotInWatchlist = errors.New("movie is not in the watchlist")
+
+// watchlistCursor is the cursor kind of the watchlist, it holds the last movie ID of the page
+const watchlistCursor = "watchlist"
+
+// AddWatchlistItemRequest holds the json data of the request, the user is notified by default
+type AddWatchlistItemRequest struct {
+	MovieID int64 `json:"movie_id" binding:"required,min=1"`
+	Notify  *bool `json:"notify"`
+}
+
+// WatchlistItemResponse holds a movie on the watchlist
+type WatchlistItemResponse struct {
+	Movie     db.Movie  `json:"movie"`
+	Notify    bool      `json:"notify"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// addWatchlistItem bookmarks a movie for the authenticated user, adding it again updates the notify flag
+func (server *Server) addWatchlistItem(ctx *gin.Context) {
+	// first i check for the bindings
+	var req AddWatchlistItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.requireServedMovie(ctx, req.MovieID) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.AddWatchlistItemParams{
+		Username: authPayload.Username,
+		MovieID:  req.MovieID,
+		Notify:   req.Notify == nil || *req.Notify,
+	}
+
+	item, err := server.store.AddWatchlistItem(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+// listWatchlist returns a page of the watchlist of the authenticated user with its movies
+func (server *Server) listWatchlist(ctx *gin.Context) {
+	// first i check for the bindings
+	var req CursorPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	afterID, err := server.decodeCursor(watchlistCursor, req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.ListWatchlistParams{
+		Username: authPayload.Username,
+		AfterID:  afterID,
+		Limit:    req.PageSize + 1,
+	}
+
+	items, err := server.store.ListWatchlist(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	items, next := trimCursorPage(server, watchlistCursor, items, req.PageSize, func(i db.WatchlistItem) int64 { return i.MovieID })
+
+	// then i get the movies of the page at once for the response
+	movies, err := server.loadMovies(ctx, collectIDs(items, func(i db.WatchlistItem) int64 { return i.MovieID }))
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result := make([]WatchlistItemResponse, 0, len(items))
+	for _, i := range items {
+		m, ok := movies[i.MovieID]
+
+		if !ok {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ErrMissingMovie))
+			return
+		}
+
+		result = append(result, WatchlistItemResponse{Movie: m, Notify: i.Notify, CreatedAt: i.CreatedAt})
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, newListResponse(ctx, result, next))
+}
+
+// RemoveWatchlistItemRequest holds the uri data of the request
+type RemoveWatchlistItemRequest struct {
+	MovieID int64 `uri:"movie_id" binding:"required,min=1"`
+}
+
+// removeWatchlistItem removes a movie from the watchlist of the authenticated user
+func (server *Server) removeWatchlistItem(ctx *gin.Context) {
+	// first i check for the bindings
+	var req RemoveWatchlistItemRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.RemoveWatchlistItemParams{
+		Username: authPayload.Username,
+		MovieID:  req.MovieID,
+	}
+
+	rows, err := server.store.RemoveWatchlistItem(ctx, arg)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrNotInWatchlist))
+		return
+	}
+
+	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+
+	ctx.JSON(http.StatusOK, nil)
+}
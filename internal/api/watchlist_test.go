@@ -0,0 +1,286 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAddWatchlistItemAPI tests addWatchlistItem handler
+func TestAddWatchlistItemAPI(t *testing.T) {
+	_, user := randomUser(t)
+	movie := randomMovie().Movie
+	item := db.WatchlistItem{Username: user.Username, MovieID: movie.ID, Notify: true}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"movie_id": movie.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.AddWatchlistItemParams{Username: user.Username, MovieID: movie.ID, Notify: true}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().AddWatchlistItem(gomock.Any(), gomock.Eq(arg)).Times(1).Return(item, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got db.WatchlistItem
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Equal(t, item, got)
+			},
+		},
+		{
+			name: "Without Notifications",
+			body: gin.H{"movie_id": movie.ID, "notify": false},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.AddWatchlistItemParams{Username: user.Username, MovieID: movie.ID, Notify: false}
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().AddWatchlistItem(gomock.Any(), gomock.Eq(arg)).Times(1).Return(item, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Invalid Movie ID",
+			body: gin.H{"movie_id": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().AddWatchlistItem(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+		{
+			name: "Movie Not Found",
+			body: gin.H{"movie_id": movie.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(db.Movie{}, sql.ErrNoRows)
+				store.EXPECT().AddWatchlistItem(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			body: gin.H{"movie_id": movie.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMovie(gomock.Any(), gomock.Eq(movie.ID)).Times(1).Return(movie, nil)
+				store.EXPECT().AddWatchlistItem(gomock.Any(), gomock.Any()).Times(1).Return(db.WatchlistItem{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			data, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/watchlist", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestListWatchlistAPI tests listWatchlist handler
+func TestListWatchlistAPI(t *testing.T) {
+	_, user := randomUser(t)
+	movies := []db.Movie{randomMovie().Movie, randomMovie().Movie, randomMovie().Movie}
+	movies[1].ID = movies[0].ID + 1
+	movies[2].ID = movies[0].ID + 2
+
+	var items []db.WatchlistItem
+	for _, m := range movies {
+		items = append(items, db.WatchlistItem{Username: user.Username, MovieID: m.ID, Notify: true})
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?page_size=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListWatchlistParams{Username: user.Username, Limit: 3}
+				store.EXPECT().ListWatchlist(gomock.Any(), gomock.Eq(arg)).Times(1).Return(items, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq([]int64{movies[0].ID, movies[1].ID})).Times(1).Return(movies[:2], nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+
+				data, err := ioutil.ReadAll(w.Body)
+				require.NoError(t, err)
+
+				var got ListResponse[WatchlistItemResponse]
+				err = json.Unmarshal(data, &got)
+				require.NoError(t, err)
+				require.Len(t, got.Items, 2)
+				require.Equal(t, movies[0].ID, got.Items[0].Movie.ID)
+				require.True(t, got.Items[0].Notify)
+				require.NotEmpty(t, got.NextCursor)
+			},
+		},
+		{
+			name:  "Empty",
+			query: "?page_size=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWatchlist(gomock.Any(), gomock.Any()).Times(1).Return([]db.WatchlistItem{}, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name:  "Missing Movie",
+			query: "?page_size=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWatchlist(gomock.Any(), gomock.Any()).Times(1).Return(items[:1], nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Movie{}, nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+		{
+			name:  "Invalid Page Size",
+			query: "?page_size=100",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWatchlist(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/watchlist"+tt.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
+
+// TestRemoveWatchlistItemAPI tests removeWatchlistItem handler
+func TestRemoveWatchlistItemAPI(t *testing.T) {
+	_, user := randomUser(t)
+	movie := randomMovie().Movie
+	arg := db.RemoveWatchlistItemParams{Username: user.Username, MovieID: movie.ID}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RemoveWatchlistItem(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, w.Code)
+			},
+		},
+		{
+			name: "Not In Watchlist",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RemoveWatchlistItem(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, w.Code)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RemoveWatchlistItem(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(0), sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, w.Code)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			server := newTestServer(t, store)
+			w := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/watchlist/%d", movie.ID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, validAuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(w, req)
+
+			tt.checkResponse(t, w)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package booking
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+)
+
+var ErrDepositTooLarge = errors.New("deposit can't be more than the total of the quote")
+
+// Quote is the price of a private booking. Net is the price of the whole auditorium without the tax,
+// the deposit is a part of the total that is paid to accept the quote
+type Quote struct {
+	Net     util.Money
+	Tax     util.Money
+	Total   util.Money
+	Deposit util.Money
+	// TermsDays is the count of the days the balance of the invoice is due after the booking is confirmed
+	TermsDays int32
+	ExpiresAt time.Time
+}
+
+// NewQuote prices a private booking with the tax rate of its venue in basis points, the quote can be accepted
+// until it expires after validFor
+func NewQuote(net util.Money, taxRate int32, deposit util.Money, termsDays int32, validFor time.Duration, now time.Time) (Quote, error) {
+	if deposit.Currency != net.Currency {
+		return Quote{}, util.ErrCurrencyMismatch
+	}
+
+	tax := net.Tax(taxRate)
+	total, err := net.Add(tax)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	if deposit.Amount > total.Amount {
+		return Quote{}, ErrDepositTooLarge
+	}
+
+	return Quote{
+		Net:       net,
+		Tax:       tax,
+		Total:     total,
+		Deposit:   deposit,
+		TermsDays: termsDays,
+		ExpiresAt: now.Add(validFor),
+	}, nil
+}
+
+// Invoice is the invoice of a confirmed private booking, the balance is the total without the paid deposit
+type Invoice struct {
+	Number   string
+	Net      util.Money
+	Tax      util.Money
+	Total    util.Money
+	Deposit  util.Money
+	Balance  util.Money
+	IssuedAt time.Time
+	DueAt    time.Time
+}
+
+// Invoice creates the invoice of a booking with the quote, its balance is due after the payment terms of the quote
+func (q Quote) Invoice(bookingID int64, issuedAt time.Time) Invoice {
+	// the deposit is never more than the total so the balance can't be negative
+	balance, _ := q.Total.Sub(q.Deposit)
+
+	return Invoice{
+		Number:   InvoiceNumber(bookingID, issuedAt),
+		Net:      q.Net,
+		Tax:      q.Tax,
+		Total:    q.Total,
+		Deposit:  q.Deposit,
+		Balance:  balance,
+		IssuedAt: issuedAt,
+		DueAt:    issuedAt.AddDate(0, 0, int(q.TermsDays)),
+	}
+}
+
+// InvoiceNumber returns the number of the invoice of a booking, like INV-2030-000042.
+// A booking has a single invoice so the number is unique
+func InvoiceNumber(bookingID int64, issuedAt time.Time) string {
+	return fmt.Sprintf("INV-%d-%06d", issuedAt.Year(), bookingID)
+}
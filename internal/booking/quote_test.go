@@ -0,0 +1,103 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewQuote tests the totals and the deposits of the quotes
+func TestNewQuote(t *testing.T) {
+	now := time.Date(2030, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		net     util.Money
+		taxRate int32
+		deposit util.Money
+		check   func(t *testing.T, q Quote, err error)
+	}{
+		{
+			name:    "OK",
+			net:     util.NewMoney(100000, "USD"),
+			taxRate: 1800,
+			deposit: util.NewMoney(20000, "USD"),
+			check: func(t *testing.T, q Quote, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.NewMoney(18000, "USD"), q.Tax)
+				require.Equal(t, util.NewMoney(118000, "USD"), q.Total)
+				require.Equal(t, util.NewMoney(20000, "USD"), q.Deposit)
+				require.Equal(t, int32(30), q.TermsDays)
+				require.Equal(t, now.Add(7*24*time.Hour), q.ExpiresAt)
+			},
+		},
+		{
+			name:    "Tax Rounded Half Up",
+			net:     util.NewMoney(1005, "USD"),
+			taxRate: 1000,
+			deposit: util.NewMoney(0, "USD"),
+			check: func(t *testing.T, q Quote, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.NewMoney(101, "USD"), q.Tax)
+				require.Equal(t, util.NewMoney(1106, "USD"), q.Total)
+			},
+		},
+		{
+			name:    "Whole Total Deposit",
+			net:     util.NewMoney(1000, "USD"),
+			taxRate: 1800,
+			deposit: util.NewMoney(1180, "USD"),
+			check: func(t *testing.T, q Quote, err error) {
+				require.NoError(t, err)
+				require.Equal(t, q.Total, q.Deposit)
+			},
+		},
+		{
+			name:    "Deposit Too Large",
+			net:     util.NewMoney(1000, "USD"),
+			taxRate: 1800,
+			deposit: util.NewMoney(1181, "USD"),
+			check: func(t *testing.T, q Quote, err error) {
+				require.ErrorIs(t, err, ErrDepositTooLarge)
+			},
+		},
+		{
+			name:    "Currency Mismatch",
+			net:     util.NewMoney(1000, "USD"),
+			deposit: util.NewMoney(100, "EUR"),
+			check: func(t *testing.T, q Quote, err error) {
+				require.ErrorIs(t, err, util.ErrCurrencyMismatch)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewQuote(tt.net, tt.taxRate, tt.deposit, 30, 7*24*time.Hour, now)
+			tt.check(t, q, err)
+		})
+	}
+}
+
+// TestQuoteInvoice tests the balance and the due date of the invoices
+func TestQuoteInvoice(t *testing.T) {
+	issued := time.Date(2030, 12, 20, 9, 0, 0, 0, time.UTC)
+
+	q, err := NewQuote(util.NewMoney(100000, "USD"), 1800, util.NewMoney(20000, "USD"), 14, time.Hour, issued)
+	require.NoError(t, err)
+
+	invoice := q.Invoice(42, issued)
+	require.Equal(t, "INV-2030-000042", invoice.Number)
+	require.Equal(t, q.Net, invoice.Net)
+	require.Equal(t, q.Tax, invoice.Tax)
+	require.Equal(t, q.Total, invoice.Total)
+	require.Equal(t, util.NewMoney(98000, "USD"), invoice.Balance)
+	require.Equal(t, issued, invoice.IssuedAt)
+	require.Equal(t, time.Date(2031, 1, 3, 9, 0, 0, 0, time.UTC), invoice.DueAt)
+
+	// the payment terms of 0 days are due on receipt
+	q.TermsDays = 0
+	require.Equal(t, issued, q.Invoice(42, issued).DueAt)
+}
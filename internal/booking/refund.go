@@ -0,0 +1,45 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+)
+
+// RefundDeposit gives back the deposit of a private booking that is closed before it is confirmed and records
+// the reference of the refund. The refund is retried with the same idempotency key, so a deposit that is
+// refunded after a failure or by another caller at the same time is given back once
+func RefundDeposit(ctx context.Context, store db.Store, payments payment.Gateway, b db.PrivateBooking) (db.PrivateBooking, error) {
+	receipt, err := payments.Refund(ctx, payment.Refund{
+		Username:       b.Organizer,
+		Amount:         util.NewMoney(b.Deposit.Int64, b.Currency.String),
+		Description:    fmt.Sprintf("deposit of private booking %d", b.ID),
+		Reference:      b.DepositReference.String,
+		IdempotencyKey: fmt.Sprintf("private-booking-refund-%d", b.ID),
+	})
+	if err != nil {
+		return b, err
+	}
+
+	done, err := store.CompletePrivateBookingRefund(ctx, db.CompletePrivateBookingRefundParams{
+		ID:                     b.ID,
+		DepositRefundReference: sql.NullString{String: receipt.Reference, Valid: true},
+	})
+
+	// the refund is completed by another caller meanwhile
+	if err == sql.ErrNoRows {
+		return store.GetPrivateBooking(ctx, b.ID)
+	}
+
+	return done, err
+}
+
+// RefundPending reports whether the deposit of a private booking is still to be refunded,
+// a booking that is closed after it is confirmed keeps its deposit
+func RefundPending(b db.PrivateBooking) bool {
+	return b.CancelledAt.Valid && b.DepositReference.Valid && !b.ConfirmedAt.Valid && !b.DepositRefundReference.Valid
+}
@@ -0,0 +1,114 @@
+package booking
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// refundGateway is a payment gateway that records the refunds for the tests
+type refundGateway struct {
+	refunds []payment.Refund
+	err     error
+}
+
+// Charge isn't used by the refunds
+func (g *refundGateway) Charge(ctx context.Context, c payment.Charge) (payment.Receipt, error) {
+	return payment.Receipt{}, errors.New("unexpected charge")
+}
+
+// Refund records the refund or returns the error of the gateway
+func (g *refundGateway) Refund(ctx context.Context, r payment.Refund) (payment.Receipt, error) {
+	if g.err != nil {
+		return payment.Receipt{}, g.err
+	}
+	g.refunds = append(g.refunds, r)
+	return payment.Receipt{Reference: "re_" + r.IdempotencyKey, Amount: r.Amount}, nil
+}
+
+// TestRefundDeposit tests RefundDeposit
+func TestRefundDeposit(t *testing.T) {
+	closed := db.PrivateBooking{
+		ID:               7,
+		Organizer:        "alice",
+		Status:           "cancelled",
+		Deposit:          sql.NullInt64{Int64: 20000, Valid: true},
+		Currency:         sql.NullString{String: "USD", Valid: true},
+		DepositReference: sql.NullString{String: "ch_1", Valid: true},
+		CancelledAt:      sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	done := closed
+	done.DepositRefundReference = sql.NullString{String: "re_private-booking-refund-7", Valid: true}
+
+	testCases := []struct {
+		name       string
+		gatewayErr error
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got db.PrivateBooking, err error, gateway *refundGateway)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CompletePrivateBookingRefundParams{ID: closed.ID, DepositRefundReference: done.DepositRefundReference}
+				store.EXPECT().CompletePrivateBookingRefund(gomock.Any(), gomock.Eq(arg)).Times(1).Return(done, nil)
+			},
+			check: func(t *testing.T, got db.PrivateBooking, err error, gateway *refundGateway) {
+				require.NoError(t, err)
+				require.Equal(t, done, got)
+				require.False(t, RefundPending(got))
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "alice", gateway.refunds[0].Username)
+				require.Equal(t, util.NewMoney(20000, "USD"), gateway.refunds[0].Amount)
+				require.Equal(t, "ch_1", gateway.refunds[0].Reference)
+				require.Equal(t, "private-booking-refund-7", gateway.refunds[0].IdempotencyKey)
+			},
+		},
+		{
+			name: "Completed Meanwhile",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CompletePrivateBookingRefund(gomock.Any(), gomock.Any()).Times(1).Return(db.PrivateBooking{}, sql.ErrNoRows)
+				store.EXPECT().GetPrivateBooking(gomock.Any(), gomock.Eq(closed.ID)).Times(1).Return(done, nil)
+			},
+			check: func(t *testing.T, got db.PrivateBooking, err error, gateway *refundGateway) {
+				require.NoError(t, err)
+				require.Equal(t, done, got)
+			},
+		},
+		{
+			name:       "Gateway Error",
+			gatewayErr: errors.New("gateway is down"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CompletePrivateBookingRefund(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, got db.PrivateBooking, err error, gateway *refundGateway) {
+				require.Error(t, err)
+				require.Equal(t, closed, got)
+				require.True(t, RefundPending(got))
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			gateway := &refundGateway{err: tt.gatewayErr}
+			got, err := RefundDeposit(context.Background(), store, gateway, closed)
+			tt.check(t, got, err, gateway)
+		})
+	}
+}
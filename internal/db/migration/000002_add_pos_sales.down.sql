@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pos_sales CASCADE;
+DROP TABLE IF EXISTS cash_shifts CASCADE;
\ No newline at end of file
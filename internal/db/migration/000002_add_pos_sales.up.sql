@@ -0,0 +1,35 @@
+CREATE TABLE "cash_shifts" (
+  "id" bigserial PRIMARY KEY,
+  "cashier" varchar NOT NULL,
+  "opening_cash" bigint NOT NULL,
+  "counted_cash" bigint,
+  "opened_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz
+);
+
+CREATE TABLE "pos_sales" (
+  "id" bigserial PRIMARY KEY,
+  "shift_id" bigint NOT NULL,
+  "movie_id" bigint NOT NULL,
+  "guest_name" varchar NOT NULL DEFAULT '',
+  "payment_method" varchar NOT NULL,
+  "ticket_code" varchar UNIQUE NOT NULL,
+  "child" smallint NOT NULL,
+  "adult" smallint NOT NULL,
+  "total" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "cash_shifts" ("cashier");
+
+CREATE UNIQUE INDEX ON "cash_shifts" ("cashier") WHERE "closed_at" IS NULL;
+
+CREATE INDEX ON "pos_sales" ("shift_id");
+
+ALTER TABLE "cash_shifts" ADD FOREIGN KEY ("cashier") REFERENCES "users" ("username");
+
+ALTER TABLE "pos_sales" ADD FOREIGN KEY ("shift_id") REFERENCES "cash_shifts" ("id");
+
+ALTER TABLE "pos_sales" ADD FOREIGN KEY ("movie_id") REFERENCES "movies" ("id");
+
+ALTER TABLE "pos_sales" ADD CHECK ("payment_method" IN ('cash', 'card'));
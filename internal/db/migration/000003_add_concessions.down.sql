@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS concession_order_items CASCADE;
+DROP TABLE IF EXISTS concession_orders CASCADE;
+DROP TABLE IF EXISTS concession_items CASCADE;
\ No newline at end of file
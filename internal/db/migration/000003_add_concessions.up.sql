@@ -0,0 +1,43 @@
+CREATE TABLE "concession_items" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "category" varchar NOT NULL,
+  "price" bigint NOT NULL,
+  "stock" integer NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "concession_orders" (
+  "id" bigserial PRIMARY KEY,
+  "ticket_id" bigint NOT NULL,
+  "order_owner" varchar NOT NULL,
+  "total" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "concession_order_items" (
+  "id" bigserial PRIMARY KEY,
+  "order_id" bigint NOT NULL,
+  "item_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "quantity" integer NOT NULL,
+  "unit_price" bigint NOT NULL
+);
+
+CREATE INDEX ON "concession_items" ("category");
+
+CREATE INDEX ON "concession_orders" ("ticket_id");
+
+CREATE INDEX ON "concession_order_items" ("order_id");
+
+ALTER TABLE "concession_items" ADD CHECK ("stock" >= 0);
+
+ALTER TABLE "concession_items" ADD CHECK ("category" IN ('popcorn', 'drink', 'snack', 'combo'));
+
+ALTER TABLE "concession_orders" ADD FOREIGN KEY ("ticket_id") REFERENCES "tickets" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "concession_orders" ADD FOREIGN KEY ("order_owner") REFERENCES "users" ("username");
+
+ALTER TABLE "concession_order_items" ADD FOREIGN KEY ("order_id") REFERENCES "concession_orders" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "concession_order_items" ADD FOREIGN KEY ("item_id") REFERENCES "concession_items" ("id");
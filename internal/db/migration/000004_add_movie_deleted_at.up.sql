@@ -0,0 +1,3 @@
+ALTER TABLE "movies" ADD COLUMN "deleted_at" timestamptz;
+
+CREATE INDEX ON "movies" ("deleted_at");
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS movie_credits CASCADE;
+ALTER TABLE directors DROP COLUMN IF EXISTS person_id;
+DROP TABLE IF EXISTS people CASCADE;
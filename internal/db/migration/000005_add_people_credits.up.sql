@@ -0,0 +1,47 @@
+CREATE TABLE "people" (
+  "id" bigserial PRIMARY KEY,
+  "first_name" varchar NOT NULL,
+  "last_name" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "movie_credits" (
+  "id" bigserial PRIMARY KEY,
+  "movie_id" bigint NOT NULL,
+  "person_id" bigint NOT NULL,
+  "role" varchar NOT NULL,
+  "billing_order" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "movie_credits" ("movie_id", "person_id", "role");
+
+CREATE INDEX ON "movie_credits" ("person_id");
+
+ALTER TABLE "movie_credits" ADD CHECK ("role" IN ('director', 'actor', 'writer', 'composer'));
+
+ALTER TABLE "movie_credits" ADD FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "movie_credits" ADD FOREIGN KEY ("person_id") REFERENCES "people" ("id");
+
+-- every director becomes a person with the same id, so the existing movies can be credited
+INSERT INTO "people" ("id", "first_name", "last_name", "created_at")
+SELECT "id", "first_name", "last_name", "created_at"
+FROM "directors";
+
+SELECT setval(pg_get_serial_sequence('people', 'id'), COALESCE(MAX("id"), 0) + 1, false) FROM "people";
+
+ALTER TABLE "directors" ADD COLUMN "person_id" bigint;
+
+UPDATE "directors" SET "person_id" = "id";
+
+ALTER TABLE "directors" ALTER COLUMN "person_id" SET NOT NULL;
+
+ALTER TABLE "directors" ADD CONSTRAINT "directors_person_id_key" UNIQUE ("person_id");
+
+ALTER TABLE "directors" ADD FOREIGN KEY ("person_id") REFERENCES "people" ("id");
+
+INSERT INTO "movie_credits" ("movie_id", "person_id", "role", "billing_order")
+SELECT m."id", d."person_id", 'director', 0
+FROM "movies" m
+JOIN "directors" d ON d."id" = m."director_id";
@@ -0,0 +1,2 @@
+ALTER TABLE directors ALTER COLUMN oscars DROP DEFAULT;
+DROP TABLE IF EXISTS awards CASCADE;
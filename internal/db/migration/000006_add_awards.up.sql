@@ -0,0 +1,34 @@
+-- the name of an award is free text, its kind tells the ceremonies that are counted apart like the oscars
+CREATE TABLE "awards" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "kind" varchar NOT NULL DEFAULT 'other',
+  "year" integer,
+  "category" varchar NOT NULL,
+  "winner" boolean NOT NULL DEFAULT false,
+  "person_id" bigint,
+  "movie_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "awards" ("year");
+
+CREATE INDEX ON "awards" ("person_id");
+
+CREATE INDEX ON "awards" ("movie_id");
+
+ALTER TABLE "awards" ADD CHECK ("person_id" IS NOT NULL OR "movie_id" IS NOT NULL);
+
+ALTER TABLE "awards" ADD CHECK ("kind" IN ('oscar', 'golden_globe', 'bafta', 'other'));
+
+ALTER TABLE "awards" ADD FOREIGN KEY ("person_id") REFERENCES "people" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "awards" ADD FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE;
+
+-- the old counters become won oscars of unknown year and category
+INSERT INTO "awards" ("name", "kind", "category", "winner", "person_id")
+SELECT 'Academy Awards', 'oscar', 'Unspecified', true, d."person_id"
+FROM "directors" d, generate_series(1, d."oscars");
+
+-- directors.oscars is only refreshed from the awards from now on
+ALTER TABLE "directors" ALTER COLUMN "oscars" SET DEFAULT 0;
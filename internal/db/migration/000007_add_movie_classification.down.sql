@@ -0,0 +1,4 @@
+ALTER TABLE movies DROP COLUMN IF EXISTS tags;
+ALTER TABLE movies DROP COLUMN IF EXISTS certification;
+DROP TABLE IF EXISTS movie_genres CASCADE;
+DROP TABLE IF EXISTS genres CASCADE;
@@ -0,0 +1,34 @@
+CREATE TABLE "genres" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "movie_genres" (
+  "movie_id" bigint NOT NULL,
+  "genre_id" bigint NOT NULL,
+  PRIMARY KEY ("movie_id", "genre_id")
+);
+
+CREATE UNIQUE INDEX ON "genres" (lower("name"));
+
+CREATE INDEX ON "movie_genres" ("genre_id");
+
+ALTER TABLE "movie_genres" ADD FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "movie_genres" ADD FOREIGN KEY ("genre_id") REFERENCES "genres" ("id") ON DELETE CASCADE;
+
+INSERT INTO "genres" ("name")
+VALUES ('Action'), ('Adventure'), ('Animation'), ('Comedy'), ('Crime'), ('Documentary'), ('Drama'),
+  ('Fantasy'), ('Horror'), ('Musical'), ('Romance'), ('Science Fiction'), ('Thriller'), ('Western');
+
+-- movies without a certification are not rated yet
+ALTER TABLE "movies" ADD COLUMN "certification" varchar NOT NULL DEFAULT 'NR';
+
+ALTER TABLE "movies" ADD COLUMN "tags" varchar[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE "movies" ADD CHECK ("certification" IN ('G', 'PG', 'PG-13', 'R', 'NC-17', 'NR'));
+
+CREATE INDEX ON "movies" ("certification");
+
+CREATE INDEX ON "movies" USING GIN ("tags");
@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- the expression must stay the same as the one in SearchMovies for the index to be used
+CREATE INDEX "movies_search_idx" ON "movies" USING GIN (
+  (setweight(to_tsvector('english', "title"), 'A') || setweight(to_tsvector('english', "summary"), 'B'))
+);
+
+CREATE INDEX "movies_title_trgm_idx" ON "movies" USING GIN ("title" gin_trgm_ops);
+
+CREATE INDEX "people_name_trgm_idx" ON "people" USING GIN (("first_name" || ' ' || "last_name") gin_trgm_ops);
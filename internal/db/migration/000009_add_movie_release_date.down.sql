@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "movies_director_id_idx";
+
+DROP INDEX IF EXISTS "movies_title_idx";
+
+DROP INDEX IF EXISTS "movies_rating_idx";
+
+ALTER TABLE "movies" DROP COLUMN IF EXISTS "release_date";
@@ -0,0 +1,12 @@
+-- existing movies are assumed to be released when they were added
+ALTER TABLE "movies" ADD COLUMN "release_date" date NOT NULL DEFAULT (CURRENT_DATE);
+
+UPDATE "movies" SET "release_date" = "created_at"::date;
+
+CREATE INDEX ON "movies" ("release_date");
+
+CREATE INDEX ON "movies" ("rating");
+
+CREATE INDEX ON "movies" ("title");
+
+CREATE INDEX ON "movies" ("director_id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS reviews CASCADE;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS checked_in_at;
@@ -0,0 +1,25 @@
+-- a ticket is checked in when its owner enters the screening
+ALTER TABLE "tickets" ADD COLUMN "checked_in_at" timestamptz;
+
+CREATE TABLE "reviews" (
+  "id" bigserial PRIMARY KEY,
+  "movie_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "rating" smallint NOT NULL,
+  "body" varchar NOT NULL DEFAULT '',
+  "verified" boolean NOT NULL DEFAULT false,
+  "status" varchar NOT NULL DEFAULT 'published',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "reviews" ("movie_id", "username");
+
+CREATE INDEX ON "reviews" ("status");
+
+ALTER TABLE "reviews" ADD CHECK ("rating" BETWEEN 1 AND 5);
+
+ALTER TABLE "reviews" ADD CHECK ("status" IN ('published', 'hidden'));
+
+ALTER TABLE "reviews" ADD FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "reviews" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
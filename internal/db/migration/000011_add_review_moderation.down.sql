@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS moderation_events;
+
+DROP TABLE IF EXISTS review_reports;
+
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS reviews_status_check;
+
+UPDATE reviews SET status = CASE status WHEN 'approved' THEN 'published' ELSE 'hidden' END;
+
+ALTER TABLE reviews ALTER COLUMN status SET DEFAULT 'published';
+
+ALTER TABLE reviews ADD CHECK (status IN ('published', 'hidden'));
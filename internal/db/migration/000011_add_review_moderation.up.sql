@@ -0,0 +1,41 @@
+-- reviews wait in the moderation queue before they are public
+ALTER TABLE "reviews" DROP CONSTRAINT IF EXISTS "reviews_status_check";
+
+UPDATE "reviews" SET "status" = CASE "status" WHEN 'hidden' THEN 'rejected' ELSE 'approved' END;
+
+ALTER TABLE "reviews" ALTER COLUMN "status" SET DEFAULT 'pending';
+
+ALTER TABLE "reviews" ADD CHECK ("status" IN ('pending', 'approved', 'rejected'));
+
+CREATE TABLE "review_reports" (
+  "id" bigserial PRIMARY KEY,
+  "review_id" bigint NOT NULL,
+  "reporter" varchar NOT NULL,
+  "reason" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  -- a report is resolved by the next decision of a moderator, only the unresolved reports requeue a review
+  "resolved_at" timestamptz
+);
+
+CREATE UNIQUE INDEX ON "review_reports" ("review_id", "reporter");
+
+ALTER TABLE "review_reports" ADD CHECK ("reason" IN ('spam', 'offensive', 'spoiler', 'other'));
+
+ALTER TABLE "review_reports" ADD FOREIGN KEY ("review_id") REFERENCES "reviews" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "review_reports" ADD FOREIGN KEY ("reporter") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+-- the audit trail outlives the reviews and the moderators, so it has no foreign keys
+CREATE TABLE "moderation_events" (
+  "id" bigserial PRIMARY KEY,
+  "review_id" bigint NOT NULL,
+  -- moderator is NULL when the decision is made automatically
+  "moderator" varchar,
+  "from_status" varchar NOT NULL,
+  "to_status" varchar NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "moderation_events" ("review_id");
@@ -0,0 +1,30 @@
+-- a screening is on sale once it is published
+CREATE TABLE "screenings" (
+  "id" bigserial PRIMARY KEY,
+  "movie_id" bigint NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "published_at" timestamptz,
+  "watchers_notified_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "screenings" ("movie_id", "starts_at");
+
+-- the watchlist job looks for the published screenings that aren't notified yet
+CREATE INDEX ON "screenings" ("id") WHERE "published_at" IS NOT NULL AND "watchers_notified_at" IS NULL;
+
+ALTER TABLE "screenings" ADD FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "watchlist_items" (
+  "username" varchar NOT NULL,
+  "movie_id" bigint NOT NULL,
+  "notify" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "movie_id")
+);
+
+CREATE INDEX ON "watchlist_items" ("movie_id");
+
+ALTER TABLE "watchlist_items" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "watchlist_items" ADD FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE;
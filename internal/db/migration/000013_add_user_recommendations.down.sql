@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tickets_movie_id_idx;
+
+DROP INDEX IF EXISTS tickets_ticket_owner_movie_id_idx;
+
+DROP TABLE IF EXISTS user_recommendations;
@@ -0,0 +1,17 @@
+-- the nightly precompute stores the recommendations of every buyer here
+CREATE TABLE "user_recommendations" (
+  "username" varchar NOT NULL,
+  "movie_id" bigint NOT NULL,
+  "score" double precision NOT NULL,
+  "reasons" varchar[] NOT NULL DEFAULT '{}',
+  "computed_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "movie_id")
+);
+
+ALTER TABLE "user_recommendations" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "user_recommendations" ADD FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "tickets" ("ticket_owner", "movie_id");
+
+CREATE INDEX ON "tickets" ("movie_id");
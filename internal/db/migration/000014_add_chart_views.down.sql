@@ -0,0 +1,3 @@
+DROP MATERIALIZED VIEW IF EXISTS movie_trending;
+
+DROP MATERIALIZED VIEW IF EXISTS movie_daily_sales;
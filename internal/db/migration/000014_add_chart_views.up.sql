@@ -0,0 +1,30 @@
+-- the charts read these views, they are refreshed on a schedule instead of scanning the tickets per request.
+-- a ticket holds many seats, so the tickets sold are the adult and child seats
+CREATE MATERIALIZED VIEW "movie_daily_sales" AS
+SELECT
+  "movie_id",
+  ("created_at" AT TIME ZONE 'UTC')::date AS "day",
+  sum("adult"::bigint + "child")::bigint AS "tickets",
+  sum("total")::bigint AS "revenue"
+FROM "tickets"
+GROUP BY "movie_id", "day";
+
+-- the unique index lets the view be refreshed concurrently
+CREATE UNIQUE INDEX ON "movie_daily_sales" ("movie_id", "day");
+
+CREATE INDEX ON "movie_daily_sales" ("day");
+
+-- trending compares the last 7 days to the 7 days before them at the time of the refresh
+CREATE MATERIALIZED VIEW "movie_trending" AS
+SELECT
+  "movie_id",
+  COALESCE(sum("adult"::bigint + "child") FILTER (WHERE "created_at" > now() - interval '7 days'), 0)::bigint AS "tickets",
+  COALESCE(sum("total") FILTER (WHERE "created_at" > now() - interval '7 days'), 0)::bigint AS "revenue",
+  COALESCE(sum("adult"::bigint + "child") FILTER (WHERE "created_at" <= now() - interval '7 days'), 0)::bigint AS "previous_tickets",
+  COALESCE(sum("total") FILTER (WHERE "created_at" <= now() - interval '7 days'), 0)::bigint AS "previous_revenue",
+  now() AS "refreshed_at"
+FROM "tickets"
+WHERE "created_at" > now() - interval '14 days'
+GROUP BY "movie_id";
+
+CREATE UNIQUE INDEX ON "movie_trending" ("movie_id");
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS pos_sales_created_at_idx;
+
+DROP INDEX IF EXISTS tickets_created_at_idx;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS tax;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS discount;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS payment_method;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS screening_id;
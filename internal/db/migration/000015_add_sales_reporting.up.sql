@@ -0,0 +1,17 @@
+-- the tickets are linked to the screening they are sold for, the older tickets have none.
+-- online tickets are paid by card, the discount and the tax are included in the total and are 0 until they are priced
+ALTER TABLE "tickets" ADD COLUMN "screening_id" bigint;
+
+ALTER TABLE "tickets" ADD COLUMN "payment_method" varchar NOT NULL DEFAULT 'card';
+
+ALTER TABLE "tickets" ADD COLUMN "discount" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "tickets" ADD COLUMN "tax" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "tickets" ADD FOREIGN KEY ("screening_id") REFERENCES "screenings" ("id");
+
+ALTER TABLE "tickets" ADD CHECK ("payment_method" IN ('cash', 'card'));
+
+CREATE INDEX ON "tickets" ("created_at");
+
+CREATE INDEX ON "pos_sales" ("created_at");
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS venue_staff;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS auditorium_id;
+
+DROP TABLE IF EXISTS auditoriums;
+
+DROP TABLE IF EXISTS venue_hours;
+
+DROP TABLE IF EXISTS venues;
@@ -0,0 +1,60 @@
+CREATE TABLE "venues" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar UNIQUE NOT NULL,
+  "address" varchar NOT NULL,
+  "time_zone" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- the hours are minutes after the midnight of the weekday in the venue's time zone,
+-- a venue closing after midnight closes after 1440
+CREATE TABLE "venue_hours" (
+  "venue_id" bigint NOT NULL,
+  "weekday" smallint NOT NULL,
+  "opens" smallint NOT NULL,
+  "closes" smallint NOT NULL,
+  PRIMARY KEY ("venue_id", "weekday")
+);
+
+ALTER TABLE "venue_hours" ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "venue_hours" ADD CHECK ("weekday" BETWEEN 0 AND 6);
+
+ALTER TABLE "venue_hours" ADD CHECK ("opens" >= 0 AND "opens" < 1440 AND "closes" > "opens" AND "closes" <= 2880);
+
+CREATE TABLE "auditoriums" (
+  "id" bigserial PRIMARY KEY,
+  "venue_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "seats" int NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("venue_id", "name")
+);
+
+ALTER TABLE "auditoriums" ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id");
+
+ALTER TABLE "auditoriums" ADD CHECK ("seats" > 0);
+
+-- the screenings created before the venues have no auditorium
+ALTER TABLE "screenings" ADD COLUMN "auditorium_id" bigint;
+
+ALTER TABLE "screenings" ADD FOREIGN KEY ("auditorium_id") REFERENCES "auditoriums" ("id");
+
+CREATE INDEX ON "screenings" ("auditorium_id", "starts_at");
+
+-- the staff roles of a venue, the global staff can act on every venue
+CREATE TABLE "venue_staff" (
+  "username" varchar NOT NULL,
+  "venue_id" bigint NOT NULL,
+  "role" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "venue_id")
+);
+
+CREATE INDEX ON "venue_staff" ("venue_id");
+
+ALTER TABLE "venue_staff" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "venue_staff" ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "venue_staff" ADD CHECK ("role" IN ('manager', 'staff'));
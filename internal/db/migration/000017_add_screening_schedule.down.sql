@@ -0,0 +1,9 @@
+ALTER TABLE screenings DROP COLUMN IF EXISTS blocked_until;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS ends_at;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS trailer_minutes;
+
+ALTER TABLE auditoriums DROP COLUMN IF EXISTS cleaning_minutes;
+
+ALTER TABLE movies DROP COLUMN IF EXISTS runtime;
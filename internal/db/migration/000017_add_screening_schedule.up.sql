@@ -0,0 +1,32 @@
+-- the runtime of a movie is in minutes, it is 0 until it is known and such a movie can't be scheduled
+ALTER TABLE "movies" ADD COLUMN "runtime" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "movies" ADD CHECK ("runtime" >= 0);
+
+-- an auditorium is cleaned after every screening
+ALTER TABLE "auditoriums" ADD COLUMN "cleaning_minutes" int NOT NULL DEFAULT 15;
+
+ALTER TABLE "auditoriums" ADD CHECK ("cleaning_minutes" >= 0);
+
+-- a screening starts with its trailers, ends after its movie and blocks its auditorium until the auditorium is cleaned
+ALTER TABLE "screenings" ADD COLUMN "trailer_minutes" int NOT NULL DEFAULT 15;
+
+ALTER TABLE "screenings" ADD COLUMN "ends_at" timestamptz;
+
+ALTER TABLE "screenings" ADD COLUMN "blocked_until" timestamptz;
+
+UPDATE "screenings"
+SET "ends_at" = "screenings"."starts_at" + make_interval(mins => "screenings"."trailer_minutes" + "movies"."runtime")
+FROM "movies"
+WHERE "movies"."id" = "screenings"."movie_id";
+
+UPDATE "screenings"
+SET "blocked_until" = "ends_at" + make_interval(mins => COALESCE(
+  (SELECT "cleaning_minutes" FROM "auditoriums" WHERE "auditoriums"."id" = "screenings"."auditorium_id"), 0
+));
+
+ALTER TABLE "screenings" ALTER COLUMN "ends_at" SET NOT NULL;
+
+ALTER TABLE "screenings" ALTER COLUMN "blocked_until" SET NOT NULL;
+
+ALTER TABLE "screenings" ADD CHECK ("starts_at" <= "ends_at" AND "ends_at" <= "blocked_until");
@@ -0,0 +1,15 @@
+ALTER TABLE tickets DROP COLUMN IF EXISTS surcharge;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS relaxed;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS audio_description;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS dubbed;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS subtitle_language;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS audio_language;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS format;
+
+DROP TABLE IF EXISTS screening_formats;
@@ -0,0 +1,32 @@
+-- the surcharge of a format is added to the price of every seat of its screenings
+CREATE TABLE "screening_formats" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "surcharge" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("surcharge" >= 0)
+);
+
+INSERT INTO "screening_formats" ("code", "name", "surcharge")
+VALUES ('2d', '2D', 0), ('3d', '3D', 300), ('imax', 'IMAX', 500), ('imax-3d', 'IMAX 3D', 700);
+
+-- the languages are ISO 639-1 codes, the screenings without subtitles have no subtitle language
+ALTER TABLE "screenings" ADD COLUMN "format" varchar NOT NULL DEFAULT '2d' REFERENCES "screening_formats" ("code");
+
+ALTER TABLE "screenings" ADD COLUMN "audio_language" varchar NOT NULL DEFAULT 'en';
+
+ALTER TABLE "screenings" ADD COLUMN "subtitle_language" varchar;
+
+ALTER TABLE "screenings" ADD COLUMN "dubbed" boolean NOT NULL DEFAULT false;
+
+ALTER TABLE "screenings" ADD COLUMN "audio_description" boolean NOT NULL DEFAULT false;
+
+-- relaxed screenings are sensory-friendly, the lights are up and the sound is down
+ALTER TABLE "screenings" ADD COLUMN "relaxed" boolean NOT NULL DEFAULT false;
+
+ALTER TABLE "screenings" ADD CHECK ("audio_language" ~ '^[a-z]{2}$');
+
+ALTER TABLE "screenings" ADD CHECK ("subtitle_language" ~ '^[a-z]{2}$');
+
+-- the surcharge of a ticket is already in its total
+ALTER TABLE "tickets" ADD COLUMN "surcharge" bigint NOT NULL DEFAULT 0;
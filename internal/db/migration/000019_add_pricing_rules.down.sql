@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS pricing_rules;
+
+ALTER TABLE venues DROP COLUMN IF EXISTS child_price;
+
+ALTER TABLE venues DROP COLUMN IF EXISTS adult_price;
@@ -0,0 +1,33 @@
+-- the base prices of the seats of a venue, the pricing rules adjust them
+ALTER TABLE "venues" ADD COLUMN "adult_price" bigint NOT NULL DEFAULT 1000;
+
+ALTER TABLE "venues" ADD COLUMN "child_price" bigint NOT NULL DEFAULT 700;
+
+ALTER TABLE "venues" ADD CHECK ("adult_price" >= 0 AND "child_price" >= 0);
+
+-- a rule without a venue applies to all the venues, only the columns of its kind are used
+CREATE TABLE "pricing_rules" (
+  "id" bigserial PRIMARY KEY,
+  "venue_id" bigint,
+  "name" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "percent" int NOT NULL,
+  "starts_before" int,
+  "weekdays" int[] NOT NULL DEFAULT '{}',
+  "holiday" date,
+  "min_occupancy" int,
+  "valid_from" timestamptz,
+  "valid_until" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("kind" IN ('matinee', 'weekday', 'holiday', 'occupancy')),
+  CHECK ("percent" >= -100),
+  CHECK ("kind" <> 'matinee' OR "starts_before" BETWEEN 1 AND 1440),
+  CHECK ("kind" <> 'weekday' OR cardinality("weekdays") > 0),
+  CHECK ("kind" <> 'holiday' OR "holiday" IS NOT NULL),
+  CHECK ("kind" <> 'occupancy' OR "min_occupancy" BETWEEN 1 AND 100),
+  CHECK ("valid_from" < "valid_until")
+);
+
+ALTER TABLE "pricing_rules" ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "pricing_rules" ("venue_id");
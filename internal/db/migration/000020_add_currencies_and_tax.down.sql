@@ -0,0 +1,9 @@
+ALTER TABLE venues DROP COLUMN IF EXISTS tax_rate;
+
+ALTER TABLE pos_sales DROP COLUMN IF EXISTS tax;
+
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_tax_check;
+
+ALTER TABLE pos_sales DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS currency;
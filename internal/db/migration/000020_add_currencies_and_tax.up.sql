@@ -0,0 +1,37 @@
+-- the amounts are minor units of a currency, like cents. The sales record the ISO 4217 currency they are sold in,
+-- the concession orders are in the currency of their ticket
+ALTER TABLE "tickets" ADD COLUMN "currency" varchar(3);
+
+ALTER TABLE "pos_sales" ADD COLUMN "currency" varchar(3);
+
+-- the existing sales are in the currency of the deployment, it is given by the theatre.currency setting of the
+-- connection that runs the migration. The app sets it to its CURRENCY config, the migrate cli needs it in the url
+-- like ?options=-c%20theatre.currency%3DEUR. The migration fails if there are sales and it isn't set
+DO $$
+DECLARE
+  deployment_currency varchar := NULLIF(current_setting('theatre.currency', true), '');
+BEGIN
+  IF deployment_currency IS NULL AND (EXISTS (SELECT 1 FROM "tickets") OR EXISTS (SELECT 1 FROM "pos_sales")) THEN
+    RAISE EXCEPTION 'theatre.currency must be set to the currency of the existing sales';
+  END IF;
+
+  UPDATE "tickets" SET "currency" = deployment_currency;
+
+  UPDATE "pos_sales" SET "currency" = deployment_currency;
+END $$;
+
+ALTER TABLE "tickets" ALTER COLUMN "currency" SET NOT NULL;
+
+ALTER TABLE "pos_sales" ALTER COLUMN "currency" SET NOT NULL;
+
+-- the tax of a sale is a part of its total, the existing box office sales didn't record their tax
+ALTER TABLE "tickets" ADD CONSTRAINT "tickets_tax_check" CHECK ("tax" >= 0 AND "tax" <= "total");
+
+ALTER TABLE "pos_sales" ADD COLUMN "tax" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "pos_sales" ADD CONSTRAINT "pos_sales_tax_check" CHECK ("tax" >= 0 AND "tax" <= "total");
+
+-- the tax rate of a venue is in basis points, 1800 is 18%. The prices of the venue are net of tax
+ALTER TABLE "venues" ADD COLUMN "tax_rate" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "venues" ADD CHECK ("tax_rate" BETWEEN 0 AND 10000);
@@ -0,0 +1,13 @@
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_payment_method_check;
+
+UPDATE tickets SET payment_method = 'card' WHERE payment_method = 'membership';
+
+ALTER TABLE tickets ADD CONSTRAINT tickets_payment_method_check CHECK (payment_method IN ('cash', 'card'));
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS membership_id;
+
+DROP TABLE IF EXISTS membership_payments;
+
+DROP TABLE IF EXISTS memberships;
+
+DROP TABLE IF EXISTS membership_plans;
@@ -0,0 +1,80 @@
+-- a membership plan gives an allowance of seats for every monthly billing period, its price is charged at the start of the period
+CREATE TABLE "membership_plans" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar UNIQUE NOT NULL,
+  "allowance" int NOT NULL,
+  "price" bigint NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("allowance" > 0),
+  CHECK ("price" >= 0)
+);
+
+-- the allowance of a membership is the allowance of its plan for the period, it is prorated when the plan is changed.
+-- a paused membership doesn't use its period, the period is extended by the pause once it is resumed
+CREATE TABLE "memberships" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "plan_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "period_start" timestamptz NOT NULL,
+  "period_end" timestamptz NOT NULL,
+  "allowance" int NOT NULL,
+  "used" int NOT NULL DEFAULT 0,
+  "cancel_at_period_end" boolean NOT NULL DEFAULT false,
+  "paused_at" timestamptz,
+  "cancelled_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("status" IN ('active', 'paused', 'past_due', 'cancelled')),
+  CHECK ("period_start" < "period_end"),
+  CHECK ("used" >= 0 AND "used" <= "allowance"),
+  CHECK ("status" <> 'paused' OR "paused_at" IS NOT NULL)
+);
+
+ALTER TABLE "memberships" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "memberships" ADD FOREIGN KEY ("plan_id") REFERENCES "membership_plans" ("id");
+
+-- a user has a single membership until it is cancelled
+CREATE UNIQUE INDEX ON "memberships" ("username") WHERE "status" <> 'cancelled';
+
+CREATE INDEX ON "memberships" ("period_end") WHERE "status" IN ('active', 'past_due');
+
+-- the charges and the refunds of the memberships, the refunds have negative amounts.
+-- reference is the reference of the payment at the payment gateway. a refund is recorded with the change it is given for
+-- and given back after the change is saved, so it is pending with a null reference until the gateway refunds it.
+-- refund_of is the charge a refund gives back and period_start is the start of the period a payment is made for
+CREATE TABLE "membership_payments" (
+  "id" bigserial PRIMARY KEY,
+  "membership_id" bigint NOT NULL,
+  "period_start" timestamptz NOT NULL,
+  "kind" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "reference" varchar,
+  "refund_of" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("kind" IN ('subscription', 'renewal', 'proration', 'refund')),
+  CHECK (("kind" = 'refund') = ("refund_of" IS NOT NULL)),
+  CHECK ("kind" = 'refund' OR "reference" IS NOT NULL)
+);
+
+ALTER TABLE "membership_payments" ADD FOREIGN KEY ("membership_id") REFERENCES "memberships" ("id");
+
+ALTER TABLE "membership_payments" ADD FOREIGN KEY ("refund_of") REFERENCES "membership_payments" ("id");
+
+CREATE INDEX ON "membership_payments" ("membership_id", "period_start");
+
+CREATE INDEX ON "membership_payments" ("refund_of");
+
+CREATE INDEX ON "membership_payments" ("id") WHERE "reference" IS NULL;
+
+-- the seats of a ticket can be covered by the allowance of a membership instead of a payment,
+-- the price of the seats is its discount so the reports keep the gross of the sales
+ALTER TABLE "tickets" ADD COLUMN "membership_id" bigint;
+
+ALTER TABLE "tickets" ADD FOREIGN KEY ("membership_id") REFERENCES "memberships" ("id");
+
+ALTER TABLE "tickets" DROP CONSTRAINT "tickets_payment_method_check";
+
+ALTER TABLE "tickets" ADD CONSTRAINT "tickets_payment_method_check" CHECK ("payment_method" IN ('cash', 'card', 'membership'));
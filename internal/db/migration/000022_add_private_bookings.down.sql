@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS private_booking_invoices;
+
+ALTER TABLE screenings DROP COLUMN IF EXISTS private_booking_id;
+
+DROP TABLE IF EXISTS private_bookings;
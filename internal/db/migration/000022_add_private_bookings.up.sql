@@ -0,0 +1,87 @@
+-- a private booking takes the whole auditorium of a screening for a school or a company.
+-- it is requested by the organizer, quoted by the staff of the venue, accepted by paying the deposit of the quote
+-- and confirmed by a manager of the venue. the quote is net of the tax, the tax is the tax rate of the venue
+CREATE TABLE "private_bookings" (
+  "id" bigserial PRIMARY KEY,
+  "organizer" varchar NOT NULL,
+  "organization" varchar NOT NULL,
+  "contact_email" varchar NOT NULL,
+  "screening_id" bigint NOT NULL,
+  "venue_id" bigint NOT NULL,
+  "guests" int NOT NULL,
+  "notes" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'requested',
+  "net" bigint,
+  "tax" bigint,
+  "total" bigint,
+  "deposit" bigint,
+  "currency" varchar(3),
+  "payment_terms_days" int,
+  "quoted_by" varchar,
+  "quoted_at" timestamptz,
+  "quote_expires_at" timestamptz,
+  "deposit_reference" varchar,
+  "accepted_at" timestamptz,
+  "approved_by" varchar,
+  "confirmed_at" timestamptz,
+  "cancelled_at" timestamptz,
+  "cancel_reason" varchar NOT NULL DEFAULT '',
+  "deposit_refund_reference" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("guests" > 0),
+  CHECK ("status" IN ('requested', 'quoted', 'accepted', 'confirmed', 'rejected', 'cancelled')),
+  CHECK ("status" IN ('requested', 'rejected', 'cancelled') OR "quoted_at" IS NOT NULL),
+  CHECK ("total" = "net" + "tax" AND "deposit" >= 0 AND "deposit" <= "total"),
+  CHECK ("payment_terms_days" >= 0)
+);
+
+ALTER TABLE "private_bookings" ADD FOREIGN KEY ("organizer") REFERENCES "users" ("username");
+
+ALTER TABLE "private_bookings" ADD FOREIGN KEY ("screening_id") REFERENCES "screenings" ("id");
+
+ALTER TABLE "private_bookings" ADD FOREIGN KEY ("venue_id") REFERENCES "venues" ("id");
+
+ALTER TABLE "private_bookings" ADD FOREIGN KEY ("quoted_by") REFERENCES "users" ("username");
+
+ALTER TABLE "private_bookings" ADD FOREIGN KEY ("approved_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "private_bookings" ("venue_id", "status");
+
+CREATE INDEX ON "private_bookings" ("organizer");
+
+-- the deposit of a booking that is closed before it is confirmed is refunded after the booking is closed,
+-- the refund is pending until its reference is saved
+CREATE INDEX ON "private_bookings" ("id") WHERE "cancelled_at" IS NOT NULL AND "deposit_reference" IS NOT NULL
+  AND "confirmed_at" IS NULL AND "deposit_refund_reference" IS NULL;
+
+-- a screening of a confirmed private booking isn't on sale, all of its seats are blocked for the booking
+ALTER TABLE "screenings" ADD COLUMN "private_booking_id" bigint;
+
+ALTER TABLE "screenings" ADD FOREIGN KEY ("private_booking_id") REFERENCES "private_bookings" ("id");
+
+CREATE UNIQUE INDEX ON "private_bookings" ("screening_id") WHERE "status" = 'confirmed';
+
+-- the invoice of a confirmed booking is the total of the quote, the balance is the total without the paid deposit
+-- and it is due after the payment terms of the quote
+CREATE TABLE "private_booking_invoices" (
+  "id" bigserial PRIMARY KEY,
+  "booking_id" bigint UNIQUE NOT NULL,
+  "number" varchar UNIQUE NOT NULL,
+  "net" bigint NOT NULL,
+  "tax" bigint NOT NULL,
+  "total" bigint NOT NULL,
+  "deposit" bigint NOT NULL,
+  "balance" bigint NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "issued_at" timestamptz NOT NULL DEFAULT (now()),
+  "due_at" timestamptz NOT NULL,
+  "paid_at" timestamptz,
+  "payment_reference" varchar,
+  "voided_at" timestamptz,
+  CHECK ("total" = "net" + "tax" AND "balance" = "total" - "deposit" AND "balance" >= 0),
+  CHECK ("paid_at" IS NULL OR "voided_at" IS NULL)
+);
+
+ALTER TABLE "private_booking_invoices" ADD FOREIGN KEY ("booking_id") REFERENCES "private_bookings" ("id");
+
+CREATE INDEX ON "private_booking_invoices" ("due_at") WHERE "paid_at" IS NULL AND "voided_at" IS NULL;
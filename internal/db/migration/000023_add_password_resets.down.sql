@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS password_resets;
+
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_at;
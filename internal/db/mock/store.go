@@ -6,7 +6,9 @@ package mockdb
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
 	gomock "github.com/golang/mock/gomock"
@@ -35,195 +37,2788 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptPrivateBooking mocks base method.
+func (m *MockStore) AcceptPrivateBooking(arg0 context.Context, arg1 db.AcceptPrivateBookingParams) (db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptPrivateBooking", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptPrivateBooking indicates an expected call of AcceptPrivateBooking.
+func (mr *MockStoreMockRecorder) AcceptPrivateBooking(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptPrivateBooking", reflect.TypeOf((*MockStore)(nil).AcceptPrivateBooking), arg0, arg1)
+}
+
+// AddConcessionStock mocks base method.
+func (m *MockStore) AddConcessionStock(arg0 context.Context, arg1 db.AddConcessionStockParams) (db.ConcessionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddConcessionStock", arg0, arg1)
+	ret0, _ := ret[0].(db.ConcessionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddConcessionStock indicates an expected call of AddConcessionStock.
+func (mr *MockStoreMockRecorder) AddConcessionStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddConcessionStock", reflect.TypeOf((*MockStore)(nil).AddConcessionStock), arg0, arg1)
+}
+
+// AddMovieGenre mocks base method.
+func (m *MockStore) AddMovieGenre(arg0 context.Context, arg1 db.AddMovieGenreParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovieGenre", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMovieGenre indicates an expected call of AddMovieGenre.
+func (mr *MockStoreMockRecorder) AddMovieGenre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovieGenre", reflect.TypeOf((*MockStore)(nil).AddMovieGenre), arg0, arg1)
+}
+
+// AddWatchlistItem mocks base method.
+func (m *MockStore) AddWatchlistItem(arg0 context.Context, arg1 db.AddWatchlistItemParams) (db.WatchlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWatchlistItem", arg0, arg1)
+	ret0, _ := ret[0].(db.WatchlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWatchlistItem indicates an expected call of AddWatchlistItem.
+func (mr *MockStoreMockRecorder) AddWatchlistItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWatchlistItem", reflect.TypeOf((*MockStore)(nil).AddWatchlistItem), arg0, arg1)
+}
+
+// AutocompleteMovies mocks base method.
+func (m *MockStore) AutocompleteMovies(arg0 context.Context, arg1 db.AutocompleteMoviesParams) ([]db.AutocompleteMoviesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutocompleteMovies", arg0, arg1)
+	ret0, _ := ret[0].([]db.AutocompleteMoviesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutocompleteMovies indicates an expected call of AutocompleteMovies.
+func (mr *MockStoreMockRecorder) AutocompleteMovies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutocompleteMovies", reflect.TypeOf((*MockStore)(nil).AutocompleteMovies), arg0, arg1)
+}
+
+// BlockScreening mocks base method.
+func (m *MockStore) BlockScreening(arg0 context.Context, arg1 db.BlockScreeningParams) (db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockScreening", arg0, arg1)
+	ret0, _ := ret[0].(db.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockScreening indicates an expected call of BlockScreening.
+func (mr *MockStoreMockRecorder) BlockScreening(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockScreening", reflect.TypeOf((*MockStore)(nil).BlockScreening), arg0, arg1)
+}
+
+// CancelMembership mocks base method.
+func (m *MockStore) CancelMembership(arg0 context.Context, arg1 int64) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelMembership", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelMembership indicates an expected call of CancelMembership.
+func (mr *MockStoreMockRecorder) CancelMembership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelMembership", reflect.TypeOf((*MockStore)(nil).CancelMembership), arg0, arg1)
+}
+
+// CancelMembershipAtPeriodEnd mocks base method.
+func (m *MockStore) CancelMembershipAtPeriodEnd(arg0 context.Context, arg1 int64) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelMembershipAtPeriodEnd", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelMembershipAtPeriodEnd indicates an expected call of CancelMembershipAtPeriodEnd.
+func (mr *MockStoreMockRecorder) CancelMembershipAtPeriodEnd(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelMembershipAtPeriodEnd", reflect.TypeOf((*MockStore)(nil).CancelMembershipAtPeriodEnd), arg0, arg1)
+}
+
+// CancelMembershipTx mocks base method.
+func (m *MockStore) CancelMembershipTx(arg0 context.Context, arg1 db.CancelMembershipTxParams) (db.MembershipTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelMembershipTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelMembershipTx indicates an expected call of CancelMembershipTx.
+func (mr *MockStoreMockRecorder) CancelMembershipTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelMembershipTx", reflect.TypeOf((*MockStore)(nil).CancelMembershipTx), arg0, arg1)
+}
+
+// CancelPrivateBooking mocks base method.
+func (m *MockStore) CancelPrivateBooking(arg0 context.Context, arg1 db.CancelPrivateBookingParams) (db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPrivateBooking", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPrivateBooking indicates an expected call of CancelPrivateBooking.
+func (mr *MockStoreMockRecorder) CancelPrivateBooking(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPrivateBooking", reflect.TypeOf((*MockStore)(nil).CancelPrivateBooking), arg0, arg1)
+}
+
+// CancelPrivateBookingTx mocks base method.
+func (m *MockStore) CancelPrivateBookingTx(arg0 context.Context, arg1 db.CancelPrivateBookingParams) (db.PrivateBookingTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPrivateBookingTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBookingTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPrivateBookingTx indicates an expected call of CancelPrivateBookingTx.
+func (mr *MockStoreMockRecorder) CancelPrivateBookingTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPrivateBookingTx", reflect.TypeOf((*MockStore)(nil).CancelPrivateBookingTx), arg0, arg1)
+}
+
+// ChangeMembershipPlan mocks base method.
+func (m *MockStore) ChangeMembershipPlan(arg0 context.Context, arg1 db.ChangeMembershipPlanParams) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeMembershipPlan", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeMembershipPlan indicates an expected call of ChangeMembershipPlan.
+func (mr *MockStoreMockRecorder) ChangeMembershipPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMembershipPlan", reflect.TypeOf((*MockStore)(nil).ChangeMembershipPlan), arg0, arg1)
+}
+
+// ChangeMembershipPlanTx mocks base method.
+func (m *MockStore) ChangeMembershipPlanTx(arg0 context.Context, arg1 db.ChangeMembershipPlanTxParams) (db.MembershipTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeMembershipPlanTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeMembershipPlanTx indicates an expected call of ChangeMembershipPlanTx.
+func (mr *MockStoreMockRecorder) ChangeMembershipPlanTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMembershipPlanTx", reflect.TypeOf((*MockStore)(nil).ChangeMembershipPlanTx), arg0, arg1)
+}
+
+// CheckInTicket mocks base method.
+func (m *MockStore) CheckInTicket(arg0 context.Context, arg1 int64) (db.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckInTicket", arg0, arg1)
+	ret0, _ := ret[0].(db.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckInTicket indicates an expected call of CheckInTicket.
+func (mr *MockStoreMockRecorder) CheckInTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInTicket", reflect.TypeOf((*MockStore)(nil).CheckInTicket), arg0, arg1)
+}
+
+// CheckInTicketTx mocks base method.
+func (m *MockStore) CheckInTicketTx(arg0 context.Context, arg1 int64) (db.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckInTicketTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckInTicketTx indicates an expected call of CheckInTicketTx.
+func (mr *MockStoreMockRecorder) CheckInTicketTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInTicketTx", reflect.TypeOf((*MockStore)(nil).CheckInTicketTx), arg0, arg1)
+}
+
+// CloseCashShift mocks base method.
+func (m *MockStore) CloseCashShift(arg0 context.Context, arg1 db.CloseCashShiftParams) (db.CashShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseCashShift", arg0, arg1)
+	ret0, _ := ret[0].(db.CashShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseCashShift indicates an expected call of CloseCashShift.
+func (mr *MockStoreMockRecorder) CloseCashShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseCashShift", reflect.TypeOf((*MockStore)(nil).CloseCashShift), arg0, arg1)
+}
+
+// CompleteMembershipRefund mocks base method.
+func (m *MockStore) CompleteMembershipRefund(arg0 context.Context, arg1 db.CompleteMembershipRefundParams) (db.MembershipPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMembershipRefund", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMembershipRefund indicates an expected call of CompleteMembershipRefund.
+func (mr *MockStoreMockRecorder) CompleteMembershipRefund(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMembershipRefund", reflect.TypeOf((*MockStore)(nil).CompleteMembershipRefund), arg0, arg1)
+}
+
+// CompletePrivateBookingRefund mocks base method.
+func (m *MockStore) CompletePrivateBookingRefund(arg0 context.Context, arg1 db.CompletePrivateBookingRefundParams) (db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletePrivateBookingRefund", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompletePrivateBookingRefund indicates an expected call of CompletePrivateBookingRefund.
+func (mr *MockStoreMockRecorder) CompletePrivateBookingRefund(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletePrivateBookingRefund", reflect.TypeOf((*MockStore)(nil).CompletePrivateBookingRefund), arg0, arg1)
+}
+
+// ConfirmPrivateBooking mocks base method.
+func (m *MockStore) ConfirmPrivateBooking(arg0 context.Context, arg1 db.ConfirmPrivateBookingParams) (db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPrivateBooking", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPrivateBooking indicates an expected call of ConfirmPrivateBooking.
+func (mr *MockStoreMockRecorder) ConfirmPrivateBooking(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPrivateBooking", reflect.TypeOf((*MockStore)(nil).ConfirmPrivateBooking), arg0, arg1)
+}
+
+// ConfirmPrivateBookingTx mocks base method.
+func (m *MockStore) ConfirmPrivateBookingTx(arg0 context.Context, arg1 db.ConfirmPrivateBookingTxParams) (db.PrivateBookingTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPrivateBookingTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBookingTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPrivateBookingTx indicates an expected call of ConfirmPrivateBookingTx.
+func (mr *MockStoreMockRecorder) ConfirmPrivateBookingTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPrivateBookingTx", reflect.TypeOf((*MockStore)(nil).ConfirmPrivateBookingTx), arg0, arg1)
+}
+
+// ConsumeMembershipAllowance mocks base method.
+func (m *MockStore) ConsumeMembershipAllowance(arg0 context.Context, arg1 db.ConsumeMembershipAllowanceParams) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeMembershipAllowance", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeMembershipAllowance indicates an expected call of ConsumeMembershipAllowance.
+func (mr *MockStoreMockRecorder) ConsumeMembershipAllowance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeMembershipAllowance", reflect.TypeOf((*MockStore)(nil).ConsumeMembershipAllowance), arg0, arg1)
+}
+
+// CountMovies mocks base method.
+func (m *MockStore) CountMovies(arg0 context.Context, arg1 db.CountMoviesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMovies", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMovies indicates an expected call of CountMovies.
+func (mr *MockStoreMockRecorder) CountMovies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMovies", reflect.TypeOf((*MockStore)(nil).CountMovies), arg0, arg1)
+}
+
+// CountReviewReports mocks base method.
+func (m *MockStore) CountReviewReports(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReviewReports", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReviewReports indicates an expected call of CountReviewReports.
+func (mr *MockStoreMockRecorder) CountReviewReports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReviewReports", reflect.TypeOf((*MockStore)(nil).CountReviewReports), arg0, arg1)
+}
+
+// CountScreeningSeats mocks base method.
+func (m *MockStore) CountScreeningSeats(arg0 context.Context, arg1 sql.NullInt64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountScreeningSeats", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountScreeningSeats indicates an expected call of CountScreeningSeats.
+func (mr *MockStoreMockRecorder) CountScreeningSeats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountScreeningSeats", reflect.TypeOf((*MockStore)(nil).CountScreeningSeats), arg0, arg1)
+}
+
+// CreateAuditorium mocks base method.
+func (m *MockStore) CreateAuditorium(arg0 context.Context, arg1 db.CreateAuditoriumParams) (db.Auditorium, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditorium", arg0, arg1)
+	ret0, _ := ret[0].(db.Auditorium)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditorium indicates an expected call of CreateAuditorium.
+func (mr *MockStoreMockRecorder) CreateAuditorium(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditorium", reflect.TypeOf((*MockStore)(nil).CreateAuditorium), arg0, arg1)
+}
+
+// CreateAward mocks base method.
+func (m *MockStore) CreateAward(arg0 context.Context, arg1 db.CreateAwardParams) (db.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAward", arg0, arg1)
+	ret0, _ := ret[0].(db.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAward indicates an expected call of CreateAward.
+func (mr *MockStoreMockRecorder) CreateAward(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAward", reflect.TypeOf((*MockStore)(nil).CreateAward), arg0, arg1)
+}
+
+// CreateAwardTx mocks base method.
+func (m *MockStore) CreateAwardTx(arg0 context.Context, arg1 db.CreateAwardParams) (db.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAwardTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAwardTx indicates an expected call of CreateAwardTx.
+func (mr *MockStoreMockRecorder) CreateAwardTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAwardTx", reflect.TypeOf((*MockStore)(nil).CreateAwardTx), arg0, arg1)
+}
+
+// CreateConcessionItem mocks base method.
+func (m *MockStore) CreateConcessionItem(arg0 context.Context, arg1 db.CreateConcessionItemParams) (db.ConcessionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConcessionItem", arg0, arg1)
+	ret0, _ := ret[0].(db.ConcessionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConcessionItem indicates an expected call of CreateConcessionItem.
+func (mr *MockStoreMockRecorder) CreateConcessionItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConcessionItem", reflect.TypeOf((*MockStore)(nil).CreateConcessionItem), arg0, arg1)
+}
+
+// CreateConcessionOrder mocks base method.
+func (m *MockStore) CreateConcessionOrder(arg0 context.Context, arg1 db.CreateConcessionOrderParams) (db.ConcessionOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConcessionOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.ConcessionOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConcessionOrder indicates an expected call of CreateConcessionOrder.
+func (mr *MockStoreMockRecorder) CreateConcessionOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConcessionOrder", reflect.TypeOf((*MockStore)(nil).CreateConcessionOrder), arg0, arg1)
+}
+
+// CreateConcessionOrderItem mocks base method.
+func (m *MockStore) CreateConcessionOrderItem(arg0 context.Context, arg1 db.CreateConcessionOrderItemParams) (db.ConcessionOrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConcessionOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(db.ConcessionOrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConcessionOrderItem indicates an expected call of CreateConcessionOrderItem.
+func (mr *MockStoreMockRecorder) CreateConcessionOrderItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConcessionOrderItem", reflect.TypeOf((*MockStore)(nil).CreateConcessionOrderItem), arg0, arg1)
+}
+
 // CreateDirector mocks base method.
 func (m *MockStore) CreateDirector(arg0 context.Context, arg1 db.CreateDirectorParams) (db.Director, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDirector", arg0, arg1)
-	ret0, _ := ret[0].(db.Director)
+	ret := m.ctrl.Call(m, "CreateDirector", arg0, arg1)
+	ret0, _ := ret[0].(db.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDirector indicates an expected call of CreateDirector.
+func (mr *MockStoreMockRecorder) CreateDirector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirector", reflect.TypeOf((*MockStore)(nil).CreateDirector), arg0, arg1)
+}
+
+// CreateDirectorTx mocks base method.
+func (m *MockStore) CreateDirectorTx(arg0 context.Context, arg1 db.CreateDirectorTxParams) (db.Director, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDirectorTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDirectorTx indicates an expected call of CreateDirectorTx.
+func (mr *MockStoreMockRecorder) CreateDirectorTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirectorTx", reflect.TypeOf((*MockStore)(nil).CreateDirectorTx), arg0, arg1)
+}
+
+// CreateGenre mocks base method.
+func (m *MockStore) CreateGenre(arg0 context.Context, arg1 string) (db.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", arg0, arg1)
+	ret0, _ := ret[0].(db.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockStoreMockRecorder) CreateGenre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockStore)(nil).CreateGenre), arg0, arg1)
+}
+
+// CreateMembership mocks base method.
+func (m *MockStore) CreateMembership(arg0 context.Context, arg1 db.CreateMembershipParams) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMembership", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMembership indicates an expected call of CreateMembership.
+func (mr *MockStoreMockRecorder) CreateMembership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMembership", reflect.TypeOf((*MockStore)(nil).CreateMembership), arg0, arg1)
+}
+
+// CreateMembershipPayment mocks base method.
+func (m *MockStore) CreateMembershipPayment(arg0 context.Context, arg1 db.CreateMembershipPaymentParams) (db.MembershipPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMembershipPayment", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMembershipPayment indicates an expected call of CreateMembershipPayment.
+func (mr *MockStoreMockRecorder) CreateMembershipPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMembershipPayment", reflect.TypeOf((*MockStore)(nil).CreateMembershipPayment), arg0, arg1)
+}
+
+// CreateMembershipPlan mocks base method.
+func (m *MockStore) CreateMembershipPlan(arg0 context.Context, arg1 db.CreateMembershipPlanParams) (db.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMembershipPlan", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMembershipPlan indicates an expected call of CreateMembershipPlan.
+func (mr *MockStoreMockRecorder) CreateMembershipPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMembershipPlan", reflect.TypeOf((*MockStore)(nil).CreateMembershipPlan), arg0, arg1)
+}
+
+// CreateMembershipTx mocks base method.
+func (m *MockStore) CreateMembershipTx(arg0 context.Context, arg1 db.CreateMembershipTxParams) (db.MembershipTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMembershipTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMembershipTx indicates an expected call of CreateMembershipTx.
+func (mr *MockStoreMockRecorder) CreateMembershipTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMembershipTx", reflect.TypeOf((*MockStore)(nil).CreateMembershipTx), arg0, arg1)
+}
+
+// CreateModerationEvent mocks base method.
+func (m *MockStore) CreateModerationEvent(arg0 context.Context, arg1 db.CreateModerationEventParams) (db.ModerationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModerationEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModerationEvent indicates an expected call of CreateModerationEvent.
+func (mr *MockStoreMockRecorder) CreateModerationEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModerationEvent", reflect.TypeOf((*MockStore)(nil).CreateModerationEvent), arg0, arg1)
+}
+
+// CreateMovie mocks base method.
+func (m *MockStore) CreateMovie(arg0 context.Context, arg1 db.CreateMovieParams) (db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMovie", arg0, arg1)
+	ret0, _ := ret[0].(db.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMovie indicates an expected call of CreateMovie.
+func (mr *MockStoreMockRecorder) CreateMovie(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockStore)(nil).CreateMovie), arg0, arg1)
+}
+
+// CreateMovieCredit mocks base method.
+func (m *MockStore) CreateMovieCredit(arg0 context.Context, arg1 db.CreateMovieCreditParams) (db.MovieCredit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMovieCredit", arg0, arg1)
+	ret0, _ := ret[0].(db.MovieCredit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMovieCredit indicates an expected call of CreateMovieCredit.
+func (mr *MockStoreMockRecorder) CreateMovieCredit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovieCredit", reflect.TypeOf((*MockStore)(nil).CreateMovieCredit), arg0, arg1)
+}
+
+// CreateMovieTx mocks base method.
+func (m *MockStore) CreateMovieTx(arg0 context.Context, arg1 db.CreateMovieTxParams) (db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMovieTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMovieTx indicates an expected call of CreateMovieTx.
+func (mr *MockStoreMockRecorder) CreateMovieTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovieTx", reflect.TypeOf((*MockStore)(nil).CreateMovieTx), arg0, arg1)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockStore) CreatePasswordReset(arg0 context.Context, arg1 db.CreatePasswordResetParams) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockStoreMockRecorder) CreatePasswordReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockStore)(nil).CreatePasswordReset), arg0, arg1)
+}
+
+// CreatePerson mocks base method.
+func (m *MockStore) CreatePerson(arg0 context.Context, arg1 db.CreatePersonParams) (db.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePerson", arg0, arg1)
+	ret0, _ := ret[0].(db.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePerson indicates an expected call of CreatePerson.
+func (mr *MockStoreMockRecorder) CreatePerson(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePerson", reflect.TypeOf((*MockStore)(nil).CreatePerson), arg0, arg1)
+}
+
+// CreatePosSale mocks base method.
+func (m *MockStore) CreatePosSale(arg0 context.Context, arg1 db.CreatePosSaleParams) (db.PosSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePosSale", arg0, arg1)
+	ret0, _ := ret[0].(db.PosSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePosSale indicates an expected call of CreatePosSale.
+func (mr *MockStoreMockRecorder) CreatePosSale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePosSale", reflect.TypeOf((*MockStore)(nil).CreatePosSale), arg0, arg1)
+}
+
+// CreatePricingRule mocks base method.
+func (m *MockStore) CreatePricingRule(arg0 context.Context, arg1 db.CreatePricingRuleParams) (db.PricingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePricingRule", arg0, arg1)
+	ret0, _ := ret[0].(db.PricingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePricingRule indicates an expected call of CreatePricingRule.
+func (mr *MockStoreMockRecorder) CreatePricingRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePricingRule", reflect.TypeOf((*MockStore)(nil).CreatePricingRule), arg0, arg1)
+}
+
+// CreatePrivateBooking mocks base method.
+func (m *MockStore) CreatePrivateBooking(arg0 context.Context, arg1 db.CreatePrivateBookingParams) (db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePrivateBooking", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePrivateBooking indicates an expected call of CreatePrivateBooking.
+func (mr *MockStoreMockRecorder) CreatePrivateBooking(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePrivateBooking", reflect.TypeOf((*MockStore)(nil).CreatePrivateBooking), arg0, arg1)
+}
+
+// CreatePrivateBookingInvoice mocks base method.
+func (m *MockStore) CreatePrivateBookingInvoice(arg0 context.Context, arg1 db.CreatePrivateBookingInvoiceParams) (db.PrivateBookingInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePrivateBookingInvoice", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBookingInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePrivateBookingInvoice indicates an expected call of CreatePrivateBookingInvoice.
+func (mr *MockStoreMockRecorder) CreatePrivateBookingInvoice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePrivateBookingInvoice", reflect.TypeOf((*MockStore)(nil).CreatePrivateBookingInvoice), arg0, arg1)
+}
+
+// CreateReview mocks base method.
+func (m *MockStore) CreateReview(arg0 context.Context, arg1 db.CreateReviewParams) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockStoreMockRecorder) CreateReview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockStore)(nil).CreateReview), arg0, arg1)
+}
+
+// CreateReviewReport mocks base method.
+func (m *MockStore) CreateReviewReport(arg0 context.Context, arg1 db.CreateReviewReportParams) (db.ReviewReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReviewReport", arg0, arg1)
+	ret0, _ := ret[0].(db.ReviewReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReviewReport indicates an expected call of CreateReviewReport.
+func (mr *MockStoreMockRecorder) CreateReviewReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReviewReport", reflect.TypeOf((*MockStore)(nil).CreateReviewReport), arg0, arg1)
+}
+
+// CreateReviewTx mocks base method.
+func (m *MockStore) CreateReviewTx(arg0 context.Context, arg1 db.CreateReviewTxParams) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReviewTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReviewTx indicates an expected call of CreateReviewTx.
+func (mr *MockStoreMockRecorder) CreateReviewTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReviewTx", reflect.TypeOf((*MockStore)(nil).CreateReviewTx), arg0, arg1)
+}
+
+// CreateScreening mocks base method.
+func (m *MockStore) CreateScreening(arg0 context.Context, arg1 db.CreateScreeningParams) (db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScreening", arg0, arg1)
+	ret0, _ := ret[0].(db.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScreening indicates an expected call of CreateScreening.
+func (mr *MockStoreMockRecorder) CreateScreening(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScreening", reflect.TypeOf((*MockStore)(nil).CreateScreening), arg0, arg1)
+}
+
+// CreateTicket mocks base method.
+func (m *MockStore) CreateTicket(arg0 context.Context, arg1 db.CreateTicketParams) (db.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTicket", arg0, arg1)
+	ret0, _ := ret[0].(db.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTicket indicates an expected call of CreateTicket.
+func (mr *MockStoreMockRecorder) CreateTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicket", reflect.TypeOf((*MockStore)(nil).CreateTicket), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateUserRecommendation mocks base method.
+func (m *MockStore) CreateUserRecommendation(arg0 context.Context, arg1 db.CreateUserRecommendationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserRecommendation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserRecommendation indicates an expected call of CreateUserRecommendation.
+func (mr *MockStoreMockRecorder) CreateUserRecommendation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserRecommendation", reflect.TypeOf((*MockStore)(nil).CreateUserRecommendation), arg0, arg1)
+}
+
+// CreateVenue mocks base method.
+func (m *MockStore) CreateVenue(arg0 context.Context, arg1 db.CreateVenueParams) (db.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVenue", arg0, arg1)
+	ret0, _ := ret[0].(db.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVenue indicates an expected call of CreateVenue.
+func (mr *MockStoreMockRecorder) CreateVenue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVenue", reflect.TypeOf((*MockStore)(nil).CreateVenue), arg0, arg1)
+}
+
+// CreateVenueHours mocks base method.
+func (m *MockStore) CreateVenueHours(arg0 context.Context, arg1 db.CreateVenueHoursParams) (db.VenueHour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVenueHours", arg0, arg1)
+	ret0, _ := ret[0].(db.VenueHour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVenueHours indicates an expected call of CreateVenueHours.
+func (mr *MockStoreMockRecorder) CreateVenueHours(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVenueHours", reflect.TypeOf((*MockStore)(nil).CreateVenueHours), arg0, arg1)
+}
+
+// CreateVenueTx mocks base method.
+func (m *MockStore) CreateVenueTx(arg0 context.Context, arg1 db.CreateVenueTxParams) (db.CreateVenueTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVenueTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateVenueTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVenueTx indicates an expected call of CreateVenueTx.
+func (mr *MockStoreMockRecorder) CreateVenueTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVenueTx", reflect.TypeOf((*MockStore)(nil).CreateVenueTx), arg0, arg1)
+}
+
+// DecrementConcessionStock mocks base method.
+func (m *MockStore) DecrementConcessionStock(arg0 context.Context, arg1 db.DecrementConcessionStockParams) (db.ConcessionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementConcessionStock", arg0, arg1)
+	ret0, _ := ret[0].(db.ConcessionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecrementConcessionStock indicates an expected call of DecrementConcessionStock.
+func (mr *MockStoreMockRecorder) DecrementConcessionStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementConcessionStock", reflect.TypeOf((*MockStore)(nil).DecrementConcessionStock), arg0, arg1)
+}
+
+// DeleteAward mocks base method.
+func (m *MockStore) DeleteAward(arg0 context.Context, arg1 int64) (db.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAward", arg0, arg1)
+	ret0, _ := ret[0].(db.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAward indicates an expected call of DeleteAward.
+func (mr *MockStoreMockRecorder) DeleteAward(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAward", reflect.TypeOf((*MockStore)(nil).DeleteAward), arg0, arg1)
+}
+
+// DeleteAwardTx mocks base method.
+func (m *MockStore) DeleteAwardTx(arg0 context.Context, arg1 int64) (db.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAwardTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAwardTx indicates an expected call of DeleteAwardTx.
+func (mr *MockStoreMockRecorder) DeleteAwardTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAwardTx", reflect.TypeOf((*MockStore)(nil).DeleteAwardTx), arg0, arg1)
+}
+
+// DeleteDirector mocks base method.
+func (m *MockStore) DeleteDirector(arg0 context.Context, arg1 int64) (db.Director, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDirector", arg0, arg1)
+	ret0, _ := ret[0].(db.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDirector indicates an expected call of DeleteDirector.
+func (mr *MockStoreMockRecorder) DeleteDirector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDirector", reflect.TypeOf((*MockStore)(nil).DeleteDirector), arg0, arg1)
+}
+
+// DeleteMovie mocks base method.
+func (m *MockStore) DeleteMovie(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovie", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMovie indicates an expected call of DeleteMovie.
+func (mr *MockStoreMockRecorder) DeleteMovie(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockStore)(nil).DeleteMovie), arg0, arg1)
+}
+
+// DeleteMovieCredit mocks base method.
+func (m *MockStore) DeleteMovieCredit(arg0 context.Context, arg1 db.DeleteMovieCreditParams) (db.MovieCredit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovieCredit", arg0, arg1)
+	ret0, _ := ret[0].(db.MovieCredit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMovieCredit indicates an expected call of DeleteMovieCredit.
+func (mr *MockStoreMockRecorder) DeleteMovieCredit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovieCredit", reflect.TypeOf((*MockStore)(nil).DeleteMovieCredit), arg0, arg1)
+}
+
+// DeleteMovieGenres mocks base method.
+func (m *MockStore) DeleteMovieGenres(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovieGenres", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMovieGenres indicates an expected call of DeleteMovieGenres.
+func (mr *MockStoreMockRecorder) DeleteMovieGenres(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovieGenres", reflect.TypeOf((*MockStore)(nil).DeleteMovieGenres), arg0, arg1)
+}
+
+// DeletePricingRule mocks base method.
+func (m *MockStore) DeletePricingRule(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePricingRule", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePricingRule indicates an expected call of DeletePricingRule.
+func (mr *MockStoreMockRecorder) DeletePricingRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePricingRule", reflect.TypeOf((*MockStore)(nil).DeletePricingRule), arg0, arg1)
+}
+
+// DeleteReview mocks base method.
+func (m *MockStore) DeleteReview(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockStoreMockRecorder) DeleteReview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockStore)(nil).DeleteReview), arg0, arg1)
+}
+
+// DeleteTicket mocks base method.
+func (m *MockStore) DeleteTicket(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTicket", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTicket indicates an expected call of DeleteTicket.
+func (mr *MockStoreMockRecorder) DeleteTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTicket", reflect.TypeOf((*MockStore)(nil).DeleteTicket), arg0, arg1)
+}
+
+// DeleteTicketTx mocks base method.
+func (m *MockStore) DeleteTicketTx(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTicketTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTicketTx indicates an expected call of DeleteTicketTx.
+func (mr *MockStoreMockRecorder) DeleteTicketTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTicketTx", reflect.TypeOf((*MockStore)(nil).DeleteTicketTx), arg0, arg1)
+}
+
+// DeleteUserRecommendations mocks base method.
+func (m *MockStore) DeleteUserRecommendations(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRecommendations", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRecommendations indicates an expected call of DeleteUserRecommendations.
+func (mr *MockStoreMockRecorder) DeleteUserRecommendations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRecommendations", reflect.TypeOf((*MockStore)(nil).DeleteUserRecommendations), arg0, arg1)
+}
+
+// DeleteVenueStaff mocks base method.
+func (m *MockStore) DeleteVenueStaff(arg0 context.Context, arg1 db.DeleteVenueStaffParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVenueStaff", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVenueStaff indicates an expected call of DeleteVenueStaff.
+func (mr *MockStoreMockRecorder) DeleteVenueStaff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVenueStaff", reflect.TypeOf((*MockStore)(nil).DeleteVenueStaff), arg0, arg1)
+}
+
+// GetAuditorium mocks base method.
+func (m *MockStore) GetAuditorium(arg0 context.Context, arg1 int64) (db.Auditorium, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditorium", arg0, arg1)
+	ret0, _ := ret[0].(db.Auditorium)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditorium indicates an expected call of GetAuditorium.
+func (mr *MockStoreMockRecorder) GetAuditorium(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditorium", reflect.TypeOf((*MockStore)(nil).GetAuditorium), arg0, arg1)
+}
+
+// GetAward mocks base method.
+func (m *MockStore) GetAward(arg0 context.Context, arg1 int64) (db.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAward", arg0, arg1)
+	ret0, _ := ret[0].(db.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAward indicates an expected call of GetAward.
+func (mr *MockStoreMockRecorder) GetAward(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAward", reflect.TypeOf((*MockStore)(nil).GetAward), arg0, arg1)
+}
+
+// GetCashShift mocks base method.
+func (m *MockStore) GetCashShift(arg0 context.Context, arg1 int64) (db.CashShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashShift", arg0, arg1)
+	ret0, _ := ret[0].(db.CashShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashShift indicates an expected call of GetCashShift.
+func (mr *MockStoreMockRecorder) GetCashShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashShift", reflect.TypeOf((*MockStore)(nil).GetCashShift), arg0, arg1)
+}
+
+// GetConcessionItem mocks base method.
+func (m *MockStore) GetConcessionItem(arg0 context.Context, arg1 int64) (db.ConcessionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConcessionItem", arg0, arg1)
+	ret0, _ := ret[0].(db.ConcessionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConcessionItem indicates an expected call of GetConcessionItem.
+func (mr *MockStoreMockRecorder) GetConcessionItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConcessionItem", reflect.TypeOf((*MockStore)(nil).GetConcessionItem), arg0, arg1)
+}
+
+// GetDirector mocks base method.
+func (m *MockStore) GetDirector(arg0 context.Context, arg1 int64) (db.Director, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirector", arg0, arg1)
+	ret0, _ := ret[0].(db.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirector indicates an expected call of GetDirector.
+func (mr *MockStoreMockRecorder) GetDirector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirector", reflect.TypeOf((*MockStore)(nil).GetDirector), arg0, arg1)
+}
+
+// GetLiveMembership mocks base method.
+func (m *MockStore) GetLiveMembership(arg0 context.Context, arg1 string) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiveMembership", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveMembership indicates an expected call of GetLiveMembership.
+func (mr *MockStoreMockRecorder) GetLiveMembership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveMembership", reflect.TypeOf((*MockStore)(nil).GetLiveMembership), arg0, arg1)
+}
+
+// GetMembership mocks base method.
+func (m *MockStore) GetMembership(arg0 context.Context, arg1 int64) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembership", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembership indicates an expected call of GetMembership.
+func (mr *MockStoreMockRecorder) GetMembership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockStore)(nil).GetMembership), arg0, arg1)
+}
+
+// GetMembershipPayment mocks base method.
+func (m *MockStore) GetMembershipPayment(arg0 context.Context, arg1 int64) (db.MembershipPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipPayment", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipPayment indicates an expected call of GetMembershipPayment.
+func (mr *MockStoreMockRecorder) GetMembershipPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipPayment", reflect.TypeOf((*MockStore)(nil).GetMembershipPayment), arg0, arg1)
+}
+
+// GetMembershipPlan mocks base method.
+func (m *MockStore) GetMembershipPlan(arg0 context.Context, arg1 int64) (db.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipPlan", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipPlan indicates an expected call of GetMembershipPlan.
+func (mr *MockStoreMockRecorder) GetMembershipPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipPlan", reflect.TypeOf((*MockStore)(nil).GetMembershipPlan), arg0, arg1)
+}
+
+// GetMovie mocks base method.
+func (m *MockStore) GetMovie(arg0 context.Context, arg1 int64) (db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovie", arg0, arg1)
+	ret0, _ := ret[0].(db.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovie indicates an expected call of GetMovie.
+func (mr *MockStoreMockRecorder) GetMovie(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovie", reflect.TypeOf((*MockStore)(nil).GetMovie), arg0, arg1)
+}
+
+// GetMovieReviewStats mocks base method.
+func (m *MockStore) GetMovieReviewStats(arg0 context.Context, arg1 int64) (db.GetMovieReviewStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieReviewStats", arg0, arg1)
+	ret0, _ := ret[0].(db.GetMovieReviewStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieReviewStats indicates an expected call of GetMovieReviewStats.
+func (mr *MockStoreMockRecorder) GetMovieReviewStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieReviewStats", reflect.TypeOf((*MockStore)(nil).GetMovieReviewStats), arg0, arg1)
+}
+
+// GetOpenCashShift mocks base method.
+func (m *MockStore) GetOpenCashShift(arg0 context.Context, arg1 string) (db.CashShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenCashShift", arg0, arg1)
+	ret0, _ := ret[0].(db.CashShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenCashShift indicates an expected call of GetOpenCashShift.
+func (mr *MockStoreMockRecorder) GetOpenCashShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenCashShift", reflect.TypeOf((*MockStore)(nil).GetOpenCashShift), arg0, arg1)
+}
+
+// GetPasswordChangedAt mocks base method.
+func (m *MockStore) GetPasswordChangedAt(arg0 context.Context, arg1 string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordChangedAt", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordChangedAt indicates an expected call of GetPasswordChangedAt.
+func (mr *MockStoreMockRecorder) GetPasswordChangedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordChangedAt", reflect.TypeOf((*MockStore)(nil).GetPasswordChangedAt), arg0, arg1)
+}
+
+// GetPerson mocks base method.
+func (m *MockStore) GetPerson(arg0 context.Context, arg1 int64) (db.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerson", arg0, arg1)
+	ret0, _ := ret[0].(db.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPerson indicates an expected call of GetPerson.
+func (mr *MockStoreMockRecorder) GetPerson(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerson", reflect.TypeOf((*MockStore)(nil).GetPerson), arg0, arg1)
+}
+
+// GetPosSaleByCode mocks base method.
+func (m *MockStore) GetPosSaleByCode(arg0 context.Context, arg1 string) (db.PosSale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosSaleByCode", arg0, arg1)
+	ret0, _ := ret[0].(db.PosSale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPosSaleByCode indicates an expected call of GetPosSaleByCode.
+func (mr *MockStoreMockRecorder) GetPosSaleByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosSaleByCode", reflect.TypeOf((*MockStore)(nil).GetPosSaleByCode), arg0, arg1)
+}
+
+// GetPrivateBooking mocks base method.
+func (m *MockStore) GetPrivateBooking(arg0 context.Context, arg1 int64) (db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateBooking", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateBooking indicates an expected call of GetPrivateBooking.
+func (mr *MockStoreMockRecorder) GetPrivateBooking(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateBooking", reflect.TypeOf((*MockStore)(nil).GetPrivateBooking), arg0, arg1)
+}
+
+// GetPrivateBookingInvoice mocks base method.
+func (m *MockStore) GetPrivateBookingInvoice(arg0 context.Context, arg1 int64) (db.PrivateBookingInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateBookingInvoice", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBookingInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateBookingInvoice indicates an expected call of GetPrivateBookingInvoice.
+func (mr *MockStoreMockRecorder) GetPrivateBookingInvoice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateBookingInvoice", reflect.TypeOf((*MockStore)(nil).GetPrivateBookingInvoice), arg0, arg1)
+}
+
+// GetReview mocks base method.
+func (m *MockStore) GetReview(arg0 context.Context, arg1 int64) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockStoreMockRecorder) GetReview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockStore)(nil).GetReview), arg0, arg1)
+}
+
+// GetReviewForUpdate mocks base method.
+func (m *MockStore) GetReviewForUpdate(arg0 context.Context, arg1 int64) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewForUpdate indicates an expected call of GetReviewForUpdate.
+func (mr *MockStoreMockRecorder) GetReviewForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewForUpdate", reflect.TypeOf((*MockStore)(nil).GetReviewForUpdate), arg0, arg1)
+}
+
+// GetScreening mocks base method.
+func (m *MockStore) GetScreening(arg0 context.Context, arg1 int64) (db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreening", arg0, arg1)
+	ret0, _ := ret[0].(db.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreening indicates an expected call of GetScreening.
+func (mr *MockStoreMockRecorder) GetScreening(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreening", reflect.TypeOf((*MockStore)(nil).GetScreening), arg0, arg1)
+}
+
+// GetScreeningForSale mocks base method.
+func (m *MockStore) GetScreeningForSale(arg0 context.Context, arg1 int64) (db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreeningForSale", arg0, arg1)
+	ret0, _ := ret[0].(db.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreeningForSale indicates an expected call of GetScreeningForSale.
+func (mr *MockStoreMockRecorder) GetScreeningForSale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreeningForSale", reflect.TypeOf((*MockStore)(nil).GetScreeningForSale), arg0, arg1)
+}
+
+// GetScreeningFormat mocks base method.
+func (m *MockStore) GetScreeningFormat(arg0 context.Context, arg1 string) (db.ScreeningFormat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreeningFormat", arg0, arg1)
+	ret0, _ := ret[0].(db.ScreeningFormat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreeningFormat indicates an expected call of GetScreeningFormat.
+func (mr *MockStoreMockRecorder) GetScreeningFormat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreeningFormat", reflect.TypeOf((*MockStore)(nil).GetScreeningFormat), arg0, arg1)
+}
+
+// GetTicket mocks base method.
+func (m *MockStore) GetTicket(arg0 context.Context, arg1 int64) (db.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicket", arg0, arg1)
+	ret0, _ := ret[0].(db.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicket indicates an expected call of GetTicket.
+func (mr *MockStoreMockRecorder) GetTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicket", reflect.TypeOf((*MockStore)(nil).GetTicket), arg0, arg1)
+}
+
+// GetTicketForUpdate mocks base method.
+func (m *MockStore) GetTicketForUpdate(arg0 context.Context, arg1 int64) (db.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicketForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicketForUpdate indicates an expected call of GetTicketForUpdate.
+func (mr *MockStoreMockRecorder) GetTicketForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicketForUpdate", reflect.TypeOf((*MockStore)(nil).GetTicketForUpdate), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetVenue mocks base method.
+func (m *MockStore) GetVenue(arg0 context.Context, arg1 int64) (db.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVenue", arg0, arg1)
+	ret0, _ := ret[0].(db.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVenue indicates an expected call of GetVenue.
+func (mr *MockStoreMockRecorder) GetVenue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVenue", reflect.TypeOf((*MockStore)(nil).GetVenue), arg0, arg1)
+}
+
+// GetVenueStaff mocks base method.
+func (m *MockStore) GetVenueStaff(arg0 context.Context, arg1 db.GetVenueStaffParams) (db.VenueStaff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVenueStaff", arg0, arg1)
+	ret0, _ := ret[0].(db.VenueStaff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVenueStaff indicates an expected call of GetVenueStaff.
+func (mr *MockStoreMockRecorder) GetVenueStaff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVenueStaff", reflect.TypeOf((*MockStore)(nil).GetVenueStaff), arg0, arg1)
+}
+
+// ListAwardsByYear mocks base method.
+func (m *MockStore) ListAwardsByYear(arg0 context.Context, arg1 db.ListAwardsByYearParams) ([]db.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAwardsByYear", arg0, arg1)
+	ret0, _ := ret[0].([]db.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAwardsByYear indicates an expected call of ListAwardsByYear.
+func (mr *MockStoreMockRecorder) ListAwardsByYear(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAwardsByYear", reflect.TypeOf((*MockStore)(nil).ListAwardsByYear), arg0, arg1)
+}
+
+// ListBoxOffice mocks base method.
+func (m *MockStore) ListBoxOffice(arg0 context.Context, arg1 db.ListBoxOfficeParams) ([]db.ListBoxOfficeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBoxOffice", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListBoxOfficeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBoxOffice indicates an expected call of ListBoxOffice.
+func (mr *MockStoreMockRecorder) ListBoxOffice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBoxOffice", reflect.TypeOf((*MockStore)(nil).ListBoxOffice), arg0, arg1)
+}
+
+// ListBuyers mocks base method.
+func (m *MockStore) ListBuyers(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBuyers", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBuyers indicates an expected call of ListBuyers.
+func (mr *MockStoreMockRecorder) ListBuyers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBuyers", reflect.TypeOf((*MockStore)(nil).ListBuyers), arg0)
+}
+
+// ListCoPurchases mocks base method.
+func (m *MockStore) ListCoPurchases(arg0 context.Context, arg1 string) ([]db.ListCoPurchasesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoPurchases", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCoPurchasesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoPurchases indicates an expected call of ListCoPurchases.
+func (mr *MockStoreMockRecorder) ListCoPurchases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoPurchases", reflect.TypeOf((*MockStore)(nil).ListCoPurchases), arg0, arg1)
+}
+
+// ListConcessionItems mocks base method.
+func (m *MockStore) ListConcessionItems(arg0 context.Context) ([]db.ConcessionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConcessionItems", arg0)
+	ret0, _ := ret[0].([]db.ConcessionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConcessionItems indicates an expected call of ListConcessionItems.
+func (mr *MockStoreMockRecorder) ListConcessionItems(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConcessionItems", reflect.TypeOf((*MockStore)(nil).ListConcessionItems), arg0)
+}
+
+// ListConcessionOrderItems mocks base method.
+func (m *MockStore) ListConcessionOrderItems(arg0 context.Context, arg1 int64) ([]db.ConcessionOrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConcessionOrderItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.ConcessionOrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConcessionOrderItems indicates an expected call of ListConcessionOrderItems.
+func (mr *MockStoreMockRecorder) ListConcessionOrderItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConcessionOrderItems", reflect.TypeOf((*MockStore)(nil).ListConcessionOrderItems), arg0, arg1)
+}
+
+// ListDailySales mocks base method.
+func (m *MockStore) ListDailySales(arg0 context.Context, arg1 db.ListDailySalesParams) ([]db.ListDailySalesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDailySales", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListDailySalesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDailySales indicates an expected call of ListDailySales.
+func (mr *MockStoreMockRecorder) ListDailySales(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDailySales", reflect.TypeOf((*MockStore)(nil).ListDailySales), arg0, arg1)
+}
+
+// ListDirectors mocks base method.
+func (m *MockStore) ListDirectors(arg0 context.Context, arg1 db.ListDirectorsParams) ([]db.Director, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDirectors", arg0, arg1)
+	ret0, _ := ret[0].([]db.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDirectors indicates an expected call of ListDirectors.
+func (mr *MockStoreMockRecorder) ListDirectors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDirectors", reflect.TypeOf((*MockStore)(nil).ListDirectors), arg0, arg1)
+}
+
+// ListDirectorsByIDs mocks base method.
+func (m *MockStore) ListDirectorsByIDs(arg0 context.Context, arg1 []int64) ([]db.Director, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDirectorsByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDirectorsByIDs indicates an expected call of ListDirectorsByIDs.
+func (mr *MockStoreMockRecorder) ListDirectorsByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDirectorsByIDs", reflect.TypeOf((*MockStore)(nil).ListDirectorsByIDs), arg0, arg1)
+}
+
+// ListDueMemberships mocks base method.
+func (m *MockStore) ListDueMemberships(arg0 context.Context, arg1 db.ListDueMembershipsParams) ([]db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueMemberships", arg0, arg1)
+	ret0, _ := ret[0].([]db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueMemberships indicates an expected call of ListDueMemberships.
+func (mr *MockStoreMockRecorder) ListDueMemberships(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueMemberships", reflect.TypeOf((*MockStore)(nil).ListDueMemberships), arg0, arg1)
+}
+
+// ListGenres mocks base method.
+func (m *MockStore) ListGenres(arg0 context.Context) ([]db.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGenres", arg0)
+	ret0, _ := ret[0].([]db.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGenres indicates an expected call of ListGenres.
+func (mr *MockStoreMockRecorder) ListGenres(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGenres", reflect.TypeOf((*MockStore)(nil).ListGenres), arg0)
+}
+
+// ListGenresOfMovies mocks base method.
+func (m *MockStore) ListGenresOfMovies(arg0 context.Context, arg1 []int64) ([]db.MovieGenre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGenresOfMovies", arg0, arg1)
+	ret0, _ := ret[0].([]db.MovieGenre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGenresOfMovies indicates an expected call of ListGenresOfMovies.
+func (mr *MockStoreMockRecorder) ListGenresOfMovies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGenresOfMovies", reflect.TypeOf((*MockStore)(nil).ListGenresOfMovies), arg0, arg1)
+}
+
+// ListMembershipPlans mocks base method.
+func (m *MockStore) ListMembershipPlans(arg0 context.Context) ([]db.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembershipPlans", arg0)
+	ret0, _ := ret[0].([]db.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembershipPlans indicates an expected call of ListMembershipPlans.
+func (mr *MockStoreMockRecorder) ListMembershipPlans(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembershipPlans", reflect.TypeOf((*MockStore)(nil).ListMembershipPlans), arg0)
+}
+
+// ListModerationEvents mocks base method.
+func (m *MockStore) ListModerationEvents(arg0 context.Context, arg1 int64) ([]db.ModerationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.ModerationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationEvents indicates an expected call of ListModerationEvents.
+func (mr *MockStoreMockRecorder) ListModerationEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationEvents", reflect.TypeOf((*MockStore)(nil).ListModerationEvents), arg0, arg1)
+}
+
+// ListMovieCredits mocks base method.
+func (m *MockStore) ListMovieCredits(arg0 context.Context, arg1 int64) ([]db.ListMovieCreditsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovieCredits", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMovieCreditsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovieCredits indicates an expected call of ListMovieCredits.
+func (mr *MockStoreMockRecorder) ListMovieCredits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovieCredits", reflect.TypeOf((*MockStore)(nil).ListMovieCredits), arg0, arg1)
+}
+
+// ListMovieGenres mocks base method.
+func (m *MockStore) ListMovieGenres(arg0 context.Context, arg1 int64) ([]db.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovieGenres", arg0, arg1)
+	ret0, _ := ret[0].([]db.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovieGenres indicates an expected call of ListMovieGenres.
+func (mr *MockStoreMockRecorder) ListMovieGenres(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovieGenres", reflect.TypeOf((*MockStore)(nil).ListMovieGenres), arg0, arg1)
+}
+
+// ListMovieReviews mocks base method.
+func (m *MockStore) ListMovieReviews(arg0 context.Context, arg1 db.ListMovieReviewsParams) ([]db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovieReviews", arg0, arg1)
+	ret0, _ := ret[0].([]db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovieReviews indicates an expected call of ListMovieReviews.
+func (mr *MockStoreMockRecorder) ListMovieReviews(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovieReviews", reflect.TypeOf((*MockStore)(nil).ListMovieReviews), arg0, arg1)
+}
+
+// ListMovieScreenings mocks base method.
+func (m *MockStore) ListMovieScreenings(arg0 context.Context, arg1 db.ListMovieScreeningsParams) ([]db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovieScreenings", arg0, arg1)
+	ret0, _ := ret[0].([]db.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovieScreenings indicates an expected call of ListMovieScreenings.
+func (mr *MockStoreMockRecorder) ListMovieScreenings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovieScreenings", reflect.TypeOf((*MockStore)(nil).ListMovieScreenings), arg0, arg1)
+}
+
+// ListMovies mocks base method.
+func (m *MockStore) ListMovies(arg0 context.Context, arg1 db.ListMoviesParams) ([]db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovies", arg0, arg1)
+	ret0, _ := ret[0].([]db.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovies indicates an expected call of ListMovies.
+func (mr *MockStoreMockRecorder) ListMovies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovies", reflect.TypeOf((*MockStore)(nil).ListMovies), arg0, arg1)
+}
+
+// ListMoviesByDirector mocks base method.
+func (m *MockStore) ListMoviesByDirector(arg0 context.Context, arg1 db.ListMoviesByDirectorParams) ([]db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMoviesByDirector", arg0, arg1)
+	ret0, _ := ret[0].([]db.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMoviesByDirector indicates an expected call of ListMoviesByDirector.
+func (mr *MockStoreMockRecorder) ListMoviesByDirector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMoviesByDirector", reflect.TypeOf((*MockStore)(nil).ListMoviesByDirector), arg0, arg1)
+}
+
+// ListMoviesByIDs mocks base method.
+func (m *MockStore) ListMoviesByIDs(arg0 context.Context, arg1 []int64) ([]db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMoviesByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMoviesByIDs indicates an expected call of ListMoviesByIDs.
+func (mr *MockStoreMockRecorder) ListMoviesByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMoviesByIDs", reflect.TypeOf((*MockStore)(nil).ListMoviesByIDs), arg0, arg1)
+}
+
+// ListNowShowingMovies mocks base method.
+func (m *MockStore) ListNowShowingMovies(arg0 context.Context) ([]db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNowShowingMovies", arg0)
+	ret0, _ := ret[0].([]db.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNowShowingMovies indicates an expected call of ListNowShowingMovies.
+func (mr *MockStoreMockRecorder) ListNowShowingMovies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNowShowingMovies", reflect.TypeOf((*MockStore)(nil).ListNowShowingMovies), arg0)
+}
+
+// ListOrganizerPrivateBookings mocks base method.
+func (m *MockStore) ListOrganizerPrivateBookings(arg0 context.Context, arg1 db.ListOrganizerPrivateBookingsParams) ([]db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizerPrivateBookings", arg0, arg1)
+	ret0, _ := ret[0].([]db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizerPrivateBookings indicates an expected call of ListOrganizerPrivateBookings.
+func (mr *MockStoreMockRecorder) ListOrganizerPrivateBookings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizerPrivateBookings", reflect.TypeOf((*MockStore)(nil).ListOrganizerPrivateBookings), arg0, arg1)
+}
+
+// ListOverlappingScreenings mocks base method.
+func (m *MockStore) ListOverlappingScreenings(arg0 context.Context, arg1 db.ListOverlappingScreeningsParams) ([]db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverlappingScreenings", arg0, arg1)
+	ret0, _ := ret[0].([]db.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverlappingScreenings indicates an expected call of ListOverlappingScreenings.
+func (mr *MockStoreMockRecorder) ListOverlappingScreenings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverlappingScreenings", reflect.TypeOf((*MockStore)(nil).ListOverlappingScreenings), arg0, arg1)
+}
+
+// ListPendingMembershipRefunds mocks base method.
+func (m *MockStore) ListPendingMembershipRefunds(arg0 context.Context, arg1 int32) ([]db.MembershipPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingMembershipRefunds", arg0, arg1)
+	ret0, _ := ret[0].([]db.MembershipPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingMembershipRefunds indicates an expected call of ListPendingMembershipRefunds.
+func (mr *MockStoreMockRecorder) ListPendingMembershipRefunds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingMembershipRefunds", reflect.TypeOf((*MockStore)(nil).ListPendingMembershipRefunds), arg0, arg1)
+}
+
+// ListPendingPrivateBookingRefunds mocks base method.
+func (m *MockStore) ListPendingPrivateBookingRefunds(arg0 context.Context, arg1 int32) ([]db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingPrivateBookingRefunds", arg0, arg1)
+	ret0, _ := ret[0].([]db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingPrivateBookingRefunds indicates an expected call of ListPendingPrivateBookingRefunds.
+func (mr *MockStoreMockRecorder) ListPendingPrivateBookingRefunds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingPrivateBookingRefunds", reflect.TypeOf((*MockStore)(nil).ListPendingPrivateBookingRefunds), arg0, arg1)
+}
+
+// ListPricingRules mocks base method.
+func (m *MockStore) ListPricingRules(arg0 context.Context, arg1 db.ListPricingRulesParams) ([]db.PricingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPricingRules", arg0, arg1)
+	ret0, _ := ret[0].([]db.PricingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPricingRules indicates an expected call of ListPricingRules.
+func (mr *MockStoreMockRecorder) ListPricingRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPricingRules", reflect.TypeOf((*MockStore)(nil).ListPricingRules), arg0, arg1)
+}
+
+// ListRefundableMembershipCharges mocks base method.
+func (m *MockStore) ListRefundableMembershipCharges(arg0 context.Context, arg1 db.ListRefundableMembershipChargesParams) ([]db.ListRefundableMembershipChargesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefundableMembershipCharges", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRefundableMembershipChargesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefundableMembershipCharges indicates an expected call of ListRefundableMembershipCharges.
+func (mr *MockStoreMockRecorder) ListRefundableMembershipCharges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefundableMembershipCharges", reflect.TypeOf((*MockStore)(nil).ListRefundableMembershipCharges), arg0, arg1)
+}
+
+// ListReviewReports mocks base method.
+func (m *MockStore) ListReviewReports(arg0 context.Context, arg1 int64) ([]db.ReviewReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewReports", arg0, arg1)
+	ret0, _ := ret[0].([]db.ReviewReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewReports indicates an expected call of ListReviewReports.
+func (mr *MockStoreMockRecorder) ListReviewReports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewReports", reflect.TypeOf((*MockStore)(nil).ListReviewReports), arg0, arg1)
+}
+
+// ListReviewsByStatus mocks base method.
+func (m *MockStore) ListReviewsByStatus(arg0 context.Context, arg1 db.ListReviewsByStatusParams) ([]db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewsByStatus", arg0, arg1)
+	ret0, _ := ret[0].([]db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewsByStatus indicates an expected call of ListReviewsByStatus.
+func (mr *MockStoreMockRecorder) ListReviewsByStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewsByStatus", reflect.TypeOf((*MockStore)(nil).ListReviewsByStatus), arg0, arg1)
+}
+
+// ListScreeningFormats mocks base method.
+func (m *MockStore) ListScreeningFormats(arg0 context.Context) ([]db.ScreeningFormat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScreeningFormats", arg0)
+	ret0, _ := ret[0].([]db.ScreeningFormat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScreeningFormats indicates an expected call of ListScreeningFormats.
+func (mr *MockStoreMockRecorder) ListScreeningFormats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScreeningFormats", reflect.TypeOf((*MockStore)(nil).ListScreeningFormats), arg0)
+}
+
+// ListTicketConcessionItems mocks base method.
+func (m *MockStore) ListTicketConcessionItems(arg0 context.Context, arg1 []int64) ([]db.ListTicketConcessionItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTicketConcessionItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTicketConcessionItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTicketConcessionItems indicates an expected call of ListTicketConcessionItems.
+func (mr *MockStoreMockRecorder) ListTicketConcessionItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTicketConcessionItems", reflect.TypeOf((*MockStore)(nil).ListTicketConcessionItems), arg0, arg1)
+}
+
+// ListTicketConcessionQuantities mocks base method.
+func (m *MockStore) ListTicketConcessionQuantities(arg0 context.Context, arg1 int64) ([]db.ListTicketConcessionQuantitiesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTicketConcessionQuantities", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTicketConcessionQuantitiesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTicketConcessionQuantities indicates an expected call of ListTicketConcessionQuantities.
+func (mr *MockStoreMockRecorder) ListTicketConcessionQuantities(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTicketConcessionQuantities", reflect.TypeOf((*MockStore)(nil).ListTicketConcessionQuantities), arg0, arg1)
+}
+
+// ListTickets mocks base method.
+func (m *MockStore) ListTickets(arg0 context.Context, arg1 db.ListTicketsParams) ([]db.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTickets", arg0, arg1)
+	ret0, _ := ret[0].([]db.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTickets indicates an expected call of ListTickets.
+func (mr *MockStoreMockRecorder) ListTickets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTickets", reflect.TypeOf((*MockStore)(nil).ListTickets), arg0, arg1)
+}
+
+// ListTrendingMovies mocks base method.
+func (m *MockStore) ListTrendingMovies(arg0 context.Context, arg1 int32) ([]db.MovieTrending, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrendingMovies", arg0, arg1)
+	ret0, _ := ret[0].([]db.MovieTrending)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrendingMovies indicates an expected call of ListTrendingMovies.
+func (mr *MockStoreMockRecorder) ListTrendingMovies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrendingMovies", reflect.TypeOf((*MockStore)(nil).ListTrendingMovies), arg0, arg1)
+}
+
+// ListUnnotifiedScreenings mocks base method.
+func (m *MockStore) ListUnnotifiedScreenings(arg0 context.Context, arg1 int32) ([]db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnnotifiedScreenings", arg0, arg1)
+	ret0, _ := ret[0].([]db.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnnotifiedScreenings indicates an expected call of ListUnnotifiedScreenings.
+func (mr *MockStoreMockRecorder) ListUnnotifiedScreenings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnnotifiedScreenings", reflect.TypeOf((*MockStore)(nil).ListUnnotifiedScreenings), arg0, arg1)
+}
+
+// ListUserPurchases mocks base method.
+func (m *MockStore) ListUserPurchases(arg0 context.Context, arg1 string) ([]db.ListUserPurchasesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPurchases", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserPurchasesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPurchases indicates an expected call of ListUserPurchases.
+func (mr *MockStoreMockRecorder) ListUserPurchases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPurchases", reflect.TypeOf((*MockStore)(nil).ListUserPurchases), arg0, arg1)
+}
+
+// ListUserRecommendations mocks base method.
+func (m *MockStore) ListUserRecommendations(arg0 context.Context, arg1 db.ListUserRecommendationsParams) ([]db.UserRecommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserRecommendations", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserRecommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserRecommendations indicates an expected call of ListUserRecommendations.
+func (mr *MockStoreMockRecorder) ListUserRecommendations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRecommendations", reflect.TypeOf((*MockStore)(nil).ListUserRecommendations), arg0, arg1)
+}
+
+// ListVenueAuditoriums mocks base method.
+func (m *MockStore) ListVenueAuditoriums(arg0 context.Context, arg1 int64) ([]db.Auditorium, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenueAuditoriums", arg0, arg1)
+	ret0, _ := ret[0].([]db.Auditorium)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenueAuditoriums indicates an expected call of ListVenueAuditoriums.
+func (mr *MockStoreMockRecorder) ListVenueAuditoriums(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenueAuditoriums", reflect.TypeOf((*MockStore)(nil).ListVenueAuditoriums), arg0, arg1)
+}
+
+// ListVenueHours mocks base method.
+func (m *MockStore) ListVenueHours(arg0 context.Context, arg1 []int64) ([]db.VenueHour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenueHours", arg0, arg1)
+	ret0, _ := ret[0].([]db.VenueHour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenueHours indicates an expected call of ListVenueHours.
+func (mr *MockStoreMockRecorder) ListVenueHours(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenueHours", reflect.TypeOf((*MockStore)(nil).ListVenueHours), arg0, arg1)
+}
+
+// ListVenuePricingRules mocks base method.
+func (m *MockStore) ListVenuePricingRules(arg0 context.Context, arg1 sql.NullInt64) ([]db.PricingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenuePricingRules", arg0, arg1)
+	ret0, _ := ret[0].([]db.PricingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenuePricingRules indicates an expected call of ListVenuePricingRules.
+func (mr *MockStoreMockRecorder) ListVenuePricingRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenuePricingRules", reflect.TypeOf((*MockStore)(nil).ListVenuePricingRules), arg0, arg1)
+}
+
+// ListVenuePrivateBookings mocks base method.
+func (m *MockStore) ListVenuePrivateBookings(arg0 context.Context, arg1 db.ListVenuePrivateBookingsParams) ([]db.PrivateBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenuePrivateBookings", arg0, arg1)
+	ret0, _ := ret[0].([]db.PrivateBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenuePrivateBookings indicates an expected call of ListVenuePrivateBookings.
+func (mr *MockStoreMockRecorder) ListVenuePrivateBookings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenuePrivateBookings", reflect.TypeOf((*MockStore)(nil).ListVenuePrivateBookings), arg0, arg1)
+}
+
+// ListVenueScreenings mocks base method.
+func (m *MockStore) ListVenueScreenings(arg0 context.Context, arg1 db.ListVenueScreeningsParams) ([]db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenueScreenings", arg0, arg1)
+	ret0, _ := ret[0].([]db.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenueScreenings indicates an expected call of ListVenueScreenings.
+func (mr *MockStoreMockRecorder) ListVenueScreenings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenueScreenings", reflect.TypeOf((*MockStore)(nil).ListVenueScreenings), arg0, arg1)
+}
+
+// ListVenueStaff mocks base method.
+func (m *MockStore) ListVenueStaff(arg0 context.Context, arg1 int64) ([]db.VenueStaff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenueStaff", arg0, arg1)
+	ret0, _ := ret[0].([]db.VenueStaff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenueStaff indicates an expected call of ListVenueStaff.
+func (mr *MockStoreMockRecorder) ListVenueStaff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenueStaff", reflect.TypeOf((*MockStore)(nil).ListVenueStaff), arg0, arg1)
+}
+
+// ListVenues mocks base method.
+func (m *MockStore) ListVenues(arg0 context.Context, arg1 db.ListVenuesParams) ([]db.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVenues", arg0, arg1)
+	ret0, _ := ret[0].([]db.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVenues indicates an expected call of ListVenues.
+func (mr *MockStoreMockRecorder) ListVenues(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVenues", reflect.TypeOf((*MockStore)(nil).ListVenues), arg0, arg1)
+}
+
+// ListWatchers mocks base method.
+func (m *MockStore) ListWatchers(arg0 context.Context, arg1 []int64) ([]db.WatchlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWatchers", arg0, arg1)
+	ret0, _ := ret[0].([]db.WatchlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWatchers indicates an expected call of ListWatchers.
+func (mr *MockStoreMockRecorder) ListWatchers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWatchers", reflect.TypeOf((*MockStore)(nil).ListWatchers), arg0, arg1)
+}
+
+// ListWatchlist mocks base method.
+func (m *MockStore) ListWatchlist(arg0 context.Context, arg1 db.ListWatchlistParams) ([]db.WatchlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWatchlist", arg0, arg1)
+	ret0, _ := ret[0].([]db.WatchlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWatchlist indicates an expected call of ListWatchlist.
+func (mr *MockStoreMockRecorder) ListWatchlist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWatchlist", reflect.TypeOf((*MockStore)(nil).ListWatchlist), arg0, arg1)
+}
+
+// LockAuditorium mocks base method.
+func (m *MockStore) LockAuditorium(arg0 context.Context, arg1 int64) (db.Auditorium, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAuditorium", arg0, arg1)
+	ret0, _ := ret[0].(db.Auditorium)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockAuditorium indicates an expected call of LockAuditorium.
+func (mr *MockStoreMockRecorder) LockAuditorium(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAuditorium", reflect.TypeOf((*MockStore)(nil).LockAuditorium), arg0, arg1)
+}
+
+// MarkMembershipPastDue mocks base method.
+func (m *MockStore) MarkMembershipPastDue(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMembershipPastDue", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMembershipPastDue indicates an expected call of MarkMembershipPastDue.
+func (mr *MockStoreMockRecorder) MarkMembershipPastDue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMembershipPastDue", reflect.TypeOf((*MockStore)(nil).MarkMembershipPastDue), arg0, arg1)
+}
+
+// MarkScreeningsNotified mocks base method.
+func (m *MockStore) MarkScreeningsNotified(arg0 context.Context, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkScreeningsNotified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkScreeningsNotified indicates an expected call of MarkScreeningsNotified.
+func (mr *MockStoreMockRecorder) MarkScreeningsNotified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkScreeningsNotified", reflect.TypeOf((*MockStore)(nil).MarkScreeningsNotified), arg0, arg1)
+}
+
+// ModerateReviewTx mocks base method.
+func (m *MockStore) ModerateReviewTx(arg0 context.Context, arg1 db.ModerateReviewTxParams) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReviewTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateReviewTx indicates an expected call of ModerateReviewTx.
+func (mr *MockStoreMockRecorder) ModerateReviewTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReviewTx", reflect.TypeOf((*MockStore)(nil).ModerateReviewTx), arg0, arg1)
+}
+
+// OpenCashShift mocks base method.
+func (m *MockStore) OpenCashShift(arg0 context.Context, arg1 db.OpenCashShiftParams) (db.CashShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCashShift", arg0, arg1)
+	ret0, _ := ret[0].(db.CashShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenCashShift indicates an expected call of OpenCashShift.
+func (mr *MockStoreMockRecorder) OpenCashShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCashShift", reflect.TypeOf((*MockStore)(nil).OpenCashShift), arg0, arg1)
+}
+
+// OrderConcessionsTx mocks base method.
+func (m *MockStore) OrderConcessionsTx(arg0 context.Context, arg1 db.OrderConcessionsTxParams) (db.ConcessionOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderConcessionsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ConcessionOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderConcessionsTx indicates an expected call of OrderConcessionsTx.
+func (mr *MockStoreMockRecorder) OrderConcessionsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderConcessionsTx", reflect.TypeOf((*MockStore)(nil).OrderConcessionsTx), arg0, arg1)
+}
+
+// PauseMembership mocks base method.
+func (m *MockStore) PauseMembership(arg0 context.Context, arg1 db.PauseMembershipParams) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseMembership", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseMembership indicates an expected call of PauseMembership.
+func (mr *MockStoreMockRecorder) PauseMembership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseMembership", reflect.TypeOf((*MockStore)(nil).PauseMembership), arg0, arg1)
+}
+
+// PayPrivateBookingInvoice mocks base method.
+func (m *MockStore) PayPrivateBookingInvoice(arg0 context.Context, arg1 db.PayPrivateBookingInvoiceParams) (db.PrivateBookingInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayPrivateBookingInvoice", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBookingInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayPrivateBookingInvoice indicates an expected call of PayPrivateBookingInvoice.
+func (mr *MockStoreMockRecorder) PayPrivateBookingInvoice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayPrivateBookingInvoice", reflect.TypeOf((*MockStore)(nil).PayPrivateBookingInvoice), arg0, arg1)
+}
+
+// PublishScreening mocks base method.
+func (m *MockStore) PublishScreening(arg0 context.Context, arg1 int64) (db.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScreening", arg0, arg1)
+	ret0, _ := ret[0].(db.Screening)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateDirector indicates an expected call of CreateDirector.
-func (mr *MockStoreMockRecorder) CreateDirector(arg0, arg1 interface{}) *gomock.Call {
+// PublishScreening indicates an expected call of PublishScreening.
+func (mr *MockStoreMockRecorder) PublishScreening(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirector", reflect.TypeOf((*MockStore)(nil).CreateDirector), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScreening", reflect.TypeOf((*MockStore)(nil).PublishScreening), arg0, arg1)
 }
 
-// CreateMovie mocks base method.
-func (m *MockStore) CreateMovie(arg0 context.Context, arg1 db.CreateMovieParams) (db.Movie, error) {
+// PurchaseTicketTx mocks base method.
+func (m *MockStore) PurchaseTicketTx(arg0 context.Context, arg1 db.PurchaseTicketTxParams) (db.PurchaseTicketTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMovie", arg0, arg1)
-	ret0, _ := ret[0].(db.Movie)
+	ret := m.ctrl.Call(m, "PurchaseTicketTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseTicketTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateMovie indicates an expected call of CreateMovie.
-func (mr *MockStoreMockRecorder) CreateMovie(arg0, arg1 interface{}) *gomock.Call {
+// PurchaseTicketTx indicates an expected call of PurchaseTicketTx.
+func (mr *MockStoreMockRecorder) PurchaseTicketTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockStore)(nil).CreateMovie), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseTicketTx", reflect.TypeOf((*MockStore)(nil).PurchaseTicketTx), arg0, arg1)
 }
 
-// CreateTicket mocks base method.
-func (m *MockStore) CreateTicket(arg0 context.Context, arg1 db.CreateTicketParams) (db.Ticket, error) {
+// QuotePrivateBooking mocks base method.
+func (m *MockStore) QuotePrivateBooking(arg0 context.Context, arg1 db.QuotePrivateBookingParams) (db.PrivateBooking, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTicket", arg0, arg1)
-	ret0, _ := ret[0].(db.Ticket)
+	ret := m.ctrl.Call(m, "QuotePrivateBooking", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBooking)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTicket indicates an expected call of CreateTicket.
-func (mr *MockStoreMockRecorder) CreateTicket(arg0, arg1 interface{}) *gomock.Call {
+// QuotePrivateBooking indicates an expected call of QuotePrivateBooking.
+func (mr *MockStoreMockRecorder) QuotePrivateBooking(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicket", reflect.TypeOf((*MockStore)(nil).CreateTicket), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuotePrivateBooking", reflect.TypeOf((*MockStore)(nil).QuotePrivateBooking), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// RefreshDirectorOscars mocks base method.
+func (m *MockStore) RefreshDirectorOscars(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "RefreshDirectorOscars", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshDirectorOscars indicates an expected call of RefreshDirectorOscars.
+func (mr *MockStoreMockRecorder) RefreshDirectorOscars(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshDirectorOscars", reflect.TypeOf((*MockStore)(nil).RefreshDirectorOscars), arg0, arg1)
+}
+
+// RefreshMovieDailySales mocks base method.
+func (m *MockStore) RefreshMovieDailySales(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshMovieDailySales", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshMovieDailySales indicates an expected call of RefreshMovieDailySales.
+func (mr *MockStoreMockRecorder) RefreshMovieDailySales(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshMovieDailySales", reflect.TypeOf((*MockStore)(nil).RefreshMovieDailySales), arg0)
+}
+
+// RefreshMovieTrending mocks base method.
+func (m *MockStore) RefreshMovieTrending(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshMovieTrending", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshMovieTrending indicates an expected call of RefreshMovieTrending.
+func (mr *MockStoreMockRecorder) RefreshMovieTrending(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshMovieTrending", reflect.TypeOf((*MockStore)(nil).RefreshMovieTrending), arg0)
+}
+
+// ReleaseMembershipAllowance mocks base method.
+func (m *MockStore) ReleaseMembershipAllowance(arg0 context.Context, arg1 db.ReleaseMembershipAllowanceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMembershipAllowance", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseMembershipAllowance indicates an expected call of ReleaseMembershipAllowance.
+func (mr *MockStoreMockRecorder) ReleaseMembershipAllowance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMembershipAllowance", reflect.TypeOf((*MockStore)(nil).ReleaseMembershipAllowance), arg0, arg1)
+}
+
+// ReleaseScreening mocks base method.
+func (m *MockStore) ReleaseScreening(arg0 context.Context, arg1 sql.NullInt64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseScreening", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseScreening indicates an expected call of ReleaseScreening.
+func (mr *MockStoreMockRecorder) ReleaseScreening(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseScreening", reflect.TypeOf((*MockStore)(nil).ReleaseScreening), arg0, arg1)
+}
+
+// RemoveWatchlistItem mocks base method.
+func (m *MockStore) RemoveWatchlistItem(arg0 context.Context, arg1 db.RemoveWatchlistItemParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWatchlistItem", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveWatchlistItem indicates an expected call of RemoveWatchlistItem.
+func (mr *MockStoreMockRecorder) RemoveWatchlistItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWatchlistItem", reflect.TypeOf((*MockStore)(nil).RemoveWatchlistItem), arg0, arg1)
+}
+
+// RenewMembership mocks base method.
+func (m *MockStore) RenewMembership(arg0 context.Context, arg1 db.RenewMembershipParams) (db.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewMembership", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewMembership indicates an expected call of RenewMembership.
+func (mr *MockStoreMockRecorder) RenewMembership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewMembership", reflect.TypeOf((*MockStore)(nil).RenewMembership), arg0, arg1)
+}
+
+// RenewMembershipTx mocks base method.
+func (m *MockStore) RenewMembershipTx(arg0 context.Context, arg1 db.RenewMembershipTxParams) (db.MembershipTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewMembershipTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MembershipTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewMembershipTx indicates an expected call of RenewMembershipTx.
+func (mr *MockStoreMockRecorder) RenewMembershipTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewMembershipTx", reflect.TypeOf((*MockStore)(nil).RenewMembershipTx), arg0, arg1)
+}
+
+// ReplaceUserRecommendationsTx mocks base method.
+func (m *MockStore) ReplaceUserRecommendationsTx(arg0 context.Context, arg1 db.ReplaceUserRecommendationsTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceUserRecommendationsTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceUserRecommendationsTx indicates an expected call of ReplaceUserRecommendationsTx.
+func (mr *MockStoreMockRecorder) ReplaceUserRecommendationsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceUserRecommendationsTx", reflect.TypeOf((*MockStore)(nil).ReplaceUserRecommendationsTx), arg0, arg1)
+}
+
+// ReportReviewTx mocks base method.
+func (m *MockStore) ReportReviewTx(arg0 context.Context, arg1 db.ReportReviewTxParams) (db.ReportReviewTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportReviewTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReportReviewTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportReviewTx indicates an expected call of ReportReviewTx.
+func (mr *MockStoreMockRecorder) ReportReviewTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportReviewTx", reflect.TypeOf((*MockStore)(nil).ReportReviewTx), arg0, arg1)
+}
+
+// ResetPasswordTx mocks base method.
+func (m *MockStore) ResetPasswordTx(arg0 context.Context, arg1 db.ResetPasswordTxParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPasswordTx", arg0, arg1)
 	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// ResetPasswordTx indicates an expected call of ResetPasswordTx.
+func (mr *MockStoreMockRecorder) ResetPasswordTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTx", reflect.TypeOf((*MockStore)(nil).ResetPasswordTx), arg0, arg1)
 }
 
-// DeleteMovie mocks base method.
-func (m *MockStore) DeleteMovie(arg0 context.Context, arg1 int64) error {
+// ResolveReviewReports mocks base method.
+func (m *MockStore) ResolveReviewReports(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMovie", arg0, arg1)
+	ret := m.ctrl.Call(m, "ResolveReviewReports", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteMovie indicates an expected call of DeleteMovie.
-func (mr *MockStoreMockRecorder) DeleteMovie(arg0, arg1 interface{}) *gomock.Call {
+// ResolveReviewReports indicates an expected call of ResolveReviewReports.
+func (mr *MockStoreMockRecorder) ResolveReviewReports(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockStore)(nil).DeleteMovie), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReviewReports", reflect.TypeOf((*MockStore)(nil).ResolveReviewReports), arg0, arg1)
 }
 
-// DeleteTicket mocks base method.
-func (m *MockStore) DeleteTicket(arg0 context.Context, arg1 int64) error {
+// ResumeMembership mocks base method.
+func (m *MockStore) ResumeMembership(arg0 context.Context, arg1 db.ResumeMembershipParams) (db.Membership, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTicket", arg0, arg1)
+	ret := m.ctrl.Call(m, "ResumeMembership", arg0, arg1)
+	ret0, _ := ret[0].(db.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeMembership indicates an expected call of ResumeMembership.
+func (mr *MockStoreMockRecorder) ResumeMembership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeMembership", reflect.TypeOf((*MockStore)(nil).ResumeMembership), arg0, arg1)
+}
+
+// RevokePasswordResets mocks base method.
+func (m *MockStore) RevokePasswordResets(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokePasswordResets", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteTicket indicates an expected call of DeleteTicket.
-func (mr *MockStoreMockRecorder) DeleteTicket(arg0, arg1 interface{}) *gomock.Call {
+// RevokePasswordResets indicates an expected call of RevokePasswordResets.
+func (mr *MockStoreMockRecorder) RevokePasswordResets(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTicket", reflect.TypeOf((*MockStore)(nil).DeleteTicket), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePasswordResets", reflect.TypeOf((*MockStore)(nil).RevokePasswordResets), arg0, arg1)
 }
 
-// GetDirector mocks base method.
-func (m *MockStore) GetDirector(arg0 context.Context, arg1 int64) (db.Director, error) {
+// ScheduleScreeningsTx mocks base method.
+func (m *MockStore) ScheduleScreeningsTx(arg0 context.Context, arg1 db.ScheduleScreeningsTxParams) (db.ScheduleScreeningsTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDirector", arg0, arg1)
+	ret := m.ctrl.Call(m, "ScheduleScreeningsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduleScreeningsTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleScreeningsTx indicates an expected call of ScheduleScreeningsTx.
+func (mr *MockStoreMockRecorder) ScheduleScreeningsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleScreeningsTx", reflect.TypeOf((*MockStore)(nil).ScheduleScreeningsTx), arg0, arg1)
+}
+
+// SearchMovies mocks base method.
+func (m *MockStore) SearchMovies(arg0 context.Context, arg1 db.SearchMoviesParams) ([]db.SearchMoviesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMovies", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchMoviesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMovies indicates an expected call of SearchMovies.
+func (mr *MockStoreMockRecorder) SearchMovies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMovies", reflect.TypeOf((*MockStore)(nil).SearchMovies), arg0, arg1)
+}
+
+// SoftDeleteMovie mocks base method.
+func (m *MockStore) SoftDeleteMovie(arg0 context.Context, arg1 int64) (db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteMovie", arg0, arg1)
+	ret0, _ := ret[0].(db.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteMovie indicates an expected call of SoftDeleteMovie.
+func (mr *MockStoreMockRecorder) SoftDeleteMovie(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteMovie", reflect.TypeOf((*MockStore)(nil).SoftDeleteMovie), arg0, arg1)
+}
+
+// StreamDailySales mocks base method.
+func (m *MockStore) StreamDailySales(arg0 context.Context, arg1 db.ListDailySalesParams, arg2 func(db.ListDailySalesRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamDailySales", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamDailySales indicates an expected call of StreamDailySales.
+func (mr *MockStoreMockRecorder) StreamDailySales(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamDailySales", reflect.TypeOf((*MockStore)(nil).StreamDailySales), arg0, arg1, arg2)
+}
+
+// SummarizeCashShift mocks base method.
+func (m *MockStore) SummarizeCashShift(arg0 context.Context, arg1 int64) ([]db.SummarizeCashShiftRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeCashShift", arg0, arg1)
+	ret0, _ := ret[0].([]db.SummarizeCashShiftRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeCashShift indicates an expected call of SummarizeCashShift.
+func (mr *MockStoreMockRecorder) SummarizeCashShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeCashShift", reflect.TypeOf((*MockStore)(nil).SummarizeCashShift), arg0, arg1)
+}
+
+// UpdateDirector mocks base method.
+func (m *MockStore) UpdateDirector(arg0 context.Context, arg1 db.UpdateDirectorParams) (db.Director, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDirector", arg0, arg1)
 	ret0, _ := ret[0].(db.Director)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDirector indicates an expected call of GetDirector.
-func (mr *MockStoreMockRecorder) GetDirector(arg0, arg1 interface{}) *gomock.Call {
+// UpdateDirector indicates an expected call of UpdateDirector.
+func (mr *MockStoreMockRecorder) UpdateDirector(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirector", reflect.TypeOf((*MockStore)(nil).GetDirector), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDirector", reflect.TypeOf((*MockStore)(nil).UpdateDirector), arg0, arg1)
 }
 
-// GetMovie mocks base method.
-func (m *MockStore) GetMovie(arg0 context.Context, arg1 int64) (db.Movie, error) {
+// UpdateDirectorTx mocks base method.
+func (m *MockStore) UpdateDirectorTx(arg0 context.Context, arg1 db.UpdateDirectorParams) (db.Director, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMovie", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateDirectorTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDirectorTx indicates an expected call of UpdateDirectorTx.
+func (mr *MockStoreMockRecorder) UpdateDirectorTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDirectorTx", reflect.TypeOf((*MockStore)(nil).UpdateDirectorTx), arg0, arg1)
+}
+
+// UpdateMovie mocks base method.
+func (m *MockStore) UpdateMovie(arg0 context.Context, arg1 db.UpdateMovieParams) (db.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovie", arg0, arg1)
 	ret0, _ := ret[0].(db.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMovie indicates an expected call of GetMovie.
-func (mr *MockStoreMockRecorder) GetMovie(arg0, arg1 interface{}) *gomock.Call {
+// UpdateMovie indicates an expected call of UpdateMovie.
+func (mr *MockStoreMockRecorder) UpdateMovie(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovie", reflect.TypeOf((*MockStore)(nil).GetMovie), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockStore)(nil).UpdateMovie), arg0, arg1)
 }
 
-// GetTicket mocks base method.
-func (m *MockStore) GetTicket(arg0 context.Context, arg1 int64) (db.Ticket, error) {
+// UpdateMovieTx mocks base method.
+func (m *MockStore) UpdateMovieTx(arg0 context.Context, arg1 db.UpdateMovieTxParams) (db.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTicket", arg0, arg1)
-	ret0, _ := ret[0].(db.Ticket)
+	ret := m.ctrl.Call(m, "UpdateMovieTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTicket indicates an expected call of GetTicket.
-func (mr *MockStoreMockRecorder) GetTicket(arg0, arg1 interface{}) *gomock.Call {
+// UpdateMovieTx indicates an expected call of UpdateMovieTx.
+func (mr *MockStoreMockRecorder) UpdateMovieTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicket", reflect.TypeOf((*MockStore)(nil).GetTicket), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieTx", reflect.TypeOf((*MockStore)(nil).UpdateMovieTx), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+// UpdatePerson mocks base method.
+func (m *MockStore) UpdatePerson(arg0 context.Context, arg1 db.UpdatePersonParams) (db.Person, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdatePerson", arg0, arg1)
+	ret0, _ := ret[0].(db.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePerson indicates an expected call of UpdatePerson.
+func (mr *MockStoreMockRecorder) UpdatePerson(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePerson", reflect.TypeOf((*MockStore)(nil).UpdatePerson), arg0, arg1)
+}
+
+// UpdateReviewStatus mocks base method.
+func (m *MockStore) UpdateReviewStatus(arg0 context.Context, arg1 db.UpdateReviewStatusParams) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReviewStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReviewStatus indicates an expected call of UpdateReviewStatus.
+func (mr *MockStoreMockRecorder) UpdateReviewStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewStatus", reflect.TypeOf((*MockStore)(nil).UpdateReviewStatus), arg0, arg1)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockStore) UpdateUserPassword(arg0 context.Context, arg1 db.UpdateUserPasswordParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1)
 	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStoreMockRecorder) UpdateUserPassword(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), arg0, arg1)
 }
 
-// ListDirectors mocks base method.
-func (m *MockStore) ListDirectors(arg0 context.Context, arg1 db.ListDirectorsParams) ([]db.Director, error) {
+// UpsertScreeningFormat mocks base method.
+func (m *MockStore) UpsertScreeningFormat(arg0 context.Context, arg1 db.UpsertScreeningFormatParams) (db.ScreeningFormat, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDirectors", arg0, arg1)
-	ret0, _ := ret[0].([]db.Director)
+	ret := m.ctrl.Call(m, "UpsertScreeningFormat", arg0, arg1)
+	ret0, _ := ret[0].(db.ScreeningFormat)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListDirectors indicates an expected call of ListDirectors.
-func (mr *MockStoreMockRecorder) ListDirectors(arg0, arg1 interface{}) *gomock.Call {
+// UpsertScreeningFormat indicates an expected call of UpsertScreeningFormat.
+func (mr *MockStoreMockRecorder) UpsertScreeningFormat(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDirectors", reflect.TypeOf((*MockStore)(nil).ListDirectors), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertScreeningFormat", reflect.TypeOf((*MockStore)(nil).UpsertScreeningFormat), arg0, arg1)
 }
 
-// ListMovies mocks base method.
-func (m *MockStore) ListMovies(arg0 context.Context, arg1 int32) ([]db.Movie, error) {
+// UpsertVenueStaff mocks base method.
+func (m *MockStore) UpsertVenueStaff(arg0 context.Context, arg1 db.UpsertVenueStaffParams) (db.VenueStaff, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMovies", arg0, arg1)
-	ret0, _ := ret[0].([]db.Movie)
+	ret := m.ctrl.Call(m, "UpsertVenueStaff", arg0, arg1)
+	ret0, _ := ret[0].(db.VenueStaff)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListMovies indicates an expected call of ListMovies.
-func (mr *MockStoreMockRecorder) ListMovies(arg0, arg1 interface{}) *gomock.Call {
+// UpsertVenueStaff indicates an expected call of UpsertVenueStaff.
+func (mr *MockStoreMockRecorder) UpsertVenueStaff(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovies", reflect.TypeOf((*MockStore)(nil).ListMovies), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVenueStaff", reflect.TypeOf((*MockStore)(nil).UpsertVenueStaff), arg0, arg1)
 }
 
-// ListTickets mocks base method.
-func (m *MockStore) ListTickets(arg0 context.Context, arg1 db.ListTicketsParams) ([]db.Ticket, error) {
+// UsePasswordReset mocks base method.
+func (m *MockStore) UsePasswordReset(arg0 context.Context, arg1 string) (db.PasswordReset, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTickets", arg0, arg1)
-	ret0, _ := ret[0].([]db.Ticket)
+	ret := m.ctrl.Call(m, "UsePasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(db.PasswordReset)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTickets indicates an expected call of ListTickets.
-func (mr *MockStoreMockRecorder) ListTickets(arg0, arg1 interface{}) *gomock.Call {
+// UsePasswordReset indicates an expected call of UsePasswordReset.
+func (mr *MockStoreMockRecorder) UsePasswordReset(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTickets", reflect.TypeOf((*MockStore)(nil).ListTickets), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordReset", reflect.TypeOf((*MockStore)(nil).UsePasswordReset), arg0, arg1)
+}
+
+// VerifyReviews mocks base method.
+func (m *MockStore) VerifyReviews(arg0 context.Context, arg1 db.VerifyReviewsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyReviews", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyReviews indicates an expected call of VerifyReviews.
+func (mr *MockStoreMockRecorder) VerifyReviews(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyReviews", reflect.TypeOf((*MockStore)(nil).VerifyReviews), arg0, arg1)
+}
+
+// VoidPrivateBookingInvoice mocks base method.
+func (m *MockStore) VoidPrivateBookingInvoice(arg0 context.Context, arg1 int64) (db.PrivateBookingInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidPrivateBookingInvoice", arg0, arg1)
+	ret0, _ := ret[0].(db.PrivateBookingInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidPrivateBookingInvoice indicates an expected call of VoidPrivateBookingInvoice.
+func (mr *MockStoreMockRecorder) VoidPrivateBookingInvoice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidPrivateBookingInvoice", reflect.TypeOf((*MockStore)(nil).VoidPrivateBookingInvoice), arg0, arg1)
 }
@@ -0,0 +1,28 @@
+-- name: CreateAward :one
+INSERT INTO awards(name, kind, year, category, winner, person_id, movie_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetAward :one
+SELECT *
+FROM awards
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListAwardsByYear :many
+SELECT *
+FROM awards
+WHERE year = sqlc.arg(year)
+  -- the page starts after the award of the cursor in the same order, 0 is the first page
+  AND (sqlc.arg(after_id)::bigint = 0 OR (awards.name, awards.category, NOT awards.winner, awards.id) > (
+    SELECT c.name, c.category, NOT c.winner, c.id
+    FROM awards c
+    WHERE c.id = sqlc.arg(after_id)
+  ))
+ORDER BY name, category, winner DESC, id
+LIMIT sqlc.arg(limit);
+
+-- name: DeleteAward :one
+DELETE FROM awards
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,28 @@
+-- name: ListBoxOffice :many
+SELECT
+  movie_id,
+  COALESCE(sum(tickets) FILTER (WHERE day >= sqlc.arg(current_from)::date), 0)::bigint AS tickets,
+  COALESCE(sum(revenue) FILTER (WHERE day >= sqlc.arg(current_from)::date), 0)::bigint AS revenue,
+  COALESCE(sum(tickets) FILTER (WHERE day < sqlc.arg(current_from)::date), 0)::bigint AS previous_tickets,
+  COALESCE(sum(revenue) FILTER (WHERE day < sqlc.arg(current_from)::date), 0)::bigint AS previous_revenue
+FROM movie_daily_sales
+WHERE day >= sqlc.arg(previous_from)::date
+  AND EXISTS (SELECT 1 FROM movies WHERE movies.id = movie_id AND movies.deleted_at IS NULL)
+GROUP BY movie_id
+HAVING sum(tickets) FILTER (WHERE day >= sqlc.arg(current_from)::date) > 0
+ORDER BY revenue DESC, tickets DESC, movie_id
+LIMIT sqlc.arg(limit);
+
+-- name: ListTrendingMovies :many
+SELECT *
+FROM movie_trending
+WHERE tickets > 0
+  AND EXISTS (SELECT 1 FROM movies WHERE movies.id = movie_id AND movies.deleted_at IS NULL)
+ORDER BY tickets - previous_tickets DESC, tickets DESC, movie_id
+LIMIT $1;
+
+-- name: RefreshMovieDailySales :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY movie_daily_sales;
+
+-- name: RefreshMovieTrending :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY movie_trending;
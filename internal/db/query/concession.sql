@@ -0,0 +1,58 @@
+-- name: CreateConcessionItem :one
+INSERT INTO concession_items(name, category, price, stock)
+VALUES($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetConcessionItem :one
+SELECT *
+FROM concession_items
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListConcessionItems :many
+SELECT *
+FROM concession_items
+ORDER BY category, name;
+
+-- name: AddConcessionStock :one
+UPDATE concession_items
+SET stock = stock + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DecrementConcessionStock :one
+UPDATE concession_items
+SET stock = stock - sqlc.arg(quantity)
+WHERE id = sqlc.arg(id) AND stock >= sqlc.arg(quantity)
+RETURNING *;
+
+-- name: CreateConcessionOrder :one
+INSERT INTO concession_orders(ticket_id, order_owner, total)
+VALUES($1, $2, $3)
+RETURNING *;
+
+-- name: CreateConcessionOrderItem :one
+INSERT INTO concession_order_items(order_id, item_id, name, quantity, unit_price)
+VALUES($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListConcessionOrderItems :many
+SELECT *
+FROM concession_order_items
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: ListTicketConcessionItems :many
+SELECT o.ticket_id, i.id, i.order_id, i.item_id, i.name, i.quantity, i.unit_price
+FROM concession_order_items i
+JOIN concession_orders o ON o.id = i.order_id
+WHERE o.ticket_id = ANY(sqlc.arg(ticket_ids)::bigint[])
+ORDER BY i.id;
+
+-- name: ListTicketConcessionQuantities :many
+SELECT i.item_id, sum(i.quantity)::int AS quantity
+FROM concession_order_items i
+JOIN concession_orders o ON o.id = i.order_id
+WHERE o.ticket_id = $1
+GROUP BY i.item_id
+ORDER BY i.item_id;
@@ -0,0 +1,16 @@
+-- name: CreateMovieCredit :one
+INSERT INTO movie_credits(movie_id, person_id, role, billing_order)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListMovieCredits :many
+SELECT mc.id, mc.person_id, p.first_name, p.last_name, mc.role, mc.billing_order
+FROM movie_credits mc
+JOIN people p ON p.id = mc.person_id
+WHERE mc.movie_id = $1
+ORDER BY mc.billing_order, mc.id;
+
+-- name: DeleteMovieCredit :one
+DELETE FROM movie_credits
+WHERE id = $1 AND movie_id = $2
+RETURNING *;
@@ -1,5 +1,5 @@
 -- name: CreateDirector :one
-INSERT INTO directors(first_name, last_name, oscars)
+INSERT INTO directors(first_name, last_name, person_id)
 VALUES ($1, $2, $3)
 RETURNING *;
 
@@ -12,6 +12,33 @@ LIMIT 1;
 -- name: ListDirectors :many
 SELECT *
 FROM directors
+WHERE id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1
-OFFSET $2;
\ No newline at end of file
+LIMIT sqlc.arg(limit);
+
+-- name: ListDirectorsByIDs :many
+SELECT *
+FROM directors
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
+
+-- name: UpdateDirector :one
+UPDATE directors
+SET
+  first_name = COALESCE(sqlc.narg(first_name), first_name),
+  last_name = COALESCE(sqlc.narg(last_name), last_name)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteDirector :one
+DELETE FROM directors
+WHERE id = $1
+RETURNING *;
+
+-- name: RefreshDirectorOscars :exec
+UPDATE directors
+SET oscars = (
+  SELECT COUNT(*)
+  FROM awards
+  WHERE awards.person_id = directors.person_id AND kind = 'oscar' AND winner
+)
+WHERE person_id = $1;
\ No newline at end of file
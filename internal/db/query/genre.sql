@@ -0,0 +1,25 @@
+-- name: CreateGenre :one
+INSERT INTO genres(name)
+VALUES ($1)
+RETURNING *;
+
+-- name: ListGenres :many
+SELECT *
+FROM genres
+ORDER BY name;
+
+-- name: ListMovieGenres :many
+SELECT genres.id, genres.name, genres.created_at
+FROM genres
+JOIN movie_genres ON movie_genres.genre_id = genres.id
+WHERE movie_genres.movie_id = $1
+ORDER BY genres.name;
+
+-- name: AddMovieGenre :exec
+INSERT INTO movie_genres(movie_id, genre_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteMovieGenres :exec
+DELETE FROM movie_genres
+WHERE movie_id = $1;
@@ -0,0 +1,128 @@
+-- name: CreateMembershipPlan :one
+INSERT INTO membership_plans(name, allowance, price, currency)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetMembershipPlan :one
+SELECT *
+FROM membership_plans
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListMembershipPlans :many
+SELECT *
+FROM membership_plans
+ORDER BY price, id;
+
+-- name: CreateMembership :one
+INSERT INTO memberships(username, plan_id, period_start, period_end, allowance)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetMembership :one
+SELECT *
+FROM memberships
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetLiveMembership :one
+SELECT *
+FROM memberships
+WHERE username = $1 AND status <> 'cancelled'
+LIMIT 1;
+
+-- name: ConsumeMembershipAllowance :one
+UPDATE memberships
+SET used = used + sqlc.arg(seats)
+WHERE username = sqlc.arg(username) AND status = 'active' AND period_start <= now() AND period_end > now()
+  AND used + sqlc.arg(seats) <= allowance
+RETURNING *;
+
+-- name: ReleaseMembershipAllowance :exec
+UPDATE memberships
+SET used = GREATEST(used - sqlc.arg(seats), 0)
+-- the seats are only given back if the ticket was bought in the current period, a renewed period starts from zero
+WHERE id = sqlc.arg(id) AND period_start <= sqlc.arg(bought_at) AND period_end > sqlc.arg(bought_at);
+
+-- name: ChangeMembershipPlan :one
+UPDATE memberships
+SET plan_id = sqlc.arg(plan_id), allowance = sqlc.arg(allowance)
+WHERE id = sqlc.arg(id) AND status = 'active' AND used <= sqlc.arg(allowance)
+RETURNING *;
+
+-- name: PauseMembership :one
+UPDATE memberships
+SET status = 'paused', paused_at = sqlc.arg(paused_at)
+WHERE id = sqlc.arg(id) AND status = 'active'
+RETURNING *;
+
+-- name: ResumeMembership :one
+UPDATE memberships
+SET status = 'active', paused_at = NULL, period_end = sqlc.arg(period_end)
+WHERE id = sqlc.arg(id) AND status = 'paused'
+RETURNING *;
+
+-- name: CancelMembershipAtPeriodEnd :one
+UPDATE memberships
+SET cancel_at_period_end = true
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: CancelMembership :one
+UPDATE memberships
+SET status = 'cancelled', cancelled_at = now()
+WHERE id = $1 AND status <> 'cancelled'
+RETURNING *;
+
+-- name: ListDueMemberships :many
+SELECT *
+FROM memberships
+WHERE status IN ('active', 'past_due') AND period_end <= sqlc.arg(due_at)
+ORDER BY period_end, id
+LIMIT sqlc.arg(limit);
+
+-- name: RenewMembership :one
+UPDATE memberships
+SET status = 'active', period_start = sqlc.arg(period_start), period_end = sqlc.arg(period_end),
+  allowance = sqlc.arg(allowance), used = 0
+WHERE id = sqlc.arg(id) AND status IN ('active', 'past_due') AND NOT cancel_at_period_end
+  AND period_end = sqlc.arg(previous_period_end)
+RETURNING *;
+
+-- name: MarkMembershipPastDue :execrows
+UPDATE memberships
+SET status = 'past_due'
+WHERE id = $1 AND status = 'active';
+
+-- name: CreateMembershipPayment :one
+INSERT INTO membership_payments(membership_id, period_start, kind, amount, currency, reference, refund_of)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetMembershipPayment :one
+SELECT *
+FROM membership_payments
+WHERE id = $1
+LIMIT 1;
+
+-- name: CompleteMembershipRefund :one
+UPDATE membership_payments
+SET reference = $2
+WHERE id = $1 AND reference IS NULL
+RETURNING *;
+
+-- name: ListPendingMembershipRefunds :many
+SELECT *
+FROM membership_payments
+WHERE reference IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: ListRefundableMembershipCharges :many
+SELECT c.id, (c.amount + COALESCE(SUM(r.amount), 0))::bigint AS refundable
+FROM membership_payments c
+LEFT JOIN membership_payments r ON r.refund_of = c.id
+WHERE c.membership_id = sqlc.arg(membership_id) AND c.period_start = sqlc.arg(period_start) AND c.amount > 0
+GROUP BY c.id
+HAVING c.amount + COALESCE(SUM(r.amount), 0) > 0
+ORDER BY c.id DESC;
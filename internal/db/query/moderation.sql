@@ -0,0 +1,31 @@
+-- name: CreateReviewReport :one
+INSERT INTO review_reports(review_id, reporter, reason, note)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: CountReviewReports :one
+SELECT count(*)
+FROM review_reports
+WHERE review_id = $1 AND resolved_at IS NULL;
+
+-- name: ResolveReviewReports :exec
+UPDATE review_reports
+SET resolved_at = now()
+WHERE review_id = $1 AND resolved_at IS NULL;
+
+-- name: ListReviewReports :many
+SELECT *
+FROM review_reports
+WHERE review_id = $1
+ORDER BY id;
+
+-- name: CreateModerationEvent :one
+INSERT INTO moderation_events(review_id, moderator, from_status, to_status, reason)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListModerationEvents :many
+SELECT *
+FROM moderation_events
+WHERE review_id = $1
+ORDER BY id;
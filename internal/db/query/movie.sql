@@ -1,8 +1,59 @@
 -- name: ListMovies :many
 SELECT *
 FROM movies
-ORDER BY id DESC
-LIMIT $1;
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(certification)::varchar IS NULL OR certification = sqlc.narg(certification))
+  AND (sqlc.narg(tag)::varchar IS NULL OR sqlc.narg(tag) = ANY(tags))
+  AND (sqlc.narg(genre)::varchar IS NULL OR EXISTS (
+    SELECT 1
+    FROM movie_genres
+    JOIN genres ON genres.id = movie_genres.genre_id
+    WHERE movie_genres.movie_id = movies.id AND lower(genres.name) = lower(sqlc.narg(genre))
+  ))
+  AND (sqlc.narg(director_id)::bigint IS NULL OR director_id = sqlc.narg(director_id))
+  AND (sqlc.narg(min_rating)::smallint IS NULL OR rating >= sqlc.narg(min_rating))
+  AND (sqlc.narg(max_rating)::smallint IS NULL OR rating <= sqlc.narg(max_rating))
+  -- the page starts after the movie of the cursor in the same order, 0 is the first page
+  AND (sqlc.arg(after_id)::bigint = 0 OR EXISTS (
+    SELECT 1
+    FROM movies c
+    WHERE c.id = sqlc.arg(after_id) AND CASE
+      WHEN sqlc.arg(sort_by)::varchar = 'rating' AND NOT sqlc.arg(sort_desc)::boolean THEN (movies.rating, movies.id) > (c.rating, c.id)
+      WHEN sqlc.arg(sort_by) = 'rating' THEN (movies.rating, movies.id) < (c.rating, c.id)
+      WHEN sqlc.arg(sort_by) = 'title' AND NOT sqlc.arg(sort_desc) THEN (movies.title, movies.id) > (c.title, c.id)
+      WHEN sqlc.arg(sort_by) = 'title' THEN (movies.title, movies.id) < (c.title, c.id)
+      WHEN sqlc.arg(sort_by) = 'release_date' AND NOT sqlc.arg(sort_desc) THEN (movies.release_date, movies.id) > (c.release_date, c.id)
+      WHEN sqlc.arg(sort_by) = 'release_date' THEN (movies.release_date, movies.id) < (c.release_date, c.id)
+      WHEN NOT sqlc.arg(sort_desc) THEN movies.id > c.id
+      ELSE movies.id < c.id
+    END
+  ))
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by) = 'rating' AND NOT sqlc.arg(sort_desc) THEN rating END ASC,
+  CASE WHEN sqlc.arg(sort_by) = 'rating' AND sqlc.arg(sort_desc) THEN rating END DESC,
+  CASE WHEN sqlc.arg(sort_by) = 'title' AND NOT sqlc.arg(sort_desc) THEN title END ASC,
+  CASE WHEN sqlc.arg(sort_by) = 'title' AND sqlc.arg(sort_desc) THEN title END DESC,
+  CASE WHEN sqlc.arg(sort_by) = 'release_date' AND NOT sqlc.arg(sort_desc) THEN release_date END ASC,
+  CASE WHEN sqlc.arg(sort_by) = 'release_date' AND sqlc.arg(sort_desc) THEN release_date END DESC,
+  CASE WHEN NOT sqlc.arg(sort_desc) THEN id END ASC,
+  id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CountMovies :one
+SELECT count(*)
+FROM movies
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(certification)::varchar IS NULL OR certification = sqlc.narg(certification))
+  AND (sqlc.narg(tag)::varchar IS NULL OR sqlc.narg(tag) = ANY(tags))
+  AND (sqlc.narg(genre)::varchar IS NULL OR EXISTS (
+    SELECT 1
+    FROM movie_genres
+    JOIN genres ON genres.id = movie_genres.genre_id
+    WHERE movie_genres.movie_id = movies.id AND lower(genres.name) = lower(sqlc.narg(genre))
+  ))
+  AND (sqlc.narg(director_id)::bigint IS NULL OR director_id = sqlc.narg(director_id))
+  AND (sqlc.narg(min_rating)::smallint IS NULL OR rating >= sqlc.narg(min_rating))
+  AND (sqlc.narg(max_rating)::smallint IS NULL OR rating <= sqlc.narg(max_rating));
 
 -- name: GetMovie :one
 SELECT *
@@ -11,11 +62,47 @@ WHERE id = $1
 ORDER BY id
 LIMIT 1;
 
+-- name: ListMoviesByIDs :many
+SELECT *
+FROM movies
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
+
 -- name: CreateMovie :one
-INSERT INTO movies(title, director_id, rating, poster, summary)
-VALUES($1, $2, $3, $4, $5)
+INSERT INTO movies(title, director_id, rating, poster, summary, certification, tags, release_date, runtime)
+VALUES(
+  sqlc.arg(title), sqlc.arg(director_id), sqlc.arg(rating), sqlc.arg(poster), sqlc.arg(summary),
+  sqlc.arg(certification), sqlc.arg(tags), COALESCE(sqlc.narg(release_date), CURRENT_DATE), sqlc.arg(runtime)
+)
+RETURNING *;
+
+-- name: UpdateMovie :one
+UPDATE movies
+SET
+  title = COALESCE(sqlc.narg(title), title),
+  rating = COALESCE(sqlc.narg(rating), rating),
+  poster = COALESCE(sqlc.narg(poster), poster),
+  summary = COALESCE(sqlc.narg(summary), summary),
+  certification = COALESCE(sqlc.narg(certification), certification),
+  tags = COALESCE(sqlc.narg(tags), tags),
+  release_date = COALESCE(sqlc.narg(release_date), release_date),
+  runtime = COALESCE(sqlc.narg(runtime), runtime)
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SoftDeleteMovie :one
+UPDATE movies
+SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: DeleteMovie :exec
 DELETE FROM movies
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ListMoviesByDirector :many
+SELECT *
+FROM movies
+WHERE director_id = sqlc.arg(director_id) AND deleted_at IS NULL
+  AND (sqlc.narg(before_id)::bigint IS NULL OR id < sqlc.narg(before_id))
+ORDER BY id DESC
+LIMIT sqlc.arg(limit);
@@ -0,0 +1,15 @@
+-- name: CreatePasswordReset :one
+INSERT INTO password_resets(username, token_hash, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UsePasswordReset :one
+UPDATE password_resets
+SET used_at = now()
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+RETURNING *;
+
+-- name: RevokePasswordResets :exec
+UPDATE password_resets
+SET used_at = now()
+WHERE username = $1 AND used_at IS NULL;
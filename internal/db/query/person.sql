@@ -0,0 +1,18 @@
+-- name: CreatePerson :one
+INSERT INTO people(first_name, last_name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetPerson :one
+SELECT *
+FROM people
+WHERE id = $1
+LIMIT 1;
+
+-- name: UpdatePerson :one
+UPDATE people
+SET
+  first_name = COALESCE(sqlc.narg(first_name), first_name),
+  last_name = COALESCE(sqlc.narg(last_name), last_name)
+WHERE id = sqlc.arg(id)
+RETURNING *;
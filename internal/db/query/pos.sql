@@ -0,0 +1,40 @@
+-- name: OpenCashShift :one
+INSERT INTO cash_shifts(cashier, opening_cash)
+VALUES($1, $2)
+RETURNING *;
+
+-- name: GetCashShift :one
+SELECT *
+FROM cash_shifts
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetOpenCashShift :one
+SELECT *
+FROM cash_shifts
+WHERE cashier = $1 AND closed_at IS NULL
+LIMIT 1;
+
+-- name: CloseCashShift :one
+UPDATE cash_shifts
+SET counted_cash = $2, closed_at = now()
+WHERE id = $1 AND closed_at IS NULL
+RETURNING *;
+
+-- name: CreatePosSale :one
+INSERT INTO pos_sales(shift_id, movie_id, guest_name, payment_method, ticket_code, child, adult, total, currency, tax)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: GetPosSaleByCode :one
+SELECT *
+FROM pos_sales
+WHERE ticket_code = $1
+LIMIT 1;
+
+-- name: SummarizeCashShift :many
+SELECT payment_method, COUNT(*) AS sales, COALESCE(SUM(total), 0)::bigint AS total
+FROM pos_sales
+WHERE shift_id = $1
+GROUP BY payment_method
+ORDER BY payment_method;
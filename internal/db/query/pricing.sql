@@ -0,0 +1,28 @@
+-- name: CreatePricingRule :one
+INSERT INTO pricing_rules(
+  venue_id, name, kind, percent, starts_before, weekdays, holiday, min_occupancy, valid_from, valid_until
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: ListPricingRules :many
+SELECT *
+FROM pricing_rules
+WHERE id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: ListVenuePricingRules :many
+SELECT *
+FROM pricing_rules
+WHERE venue_id IS NULL OR venue_id = $1
+ORDER BY id;
+
+-- name: DeletePricingRule :execrows
+DELETE FROM pricing_rules
+WHERE id = $1;
+
+-- name: CountScreeningSeats :one
+SELECT COALESCE(SUM("adult" + "child"), 0)::bigint
+FROM tickets
+WHERE screening_id = $1;
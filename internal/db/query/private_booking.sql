@@ -0,0 +1,104 @@
+-- name: CreatePrivateBooking :one
+INSERT INTO private_bookings(
+  organizer, organization, contact_email, screening_id, venue_id, guests, notes
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetPrivateBooking :one
+SELECT *
+FROM private_bookings
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListVenuePrivateBookings :many
+SELECT *
+FROM private_bookings
+WHERE venue_id = sqlc.arg(venue_id)
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: ListOrganizerPrivateBookings :many
+SELECT *
+FROM private_bookings
+WHERE organizer = sqlc.arg(organizer) AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: QuotePrivateBooking :one
+UPDATE private_bookings
+SET status = 'quoted', net = sqlc.arg(net), tax = sqlc.arg(tax), total = sqlc.arg(total), deposit = sqlc.arg(deposit),
+  currency = sqlc.arg(currency), payment_terms_days = sqlc.arg(payment_terms_days), quoted_by = sqlc.arg(quoted_by),
+  quoted_at = now(), quote_expires_at = sqlc.arg(quote_expires_at)
+WHERE id = sqlc.arg(id) AND status IN ('requested', 'quoted')
+RETURNING *;
+
+-- name: AcceptPrivateBooking :one
+UPDATE private_bookings
+SET status = 'accepted', deposit_reference = sqlc.narg(deposit_reference), accepted_at = now()
+WHERE id = sqlc.arg(id) AND status = 'quoted' AND quoted_at = sqlc.arg(quoted_at) AND quote_expires_at > now()
+RETURNING *;
+
+-- name: ConfirmPrivateBooking :one
+UPDATE private_bookings
+SET status = 'confirmed', approved_by = sqlc.arg(approved_by), confirmed_at = now()
+WHERE id = sqlc.arg(id) AND status = 'accepted'
+RETURNING *;
+
+-- name: CancelPrivateBooking :one
+UPDATE private_bookings
+SET status = sqlc.arg(status), cancel_reason = sqlc.arg(cancel_reason), cancelled_at = now()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(previous_status)
+RETURNING *;
+
+-- name: CompletePrivateBookingRefund :one
+UPDATE private_bookings
+SET deposit_refund_reference = $2
+WHERE id = $1 AND deposit_refund_reference IS NULL
+RETURNING *;
+
+-- name: ListPendingPrivateBookingRefunds :many
+SELECT *
+FROM private_bookings
+WHERE cancelled_at IS NOT NULL AND deposit_reference IS NOT NULL
+  AND confirmed_at IS NULL AND deposit_refund_reference IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: BlockScreening :one
+UPDATE screenings
+SET private_booking_id = sqlc.arg(private_booking_id)
+WHERE id = sqlc.arg(id) AND private_booking_id IS NULL
+RETURNING *;
+
+-- name: ReleaseScreening :exec
+UPDATE screenings
+SET private_booking_id = NULL
+WHERE private_booking_id = $1;
+
+-- name: CreatePrivateBookingInvoice :one
+INSERT INTO private_booking_invoices(
+  booking_id, number, net, tax, total, deposit, balance, currency, issued_at, due_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: GetPrivateBookingInvoice :one
+SELECT *
+FROM private_booking_invoices
+WHERE booking_id = $1
+LIMIT 1;
+
+-- name: PayPrivateBookingInvoice :one
+UPDATE private_booking_invoices
+SET paid_at = now(), payment_reference = sqlc.arg(payment_reference)
+WHERE booking_id = sqlc.arg(booking_id) AND paid_at IS NULL AND voided_at IS NULL
+RETURNING *;
+
+-- name: VoidPrivateBookingInvoice :one
+UPDATE private_booking_invoices
+SET voided_at = now()
+WHERE booking_id = $1 AND paid_at IS NULL AND voided_at IS NULL
+RETURNING *;
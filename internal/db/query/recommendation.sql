@@ -0,0 +1,69 @@
+-- name: ListUserPurchases :many
+SELECT tickets.movie_id, movies.director_id, count(*) AS tickets
+FROM tickets
+JOIN movies ON movies.id = tickets.movie_id
+WHERE tickets.ticket_owner = $1
+GROUP BY tickets.movie_id, movies.director_id
+ORDER BY tickets.movie_id;
+
+-- name: ListNowShowingMovies :many
+SELECT *
+FROM movies
+WHERE deleted_at IS NULL AND EXISTS (
+  SELECT 1
+  FROM screenings
+  WHERE screenings.movie_id = movies.id AND published_at IS NOT NULL AND starts_at > now()
+    AND private_booking_id IS NULL
+)
+ORDER BY id;
+
+-- name: ListGenresOfMovies :many
+SELECT *
+FROM movie_genres
+WHERE movie_id = ANY(sqlc.arg(movie_ids)::bigint[])
+ORDER BY movie_id, genre_id;
+
+-- name: ListCoPurchases :many
+WITH mine AS (
+  SELECT DISTINCT movie_id
+  FROM tickets
+  WHERE ticket_owner = sqlc.arg(username)
+), peers AS (
+  SELECT DISTINCT ticket_owner
+  FROM tickets
+  WHERE movie_id IN (SELECT movie_id FROM mine) AND ticket_owner <> sqlc.arg(username)
+)
+SELECT tickets.movie_id, count(DISTINCT tickets.ticket_owner) AS buyers
+FROM tickets
+WHERE tickets.ticket_owner IN (SELECT ticket_owner FROM peers)
+  AND tickets.movie_id NOT IN (SELECT movie_id FROM mine)
+GROUP BY tickets.movie_id
+ORDER BY tickets.movie_id;
+
+-- name: ListBuyers :many
+SELECT DISTINCT ticket_owner
+FROM tickets
+ORDER BY ticket_owner;
+
+-- name: CreateUserRecommendation :exec
+INSERT INTO user_recommendations(username, movie_id, score, reasons)
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteUserRecommendations :exec
+DELETE FROM user_recommendations
+WHERE username = $1;
+
+-- name: ListUserRecommendations :many
+SELECT *
+FROM user_recommendations
+WHERE username = sqlc.arg(username) AND computed_at > sqlc.arg(computed_after)
+  AND EXISTS (
+    SELECT 1
+    FROM screenings
+    JOIN movies ON movies.id = screenings.movie_id
+    WHERE screenings.movie_id = user_recommendations.movie_id AND movies.deleted_at IS NULL
+      AND screenings.published_at IS NOT NULL AND screenings.starts_at > now()
+      AND screenings.private_booking_id IS NULL
+  )
+ORDER BY score DESC, movie_id
+LIMIT sqlc.arg(limit);
@@ -0,0 +1,45 @@
+-- name: ListDailySales :many
+WITH sales AS (
+  SELECT 'online'::varchar AS channel, movie_id, screening_id, payment_method, adult, child,
+    currency, total + discount AS gross, discount, tax, created_at
+  FROM tickets
+  WHERE created_at >= sqlc.arg(created_from) AND created_at < sqlc.arg(created_to)
+  UNION ALL
+  SELECT 'box_office'::varchar, movie_id, NULL::bigint, payment_method, adult, child,
+    currency, total, 0::bigint, tax, created_at
+  FROM pos_sales
+  WHERE created_at >= sqlc.arg(created_from) AND created_at < sqlc.arg(created_to)
+), lines AS (
+  -- the amounts of a sale are split to its ticket types by the seats, the adults get the remainder of the division
+  SELECT sales.channel, sales.movie_id, sales.screening_id, sales.payment_method, sales.currency, sales.created_at, types.*
+  FROM sales
+  CROSS JOIN LATERAL (VALUES
+    ('adult'::varchar, sales.adult::bigint,
+      sales.gross - sales.gross * sales.child / GREATEST(sales.adult + sales.child, 1),
+      sales.discount - sales.discount * sales.child / GREATEST(sales.adult + sales.child, 1),
+      sales.tax - sales.tax * sales.child / GREATEST(sales.adult + sales.child, 1)),
+    ('child'::varchar, sales.child::bigint,
+      sales.gross * sales.child / GREATEST(sales.adult + sales.child, 1),
+      sales.discount * sales.child / GREATEST(sales.adult + sales.child, 1),
+      sales.tax * sales.child / GREATEST(sales.adult + sales.child, 1))
+  ) AS types(ticket_type, quantity, gross, discount, tax)
+  WHERE types.quantity > 0
+)
+SELECT
+  (lines.created_at AT TIME ZONE sqlc.arg(time_zone)::text)::date AS day,
+  lines.movie_id,
+  movies.title,
+  lines.screening_id,
+  lines.ticket_type,
+  lines.payment_method,
+  lines.channel,
+  lines.currency,
+  sum(lines.quantity)::bigint AS quantity,
+  sum(lines.gross)::bigint AS gross,
+  sum(lines.discount)::bigint AS discount,
+  sum(lines.tax)::bigint AS tax,
+  sum(lines.gross - lines.discount - lines.tax)::bigint AS net
+FROM lines
+JOIN movies ON movies.id = lines.movie_id
+GROUP BY day, lines.movie_id, movies.title, lines.screening_id, lines.ticket_type, lines.payment_method, lines.channel, lines.currency
+ORDER BY day, lines.movie_id, lines.screening_id NULLS FIRST, lines.ticket_type, lines.payment_method, lines.channel, lines.currency;
@@ -0,0 +1,67 @@
+-- name: CreateReview :one
+INSERT INTO reviews(movie_id, username, rating, body, status, verified)
+VALUES (
+  sqlc.arg(movie_id), sqlc.arg(username), sqlc.arg(rating), sqlc.arg(body), sqlc.arg(status),
+  EXISTS (
+    SELECT 1
+    FROM tickets
+    WHERE tickets.movie_id = sqlc.arg(movie_id) AND ticket_owner = sqlc.arg(username) AND checked_in_at IS NOT NULL
+  )
+)
+RETURNING *;
+
+-- name: GetReview :one
+SELECT *
+FROM reviews
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetReviewForUpdate :one
+SELECT *
+FROM reviews
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListMovieReviews :many
+SELECT *
+FROM reviews
+WHERE movie_id = sqlc.arg(movie_id) AND status = 'approved'
+  AND (sqlc.narg(before_id)::bigint IS NULL OR id < sqlc.narg(before_id))
+ORDER BY id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListReviewsByStatus :many
+SELECT *
+FROM reviews
+WHERE status = sqlc.arg(status)
+  AND (sqlc.narg(before_id)::bigint IS NULL OR id < sqlc.narg(before_id))
+ORDER BY id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: UpdateReviewStatus :one
+UPDATE reviews
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteReview :exec
+DELETE FROM reviews
+WHERE id = $1;
+
+-- name: VerifyReviews :exec
+UPDATE reviews
+SET verified = true
+WHERE movie_id = $1 AND username = $2;
+
+-- name: GetMovieReviewStats :one
+SELECT
+  count(*) AS count,
+  COALESCE(avg(rating), 0)::float8 AS average,
+  count(*) FILTER (WHERE rating = 1) AS one_star,
+  count(*) FILTER (WHERE rating = 2) AS two_stars,
+  count(*) FILTER (WHERE rating = 3) AS three_stars,
+  count(*) FILTER (WHERE rating = 4) AS four_stars,
+  count(*) FILTER (WHERE rating = 5) AS five_stars
+FROM reviews
+WHERE movie_id = $1 AND status = 'approved';
@@ -0,0 +1,79 @@
+-- name: CreateScreening :one
+INSERT INTO screenings(
+  movie_id, starts_at, auditorium_id, trailer_minutes, ends_at, blocked_until,
+  format, audio_language, subtitle_language, dubbed, audio_description, relaxed
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: GetScreening :one
+SELECT *
+FROM screenings
+WHERE id = $1
+LIMIT 1;
+
+-- name: PublishScreening :one
+UPDATE screenings
+SET published_at = now()
+WHERE id = $1 AND published_at IS NULL
+RETURNING *;
+
+-- name: ListMovieScreenings :many
+SELECT *
+FROM screenings
+WHERE movie_id = sqlc.arg(movie_id) AND published_at IS NOT NULL AND starts_at > now()
+  AND private_booking_id IS NULL
+  AND (sqlc.narg(format)::varchar IS NULL OR screenings.format = sqlc.narg(format))
+  AND (sqlc.narg(audio_language)::varchar IS NULL OR screenings.audio_language = sqlc.narg(audio_language))
+  AND (sqlc.narg(subtitle_language)::varchar IS NULL OR screenings.subtitle_language = sqlc.narg(subtitle_language))
+  AND (sqlc.narg(subtitled)::boolean IS NULL OR (screenings.subtitle_language IS NOT NULL) = sqlc.narg(subtitled))
+  AND (sqlc.narg(dubbed)::boolean IS NULL OR screenings.dubbed = sqlc.narg(dubbed))
+  AND (sqlc.narg(audio_description)::boolean IS NULL OR screenings.audio_description = sqlc.narg(audio_description))
+  AND (sqlc.narg(relaxed)::boolean IS NULL OR screenings.relaxed = sqlc.narg(relaxed))
+ORDER BY starts_at;
+
+-- name: ListUnnotifiedScreenings :many
+SELECT *
+FROM screenings
+WHERE published_at IS NOT NULL AND watchers_notified_at IS NULL AND starts_at > now()
+  AND private_booking_id IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: MarkScreeningsNotified :exec
+UPDATE screenings
+SET watchers_notified_at = now()
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
+
+-- name: ListOverlappingScreenings :many
+SELECT *
+FROM screenings
+WHERE auditorium_id = sqlc.arg(auditorium_id)
+  AND starts_at < sqlc.arg(blocked_until)
+  AND blocked_until > sqlc.arg(starts_at)
+ORDER BY starts_at, id;
+
+-- name: ListScreeningFormats :many
+SELECT *
+FROM screening_formats
+ORDER BY surcharge, code;
+
+-- name: GetScreeningFormat :one
+SELECT *
+FROM screening_formats
+WHERE code = $1
+LIMIT 1;
+
+-- name: UpsertScreeningFormat :one
+INSERT INTO screening_formats(code, name, surcharge)
+VALUES ($1, $2, $3)
+ON CONFLICT (code) DO UPDATE
+SET name = EXCLUDED.name, surcharge = EXCLUDED.surcharge
+RETURNING *;
+
+-- name: GetScreeningForSale :one
+SELECT *
+FROM screenings
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
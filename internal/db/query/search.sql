@@ -0,0 +1,41 @@
+-- name: SearchMovies :many
+SELECT
+  movies.id,
+  movies.title,
+  movies.poster,
+  movies.certification,
+  (
+    ts_rank(setweight(to_tsvector('english', movies.title), 'A') || setweight(to_tsvector('english', movies.summary), 'B'), query)
+    + word_similarity(sqlc.arg(q), movies.title)
+    + COALESCE(MAX(word_similarity(sqlc.arg(q), people.first_name || ' ' || people.last_name)), 0)
+  )::real AS rank,
+  -- the summary is HTML escaped before it is highlighted, so the bold tags are the only markup of the snippet
+  ts_headline(
+    'english',
+    replace(replace(replace(replace(replace(movies.summary, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;'),
+    query,
+    'StartSel=<b>, StopSel=</b>, MaxWords=20, MinWords=5'
+  ) AS snippet
+FROM movies
+CROSS JOIN websearch_to_tsquery('english', sqlc.arg(q)) AS query
+LEFT JOIN movie_credits ON movie_credits.movie_id = movies.id
+LEFT JOIN people ON people.id = movie_credits.person_id
+WHERE movies.deleted_at IS NULL
+GROUP BY movies.id, query
+HAVING (setweight(to_tsvector('english', movies.title), 'A') || setweight(to_tsvector('english', movies.summary), 'B')) @@ query
+  OR sqlc.arg(q) <% movies.title
+  OR bool_or(sqlc.arg(q) <% (people.first_name || ' ' || people.last_name))
+ORDER BY rank DESC, movies.id DESC
+LIMIT sqlc.arg(count);
+
+-- name: AutocompleteMovies :many
+WITH pattern AS (
+  -- the wildcards of the prefix are escaped so they only match themselves
+  SELECT replace(replace(replace(sqlc.arg(prefix)::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' AS value
+)
+SELECT movies.id, movies.title
+FROM movies
+CROSS JOIN pattern
+WHERE movies.deleted_at IS NULL AND (movies.title ILIKE pattern.value ESCAPE '\' OR sqlc.arg(prefix) <% movies.title)
+ORDER BY movies.title ILIKE pattern.value ESCAPE '\' DESC, word_similarity(sqlc.arg(prefix), movies.title) DESC, movies.title
+LIMIT sqlc.arg(count);
@@ -1,6 +1,8 @@
 -- name: CreateTicket :one
-INSERT INTO tickets(movie_id, ticket_owner, child, adult, total)
-VALUES($1, $2, $3, $4, $5)
+INSERT INTO tickets(
+  movie_id, ticket_owner, child, adult, total, screening_id, surcharge, tax, currency, discount, payment_method, membership_id
+)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 RETURNING *;
 
 -- name: GetTicket :one
@@ -9,14 +11,26 @@ FROM tickets
 WHERE id = $1
 LIMIT 1;
 
+-- name: GetTicketForUpdate :one
+SELECT *
+FROM tickets
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
 -- name: ListTickets :many
 SELECT *
 FROM tickets
-WHERE ticket_owner = $1
+WHERE ticket_owner = sqlc.arg(ticket_owner) AND id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg(limit);
 
 -- name: DeleteTicket :exec
 DELETE FROM tickets
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: CheckInTicket :one
+UPDATE tickets
+SET checked_in_at = now()
+WHERE id = $1 AND checked_in_at IS NULL
+RETURNING *;
@@ -7,4 +7,22 @@ RETURNING *;
 SELECT *
 FROM users
 WHERE username = $1
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+
+-- name: GetUserByEmail :one
+SELECT *
+FROM users
+WHERE email = $1
+LIMIT 1;
+
+-- name: GetPasswordChangedAt :one
+SELECT password_changed_at
+FROM users
+WHERE username = $1
+LIMIT 1;
+
+-- name: UpdateUserPassword :one
+UPDATE users
+SET hashed_password = sqlc.arg(hashed_password), password_changed_at = sqlc.arg(password_changed_at)
+WHERE username = sqlc.arg(username)
+RETURNING *;
@@ -0,0 +1,98 @@
+-- name: CreateVenue :one
+INSERT INTO venues(name, address, time_zone, adult_price, child_price, tax_rate)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetVenue :one
+SELECT *
+FROM venues
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListVenues :many
+SELECT *
+FROM venues
+WHERE id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: CreateVenueHours :one
+INSERT INTO venue_hours(venue_id, weekday, opens, closes)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListVenueHours :many
+SELECT *
+FROM venue_hours
+WHERE venue_id = ANY(sqlc.arg(venue_ids)::bigint[])
+ORDER BY venue_id, weekday;
+
+-- name: CreateAuditorium :one
+INSERT INTO auditoriums(venue_id, name, seats, cleaning_minutes)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAuditorium :one
+SELECT *
+FROM auditoriums
+WHERE id = $1
+LIMIT 1;
+
+-- name: LockAuditorium :one
+SELECT *
+FROM auditoriums
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListVenueAuditoriums :many
+SELECT *
+FROM auditoriums
+WHERE venue_id = $1
+ORDER BY name;
+
+-- name: ListVenueScreenings :many
+SELECT screenings.id, screenings.movie_id, screenings.starts_at, screenings.published_at,
+  screenings.watchers_notified_at, screenings.created_at, screenings.auditorium_id,
+  screenings.trailer_minutes, screenings.ends_at, screenings.blocked_until, screenings.format,
+  screenings.audio_language, screenings.subtitle_language, screenings.dubbed, screenings.audio_description,
+  screenings.relaxed, screenings.private_booking_id
+FROM screenings
+JOIN auditoriums ON auditoriums.id = screenings.auditorium_id
+JOIN movies ON movies.id = screenings.movie_id
+WHERE auditoriums.venue_id = sqlc.arg(venue_id)
+  AND screenings.published_at IS NOT NULL
+  AND screenings.private_booking_id IS NULL
+  AND screenings.starts_at > now()
+  AND screenings.starts_at < sqlc.arg(starts_before)
+  AND movies.deleted_at IS NULL
+  AND (sqlc.narg(format)::varchar IS NULL OR screenings.format = sqlc.narg(format))
+  AND (sqlc.narg(audio_language)::varchar IS NULL OR screenings.audio_language = sqlc.narg(audio_language))
+  AND (sqlc.narg(subtitle_language)::varchar IS NULL OR screenings.subtitle_language = sqlc.narg(subtitle_language))
+  AND (sqlc.narg(subtitled)::boolean IS NULL OR (screenings.subtitle_language IS NOT NULL) = sqlc.narg(subtitled))
+  AND (sqlc.narg(dubbed)::boolean IS NULL OR screenings.dubbed = sqlc.narg(dubbed))
+  AND (sqlc.narg(audio_description)::boolean IS NULL OR screenings.audio_description = sqlc.narg(audio_description))
+  AND (sqlc.narg(relaxed)::boolean IS NULL OR screenings.relaxed = sqlc.narg(relaxed))
+ORDER BY screenings.starts_at, screenings.id;
+
+-- name: UpsertVenueStaff :one
+INSERT INTO venue_staff(username, venue_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (username, venue_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: GetVenueStaff :one
+SELECT *
+FROM venue_staff
+WHERE username = $1 AND venue_id = $2
+LIMIT 1;
+
+-- name: ListVenueStaff :many
+SELECT *
+FROM venue_staff
+WHERE venue_id = $1
+ORDER BY username;
+
+-- name: DeleteVenueStaff :execrows
+DELETE FROM venue_staff
+WHERE username = $1 AND venue_id = $2;
@@ -0,0 +1,22 @@
+-- name: AddWatchlistItem :one
+INSERT INTO watchlist_items(username, movie_id, notify)
+VALUES ($1, $2, $3)
+ON CONFLICT (username, movie_id) DO UPDATE SET notify = EXCLUDED.notify
+RETURNING *;
+
+-- name: ListWatchlist :many
+SELECT *
+FROM watchlist_items
+WHERE username = sqlc.arg(username) AND movie_id > sqlc.arg(after_id)
+ORDER BY movie_id
+LIMIT sqlc.arg(limit);
+
+-- name: RemoveWatchlistItem :execrows
+DELETE FROM watchlist_items
+WHERE username = $1 AND movie_id = $2;
+
+-- name: ListWatchers :many
+SELECT *
+FROM watchlist_items
+WHERE movie_id = ANY(sqlc.arg(movie_ids)::bigint[]) AND notify = true
+ORDER BY username, movie_id;
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomAward creates a random award of given year for a random person
+func createRandomAward(t *testing.T, year int32) Award {
+	p := createRandomPerson(t)
+	arg := CreateAwardParams{
+		Name:     util.RandomName(),
+		Kind:     "other",
+		Year:     sql.NullInt32{Int32: year, Valid: true},
+		Category: util.RandomName(),
+		Winner:   true,
+		PersonID: sql.NullInt64{Int64: p.ID, Valid: true},
+	}
+
+	a, err := testQueries.CreateAward(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, a.ID)
+	require.Equal(t, arg.Name, a.Name)
+	require.Equal(t, arg.Kind, a.Kind)
+	require.Equal(t, arg.Year, a.Year)
+	require.Equal(t, arg.Category, a.Category)
+	require.Equal(t, arg.Winner, a.Winner)
+	require.Equal(t, arg.PersonID, a.PersonID)
+	require.False(t, a.MovieID.Valid)
+
+	return a
+}
+
+// TestCreateAward tests CreateAward DB operation
+func TestCreateAward(t *testing.T) {
+	createRandomAward(t, 2001)
+
+	// an award must be given to someone
+	_, err := testQueries.CreateAward(context.Background(), CreateAwardParams{
+		Name:     util.RandomName(),
+		Kind:     "other",
+		Category: util.RandomName(),
+	})
+	require.Error(t, err)
+
+	// the kinds are a fixed set
+	_, err = testQueries.CreateAward(context.Background(), CreateAwardParams{
+		Name:     util.RandomName(),
+		Kind:     util.RandomString(6),
+		Category: util.RandomName(),
+		PersonID: sql.NullInt64{Int64: createRandomPerson(t).ID, Valid: true},
+	})
+	require.Error(t, err)
+}
+
+// TestGetAward tests GetAward DB operation
+func TestGetAward(t *testing.T) {
+	a1 := createRandomAward(t, 2002)
+
+	a2, err := testQueries.GetAward(context.Background(), a1.ID)
+	require.NoError(t, err)
+	require.Equal(t, a1.ID, a2.ID)
+	require.Equal(t, a1.Name, a2.Name)
+	require.Equal(t, a1.PersonID, a2.PersonID)
+}
+
+// TestListAwardsByYear tests ListAwardsByYear DB operation
+func TestListAwardsByYear(t *testing.T) {
+	year := int32(util.RandomInt(1900, 1950))
+	for i := 0; i < 3; i++ {
+		createRandomAward(t, year)
+	}
+
+	arg := ListAwardsByYearParams{
+		Year:  sql.NullInt32{Int32: year, Valid: true},
+		Limit: 2,
+	}
+
+	awards, err := testQueries.ListAwardsByYear(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, awards, 2)
+
+	for _, a := range awards {
+		require.Equal(t, year, a.Year.Int32)
+	}
+
+	// the next page starts after the last award of the first page in the same order
+	arg.AfterID = awards[1].ID
+	next, err := testQueries.ListAwardsByYear(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, next)
+
+	for _, a := range next {
+		require.NotEqual(t, awards[0].ID, a.ID)
+		require.NotEqual(t, awards[1].ID, a.ID)
+		require.LessOrEqual(t, awards[1].Name, a.Name)
+	}
+}
+
+// TestDeleteAward tests DeleteAward DB operation
+func TestDeleteAward(t *testing.T) {
+	a := createRandomAward(t, 2003)
+
+	_, err := testQueries.DeleteAward(context.Background(), a.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetAward(context.Background(), a.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
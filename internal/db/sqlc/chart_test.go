@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sellTickets creates a ticket of given seats and total that outranks the other tests' tickets
+func sellTickets(t *testing.T, m Movie, adult int16, total int64) {
+	u := createRandomUser(t)
+
+	_, err := testQueries.CreateTicket(context.Background(), CreateTicketParams{
+		TicketOwner:   u.Username,
+		MovieID:       m.ID,
+		Adult:         adult,
+		Total:         total,
+		Currency:      "USD",
+		PaymentMethod: "card",
+	})
+	require.NoError(t, err)
+}
+
+// refreshCharts refreshes the chart views
+func refreshCharts(t *testing.T) {
+	require.NoError(t, testQueries.RefreshMovieDailySales(context.Background()))
+	require.NoError(t, testQueries.RefreshMovieTrending(context.Background()))
+}
+
+// TestListBoxOffice tests ListBoxOffice DB operation
+func TestListBoxOffice(t *testing.T) {
+	m := createRandomMovie(t)
+	deleted := createRandomMovie(t)
+	sellTickets(t, m, 30000, 1_000_000_000_000)
+	sellTickets(t, deleted, 30000, 2_000_000_000_000)
+
+	_, err := testQueries.SoftDeleteMovie(context.Background(), deleted.ID)
+	require.NoError(t, err)
+
+	refreshCharts(t)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	rows, err := testQueries.ListBoxOffice(context.Background(), ListBoxOfficeParams{
+		CurrentFrom:  today.AddDate(0, 0, -6),
+		PreviousFrom: today.AddDate(0, 0, -13),
+		Limit:        1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, m.ID, rows[0].MovieID)
+	require.Equal(t, int64(30000), rows[0].Tickets)
+	require.Equal(t, int64(1_000_000_000_000), rows[0].Revenue)
+	require.Zero(t, rows[0].PreviousTickets)
+	require.Zero(t, rows[0].PreviousRevenue)
+
+	// the sales of today are in the previous window of tomorrow's 1 day window
+	tomorrow := today.AddDate(0, 0, 1)
+	rows, err = testQueries.ListBoxOffice(context.Background(), ListBoxOfficeParams{
+		CurrentFrom:  tomorrow,
+		PreviousFrom: today,
+		Limit:        50,
+	})
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
+
+// TestListTrendingMovies tests ListTrendingMovies DB operation
+func TestListTrendingMovies(t *testing.T) {
+	m := createRandomMovie(t)
+	sellTickets(t, m, 32000, 10)
+
+	refreshCharts(t)
+
+	rows, err := testQueries.ListTrendingMovies(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, m.ID, rows[0].MovieID)
+	require.Equal(t, int64(32000), rows[0].Tickets)
+	require.Equal(t, int64(10), rows[0].Revenue)
+	require.WithinDuration(t, time.Now(), rows[0].RefreshedAt, time.Minute)
+}
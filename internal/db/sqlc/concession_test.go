@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomConcessionItem creates a random concession item with given stock
+func createRandomConcessionItem(t *testing.T, stock int32) ConcessionItem {
+	arg := CreateConcessionItemParams{
+		Name:     util.RandomName(),
+		Category: "drink",
+		Price:    util.RandomInt(5, 20),
+		Stock:    stock,
+	}
+
+	item, err := testQueries.CreateConcessionItem(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, item)
+
+	require.NotZero(t, item.ID)
+	require.NotZero(t, item.CreatedAt)
+	require.Equal(t, arg.Name, item.Name)
+	require.Equal(t, arg.Category, item.Category)
+	require.Equal(t, arg.Price, item.Price)
+	require.Equal(t, arg.Stock, item.Stock)
+
+	return item
+}
+
+// TestGetConcessionItem tests GetConcessionItem DB operation
+func TestGetConcessionItem(t *testing.T) {
+	item1 := createRandomConcessionItem(t, 10)
+
+	item2, err := testQueries.GetConcessionItem(context.Background(), item1.ID)
+	require.NoError(t, err)
+	require.Equal(t, item1.Name, item2.Name)
+	require.Equal(t, item1.Stock, item2.Stock)
+}
+
+// TestListConcessionItems tests ListConcessionItems DB operation
+func TestListConcessionItems(t *testing.T) {
+	createRandomConcessionItem(t, 10)
+
+	items, err := testQueries.ListConcessionItems(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, items)
+}
+
+// TestAddConcessionStock tests AddConcessionStock DB operation
+func TestAddConcessionStock(t *testing.T) {
+	item1 := createRandomConcessionItem(t, 10)
+
+	item2, err := testQueries.AddConcessionStock(context.Background(), AddConcessionStockParams{ID: item1.ID, Amount: 5})
+	require.NoError(t, err)
+	require.Equal(t, item1.Stock+5, item2.Stock)
+}
+
+// TestDecrementConcessionStock tests DecrementConcessionStock DB operation
+func TestDecrementConcessionStock(t *testing.T) {
+	item1 := createRandomConcessionItem(t, 3)
+
+	item2, err := testQueries.DecrementConcessionStock(context.Background(), DecrementConcessionStockParams{ID: item1.ID, Quantity: 2})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), item2.Stock)
+
+	// stock never goes below zero
+	_, err = testQueries.DecrementConcessionStock(context.Background(), DecrementConcessionStockParams{ID: item1.ID, Quantity: 2})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
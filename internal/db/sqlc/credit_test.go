@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomMovieCredit credits a random person in given movie
+func createRandomMovieCredit(t *testing.T, movie Movie, role string, billingOrder int32) MovieCredit {
+	p := createRandomPerson(t)
+	arg := CreateMovieCreditParams{
+		MovieID:      movie.ID,
+		PersonID:     p.ID,
+		Role:         role,
+		BillingOrder: billingOrder,
+	}
+
+	credit, err := testQueries.CreateMovieCredit(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, credit.ID)
+	require.Equal(t, arg.MovieID, credit.MovieID)
+	require.Equal(t, arg.PersonID, credit.PersonID)
+	require.Equal(t, arg.Role, credit.Role)
+	require.Equal(t, arg.BillingOrder, credit.BillingOrder)
+
+	return credit
+}
+
+// TestCreateMovieCredit tests CreateMovieCredit DB operation
+func TestCreateMovieCredit(t *testing.T) {
+	m := createRandomMovie(t)
+	credit := createRandomMovieCredit(t, m, "actor", 1)
+
+	// a person can't be credited twice with the same role
+	_, err := testQueries.CreateMovieCredit(context.Background(), CreateMovieCreditParams{
+		MovieID:  m.ID,
+		PersonID: credit.PersonID,
+		Role:     "actor",
+	})
+	require.Error(t, err)
+
+	// only the known roles are accepted
+	_, err = testQueries.CreateMovieCredit(context.Background(), CreateMovieCreditParams{
+		MovieID:  m.ID,
+		PersonID: credit.PersonID,
+		Role:     "stunt",
+	})
+	require.Error(t, err)
+}
+
+// TestListMovieCredits tests ListMovieCredits DB operation
+func TestListMovieCredits(t *testing.T) {
+	m := createRandomMovie(t)
+	second := createRandomMovieCredit(t, m, "actor", 2)
+	first := createRandomMovieCredit(t, m, "actor", 1)
+
+	credits, err := testQueries.ListMovieCredits(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Len(t, credits, 2)
+
+	require.Equal(t, first.ID, credits[0].ID)
+	require.Equal(t, second.ID, credits[1].ID)
+	require.NotEmpty(t, credits[0].FirstName)
+}
+
+// TestDeleteMovieCredit tests DeleteMovieCredit DB operation
+func TestDeleteMovieCredit(t *testing.T) {
+	m := createRandomMovie(t)
+	credit := createRandomMovieCredit(t, m, "composer", 0)
+
+	// the credit must belong to the given movie
+	_, err := testQueries.DeleteMovieCredit(context.Background(), DeleteMovieCreditParams{ID: credit.ID, MovieID: m.ID + 1})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.DeleteMovieCredit(context.Background(), DeleteMovieCreditParams{ID: credit.ID, MovieID: m.ID})
+	require.NoError(t, err)
+
+	credits, err := testQueries.ListMovieCredits(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Empty(t, credits)
+}
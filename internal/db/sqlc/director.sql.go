@@ -7,22 +7,25 @@ package db
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const createDirector = `-- name: CreateDirector :one
-INSERT INTO directors(first_name, last_name, oscars)
+INSERT INTO directors(first_name, last_name, person_id)
 VALUES ($1, $2, $3)
-RETURNING id, first_name, last_name, oscars, created_at
+RETURNING id, first_name, last_name, oscars, created_at, person_id
 `
 
 type CreateDirectorParams struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
-	Oscars    int64  `json:"oscars"`
+	PersonID  int64  `json:"person_id"`
 }
 
 func (q *Queries) CreateDirector(ctx context.Context, arg CreateDirectorParams) (Director, error) {
-	row := q.db.QueryRowContext(ctx, createDirector, arg.FirstName, arg.LastName, arg.Oscars)
+	row := q.db.QueryRowContext(ctx, createDirector, arg.FirstName, arg.LastName, arg.PersonID)
 	var i Director
 	err := row.Scan(
 		&i.ID,
@@ -30,12 +33,33 @@ func (q *Queries) CreateDirector(ctx context.Context, arg CreateDirectorParams)
 		&i.LastName,
 		&i.Oscars,
 		&i.CreatedAt,
+		&i.PersonID,
+	)
+	return i, err
+}
+
+const deleteDirector = `-- name: DeleteDirector :one
+DELETE FROM directors
+WHERE id = $1
+RETURNING id, first_name, last_name, oscars, created_at, person_id
+`
+
+func (q *Queries) DeleteDirector(ctx context.Context, id int64) (Director, error) {
+	row := q.db.QueryRowContext(ctx, deleteDirector, id)
+	var i Director
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Oscars,
+		&i.CreatedAt,
+		&i.PersonID,
 	)
 	return i, err
 }
 
 const getDirector = `-- name: GetDirector :one
-SELECT id, first_name, last_name, oscars, created_at
+SELECT id, first_name, last_name, oscars, created_at, person_id
 FROM directors
 WHERE id = $1
 LIMIT 1
@@ -50,25 +74,26 @@ func (q *Queries) GetDirector(ctx context.Context, id int64) (Director, error) {
 		&i.LastName,
 		&i.Oscars,
 		&i.CreatedAt,
+		&i.PersonID,
 	)
 	return i, err
 }
 
 const listDirectors = `-- name: ListDirectors :many
-SELECT id, first_name, last_name, oscars, created_at
+SELECT id, first_name, last_name, oscars, created_at, person_id
 FROM directors
+WHERE id > $1
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
 `
 
 type ListDirectorsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListDirectors(ctx context.Context, arg ListDirectorsParams) ([]Director, error) {
-	rows, err := q.db.QueryContext(ctx, listDirectors, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listDirectors, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +107,7 @@ func (q *Queries) ListDirectors(ctx context.Context, arg ListDirectorsParams) ([
 			&i.LastName,
 			&i.Oscars,
 			&i.CreatedAt,
+			&i.PersonID,
 		); err != nil {
 			return nil, err
 		}
@@ -95,3 +121,83 @@ func (q *Queries) ListDirectors(ctx context.Context, arg ListDirectorsParams) ([
 	}
 	return items, nil
 }
+
+const listDirectorsByIDs = `-- name: ListDirectorsByIDs :many
+SELECT id, first_name, last_name, oscars, created_at, person_id
+FROM directors
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) ListDirectorsByIDs(ctx context.Context, ids []int64) ([]Director, error) {
+	rows, err := q.db.QueryContext(ctx, listDirectorsByIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Director{}
+	for rows.Next() {
+		var i Director
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Oscars,
+			&i.CreatedAt,
+			&i.PersonID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const refreshDirectorOscars = `-- name: RefreshDirectorOscars :exec
+UPDATE directors
+SET oscars = (
+  SELECT COUNT(*)
+  FROM awards
+  WHERE awards.person_id = directors.person_id AND kind = 'oscar' AND winner
+)
+WHERE person_id = $1
+`
+
+func (q *Queries) RefreshDirectorOscars(ctx context.Context, personID int64) error {
+	_, err := q.db.ExecContext(ctx, refreshDirectorOscars, personID)
+	return err
+}
+
+const updateDirector = `-- name: UpdateDirector :one
+UPDATE directors
+SET
+  first_name = COALESCE($1, first_name),
+  last_name = COALESCE($2, last_name)
+WHERE id = $3
+RETURNING id, first_name, last_name, oscars, created_at, person_id
+`
+
+type UpdateDirectorParams struct {
+	FirstName sql.NullString `json:"first_name"`
+	LastName  sql.NullString `json:"last_name"`
+	ID        int64          `json:"id"`
+}
+
+func (q *Queries) UpdateDirector(ctx context.Context, arg UpdateDirectorParams) (Director, error) {
+	row := q.db.QueryRowContext(ctx, updateDirector, arg.FirstName, arg.LastName, arg.ID)
+	var i Director
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Oscars,
+		&i.CreatedAt,
+		&i.PersonID,
+	)
+	return i, err
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -11,10 +12,11 @@ import (
 
 // createRandomDirector takes testing.T as arg and returns a random director
 func createRandomDirector(t *testing.T) Director {
+	p := createRandomPerson(t)
 	arg := CreateDirectorParams{
-		FirstName: util.RandomName(),
-		LastName:  util.RandomName(),
-		Oscars:    util.RandomInt(0, 5),
+		FirstName: p.FirstName,
+		LastName:  p.LastName,
+		PersonID:  p.ID,
 	}
 
 	director, err := testQueries.CreateDirector(context.Background(), arg)
@@ -24,7 +26,8 @@ func createRandomDirector(t *testing.T) Director {
 
 	require.Equal(t, arg.FirstName, director.FirstName)
 	require.Equal(t, arg.LastName, director.LastName)
-	require.Equal(t, arg.Oscars, director.Oscars)
+	require.Zero(t, director.Oscars)
+	require.Equal(t, arg.PersonID, director.PersonID)
 	require.NotZero(t, director.ID)
 	require.NotZero(t, director.CreatedAt)
 
@@ -51,22 +54,77 @@ func TestGetDirector(t *testing.T) {
 	require.WithinDuration(t, d1.CreatedAt, d2.CreatedAt, time.Second)
 }
 
-// TestListDirectors creates 10 accounts and gets 5 of those from DB
+// TestListDirectors creates 10 directors and gets 5 of those that come after the first one from DB
 func TestListDirectors(t *testing.T) {
+	var first Director
 	for i := 0; i < 10; i++ {
-		createRandomDirector(t)
+		d := createRandomDirector(t)
+		if i == 0 {
+			first = d
+		}
 	}
 
 	arg := ListDirectorsParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: first.ID,
+		Limit:   5,
 	}
 
 	directors, err := testQueries.ListDirectors(context.Background(), arg)
 	require.NoError(t, err)
 	require.Len(t, directors, 5)
 
-	for _, v := range directors {
+	for i, v := range directors {
 		require.NotEmpty(t, v)
+		require.Greater(t, v.ID, arg.AfterID)
+		if i > 0 {
+			require.Greater(t, v.ID, directors[i-1].ID)
+		}
 	}
 }
+
+// TestListDirectorsByIDs tests ListDirectorsByIDs DB operation
+func TestListDirectorsByIDs(t *testing.T) {
+	d1 := createRandomDirector(t)
+	d2 := createRandomDirector(t)
+
+	directors, err := testQueries.ListDirectorsByIDs(context.Background(), []int64{d1.ID, d2.ID, d1.ID})
+	require.NoError(t, err)
+	require.Len(t, directors, 2)
+	require.ElementsMatch(t, []int64{d1.ID, d2.ID}, []int64{directors[0].ID, directors[1].ID})
+
+	directors, err = testQueries.ListDirectorsByIDs(context.Background(), []int64{})
+	require.NoError(t, err)
+	require.Empty(t, directors)
+}
+
+// TestUpdateDirector tests UpdateDirector DB operation
+func TestUpdateDirector(t *testing.T) {
+	d1 := createRandomDirector(t)
+	arg := UpdateDirectorParams{
+		ID:       d1.ID,
+		LastName: sql.NullString{String: util.RandomName(), Valid: true},
+	}
+
+	d2, err := testQueries.UpdateDirector(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, d1.FirstName, d2.FirstName)
+	require.Equal(t, arg.LastName.String, d2.LastName)
+	require.Equal(t, d1.Oscars, d2.Oscars)
+}
+
+// TestDeleteDirector tests DeleteDirector DB operation
+func TestDeleteDirector(t *testing.T) {
+	d1 := createRandomDirector(t)
+
+	_, err := testQueries.DeleteDirector(context.Background(), d1.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetDirector(context.Background(), d1.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	// directors with movies can't be deleted
+	m := createRandomMovie(t)
+	_, err = testQueries.DeleteDirector(context.Background(), m.DirectorID)
+	require.Error(t, err)
+}
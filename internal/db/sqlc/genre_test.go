@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomGenre creates a random genre
+func createRandomGenre(t *testing.T) Genre {
+	name := util.RandomString(12)
+
+	g, err := testQueries.CreateGenre(context.Background(), name)
+	require.NoError(t, err)
+	require.NotZero(t, g.ID)
+	require.Equal(t, name, g.Name)
+
+	return g
+}
+
+// TestCreateGenre tests CreateGenre DB operation
+func TestCreateGenre(t *testing.T) {
+	g := createRandomGenre(t)
+
+	// genre names are unique regardless of their case
+	_, err := testQueries.CreateGenre(context.Background(), strings.ToUpper(g.Name))
+	require.Error(t, err)
+}
+
+// TestListGenres tests ListGenres DB operation
+func TestListGenres(t *testing.T) {
+	createRandomGenre(t)
+
+	genres, err := testQueries.ListGenres(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, genres)
+}
+
+// TestMovieGenres tests AddMovieGenre, ListMovieGenres and DeleteMovieGenres DB operations
+func TestMovieGenres(t *testing.T) {
+	m := createRandomMovie(t)
+	g := createRandomGenre(t)
+
+	arg := AddMovieGenreParams{MovieID: m.ID, GenreID: g.ID}
+	err := testQueries.AddMovieGenre(context.Background(), arg)
+	require.NoError(t, err)
+
+	// adding the same genre twice is ignored
+	err = testQueries.AddMovieGenre(context.Background(), arg)
+	require.NoError(t, err)
+
+	genres, err := testQueries.ListMovieGenres(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Len(t, genres, 1)
+	require.Equal(t, g.Name, genres[0].Name)
+
+	err = testQueries.DeleteMovieGenres(context.Background(), m.ID)
+	require.NoError(t, err)
+
+	genres, err = testQueries.ListMovieGenres(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Empty(t, genres)
+}
@@ -11,6 +11,7 @@ import (
 )
 
 var testQueries *Queries
+var testStore Store
 var testDB *sql.DB
 
 // TestMain sets up the DB connection for testing
@@ -27,6 +28,7 @@ func TestMain(m *testing.M) {
 	}
 
 	testQueries = New(testDB)
+	testStore = NewStore(testDB)
 
 	os.Exit(m.Run())
 }
@@ -0,0 +1,409 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomMembershipPlan creates a random membership plan with given allowance
+func createRandomMembershipPlan(t *testing.T, allowance int32) MembershipPlan {
+	arg := CreateMembershipPlanParams{
+		Name:      util.RandomString(12),
+		Allowance: allowance,
+		Price:     util.RandomInt(500, 5000),
+		Currency:  "USD",
+	}
+
+	plan, err := testQueries.CreateMembershipPlan(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, plan)
+
+	require.NotZero(t, plan.ID)
+	require.NotZero(t, plan.CreatedAt)
+	require.Equal(t, arg.Name, plan.Name)
+	require.Equal(t, arg.Allowance, plan.Allowance)
+	require.Equal(t, arg.Price, plan.Price)
+	require.Equal(t, arg.Currency, plan.Currency)
+
+	return plan
+}
+
+// createRandomMembership creates an active membership of a random user whose period started an hour ago
+func createRandomMembership(t *testing.T, plan MembershipPlan) Membership {
+	u := createRandomUser(t)
+	start := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	arg := CreateMembershipParams{
+		Username:    u.Username,
+		PlanID:      plan.ID,
+		PeriodStart: start,
+		PeriodEnd:   start.AddDate(0, 1, 0),
+		Allowance:   plan.Allowance,
+	}
+
+	m, err := testQueries.CreateMembership(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, m)
+
+	require.NotZero(t, m.ID)
+	require.Equal(t, arg.Username, m.Username)
+	require.Equal(t, arg.PlanID, m.PlanID)
+	require.Equal(t, "active", m.Status)
+	require.WithinDuration(t, arg.PeriodStart, m.PeriodStart, time.Second)
+	require.WithinDuration(t, arg.PeriodEnd, m.PeriodEnd, time.Second)
+	require.Equal(t, arg.Allowance, m.Allowance)
+	require.Zero(t, m.Used)
+	require.False(t, m.CancelAtPeriodEnd)
+
+	return m
+}
+
+// TestCreateMembership tests CreateMembership DB operation, a user can't have two live memberships
+func TestCreateMembership(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 4)
+	m := createRandomMembership(t, plan)
+
+	_, err := testQueries.CreateMembership(context.Background(), CreateMembershipParams{
+		Username:    m.Username,
+		PlanID:      plan.ID,
+		PeriodStart: m.PeriodStart,
+		PeriodEnd:   m.PeriodEnd,
+		Allowance:   plan.Allowance,
+	})
+	require.Error(t, err)
+
+	got, err := testQueries.GetLiveMembership(context.Background(), m.Username)
+	require.NoError(t, err)
+	require.Equal(t, m.ID, got.ID)
+}
+
+// TestConsumeMembershipAllowance tests ConsumeMembershipAllowance DB operation
+func TestConsumeMembershipAllowance(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 4)
+	m := createRandomMembership(t, plan)
+
+	got, err := testQueries.ConsumeMembershipAllowance(context.Background(), ConsumeMembershipAllowanceParams{Seats: 3, Username: m.Username})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), got.Used)
+
+	// the allowance isn't consumed partially
+	_, err = testQueries.ConsumeMembershipAllowance(context.Background(), ConsumeMembershipAllowanceParams{Seats: 2, Username: m.Username})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	// a paused membership has no allowance
+	_, err = testQueries.PauseMembership(context.Background(), PauseMembershipParams{PausedAt: time.Now(), ID: m.ID})
+	require.NoError(t, err)
+
+	_, err = testQueries.ConsumeMembershipAllowance(context.Background(), ConsumeMembershipAllowanceParams{Seats: 1, Username: m.Username})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestPauseResumeMembership tests PauseMembership and ResumeMembership DB operations
+func TestPauseResumeMembership(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 2)
+	m := createRandomMembership(t, plan)
+
+	pausedAt := time.Now().UTC().Truncate(time.Second)
+	paused, err := testQueries.PauseMembership(context.Background(), PauseMembershipParams{PausedAt: pausedAt, ID: m.ID})
+	require.NoError(t, err)
+	require.Equal(t, "paused", paused.Status)
+	require.WithinDuration(t, pausedAt, paused.PausedAt.Time, time.Second)
+
+	_, err = testQueries.PauseMembership(context.Background(), PauseMembershipParams{PausedAt: pausedAt, ID: m.ID})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	end := m.PeriodEnd.Add(24 * time.Hour)
+	resumed, err := testQueries.ResumeMembership(context.Background(), ResumeMembershipParams{PeriodEnd: end, ID: m.ID})
+	require.NoError(t, err)
+	require.Equal(t, "active", resumed.Status)
+	require.False(t, resumed.PausedAt.Valid)
+	require.WithinDuration(t, end, resumed.PeriodEnd, time.Second)
+}
+
+// TestChangeMembershipPlan tests ChangeMembershipPlan DB operation
+func TestChangeMembershipPlan(t *testing.T) {
+	small := createRandomMembershipPlan(t, 2)
+	large := createRandomMembershipPlan(t, 8)
+	m := createRandomMembership(t, small)
+
+	_, err := testQueries.ConsumeMembershipAllowance(context.Background(), ConsumeMembershipAllowanceParams{Seats: 2, Username: m.Username})
+	require.NoError(t, err)
+
+	got, err := testQueries.ChangeMembershipPlan(context.Background(), ChangeMembershipPlanParams{PlanID: large.ID, Allowance: 6, ID: m.ID})
+	require.NoError(t, err)
+	require.Equal(t, large.ID, got.PlanID)
+	require.Equal(t, int32(6), got.Allowance)
+	require.Equal(t, int32(2), got.Used)
+
+	// the allowance can't be less than the used seats
+	_, err = testQueries.ChangeMembershipPlan(context.Background(), ChangeMembershipPlanParams{PlanID: small.ID, Allowance: 1, ID: m.ID})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestRenewMembership tests ListDueMemberships and RenewMembership DB operations
+func TestRenewMembership(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 4)
+	m := createRandomMembership(t, plan)
+
+	due, err := testQueries.ListDueMemberships(context.Background(), ListDueMembershipsParams{DueAt: m.PeriodEnd, Limit: 1000})
+	require.NoError(t, err)
+	require.Contains(t, membershipIDs(due), m.ID)
+
+	arg := RenewMembershipParams{
+		PeriodStart:       m.PeriodEnd,
+		PeriodEnd:         m.PeriodEnd.AddDate(0, 1, 0),
+		Allowance:         plan.Allowance,
+		ID:                m.ID,
+		PreviousPeriodEnd: m.PeriodEnd,
+	}
+
+	renewed, err := testQueries.RenewMembership(context.Background(), arg)
+	require.NoError(t, err)
+	require.WithinDuration(t, arg.PeriodStart, renewed.PeriodStart, time.Second)
+	require.WithinDuration(t, arg.PeriodEnd, renewed.PeriodEnd, time.Second)
+	require.Zero(t, renewed.Used)
+
+	// a period is renewed once
+	_, err = testQueries.RenewMembership(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestCancelMembership tests CancelMembershipAtPeriodEnd and CancelMembership DB operations
+func TestCancelMembership(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 4)
+	m := createRandomMembership(t, plan)
+
+	got, err := testQueries.CancelMembershipAtPeriodEnd(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.True(t, got.CancelAtPeriodEnd)
+	require.Equal(t, "active", got.Status)
+
+	got, err = testQueries.CancelMembership(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Equal(t, "cancelled", got.Status)
+	require.True(t, got.CancelledAt.Valid)
+
+	_, err = testQueries.GetLiveMembership(context.Background(), m.Username)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestListRefundableMembershipCharges tests ListRefundableMembershipCharges DB operation, the charges of the other
+// periods and the refunded charges aren't refundable
+func TestListRefundableMembershipCharges(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 4)
+	m := createRandomMembership(t, plan)
+
+	last := m
+	last.PeriodStart = m.PeriodStart.AddDate(0, -1, 0)
+	createRandomMembershipCharge(t, last, plan.Price)
+
+	charge := createRandomMembershipCharge(t, m, plan.Price)
+	refunded := createRandomMembershipCharge(t, m, 100)
+
+	for _, c := range []MembershipPayment{charge, refunded} {
+		_, err := testQueries.CreateMembershipPayment(context.Background(), CreateMembershipPaymentParams{
+			MembershipID: m.ID,
+			PeriodStart:  m.PeriodStart,
+			Kind:         "refund",
+			Amount:       -100,
+			Currency:     plan.Currency,
+			RefundOf:     sql.NullInt64{Int64: c.ID, Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := testQueries.ListRefundableMembershipCharges(context.Background(), ListRefundableMembershipChargesParams{
+		MembershipID: m.ID,
+		PeriodStart:  m.PeriodStart,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ListRefundableMembershipChargesRow{{ID: charge.ID, Refundable: plan.Price - 100}}, got)
+}
+
+// TestCancelMembershipTxRefund tests that CancelMembershipTx records a pending refund which is completed once
+func TestCancelMembershipTxRefund(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 4)
+	m := createRandomMembership(t, plan)
+	charge := createRandomMembershipCharge(t, m, plan.Price)
+
+	// the refund can't be more than the charges of the period
+	result, err := testStore.CancelMembershipTx(context.Background(), CancelMembershipTxParams{
+		ID:     m.ID,
+		Refund: &MembershipRefundParams{Amount: plan.Price + 100, Currency: plan.Currency},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "cancelled", result.Membership.Status)
+	require.Len(t, result.Refunds, 1)
+
+	refund := result.Refunds[0]
+	require.Equal(t, "refund", refund.Kind)
+	require.Equal(t, -plan.Price, refund.Amount)
+	require.Equal(t, charge.ID, refund.RefundOf.Int64)
+	require.False(t, refund.Reference.Valid)
+
+	pending, err := testQueries.ListPendingMembershipRefunds(context.Background(), 1000)
+	require.NoError(t, err)
+	require.Contains(t, membershipPaymentIDs(pending), refund.ID)
+
+	reference := sql.NullString{String: util.RandomString(10), Valid: true}
+	done, err := testQueries.CompleteMembershipRefund(context.Background(), CompleteMembershipRefundParams{ID: refund.ID, Reference: reference})
+	require.NoError(t, err)
+	require.Equal(t, reference, done.Reference)
+
+	// a refund is completed once
+	_, err = testQueries.CompleteMembershipRefund(context.Background(), CompleteMembershipRefundParams{ID: refund.ID, Reference: reference})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	pending, err = testQueries.ListPendingMembershipRefunds(context.Background(), 1000)
+	require.NoError(t, err)
+	require.NotContains(t, membershipPaymentIDs(pending), refund.ID)
+}
+
+// TestCancelMembershipTxAfterUpgrade tests that a membership cancelled right after an upgrade is refunded
+// from the proration charge of the upgrade and the charge of the period
+func TestCancelMembershipTxAfterUpgrade(t *testing.T) {
+	small := createRandomMembershipPlan(t, 2)
+	large := createRandomMembershipPlan(t, 8)
+	m := createRandomMembership(t, small)
+	charge := createRandomMembershipCharge(t, m, small.Price)
+
+	proration := int64(300)
+	upgraded, err := testStore.ChangeMembershipPlanTx(context.Background(), ChangeMembershipPlanTxParams{
+		ChangeMembershipPlanParams: ChangeMembershipPlanParams{PlanID: large.ID, Allowance: 7, ID: m.ID},
+		Payment:                    &MembershipPaymentParams{Kind: "proration", Amount: proration, Currency: "USD", Reference: util.RandomString(10)},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, upgraded.Payment)
+	require.Empty(t, upgraded.Refunds)
+
+	// the refund is more than the proration charge, the rest is given back from the charge of the period
+	amount := proration + small.Price/2
+	result, err := testStore.CancelMembershipTx(context.Background(), CancelMembershipTxParams{
+		ID:     m.ID,
+		Refund: &MembershipRefundParams{Amount: amount, Currency: "USD"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Refunds, 2)
+
+	require.Equal(t, upgraded.Payment.ID, result.Refunds[0].RefundOf.Int64)
+	require.Equal(t, -proration, result.Refunds[0].Amount)
+	require.Equal(t, charge.ID, result.Refunds[1].RefundOf.Int64)
+	require.Equal(t, -(amount - proration), result.Refunds[1].Amount)
+
+	for _, r := range result.Refunds {
+		require.False(t, r.Reference.Valid)
+		require.WithinDuration(t, m.PeriodStart, r.PeriodStart, time.Second)
+	}
+}
+
+// createRandomMembershipCharge records a charge of the period of a membership with a random reference
+func createRandomMembershipCharge(t *testing.T, m Membership, amount int64) MembershipPayment {
+	charge, err := testQueries.CreateMembershipPayment(context.Background(), CreateMembershipPaymentParams{
+		MembershipID: m.ID,
+		PeriodStart:  m.PeriodStart,
+		Kind:         "subscription",
+		Amount:       amount,
+		Currency:     "USD",
+		Reference:    sql.NullString{String: util.RandomString(10), Valid: true},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, charge.ID)
+
+	return charge
+}
+
+// membershipPaymentIDs returns the ids of the payments
+func membershipPaymentIDs(payments []MembershipPayment) []int64 {
+	ids := make([]int64, 0, len(payments))
+	for _, p := range payments {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+// TestPurchaseTicketTxMembership tests that PurchaseTicketTx uses the allowance of the membership of the owner
+func TestPurchaseTicketTxMembership(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 2)
+	m := createRandomMembership(t, plan)
+	movie := createRandomMovie(t)
+
+	arg := PurchaseTicketTxParams{
+		CreateTicketParams: CreateTicketParams{
+			MovieID:       movie.ID,
+			TicketOwner:   m.Username,
+			Adult:         2,
+			Total:         2000,
+			Tax:           300,
+			Currency:      "USD",
+			PaymentMethod: "card",
+		},
+	}
+
+	result, err := testStore.PurchaseTicketTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotNil(t, result.Membership)
+	require.Equal(t, int32(2), result.Membership.Used)
+	require.Equal(t, MembershipPaymentMethod, result.Ticket.PaymentMethod)
+	require.Equal(t, m.ID, result.Ticket.MembershipID.Int64)
+	require.Zero(t, result.Ticket.Total)
+	require.Zero(t, result.Ticket.Tax)
+	require.Equal(t, int64(2000), result.Ticket.Discount)
+
+	// the allowance is used up so the next ticket is paid
+	result, err = testStore.PurchaseTicketTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Nil(t, result.Membership)
+	require.Equal(t, "card", result.Ticket.PaymentMethod)
+	require.Equal(t, arg.Total, result.Ticket.Total)
+}
+
+// TestDeleteTicketTxMembership tests that the seats of a deleted membership ticket are given back to the allowance
+func TestDeleteTicketTxMembership(t *testing.T) {
+	plan := createRandomMembershipPlan(t, 3)
+	m := createRandomMembership(t, plan)
+	movie := createRandomMovie(t)
+
+	arg := PurchaseTicketTxParams{
+		CreateTicketParams: CreateTicketParams{
+			MovieID:       movie.ID,
+			TicketOwner:   m.Username,
+			Adult:         1,
+			Child:         1,
+			Total:         2000,
+			Currency:      "USD",
+			PaymentMethod: "card",
+		},
+	}
+
+	result, err := testStore.PurchaseTicketTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotNil(t, result.Membership)
+	require.Equal(t, int32(2), result.Membership.Used)
+
+	err = testStore.DeleteTicketTx(context.Background(), result.Ticket.ID)
+	require.NoError(t, err)
+
+	got, err := testQueries.GetMembership(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Zero(t, got.Used)
+
+	// the seats can be used again
+	result, err = testStore.PurchaseTicketTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotNil(t, result.Membership)
+	require.Equal(t, MembershipPaymentMethod, result.Ticket.PaymentMethod)
+}
+
+// membershipIDs returns the IDs of given memberships
+func membershipIDs(memberships []Membership) []int64 {
+	ids := make([]int64, 0, len(memberships))
+	for _, m := range memberships {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
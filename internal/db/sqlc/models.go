@@ -5,41 +5,375 @@
 package db
 
 import (
+	"database/sql"
 	"time"
 )
 
+type Auditorium struct {
+	ID              int64     `json:"id"`
+	VenueID         int64     `json:"venue_id"`
+	Name            string    `json:"name"`
+	Seats           int32     `json:"seats"`
+	CreatedAt       time.Time `json:"created_at"`
+	CleaningMinutes int32     `json:"cleaning_minutes"`
+}
+
+type Award struct {
+	ID        int64         `json:"id"`
+	Name      string        `json:"name"`
+	Kind      string        `json:"kind"`
+	Year      sql.NullInt32 `json:"year"`
+	Category  string        `json:"category"`
+	Winner    bool          `json:"winner"`
+	PersonID  sql.NullInt64 `json:"person_id"`
+	MovieID   sql.NullInt64 `json:"movie_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type CashShift struct {
+	ID          int64         `json:"id"`
+	Cashier     string        `json:"cashier"`
+	OpeningCash int64         `json:"opening_cash"`
+	CountedCash sql.NullInt64 `json:"counted_cash"`
+	OpenedAt    time.Time     `json:"opened_at"`
+	ClosedAt    sql.NullTime  `json:"closed_at"`
+}
+
+type ConcessionItem struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Price     int64     `json:"price"`
+	Stock     int32     `json:"stock"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ConcessionOrder struct {
+	ID         int64     `json:"id"`
+	TicketID   int64     `json:"ticket_id"`
+	OrderOwner string    `json:"order_owner"`
+	Total      int64     `json:"total"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ConcessionOrderItem struct {
+	ID        int64  `json:"id"`
+	OrderID   int64  `json:"order_id"`
+	ItemID    int64  `json:"item_id"`
+	Name      string `json:"name"`
+	Quantity  int32  `json:"quantity"`
+	UnitPrice int64  `json:"unit_price"`
+}
+
 type Director struct {
 	ID        int64     `json:"id"`
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
 	Oscars    int64     `json:"oscars"`
 	CreatedAt time.Time `json:"created_at"`
+	PersonID  int64     `json:"person_id"`
+}
+
+type Genre struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Membership struct {
+	ID                int64        `json:"id"`
+	Username          string       `json:"username"`
+	PlanID            int64        `json:"plan_id"`
+	Status            string       `json:"status"`
+	PeriodStart       time.Time    `json:"period_start"`
+	PeriodEnd         time.Time    `json:"period_end"`
+	Allowance         int32        `json:"allowance"`
+	Used              int32        `json:"used"`
+	CancelAtPeriodEnd bool         `json:"cancel_at_period_end"`
+	PausedAt          sql.NullTime `json:"paused_at"`
+	CancelledAt       sql.NullTime `json:"cancelled_at"`
+	CreatedAt         time.Time    `json:"created_at"`
+}
+
+type MembershipPayment struct {
+	ID           int64          `json:"id"`
+	MembershipID int64          `json:"membership_id"`
+	PeriodStart  time.Time      `json:"period_start"`
+	Kind         string         `json:"kind"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Reference    sql.NullString `json:"reference"`
+	RefundOf     sql.NullInt64  `json:"refund_of"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+type MembershipPlan struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Allowance int32     `json:"allowance"`
+	Price     int64     `json:"price"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ModerationEvent struct {
+	ID         int64          `json:"id"`
+	ReviewID   int64          `json:"review_id"`
+	Moderator  sql.NullString `json:"moderator"`
+	FromStatus string         `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Reason     string         `json:"reason"`
+	CreatedAt  time.Time      `json:"created_at"`
 }
 
 type Movie struct {
-	ID         int64     `json:"id"`
-	Title      string    `json:"title"`
-	DirectorID int64     `json:"director_id"`
-	Rating     int16     `json:"rating"`
-	Poster     string    `json:"poster"`
-	Summary    string    `json:"summary"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID            int64        `json:"id"`
+	Title         string       `json:"title"`
+	DirectorID    int64        `json:"director_id"`
+	Rating        int16        `json:"rating"`
+	Poster        string       `json:"poster"`
+	Summary       string       `json:"summary"`
+	CreatedAt     time.Time    `json:"created_at"`
+	DeletedAt     sql.NullTime `json:"deleted_at"`
+	Certification string       `json:"certification"`
+	Tags          []string     `json:"tags"`
+	ReleaseDate   time.Time    `json:"release_date"`
+	Runtime       int32        `json:"runtime"`
+}
+
+type MovieCredit struct {
+	ID           int64     `json:"id"`
+	MovieID      int64     `json:"movie_id"`
+	PersonID     int64     `json:"person_id"`
+	Role         string    `json:"role"`
+	BillingOrder int32     `json:"billing_order"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type MovieDailySale struct {
+	MovieID int64     `json:"movie_id"`
+	Day     time.Time `json:"day"`
+	Tickets int64     `json:"tickets"`
+	Revenue int64     `json:"revenue"`
+}
+
+type MovieGenre struct {
+	MovieID int64 `json:"movie_id"`
+	GenreID int64 `json:"genre_id"`
+}
+
+type MovieTrending struct {
+	MovieID         int64     `json:"movie_id"`
+	Tickets         int64     `json:"tickets"`
+	Revenue         int64     `json:"revenue"`
+	PreviousTickets int64     `json:"previous_tickets"`
+	PreviousRevenue int64     `json:"previous_revenue"`
+	RefreshedAt     time.Time `json:"refreshed_at"`
+}
+
+type PasswordReset struct {
+	ID        int64        `json:"id"`
+	Username  string       `json:"username"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type Person struct {
+	ID        int64     `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PosSale struct {
+	ID            int64     `json:"id"`
+	ShiftID       int64     `json:"shift_id"`
+	MovieID       int64     `json:"movie_id"`
+	GuestName     string    `json:"guest_name"`
+	PaymentMethod string    `json:"payment_method"`
+	TicketCode    string    `json:"ticket_code"`
+	Child         int16     `json:"child"`
+	Adult         int16     `json:"adult"`
+	Total         int64     `json:"total"`
+	CreatedAt     time.Time `json:"created_at"`
+	Currency      string    `json:"currency"`
+	Tax           int64     `json:"tax"`
+}
+
+type PricingRule struct {
+	ID           int64         `json:"id"`
+	VenueID      sql.NullInt64 `json:"venue_id"`
+	Name         string        `json:"name"`
+	Kind         string        `json:"kind"`
+	Percent      int32         `json:"percent"`
+	StartsBefore sql.NullInt32 `json:"starts_before"`
+	Weekdays     []int32       `json:"weekdays"`
+	Holiday      sql.NullTime  `json:"holiday"`
+	MinOccupancy sql.NullInt32 `json:"min_occupancy"`
+	ValidFrom    sql.NullTime  `json:"valid_from"`
+	ValidUntil   sql.NullTime  `json:"valid_until"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type PrivateBooking struct {
+	ID                     int64          `json:"id"`
+	Organizer              string         `json:"organizer"`
+	Organization           string         `json:"organization"`
+	ContactEmail           string         `json:"contact_email"`
+	ScreeningID            int64          `json:"screening_id"`
+	VenueID                int64          `json:"venue_id"`
+	Guests                 int32          `json:"guests"`
+	Notes                  string         `json:"notes"`
+	Status                 string         `json:"status"`
+	Net                    sql.NullInt64  `json:"net"`
+	Tax                    sql.NullInt64  `json:"tax"`
+	Total                  sql.NullInt64  `json:"total"`
+	Deposit                sql.NullInt64  `json:"deposit"`
+	Currency               sql.NullString `json:"currency"`
+	PaymentTermsDays       sql.NullInt32  `json:"payment_terms_days"`
+	QuotedBy               sql.NullString `json:"quoted_by"`
+	QuotedAt               sql.NullTime   `json:"quoted_at"`
+	QuoteExpiresAt         sql.NullTime   `json:"quote_expires_at"`
+	DepositReference       sql.NullString `json:"deposit_reference"`
+	AcceptedAt             sql.NullTime   `json:"accepted_at"`
+	ApprovedBy             sql.NullString `json:"approved_by"`
+	ConfirmedAt            sql.NullTime   `json:"confirmed_at"`
+	CancelledAt            sql.NullTime   `json:"cancelled_at"`
+	CancelReason           string         `json:"cancel_reason"`
+	DepositRefundReference sql.NullString `json:"deposit_refund_reference"`
+	CreatedAt              time.Time      `json:"created_at"`
+}
+
+type PrivateBookingInvoice struct {
+	ID               int64          `json:"id"`
+	BookingID        int64          `json:"booking_id"`
+	Number           string         `json:"number"`
+	Net              int64          `json:"net"`
+	Tax              int64          `json:"tax"`
+	Total            int64          `json:"total"`
+	Deposit          int64          `json:"deposit"`
+	Balance          int64          `json:"balance"`
+	Currency         string         `json:"currency"`
+	IssuedAt         time.Time      `json:"issued_at"`
+	DueAt            time.Time      `json:"due_at"`
+	PaidAt           sql.NullTime   `json:"paid_at"`
+	PaymentReference sql.NullString `json:"payment_reference"`
+	VoidedAt         sql.NullTime   `json:"voided_at"`
+}
+
+type Review struct {
+	ID        int64     `json:"id"`
+	MovieID   int64     `json:"movie_id"`
+	Username  string    `json:"username"`
+	Rating    int16     `json:"rating"`
+	Body      string    `json:"body"`
+	Verified  bool      `json:"verified"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReviewReport struct {
+	ID         int64        `json:"id"`
+	ReviewID   int64        `json:"review_id"`
+	Reporter   string       `json:"reporter"`
+	Reason     string       `json:"reason"`
+	Note       string       `json:"note"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ResolvedAt sql.NullTime `json:"resolved_at"`
+}
+
+type Screening struct {
+	ID                 int64          `json:"id"`
+	MovieID            int64          `json:"movie_id"`
+	StartsAt           time.Time      `json:"starts_at"`
+	PublishedAt        sql.NullTime   `json:"published_at"`
+	WatchersNotifiedAt sql.NullTime   `json:"watchers_notified_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+	AuditoriumID       sql.NullInt64  `json:"auditorium_id"`
+	TrailerMinutes     int32          `json:"trailer_minutes"`
+	EndsAt             time.Time      `json:"ends_at"`
+	BlockedUntil       time.Time      `json:"blocked_until"`
+	Format             string         `json:"format"`
+	AudioLanguage      string         `json:"audio_language"`
+	SubtitleLanguage   sql.NullString `json:"subtitle_language"`
+	Dubbed             bool           `json:"dubbed"`
+	AudioDescription   bool           `json:"audio_description"`
+	Relaxed            bool           `json:"relaxed"`
+	PrivateBookingID   sql.NullInt64  `json:"private_booking_id"`
+}
+
+type ScreeningFormat struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Surcharge int64     `json:"surcharge"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Ticket struct {
-	ID          int64     `json:"id"`
-	MovieID     int64     `json:"movie_id"`
-	TicketOwner string    `json:"ticket_owner"`
-	Child       int16     `json:"child"`
-	Adult       int16     `json:"adult"`
-	Total       int64     `json:"total"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID            int64         `json:"id"`
+	MovieID       int64         `json:"movie_id"`
+	TicketOwner   string        `json:"ticket_owner"`
+	Child         int16         `json:"child"`
+	Adult         int16         `json:"adult"`
+	Total         int64         `json:"total"`
+	CreatedAt     time.Time     `json:"created_at"`
+	CheckedInAt   sql.NullTime  `json:"checked_in_at"`
+	ScreeningID   sql.NullInt64 `json:"screening_id"`
+	PaymentMethod string        `json:"payment_method"`
+	Discount      int64         `json:"discount"`
+	Tax           int64         `json:"tax"`
+	Surcharge     int64         `json:"surcharge"`
+	Currency      string        `json:"currency"`
+	MembershipID  sql.NullInt64 `json:"membership_id"`
 }
 
 type User struct {
-	Username       string    `json:"username"`
-	HashedPassword string    `json:"hashed_password"`
-	Email          string    `json:"email"`
-	AccessLevel    int16     `json:"access_level"`
-	CreatedAt      time.Time `json:"created_at"`
+	Username          string    `json:"username"`
+	HashedPassword    string    `json:"hashed_password"`
+	Email             string    `json:"email"`
+	AccessLevel       int16     `json:"access_level"`
+	CreatedAt         time.Time `json:"created_at"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+}
+
+type UserRecommendation struct {
+	Username   string    `json:"username"`
+	MovieID    int64     `json:"movie_id"`
+	Score      float64   `json:"score"`
+	Reasons    []string  `json:"reasons"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+type Venue struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Address    string    `json:"address"`
+	TimeZone   string    `json:"time_zone"`
+	CreatedAt  time.Time `json:"created_at"`
+	AdultPrice int64     `json:"adult_price"`
+	ChildPrice int64     `json:"child_price"`
+	TaxRate    int32     `json:"tax_rate"`
+}
+
+type VenueHour struct {
+	VenueID int64 `json:"venue_id"`
+	Weekday int16 `json:"weekday"`
+	Opens   int16 `json:"opens"`
+	Closes  int16 `json:"closes"`
+}
+
+type VenueStaff struct {
+	Username  string    `json:"username"`
+	VenueID   int64     `json:"venue_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WatchlistItem struct {
+	Username  string    `json:"username"`
+	MovieID   int64     `json:"movie_id"`
+	Notify    bool      `json:"notify"`
+	CreatedAt time.Time `json:"created_at"`
 }
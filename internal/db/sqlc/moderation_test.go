@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomReviewReport creates a random report of a new user for given review
+func createRandomReviewReport(t *testing.T, review Review) ReviewReport {
+	u := createRandomUser(t)
+	arg := CreateReviewReportParams{
+		ReviewID: review.ID,
+		Reporter: u.Username,
+		Reason:   "spoiler",
+		Note:     util.RandomString(10),
+	}
+
+	report, err := testQueries.CreateReviewReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, report.ID)
+	require.Equal(t, arg.ReviewID, report.ReviewID)
+	require.Equal(t, arg.Reporter, report.Reporter)
+	require.Equal(t, arg.Reason, report.Reason)
+	require.Equal(t, arg.Note, report.Note)
+	require.NotZero(t, report.CreatedAt)
+
+	return report
+}
+
+// TestCreateReviewReport tests CreateReviewReport DB operation
+func TestCreateReviewReport(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+	report := createRandomReviewReport(t, review)
+
+	// a user can report a review only once
+	_, err := testQueries.CreateReviewReport(context.Background(), CreateReviewReportParams{
+		ReviewID: review.ID,
+		Reporter: report.Reporter,
+		Reason:   "spam",
+	})
+	require.Error(t, err)
+}
+
+// TestListReviewReports tests ListReviewReports and CountReviewReports DB operations
+func TestListReviewReports(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+	r1 := createRandomReviewReport(t, review)
+	r2 := createRandomReviewReport(t, review)
+
+	count, err := testQueries.CountReviewReports(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	reports, err := testQueries.ListReviewReports(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Equal(t, r1.ID, reports[0].ID)
+	require.Equal(t, r2.ID, reports[1].ID)
+}
+
+// TestModerationEvents tests CreateModerationEvent and ListModerationEvents DB operations
+func TestModerationEvents(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+	moderator := createRandomUser(t)
+
+	arg := CreateModerationEventParams{
+		ReviewID:   review.ID,
+		Moderator:  sql.NullString{String: moderator.Username, Valid: true},
+		FromStatus: ReviewPending,
+		ToStatus:   ReviewApproved,
+	}
+
+	event, err := testQueries.CreateModerationEvent(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, event.ID)
+	require.Equal(t, arg.Moderator, event.Moderator)
+
+	// the audit trail is kept after the review is deleted
+	err = testQueries.DeleteReview(context.Background(), review.ID)
+	require.NoError(t, err)
+
+	events, err := testQueries.ListModerationEvents(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, event.ID, events[0].ID)
+}
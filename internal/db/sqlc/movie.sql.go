@@ -7,20 +7,70 @@ package db
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
 )
 
+const countMovies = `-- name: CountMovies :one
+SELECT count(*)
+FROM movies
+WHERE deleted_at IS NULL
+  AND ($1::varchar IS NULL OR certification = $1)
+  AND ($2::varchar IS NULL OR $2 = ANY(tags))
+  AND ($3::varchar IS NULL OR EXISTS (
+    SELECT 1
+    FROM movie_genres
+    JOIN genres ON genres.id = movie_genres.genre_id
+    WHERE movie_genres.movie_id = movies.id AND lower(genres.name) = lower($3)
+  ))
+  AND ($4::bigint IS NULL OR director_id = $4)
+  AND ($5::smallint IS NULL OR rating >= $5)
+  AND ($6::smallint IS NULL OR rating <= $6)
+`
+
+type CountMoviesParams struct {
+	Certification sql.NullString `json:"certification"`
+	Tag           sql.NullString `json:"tag"`
+	Genre         sql.NullString `json:"genre"`
+	DirectorID    sql.NullInt64  `json:"director_id"`
+	MinRating     sql.NullInt16  `json:"min_rating"`
+	MaxRating     sql.NullInt16  `json:"max_rating"`
+}
+
+func (q *Queries) CountMovies(ctx context.Context, arg CountMoviesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMovies,
+		arg.Certification,
+		arg.Tag,
+		arg.Genre,
+		arg.DirectorID,
+		arg.MinRating,
+		arg.MaxRating,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createMovie = `-- name: CreateMovie :one
-INSERT INTO movies(title, director_id, rating, poster, summary)
-VALUES($1, $2, $3, $4, $5)
-RETURNING id, title, director_id, rating, poster, summary, created_at
+INSERT INTO movies(title, director_id, rating, poster, summary, certification, tags, release_date, runtime)
+VALUES(
+  $1, $2, $3, $4, $5,
+  $6, $7, COALESCE($8, CURRENT_DATE), $9
+)
+RETURNING id, title, director_id, rating, poster, summary, created_at, deleted_at, certification, tags, release_date, runtime
 `
 
 type CreateMovieParams struct {
-	Title      string `json:"title"`
-	DirectorID int64  `json:"director_id"`
-	Rating     int16  `json:"rating"`
-	Poster     string `json:"poster"`
-	Summary    string `json:"summary"`
+	Title         string       `json:"title"`
+	DirectorID    int64        `json:"director_id"`
+	Rating        int16        `json:"rating"`
+	Poster        string       `json:"poster"`
+	Summary       string       `json:"summary"`
+	Certification string       `json:"certification"`
+	Tags          []string     `json:"tags"`
+	ReleaseDate   sql.NullTime `json:"release_date"`
+	Runtime       int32        `json:"runtime"`
 }
 
 func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (Movie, error) {
@@ -30,6 +80,10 @@ func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (Movie
 		arg.Rating,
 		arg.Poster,
 		arg.Summary,
+		arg.Certification,
+		pq.Array(arg.Tags),
+		arg.ReleaseDate,
+		arg.Runtime,
 	)
 	var i Movie
 	err := row.Scan(
@@ -40,6 +94,11 @@ func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (Movie
 		&i.Poster,
 		&i.Summary,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Certification,
+		pq.Array(&i.Tags),
+		&i.ReleaseDate,
+		&i.Runtime,
 	)
 	return i, err
 }
@@ -55,7 +114,7 @@ func (q *Queries) DeleteMovie(ctx context.Context, id int64) error {
 }
 
 const getMovie = `-- name: GetMovie :one
-SELECT id, title, director_id, rating, poster, summary, created_at
+SELECT id, title, director_id, rating, poster, summary, created_at, deleted_at, certification, tags, release_date, runtime
 FROM movies
 WHERE id = $1
 ORDER BY id
@@ -73,19 +132,176 @@ func (q *Queries) GetMovie(ctx context.Context, id int64) (Movie, error) {
 		&i.Poster,
 		&i.Summary,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Certification,
+		pq.Array(&i.Tags),
+		&i.ReleaseDate,
+		&i.Runtime,
 	)
 	return i, err
 }
 
 const listMovies = `-- name: ListMovies :many
-SELECT id, title, director_id, rating, poster, summary, created_at
+SELECT id, title, director_id, rating, poster, summary, created_at, deleted_at, certification, tags, release_date, runtime
 FROM movies
+WHERE deleted_at IS NULL
+  AND ($1::varchar IS NULL OR certification = $1)
+  AND ($2::varchar IS NULL OR $2 = ANY(tags))
+  AND ($3::varchar IS NULL OR EXISTS (
+    SELECT 1
+    FROM movie_genres
+    JOIN genres ON genres.id = movie_genres.genre_id
+    WHERE movie_genres.movie_id = movies.id AND lower(genres.name) = lower($3)
+  ))
+  AND ($4::bigint IS NULL OR director_id = $4)
+  AND ($5::smallint IS NULL OR rating >= $5)
+  AND ($6::smallint IS NULL OR rating <= $6)
+  -- the page starts after the movie of the cursor in the same order, 0 is the first page
+  AND ($7::bigint = 0 OR EXISTS (
+    SELECT 1
+    FROM movies c
+    WHERE c.id = $7 AND CASE
+      WHEN $8::varchar = 'rating' AND NOT $9::boolean THEN (movies.rating, movies.id) > (c.rating, c.id)
+      WHEN $8 = 'rating' THEN (movies.rating, movies.id) < (c.rating, c.id)
+      WHEN $8 = 'title' AND NOT $9 THEN (movies.title, movies.id) > (c.title, c.id)
+      WHEN $8 = 'title' THEN (movies.title, movies.id) < (c.title, c.id)
+      WHEN $8 = 'release_date' AND NOT $9 THEN (movies.release_date, movies.id) > (c.release_date, c.id)
+      WHEN $8 = 'release_date' THEN (movies.release_date, movies.id) < (c.release_date, c.id)
+      WHEN NOT $9 THEN movies.id > c.id
+      ELSE movies.id < c.id
+    END
+  ))
+ORDER BY
+  CASE WHEN $8 = 'rating' AND NOT $9 THEN rating END ASC,
+  CASE WHEN $8 = 'rating' AND $9 THEN rating END DESC,
+  CASE WHEN $8 = 'title' AND NOT $9 THEN title END ASC,
+  CASE WHEN $8 = 'title' AND $9 THEN title END DESC,
+  CASE WHEN $8 = 'release_date' AND NOT $9 THEN release_date END ASC,
+  CASE WHEN $8 = 'release_date' AND $9 THEN release_date END DESC,
+  CASE WHEN NOT $9 THEN id END ASC,
+  id DESC
+LIMIT $10
+`
+
+type ListMoviesParams struct {
+	Certification sql.NullString `json:"certification"`
+	Tag           sql.NullString `json:"tag"`
+	Genre         sql.NullString `json:"genre"`
+	DirectorID    sql.NullInt64  `json:"director_id"`
+	MinRating     sql.NullInt16  `json:"min_rating"`
+	MaxRating     sql.NullInt16  `json:"max_rating"`
+	AfterID       int64          `json:"after_id"`
+	SortBy        string         `json:"sort_by"`
+	SortDesc      bool           `json:"sort_desc"`
+	Limit         int32          `json:"limit"`
+}
+
+func (q *Queries) ListMovies(ctx context.Context, arg ListMoviesParams) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, listMovies,
+		arg.Certification,
+		arg.Tag,
+		arg.Genre,
+		arg.DirectorID,
+		arg.MinRating,
+		arg.MaxRating,
+		arg.AfterID,
+		arg.SortBy,
+		arg.SortDesc,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Movie{}
+	for rows.Next() {
+		var i Movie
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.DirectorID,
+			&i.Rating,
+			&i.Poster,
+			&i.Summary,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Certification,
+			pq.Array(&i.Tags),
+			&i.ReleaseDate,
+			&i.Runtime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMoviesByDirector = `-- name: ListMoviesByDirector :many
+SELECT id, title, director_id, rating, poster, summary, created_at, deleted_at, certification, tags, release_date, runtime
+FROM movies
+WHERE director_id = $1 AND deleted_at IS NULL
+  AND ($2::bigint IS NULL OR id < $2)
 ORDER BY id DESC
-LIMIT $1
+LIMIT $3
+`
+
+type ListMoviesByDirectorParams struct {
+	DirectorID int64         `json:"director_id"`
+	BeforeID   sql.NullInt64 `json:"before_id"`
+	Limit      int32         `json:"limit"`
+}
+
+func (q *Queries) ListMoviesByDirector(ctx context.Context, arg ListMoviesByDirectorParams) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, listMoviesByDirector, arg.DirectorID, arg.BeforeID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Movie{}
+	for rows.Next() {
+		var i Movie
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.DirectorID,
+			&i.Rating,
+			&i.Poster,
+			&i.Summary,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Certification,
+			pq.Array(&i.Tags),
+			&i.ReleaseDate,
+			&i.Runtime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMoviesByIDs = `-- name: ListMoviesByIDs :many
+SELECT id, title, director_id, rating, poster, summary, created_at, deleted_at, certification, tags, release_date, runtime
+FROM movies
+WHERE id = ANY($1::bigint[])
 `
 
-func (q *Queries) ListMovies(ctx context.Context, limit int32) ([]Movie, error) {
-	rows, err := q.db.QueryContext(ctx, listMovies, limit)
+func (q *Queries) ListMoviesByIDs(ctx context.Context, ids []int64) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, listMoviesByIDs, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +317,11 @@ func (q *Queries) ListMovies(ctx context.Context, limit int32) ([]Movie, error)
 			&i.Poster,
 			&i.Summary,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Certification,
+			pq.Array(&i.Tags),
+			&i.ReleaseDate,
+			&i.Runtime,
 		); err != nil {
 			return nil, err
 		}
@@ -114,3 +335,87 @@ func (q *Queries) ListMovies(ctx context.Context, limit int32) ([]Movie, error)
 	}
 	return items, nil
 }
+
+const softDeleteMovie = `-- name: SoftDeleteMovie :one
+UPDATE movies
+SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, director_id, rating, poster, summary, created_at, deleted_at, certification, tags, release_date, runtime
+`
+
+func (q *Queries) SoftDeleteMovie(ctx context.Context, id int64) (Movie, error) {
+	row := q.db.QueryRowContext(ctx, softDeleteMovie, id)
+	var i Movie
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.DirectorID,
+		&i.Rating,
+		&i.Poster,
+		&i.Summary,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Certification,
+		pq.Array(&i.Tags),
+		&i.ReleaseDate,
+		&i.Runtime,
+	)
+	return i, err
+}
+
+const updateMovie = `-- name: UpdateMovie :one
+UPDATE movies
+SET
+  title = COALESCE($1, title),
+  rating = COALESCE($2, rating),
+  poster = COALESCE($3, poster),
+  summary = COALESCE($4, summary),
+  certification = COALESCE($5, certification),
+  tags = COALESCE($6, tags),
+  release_date = COALESCE($7, release_date),
+  runtime = COALESCE($8, runtime)
+WHERE id = $9 AND deleted_at IS NULL
+RETURNING id, title, director_id, rating, poster, summary, created_at, deleted_at, certification, tags, release_date, runtime
+`
+
+type UpdateMovieParams struct {
+	Title         sql.NullString `json:"title"`
+	Rating        sql.NullInt16  `json:"rating"`
+	Poster        sql.NullString `json:"poster"`
+	Summary       sql.NullString `json:"summary"`
+	Certification sql.NullString `json:"certification"`
+	Tags          []string       `json:"tags"`
+	ReleaseDate   sql.NullTime   `json:"release_date"`
+	Runtime       sql.NullInt32  `json:"runtime"`
+	ID            int64          `json:"id"`
+}
+
+func (q *Queries) UpdateMovie(ctx context.Context, arg UpdateMovieParams) (Movie, error) {
+	row := q.db.QueryRowContext(ctx, updateMovie,
+		arg.Title,
+		arg.Rating,
+		arg.Poster,
+		arg.Summary,
+		arg.Certification,
+		pq.Array(arg.Tags),
+		arg.ReleaseDate,
+		arg.Runtime,
+		arg.ID,
+	)
+	var i Movie
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.DirectorID,
+		&i.Rating,
+		&i.Poster,
+		&i.Summary,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Certification,
+		pq.Array(&i.Tags),
+		&i.ReleaseDate,
+		&i.Runtime,
+	)
+	return i, err
+}
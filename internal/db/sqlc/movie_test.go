@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +20,10 @@ func createRandomMovie(t *testing.T) Movie {
 		Rating:     int16(util.RandomInt(6, 10)),
 		Poster:     util.RandomString(10),
 		Summary:    util.RandomString(10),
+		// the certification doesn't restrict children so tickets of random movies can have any count
+		Certification: "PG",
+		Tags:          []string{util.RandomName()},
+		ReleaseDate:   sql.NullTime{Time: time.Date(int(util.RandomInt(1950, 2022)), 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
 	}
 
 	m, err := testQueries.CreateMovie(context.Background(), arg)
@@ -31,6 +36,9 @@ func createRandomMovie(t *testing.T) Movie {
 	require.Equal(t, m.DirectorID, arg.DirectorID)
 	require.Equal(t, m.Rating, arg.Rating)
 	require.Equal(t, m.Poster, arg.Poster)
+	require.Equal(t, m.Certification, arg.Certification)
+	require.Equal(t, m.Tags, arg.Tags)
+	require.Equal(t, arg.ReleaseDate.Time.Year(), m.ReleaseDate.Year())
 
 	return m
 }
@@ -64,13 +72,228 @@ func TestListMovies(t *testing.T) {
 		createRandomMovie(t)
 	}
 
-	movies, err := testQueries.ListMovies(context.Background(), int32(length))
+	arg := ListMoviesParams{SortDesc: true, Limit: 5}
+
+	movies, err := testQueries.ListMovies(context.Background(), arg)
 	require.NoError(t, err)
-	require.Len(t, movies, length)
+	require.Len(t, movies, 5)
 
 	for _, v := range movies {
 		require.NotEmpty(t, v)
 	}
+
+	// the next page starts after the last movie of the first page
+	arg.AfterID = movies[4].ID
+	next, err := testQueries.ListMovies(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, next)
+	require.Greater(t, movies[4].ID, next[0].ID)
+
+	total, err := testQueries.CountMovies(context.Background(), CountMoviesParams{})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, total, int64(length))
+}
+
+// TestListMoviesSort tests the sorting of ListMovies DB operation
+func TestListMoviesSort(t *testing.T) {
+	m1 := createRandomMovie(t)
+	d := m1.DirectorID
+
+	_, err := testQueries.UpdateMovie(context.Background(), UpdateMovieParams{
+		ID:          m1.ID,
+		Title:       sql.NullString{String: "b" + m1.Title, Valid: true},
+		Rating:      sql.NullInt16{Int16: 9, Valid: true},
+		ReleaseDate: sql.NullTime{Time: time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	})
+	require.NoError(t, err)
+
+	m2, err := testQueries.CreateMovie(context.Background(), CreateMovieParams{
+		Title:         "a" + util.RandomName(),
+		DirectorID:    d,
+		Rating:        7,
+		Poster:        m1.Poster,
+		Summary:       m1.Summary,
+		Certification: m1.Certification,
+		Tags:          m1.Tags,
+		ReleaseDate:   sql.NullTime{Time: time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		sortBy   string
+		sortDesc bool
+		first    int64
+	}{
+		{sortBy: "", sortDesc: true, first: m2.ID},
+		{sortBy: "", sortDesc: false, first: m1.ID},
+		{sortBy: "rating", sortDesc: true, first: m1.ID},
+		{sortBy: "rating", sortDesc: false, first: m2.ID},
+		{sortBy: "title", sortDesc: false, first: m2.ID},
+		{sortBy: "title", sortDesc: true, first: m1.ID},
+		{sortBy: "release_date", sortDesc: true, first: m2.ID},
+		{sortBy: "release_date", sortDesc: false, first: m1.ID},
+	}
+
+	for _, tt := range testCases {
+		movies, err := testQueries.ListMovies(context.Background(), ListMoviesParams{
+			DirectorID: sql.NullInt64{Int64: d, Valid: true},
+			SortBy:     tt.sortBy,
+			SortDesc:   tt.sortDesc,
+			Limit:      5,
+		})
+		require.NoError(t, err)
+		require.Len(t, movies, 2)
+		require.Equal(t, tt.first, movies[0].ID, tt.sortBy)
+
+		// the page after the first movie has only the other movie in the same order
+		next, err := testQueries.ListMovies(context.Background(), ListMoviesParams{
+			DirectorID: sql.NullInt64{Int64: d, Valid: true},
+			AfterID:    movies[0].ID,
+			SortBy:     tt.sortBy,
+			SortDesc:   tt.sortDesc,
+			Limit:      5,
+		})
+		require.NoError(t, err)
+		require.Len(t, next, 1)
+		require.Equal(t, movies[1].ID, next[0].ID, tt.sortBy)
+	}
+}
+
+// TestListMoviesFilters tests the filters of ListMovies DB operation
+func TestListMoviesFilters(t *testing.T) {
+	m := createRandomMovie(t)
+	g := createRandomGenre(t)
+
+	err := testQueries.AddMovieGenre(context.Background(), AddMovieGenreParams{MovieID: m.ID, GenreID: g.ID})
+	require.NoError(t, err)
+
+	arg := ListMoviesParams{
+		Limit:         8,
+		Certification: sql.NullString{String: m.Certification, Valid: true},
+		Tag:           sql.NullString{String: m.Tags[0], Valid: true},
+		Genre:         sql.NullString{String: strings.ToUpper(g.Name), Valid: true},
+		DirectorID:    sql.NullInt64{Int64: m.DirectorID, Valid: true},
+		MinRating:     sql.NullInt16{Int16: m.Rating, Valid: true},
+		MaxRating:     sql.NullInt16{Int16: m.Rating, Valid: true},
+	}
+
+	movies, err := testQueries.ListMovies(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	require.Equal(t, m.ID, movies[0].ID)
+
+	total, err := testQueries.CountMovies(context.Background(), CountMoviesParams{
+		Certification: arg.Certification,
+		Tag:           arg.Tag,
+		Genre:         arg.Genre,
+		DirectorID:    arg.DirectorID,
+		MinRating:     arg.MinRating,
+		MaxRating:     arg.MaxRating,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+
+	arg.MinRating = sql.NullInt16{Int16: m.Rating + 1, Valid: true}
+	arg.MaxRating = sql.NullInt16{}
+	movies, err = testQueries.ListMovies(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, movies)
+
+	arg.MinRating = sql.NullInt16{}
+	arg.Certification = sql.NullString{String: "NC-17", Valid: true}
+	movies, err = testQueries.ListMovies(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, movies)
+}
+
+// TestListMoviesByIDs tests ListMoviesByIDs DB operation
+func TestListMoviesByIDs(t *testing.T) {
+	m1 := createRandomMovie(t)
+	m2 := createRandomMovie(t)
+
+	// deleted movies are listed as well since their tickets are still valid
+	_, err := testQueries.SoftDeleteMovie(context.Background(), m2.ID)
+	require.NoError(t, err)
+
+	movies, err := testQueries.ListMoviesByIDs(context.Background(), []int64{m1.ID, m2.ID})
+	require.NoError(t, err)
+	require.Len(t, movies, 2)
+	require.ElementsMatch(t, []int64{m1.ID, m2.ID}, []int64{movies[0].ID, movies[1].ID})
+}
+
+// TestListMoviesByDirector tests ListMoviesByDirector DB operation
+func TestListMoviesByDirector(t *testing.T) {
+	m1 := createRandomMovie(t)
+
+	// a deleted movie of the same director
+	m2, err := testQueries.CreateMovie(context.Background(), CreateMovieParams{
+		Title:      util.RandomName(),
+		DirectorID: m1.DirectorID,
+		Rating:     m1.Rating,
+		Poster:     m1.Poster,
+		Summary:    m1.Summary,
+		// the certification doesn't restrict children so tickets of random movies can have any count
+		Certification: m1.Certification,
+		Tags:          m1.Tags,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.SoftDeleteMovie(context.Background(), m2.ID)
+	require.NoError(t, err)
+
+	arg := ListMoviesByDirectorParams{
+		DirectorID: m1.DirectorID,
+		Limit:      5,
+	}
+
+	movies, err := testQueries.ListMoviesByDirector(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	require.Equal(t, m1.ID, movies[0].ID)
+
+	// the movie isn't listed before itself
+	arg.BeforeID = sql.NullInt64{Int64: m1.ID, Valid: true}
+	movies, err = testQueries.ListMoviesByDirector(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, movies)
+}
+
+// TestUpdateMovie tests UpdateMovie DB operation
+func TestUpdateMovie(t *testing.T) {
+	m1 := createRandomMovie(t)
+	arg := UpdateMovieParams{
+		ID:    m1.ID,
+		Title: sql.NullString{String: util.RandomName(), Valid: true},
+	}
+
+	m2, err := testQueries.UpdateMovie(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Title.String, m2.Title)
+	require.Equal(t, m1.Summary, m2.Summary)
+	require.Equal(t, m1.Poster, m2.Poster)
+	require.Equal(t, m1.Rating, m2.Rating)
+}
+
+// TestSoftDeleteMovie tests SoftDeleteMovie DB operation
+func TestSoftDeleteMovie(t *testing.T) {
+	m1 := createRandomMovie(t)
+
+	m2, err := testQueries.SoftDeleteMovie(context.Background(), m1.ID)
+	require.NoError(t, err)
+	require.True(t, m2.DeletedAt.Valid)
+
+	// the movie is kept for its tickets
+	m3, err := testQueries.GetMovie(context.Background(), m1.ID)
+	require.NoError(t, err)
+	require.True(t, m3.DeletedAt.Valid)
+
+	// deleted movies can't be deleted or updated again
+	_, err = testQueries.SoftDeleteMovie(context.Background(), m1.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.UpdateMovie(context.Background(), UpdateMovieParams{ID: m1.ID, Title: sql.NullString{String: "title", Valid: true}})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
 }
 
 // TestDeleteMovie tests DeleteMovie DB operation
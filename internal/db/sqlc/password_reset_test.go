@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomPasswordReset creates a password reset for the user in DB and returns it with its token
+func createRandomPasswordReset(t *testing.T, u User, expiresAt time.Time) (string, PasswordReset) {
+	resetToken, err := util.NewResetToken()
+	require.NoError(t, err)
+
+	arg := CreatePasswordResetParams{
+		Username:  u.Username,
+		TokenHash: util.HashResetToken(resetToken),
+		ExpiresAt: expiresAt,
+	}
+
+	reset, err := testQueries.CreatePasswordReset(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, reset.ID)
+	require.Equal(t, arg.Username, reset.Username)
+	require.Equal(t, arg.TokenHash, reset.TokenHash)
+	require.WithinDuration(t, arg.ExpiresAt, reset.ExpiresAt, time.Millisecond)
+	require.False(t, reset.UsedAt.Valid)
+	require.NotZero(t, reset.CreatedAt)
+
+	return resetToken, reset
+}
+
+// TestCreatePasswordReset tests CreatePasswordReset DB operation
+func TestCreatePasswordReset(t *testing.T) {
+	createRandomPasswordReset(t, createRandomUser(t), time.Now().Add(time.Hour))
+}
+
+// TestUsePasswordReset tests UsePasswordReset DB operation
+func TestUsePasswordReset(t *testing.T) {
+	u := createRandomUser(t)
+	_, reset := createRandomPasswordReset(t, u, time.Now().Add(time.Hour))
+
+	used, err := testQueries.UsePasswordReset(context.Background(), reset.TokenHash)
+	require.NoError(t, err)
+	require.Equal(t, reset.ID, used.ID)
+	require.True(t, used.UsedAt.Valid)
+
+	// a token can be used only once
+	_, err = testQueries.UsePasswordReset(context.Background(), reset.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// an expired token can't be used
+	_, expired := createRandomPasswordReset(t, u, time.Now().Add(-time.Minute))
+	_, err = testQueries.UsePasswordReset(context.Background(), expired.TokenHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// TestRevokePasswordResets tests RevokePasswordResets DB operation
+func TestRevokePasswordResets(t *testing.T) {
+	u := createRandomUser(t)
+	_, reset1 := createRandomPasswordReset(t, u, time.Now().Add(time.Hour))
+	_, reset2 := createRandomPasswordReset(t, u, time.Now().Add(time.Hour))
+
+	// the tokens of the other users aren't revoked
+	_, other := createRandomPasswordReset(t, createRandomUser(t), time.Now().Add(time.Hour))
+
+	err := testQueries.RevokePasswordResets(context.Background(), u.Username)
+	require.NoError(t, err)
+
+	for _, reset := range []PasswordReset{reset1, reset2} {
+		_, err = testQueries.UsePasswordReset(context.Background(), reset.TokenHash)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	}
+
+	_, err = testQueries.UsePasswordReset(context.Background(), other.TokenHash)
+	require.NoError(t, err)
+}
+
+// TestResetPasswordTx tests ResetPasswordTx
+func TestResetPasswordTx(t *testing.T) {
+	u := createRandomUser(t)
+	_, reset1 := createRandomPasswordReset(t, u, time.Now().Add(time.Hour))
+	_, reset2 := createRandomPasswordReset(t, u, time.Now().Add(time.Hour))
+
+	hashedPassword, err := util.HashPassword(util.RandomString(8))
+	require.NoError(t, err)
+
+	arg := ResetPasswordTxParams{
+		TokenHash:         reset1.TokenHash,
+		HashedPassword:    hashedPassword,
+		PasswordChangedAt: time.Now(),
+	}
+
+	updated, err := testStore.ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, u.Username, updated.Username)
+	require.Equal(t, hashedPassword, updated.HashedPassword)
+	require.WithinDuration(t, arg.PasswordChangedAt, updated.PasswordChangedAt, time.Millisecond)
+
+	// the used token and the other tokens of the user can't be used again
+	_, err = testStore.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.TokenHash = reset2.TokenHash
+	_, err = testStore.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// the password isn't changed by the failed resets
+	u2, err := testQueries.GetUser(context.Background(), u.Username)
+	require.NoError(t, err)
+	require.Equal(t, updated.HashedPassword, u2.HashedPassword)
+	require.WithinDuration(t, updated.PasswordChangedAt, u2.PasswordChangedAt, time.Millisecond)
+}
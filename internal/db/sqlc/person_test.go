@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomPerson creates a random person
+func createRandomPerson(t *testing.T) Person {
+	arg := CreatePersonParams{
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+	}
+
+	p, err := testQueries.CreatePerson(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, p)
+
+	require.NotZero(t, p.ID)
+	require.NotZero(t, p.CreatedAt)
+	require.Equal(t, arg.FirstName, p.FirstName)
+	require.Equal(t, arg.LastName, p.LastName)
+
+	return p
+}
+
+// TestCreatePerson tests CreatePerson DB operation
+func TestCreatePerson(t *testing.T) {
+	createRandomPerson(t)
+}
+
+// TestGetPerson tests GetPerson DB operation
+func TestGetPerson(t *testing.T) {
+	p1 := createRandomPerson(t)
+
+	p2, err := testQueries.GetPerson(context.Background(), p1.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, p1.ID, p2.ID)
+	require.Equal(t, p1.FirstName, p2.FirstName)
+	require.Equal(t, p1.LastName, p2.LastName)
+	require.WithinDuration(t, p1.CreatedAt, p2.CreatedAt, time.Second)
+}
+
+// TestUpdatePerson tests UpdatePerson DB operation
+func TestUpdatePerson(t *testing.T) {
+	p1 := createRandomPerson(t)
+	arg := UpdatePersonParams{
+		ID:       p1.ID,
+		LastName: sql.NullString{String: util.RandomName(), Valid: true},
+	}
+
+	p2, err := testQueries.UpdatePerson(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, p1.FirstName, p2.FirstName)
+	require.Equal(t, arg.LastName.String, p2.LastName)
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomCashShift opens a cash shift for a random cashier
+func createRandomCashShift(t *testing.T) CashShift {
+	u := createRandomUser(t)
+	arg := OpenCashShiftParams{
+		Cashier:     u.Username,
+		OpeningCash: util.RandomInt(0, 1000),
+	}
+
+	shift, err := testQueries.OpenCashShift(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, shift)
+
+	require.NotZero(t, shift.ID)
+	require.NotZero(t, shift.OpenedAt)
+	require.Equal(t, arg.Cashier, shift.Cashier)
+	require.Equal(t, arg.OpeningCash, shift.OpeningCash)
+	require.False(t, shift.ClosedAt.Valid)
+	require.False(t, shift.CountedCash.Valid)
+
+	return shift
+}
+
+// createRandomPosSale creates a random box office sale in given shift
+func createRandomPosSale(t *testing.T, shift CashShift, paymentMethod string) PosSale {
+	m := createRandomMovie(t)
+	arg := CreatePosSaleParams{
+		ShiftID:       shift.ID,
+		MovieID:       m.ID,
+		GuestName:     util.RandomName(),
+		PaymentMethod: paymentMethod,
+		TicketCode:    util.RandomString(10),
+		Child:         int16(util.RandomInt(0, 5)),
+		Adult:         int16(util.RandomInt(1, 5)),
+		Total:         util.RandomInt(20, 500),
+		Currency:      "USD",
+	}
+
+	sale, err := testQueries.CreatePosSale(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, sale)
+
+	require.NotZero(t, sale.ID)
+	require.NotZero(t, sale.CreatedAt)
+	require.Equal(t, arg.ShiftID, sale.ShiftID)
+	require.Equal(t, arg.MovieID, sale.MovieID)
+	require.Equal(t, arg.PaymentMethod, sale.PaymentMethod)
+	require.Equal(t, arg.TicketCode, sale.TicketCode)
+	require.Equal(t, arg.Total, sale.Total)
+
+	return sale
+}
+
+// TestOpenCashShift tests OpenCashShift DB operation
+func TestOpenCashShift(t *testing.T) {
+	shift := createRandomCashShift(t)
+
+	// a cashier can't open a second shift while the first one is open
+	_, err := testQueries.OpenCashShift(context.Background(), OpenCashShiftParams{Cashier: shift.Cashier})
+	require.Error(t, err)
+}
+
+// TestGetOpenCashShift tests GetOpenCashShift DB operation
+func TestGetOpenCashShift(t *testing.T) {
+	shift1 := createRandomCashShift(t)
+
+	shift2, err := testQueries.GetOpenCashShift(context.Background(), shift1.Cashier)
+	require.NoError(t, err)
+	require.Equal(t, shift1.ID, shift2.ID)
+	require.WithinDuration(t, shift1.OpenedAt, shift2.OpenedAt, time.Second)
+}
+
+// TestCloseCashShift tests CloseCashShift DB operation
+func TestCloseCashShift(t *testing.T) {
+	shift1 := createRandomCashShift(t)
+	arg := CloseCashShiftParams{
+		ID:          shift1.ID,
+		CountedCash: sql.NullInt64{Int64: util.RandomInt(0, 1000), Valid: true},
+	}
+
+	shift2, err := testQueries.CloseCashShift(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.CountedCash, shift2.CountedCash)
+	require.True(t, shift2.ClosedAt.Valid)
+
+	// a closed shift can't be closed again
+	_, err = testQueries.CloseCashShift(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.GetOpenCashShift(context.Background(), shift1.Cashier)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestGetPosSaleByCode tests GetPosSaleByCode DB operation
+func TestGetPosSaleByCode(t *testing.T) {
+	shift := createRandomCashShift(t)
+	sale1 := createRandomPosSale(t, shift, "cash")
+
+	sale2, err := testQueries.GetPosSaleByCode(context.Background(), sale1.TicketCode)
+	require.NoError(t, err)
+	require.Equal(t, sale1.ID, sale2.ID)
+	require.Equal(t, sale1.GuestName, sale2.GuestName)
+	require.WithinDuration(t, sale1.CreatedAt, sale2.CreatedAt, time.Second)
+}
+
+// TestSummarizeCashShift tests SummarizeCashShift DB operation
+func TestSummarizeCashShift(t *testing.T) {
+	shift := createRandomCashShift(t)
+
+	var cashTotal, cardTotal int64
+	for i := 0; i < 3; i++ {
+		cashTotal += createRandomPosSale(t, shift, "cash").Total
+	}
+	for i := 0; i < 2; i++ {
+		cardTotal += createRandomPosSale(t, shift, "card").Total
+	}
+
+	rows, err := testQueries.SummarizeCashShift(context.Background(), shift.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.Equal(t, SummarizeCashShiftRow{PaymentMethod: "card", Sales: 2, Total: cardTotal}, rows[0])
+	require.Equal(t, SummarizeCashShiftRow{PaymentMethod: "cash", Sales: 3, Total: cashTotal}, rows[1])
+}
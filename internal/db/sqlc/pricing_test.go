@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPricingRule(t *testing.T, venueID sql.NullInt64) PricingRule {
+	arg := CreatePricingRuleParams{
+		VenueID:  venueID,
+		Name:     util.RandomName(),
+		Kind:     "weekday",
+		Percent:  -int32(util.RandomInt(5, 50)),
+		Weekdays: []int32{2, 3},
+	}
+
+	r, err := testQueries.CreatePricingRule(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, r.ID)
+	require.Equal(t, arg.VenueID, r.VenueID)
+	require.Equal(t, arg.Name, r.Name)
+	require.Equal(t, arg.Kind, r.Kind)
+	require.Equal(t, arg.Percent, r.Percent)
+	require.Equal(t, arg.Weekdays, r.Weekdays)
+	require.False(t, r.StartsBefore.Valid)
+	require.NotZero(t, r.CreatedAt)
+
+	return r
+}
+
+// TestCreatePricingRule tests CreatePricingRule DB operation
+func TestCreatePricingRule(t *testing.T) {
+	createRandomPricingRule(t, sql.NullInt64{})
+}
+
+// TestCreatePricingRuleMissingFields tests that a rule can't miss the fields of its kind
+func TestCreatePricingRuleMissingFields(t *testing.T) {
+	_, err := testQueries.CreatePricingRule(context.Background(), CreatePricingRuleParams{
+		Name:     util.RandomName(),
+		Kind:     "matinee",
+		Percent:  -20,
+		Weekdays: []int32{},
+	})
+	require.Error(t, err)
+}
+
+// TestListVenuePricingRules tests ListVenuePricingRules DB operation
+func TestListVenuePricingRules(t *testing.T) {
+	v1 := createRandomVenue(t)
+	v2 := createRandomVenue(t)
+
+	everyVenue := createRandomPricingRule(t, sql.NullInt64{})
+	own := createRandomPricingRule(t, sql.NullInt64{Int64: v1.ID, Valid: true})
+	other := createRandomPricingRule(t, sql.NullInt64{Int64: v2.ID, Valid: true})
+
+	rules, err := testQueries.ListVenuePricingRules(context.Background(), sql.NullInt64{Int64: v1.ID, Valid: true})
+	require.NoError(t, err)
+
+	ids := make(map[int64]bool, len(rules))
+	for _, r := range rules {
+		ids[r.ID] = true
+	}
+
+	require.True(t, ids[everyVenue.ID])
+	require.True(t, ids[own.ID])
+	require.False(t, ids[other.ID])
+}
+
+// TestDeletePricingRule tests DeletePricingRule DB operation
+func TestDeletePricingRule(t *testing.T) {
+	r := createRandomPricingRule(t, sql.NullInt64{})
+
+	n, err := testQueries.DeletePricingRule(context.Background(), r.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = testQueries.DeletePricingRule(context.Background(), r.ID)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+// TestCountScreeningSeats tests CountScreeningSeats DB operation
+func TestCountScreeningSeats(t *testing.T) {
+	u := createRandomUser(t)
+	m := createRandomMovie(t)
+	s := createRandomScreening(t, m)
+	screeningID := sql.NullInt64{Int64: s.ID, Valid: true}
+
+	seats, err := testQueries.CountScreeningSeats(context.Background(), screeningID)
+	require.NoError(t, err)
+	require.Zero(t, seats)
+
+	_, err = testQueries.CreateTicket(context.Background(), CreateTicketParams{
+		TicketOwner:   u.Username,
+		MovieID:       m.ID,
+		Child:         1,
+		Adult:         2,
+		Total:         2700,
+		Currency:      "USD",
+		PaymentMethod: "card",
+		ScreeningID:   screeningID,
+	})
+	require.NoError(t, err)
+
+	seats, err = testQueries.CountScreeningSeats(context.Background(), screeningID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), seats)
+}
@@ -0,0 +1,340 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomPrivateBooking creates a requested booking of a new screening in a new venue
+func createRandomPrivateBooking(t *testing.T) PrivateBooking {
+	u := createRandomUser(t)
+	v := createRandomVenue(t)
+	s := createRandomScreening(t, createRandomMovie(t))
+
+	arg := CreatePrivateBookingParams{
+		Organizer:    u.Username,
+		Organization: util.RandomString(12),
+		ContactEmail: util.RandomEmail(),
+		ScreeningID:  s.ID,
+		VenueID:      v.ID,
+		Guests:       int32(util.RandomInt(20, 50)),
+		Notes:        util.RandomString(20),
+	}
+
+	b, err := testQueries.CreatePrivateBooking(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, b)
+
+	require.NotZero(t, b.ID)
+	require.NotZero(t, b.CreatedAt)
+	require.Equal(t, arg.Organizer, b.Organizer)
+	require.Equal(t, arg.Organization, b.Organization)
+	require.Equal(t, arg.ContactEmail, b.ContactEmail)
+	require.Equal(t, arg.ScreeningID, b.ScreeningID)
+	require.Equal(t, arg.VenueID, b.VenueID)
+	require.Equal(t, arg.Guests, b.Guests)
+	require.Equal(t, arg.Notes, b.Notes)
+	require.Equal(t, "requested", b.Status)
+	require.False(t, b.QuotedAt.Valid)
+
+	return b
+}
+
+// quoteRandomPrivateBooking quotes given booking with a deposit of 200.00 that can be accepted for a week
+func quoteRandomPrivateBooking(t *testing.T, b PrivateBooking) PrivateBooking {
+	arg := QuotePrivateBookingParams{
+		Net:              sql.NullInt64{Int64: 100000, Valid: true},
+		Tax:              sql.NullInt64{Int64: 18000, Valid: true},
+		Total:            sql.NullInt64{Int64: 118000, Valid: true},
+		Deposit:          sql.NullInt64{Int64: 20000, Valid: true},
+		Currency:         sql.NullString{String: "USD", Valid: true},
+		PaymentTermsDays: sql.NullInt32{Int32: 30, Valid: true},
+		QuotedBy:         sql.NullString{String: createRandomUser(t).Username, Valid: true},
+		QuoteExpiresAt:   sql.NullTime{Time: time.Now().AddDate(0, 0, 7), Valid: true},
+		ID:               b.ID,
+	}
+
+	quoted, err := testQueries.QuotePrivateBooking(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "quoted", quoted.Status)
+	require.Equal(t, arg.Total, quoted.Total)
+	require.Equal(t, arg.Deposit, quoted.Deposit)
+	require.Equal(t, arg.QuotedBy, quoted.QuotedBy)
+	require.True(t, quoted.QuotedAt.Valid)
+	require.WithinDuration(t, arg.QuoteExpiresAt.Time, quoted.QuoteExpiresAt.Time, time.Second)
+
+	return quoted
+}
+
+// acceptRandomPrivateBooking accepts the quote of given booking
+func acceptRandomPrivateBooking(t *testing.T, b PrivateBooking) PrivateBooking {
+	accepted, err := testQueries.AcceptPrivateBooking(context.Background(), AcceptPrivateBookingParams{
+		DepositReference: sql.NullString{String: util.RandomString(10), Valid: true},
+		ID:               b.ID,
+		QuotedAt:         b.QuotedAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "accepted", accepted.Status)
+	require.True(t, accepted.AcceptedAt.Valid)
+
+	return accepted
+}
+
+// confirmTxParams returns the params of the confirmation of given booking with its invoice
+func confirmTxParams(t *testing.T, b PrivateBooking) ConfirmPrivateBookingTxParams {
+	issuedAt := time.Now()
+
+	return ConfirmPrivateBookingTxParams{
+		ConfirmPrivateBookingParams: ConfirmPrivateBookingParams{
+			ApprovedBy: sql.NullString{String: createRandomUser(t).Username, Valid: true},
+			ID:         b.ID,
+		},
+		Invoice: CreatePrivateBookingInvoiceParams{
+			Number:   util.RandomString(16),
+			Net:      b.Net.Int64,
+			Tax:      b.Tax.Int64,
+			Total:    b.Total.Int64,
+			Deposit:  b.Deposit.Int64,
+			Balance:  b.Total.Int64 - b.Deposit.Int64,
+			Currency: b.Currency.String,
+			IssuedAt: issuedAt,
+			DueAt:    issuedAt.AddDate(0, 0, int(b.PaymentTermsDays.Int32)),
+		},
+	}
+}
+
+// TestListPrivateBookings tests ListVenuePrivateBookings and ListOrganizerPrivateBookings DB operations
+func TestListPrivateBookings(t *testing.T) {
+	b := createRandomPrivateBooking(t)
+
+	bookings, err := testQueries.ListVenuePrivateBookings(context.Background(), ListVenuePrivateBookingsParams{
+		VenueID: b.VenueID,
+		Status:  sql.NullString{String: "requested", Valid: true},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, bookings, 1)
+	require.Equal(t, b.ID, bookings[0].ID)
+
+	bookings, err = testQueries.ListVenuePrivateBookings(context.Background(), ListVenuePrivateBookingsParams{
+		VenueID: b.VenueID,
+		Status:  sql.NullString{String: "quoted", Valid: true},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, bookings)
+
+	bookings, err = testQueries.ListOrganizerPrivateBookings(context.Background(), ListOrganizerPrivateBookingsParams{
+		Organizer: b.Organizer,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, bookings, 1)
+	require.Equal(t, b.ID, bookings[0].ID)
+}
+
+// TestAcceptPrivateBooking tests AcceptPrivateBooking DB operation, a quote is accepted once and a changed quote can't be accepted
+func TestAcceptPrivateBooking(t *testing.T) {
+	b := quoteRandomPrivateBooking(t, createRandomPrivateBooking(t))
+	stale := b.QuotedAt
+	stale.Time = stale.Time.Add(-time.Minute)
+
+	_, err := testQueries.AcceptPrivateBooking(context.Background(), AcceptPrivateBookingParams{ID: b.ID, QuotedAt: stale})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	acceptRandomPrivateBooking(t, b)
+
+	_, err = testQueries.AcceptPrivateBooking(context.Background(), AcceptPrivateBookingParams{ID: b.ID, QuotedAt: b.QuotedAt})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	// an accepted quote can't be changed
+	_, err = testQueries.QuotePrivateBooking(context.Background(), QuotePrivateBookingParams{
+		Net:              b.Net,
+		Tax:              b.Tax,
+		Total:            b.Total,
+		Deposit:          b.Deposit,
+		Currency:         b.Currency,
+		PaymentTermsDays: b.PaymentTermsDays,
+		QuotedBy:         b.QuotedBy,
+		QuoteExpiresAt:   b.QuoteExpiresAt,
+		ID:               b.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestConfirmPrivateBookingTx tests ConfirmPrivateBookingTx, the seats of the screening are blocked for the booking
+func TestConfirmPrivateBookingTx(t *testing.T) {
+	b := acceptRandomPrivateBooking(t, quoteRandomPrivateBooking(t, createRandomPrivateBooking(t)))
+	arg := confirmTxParams(t, b)
+
+	result, err := testStore.ConfirmPrivateBookingTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, PrivateBookingConfirmed, result.Booking.Status)
+	require.Equal(t, arg.ApprovedBy, result.Booking.ApprovedBy)
+	require.True(t, result.Booking.ConfirmedAt.Valid)
+
+	require.NotNil(t, result.Invoice)
+	require.Equal(t, b.ID, result.Invoice.BookingID)
+	require.Equal(t, arg.Invoice.Number, result.Invoice.Number)
+	require.Equal(t, int64(98000), result.Invoice.Balance)
+	require.WithinDuration(t, arg.Invoice.DueAt, result.Invoice.DueAt, time.Second)
+	require.False(t, result.Invoice.PaidAt.Valid)
+
+	s, err := testQueries.GetScreening(context.Background(), b.ScreeningID)
+	require.NoError(t, err)
+	require.Equal(t, b.ID, s.PrivateBookingID.Int64)
+
+	// a booked screening isn't sold
+	_, err = testStore.PurchaseTicketTx(context.Background(), PurchaseTicketTxParams{
+		CreateTicketParams: CreateTicketParams{
+			MovieID:       s.MovieID,
+			TicketOwner:   createRandomUser(t).Username,
+			Adult:         1,
+			Total:         1000,
+			ScreeningID:   sql.NullInt64{Int64: s.ID, Valid: true},
+			Currency:      "USD",
+			PaymentMethod: "card",
+		},
+	})
+	require.ErrorIs(t, err, ErrScreeningBooked)
+
+	// a booking is confirmed once
+	_, err = testStore.ConfirmPrivateBookingTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// TestConfirmPrivateBookingTxSold tests that ConfirmPrivateBookingTx fails if seats of the screening are sold
+func TestConfirmPrivateBookingTxSold(t *testing.T) {
+	b := acceptRandomPrivateBooking(t, quoteRandomPrivateBooking(t, createRandomPrivateBooking(t)))
+	s, err := testQueries.GetScreening(context.Background(), b.ScreeningID)
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateTicket(context.Background(), CreateTicketParams{
+		MovieID:       s.MovieID,
+		TicketOwner:   createRandomUser(t).Username,
+		Adult:         1,
+		Total:         1000,
+		ScreeningID:   sql.NullInt64{Int64: s.ID, Valid: true},
+		Currency:      "USD",
+		PaymentMethod: "card",
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.ConfirmPrivateBookingTx(context.Background(), confirmTxParams(t, b))
+	require.ErrorIs(t, err, ErrScreeningSold)
+
+	// the transaction is rolled back
+	got, err := testQueries.GetPrivateBooking(context.Background(), b.ID)
+	require.NoError(t, err)
+	require.Equal(t, "accepted", got.Status)
+
+	s, err = testQueries.GetScreening(context.Background(), b.ScreeningID)
+	require.NoError(t, err)
+	require.False(t, s.PrivateBookingID.Valid)
+}
+
+// TestCancelPrivateBookingTx tests CancelPrivateBookingTx, a confirmed booking releases its screening and voids its invoice
+func TestCancelPrivateBookingTx(t *testing.T) {
+	b := acceptRandomPrivateBooking(t, quoteRandomPrivateBooking(t, createRandomPrivateBooking(t)))
+	confirmed, err := testStore.ConfirmPrivateBookingTx(context.Background(), confirmTxParams(t, b))
+	require.NoError(t, err)
+
+	arg := CancelPrivateBookingParams{
+		Status:         "cancelled",
+		CancelReason:   util.RandomString(20),
+		ID:             b.ID,
+		PreviousStatus: confirmed.Booking.Status,
+	}
+
+	result, err := testStore.CancelPrivateBookingTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "cancelled", result.Booking.Status)
+	require.Equal(t, arg.CancelReason, result.Booking.CancelReason)
+	require.True(t, result.Booking.CancelledAt.Valid)
+
+	invoice, err := testQueries.GetPrivateBookingInvoice(context.Background(), b.ID)
+	require.NoError(t, err)
+	require.True(t, invoice.VoidedAt.Valid)
+
+	s, err := testQueries.GetScreening(context.Background(), b.ScreeningID)
+	require.NoError(t, err)
+	require.False(t, s.PrivateBookingID.Valid)
+
+	// a voided invoice isn't paid
+	_, err = testQueries.PayPrivateBookingInvoice(context.Background(), PayPrivateBookingInvoiceParams{BookingID: b.ID})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestCancelPrivateBookingTxPaid tests that CancelPrivateBookingTx fails if the invoice of the booking is paid
+func TestCancelPrivateBookingTxPaid(t *testing.T) {
+	b := acceptRandomPrivateBooking(t, quoteRandomPrivateBooking(t, createRandomPrivateBooking(t)))
+	_, err := testStore.ConfirmPrivateBookingTx(context.Background(), confirmTxParams(t, b))
+	require.NoError(t, err)
+
+	paid, err := testQueries.PayPrivateBookingInvoice(context.Background(), PayPrivateBookingInvoiceParams{
+		PaymentReference: sql.NullString{String: util.RandomString(10), Valid: true},
+		BookingID:        b.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, paid.PaidAt.Valid)
+
+	_, err = testStore.CancelPrivateBookingTx(context.Background(), CancelPrivateBookingParams{
+		Status:         "cancelled",
+		ID:             b.ID,
+		PreviousStatus: PrivateBookingConfirmed,
+	})
+	require.ErrorIs(t, err, ErrInvoicePaid)
+
+	got, err := testQueries.GetPrivateBooking(context.Background(), b.ID)
+	require.NoError(t, err)
+	require.Equal(t, PrivateBookingConfirmed, got.Status)
+}
+
+// TestCompletePrivateBookingRefund tests that the deposit of a closed booking is pending until its refund is completed once
+func TestCompletePrivateBookingRefund(t *testing.T) {
+	b := acceptRandomPrivateBooking(t, quoteRandomPrivateBooking(t, createRandomPrivateBooking(t)))
+
+	result, err := testStore.CancelPrivateBookingTx(context.Background(), CancelPrivateBookingParams{
+		Status:         "rejected",
+		CancelReason:   util.RandomString(20),
+		ID:             b.ID,
+		PreviousStatus: b.Status,
+	})
+	require.NoError(t, err)
+	require.False(t, result.Booking.DepositRefundReference.Valid)
+
+	pending, err := testQueries.ListPendingPrivateBookingRefunds(context.Background(), 1000)
+	require.NoError(t, err)
+	require.Contains(t, privateBookingIDs(pending), b.ID)
+
+	arg := CompletePrivateBookingRefundParams{
+		ID:                     b.ID,
+		DepositRefundReference: sql.NullString{String: util.RandomString(10), Valid: true},
+	}
+
+	refunded, err := testQueries.CompletePrivateBookingRefund(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.DepositRefundReference, refunded.DepositRefundReference)
+
+	// a refund is completed once
+	_, err = testQueries.CompletePrivateBookingRefund(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	pending, err = testQueries.ListPendingPrivateBookingRefunds(context.Background(), 1000)
+	require.NoError(t, err)
+	require.NotContains(t, privateBookingIDs(pending), b.ID)
+}
+
+// privateBookingIDs returns the ids of the bookings
+func privateBookingIDs(bookings []PrivateBooking) []int64 {
+	ids := make([]int64, len(bookings))
+	for i, b := range bookings {
+		ids[i] = b.ID
+	}
+	return ids
+}
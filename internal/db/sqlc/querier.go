@@ -6,22 +6,174 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AcceptPrivateBooking(ctx context.Context, arg AcceptPrivateBookingParams) (PrivateBooking, error)
+	AddConcessionStock(ctx context.Context, arg AddConcessionStockParams) (ConcessionItem, error)
+	AddMovieGenre(ctx context.Context, arg AddMovieGenreParams) error
+	AddWatchlistItem(ctx context.Context, arg AddWatchlistItemParams) (WatchlistItem, error)
+	AutocompleteMovies(ctx context.Context, arg AutocompleteMoviesParams) ([]AutocompleteMoviesRow, error)
+	BlockScreening(ctx context.Context, arg BlockScreeningParams) (Screening, error)
+	CancelMembership(ctx context.Context, id int64) (Membership, error)
+	CancelMembershipAtPeriodEnd(ctx context.Context, id int64) (Membership, error)
+	CancelPrivateBooking(ctx context.Context, arg CancelPrivateBookingParams) (PrivateBooking, error)
+	ChangeMembershipPlan(ctx context.Context, arg ChangeMembershipPlanParams) (Membership, error)
+	CheckInTicket(ctx context.Context, id int64) (Ticket, error)
+	CloseCashShift(ctx context.Context, arg CloseCashShiftParams) (CashShift, error)
+	CompleteMembershipRefund(ctx context.Context, arg CompleteMembershipRefundParams) (MembershipPayment, error)
+	CompletePrivateBookingRefund(ctx context.Context, arg CompletePrivateBookingRefundParams) (PrivateBooking, error)
+	ConfirmPrivateBooking(ctx context.Context, arg ConfirmPrivateBookingParams) (PrivateBooking, error)
+	ConsumeMembershipAllowance(ctx context.Context, arg ConsumeMembershipAllowanceParams) (Membership, error)
+	CountMovies(ctx context.Context, arg CountMoviesParams) (int64, error)
+	CountReviewReports(ctx context.Context, reviewID int64) (int64, error)
+	CountScreeningSeats(ctx context.Context, screeningID sql.NullInt64) (int64, error)
+	CreateAuditorium(ctx context.Context, arg CreateAuditoriumParams) (Auditorium, error)
+	CreateAward(ctx context.Context, arg CreateAwardParams) (Award, error)
+	CreateConcessionItem(ctx context.Context, arg CreateConcessionItemParams) (ConcessionItem, error)
+	CreateConcessionOrder(ctx context.Context, arg CreateConcessionOrderParams) (ConcessionOrder, error)
+	CreateConcessionOrderItem(ctx context.Context, arg CreateConcessionOrderItemParams) (ConcessionOrderItem, error)
 	CreateDirector(ctx context.Context, arg CreateDirectorParams) (Director, error)
+	CreateGenre(ctx context.Context, name string) (Genre, error)
+	CreateMembership(ctx context.Context, arg CreateMembershipParams) (Membership, error)
+	CreateMembershipPayment(ctx context.Context, arg CreateMembershipPaymentParams) (MembershipPayment, error)
+	CreateMembershipPlan(ctx context.Context, arg CreateMembershipPlanParams) (MembershipPlan, error)
+	CreateModerationEvent(ctx context.Context, arg CreateModerationEventParams) (ModerationEvent, error)
 	CreateMovie(ctx context.Context, arg CreateMovieParams) (Movie, error)
+	CreateMovieCredit(ctx context.Context, arg CreateMovieCreditParams) (MovieCredit, error)
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreatePerson(ctx context.Context, arg CreatePersonParams) (Person, error)
+	CreatePosSale(ctx context.Context, arg CreatePosSaleParams) (PosSale, error)
+	CreatePricingRule(ctx context.Context, arg CreatePricingRuleParams) (PricingRule, error)
+	CreatePrivateBooking(ctx context.Context, arg CreatePrivateBookingParams) (PrivateBooking, error)
+	CreatePrivateBookingInvoice(ctx context.Context, arg CreatePrivateBookingInvoiceParams) (PrivateBookingInvoice, error)
+	CreateReview(ctx context.Context, arg CreateReviewParams) (Review, error)
+	CreateReviewReport(ctx context.Context, arg CreateReviewReportParams) (ReviewReport, error)
+	CreateScreening(ctx context.Context, arg CreateScreeningParams) (Screening, error)
 	CreateTicket(ctx context.Context, arg CreateTicketParams) (Ticket, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserRecommendation(ctx context.Context, arg CreateUserRecommendationParams) error
+	CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue, error)
+	CreateVenueHours(ctx context.Context, arg CreateVenueHoursParams) (VenueHour, error)
+	DecrementConcessionStock(ctx context.Context, arg DecrementConcessionStockParams) (ConcessionItem, error)
+	DeleteAward(ctx context.Context, id int64) (Award, error)
+	DeleteDirector(ctx context.Context, id int64) (Director, error)
 	DeleteMovie(ctx context.Context, id int64) error
+	DeleteMovieCredit(ctx context.Context, arg DeleteMovieCreditParams) (MovieCredit, error)
+	DeleteMovieGenres(ctx context.Context, movieID int64) error
+	DeletePricingRule(ctx context.Context, id int64) (int64, error)
+	DeleteReview(ctx context.Context, id int64) error
 	DeleteTicket(ctx context.Context, id int64) error
+	DeleteUserRecommendations(ctx context.Context, username string) error
+	DeleteVenueStaff(ctx context.Context, arg DeleteVenueStaffParams) (int64, error)
+	GetAuditorium(ctx context.Context, id int64) (Auditorium, error)
+	GetAward(ctx context.Context, id int64) (Award, error)
+	GetCashShift(ctx context.Context, id int64) (CashShift, error)
+	GetConcessionItem(ctx context.Context, id int64) (ConcessionItem, error)
 	GetDirector(ctx context.Context, id int64) (Director, error)
+	GetLiveMembership(ctx context.Context, username string) (Membership, error)
+	GetMembership(ctx context.Context, id int64) (Membership, error)
+	GetMembershipPayment(ctx context.Context, id int64) (MembershipPayment, error)
+	GetMembershipPlan(ctx context.Context, id int64) (MembershipPlan, error)
 	GetMovie(ctx context.Context, id int64) (Movie, error)
+	GetMovieReviewStats(ctx context.Context, movieID int64) (GetMovieReviewStatsRow, error)
+	GetOpenCashShift(ctx context.Context, cashier string) (CashShift, error)
+	GetPasswordChangedAt(ctx context.Context, username string) (time.Time, error)
+	GetPerson(ctx context.Context, id int64) (Person, error)
+	GetPosSaleByCode(ctx context.Context, ticketCode string) (PosSale, error)
+	GetPrivateBooking(ctx context.Context, id int64) (PrivateBooking, error)
+	GetPrivateBookingInvoice(ctx context.Context, bookingID int64) (PrivateBookingInvoice, error)
+	GetReview(ctx context.Context, id int64) (Review, error)
+	GetReviewForUpdate(ctx context.Context, id int64) (Review, error)
+	GetScreening(ctx context.Context, id int64) (Screening, error)
+	GetScreeningForSale(ctx context.Context, id int64) (Screening, error)
+	GetScreeningFormat(ctx context.Context, code string) (ScreeningFormat, error)
 	GetTicket(ctx context.Context, id int64) (Ticket, error)
+	GetTicketForUpdate(ctx context.Context, id int64) (Ticket, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetVenue(ctx context.Context, id int64) (Venue, error)
+	GetVenueStaff(ctx context.Context, arg GetVenueStaffParams) (VenueStaff, error)
+	ListAwardsByYear(ctx context.Context, arg ListAwardsByYearParams) ([]Award, error)
+	ListBoxOffice(ctx context.Context, arg ListBoxOfficeParams) ([]ListBoxOfficeRow, error)
+	ListBuyers(ctx context.Context) ([]string, error)
+	ListCoPurchases(ctx context.Context, username string) ([]ListCoPurchasesRow, error)
+	ListConcessionItems(ctx context.Context) ([]ConcessionItem, error)
+	ListConcessionOrderItems(ctx context.Context, orderID int64) ([]ConcessionOrderItem, error)
+	ListDailySales(ctx context.Context, arg ListDailySalesParams) ([]ListDailySalesRow, error)
 	ListDirectors(ctx context.Context, arg ListDirectorsParams) ([]Director, error)
-	ListMovies(ctx context.Context, limit int32) ([]Movie, error)
+	ListDirectorsByIDs(ctx context.Context, ids []int64) ([]Director, error)
+	ListDueMemberships(ctx context.Context, arg ListDueMembershipsParams) ([]Membership, error)
+	ListGenres(ctx context.Context) ([]Genre, error)
+	ListGenresOfMovies(ctx context.Context, movieIds []int64) ([]MovieGenre, error)
+	ListMembershipPlans(ctx context.Context) ([]MembershipPlan, error)
+	ListModerationEvents(ctx context.Context, reviewID int64) ([]ModerationEvent, error)
+	ListMovieCredits(ctx context.Context, movieID int64) ([]ListMovieCreditsRow, error)
+	ListMovieGenres(ctx context.Context, movieID int64) ([]Genre, error)
+	ListMovieReviews(ctx context.Context, arg ListMovieReviewsParams) ([]Review, error)
+	ListMovieScreenings(ctx context.Context, arg ListMovieScreeningsParams) ([]Screening, error)
+	ListMovies(ctx context.Context, arg ListMoviesParams) ([]Movie, error)
+	ListMoviesByDirector(ctx context.Context, arg ListMoviesByDirectorParams) ([]Movie, error)
+	ListMoviesByIDs(ctx context.Context, ids []int64) ([]Movie, error)
+	ListNowShowingMovies(ctx context.Context) ([]Movie, error)
+	ListOrganizerPrivateBookings(ctx context.Context, arg ListOrganizerPrivateBookingsParams) ([]PrivateBooking, error)
+	ListOverlappingScreenings(ctx context.Context, arg ListOverlappingScreeningsParams) ([]Screening, error)
+	ListPendingMembershipRefunds(ctx context.Context, limit int32) ([]MembershipPayment, error)
+	ListPendingPrivateBookingRefunds(ctx context.Context, limit int32) ([]PrivateBooking, error)
+	ListPricingRules(ctx context.Context, arg ListPricingRulesParams) ([]PricingRule, error)
+	ListRefundableMembershipCharges(ctx context.Context, arg ListRefundableMembershipChargesParams) ([]ListRefundableMembershipChargesRow, error)
+	ListReviewReports(ctx context.Context, reviewID int64) ([]ReviewReport, error)
+	ListReviewsByStatus(ctx context.Context, arg ListReviewsByStatusParams) ([]Review, error)
+	ListScreeningFormats(ctx context.Context) ([]ScreeningFormat, error)
+	ListTicketConcessionItems(ctx context.Context, ticketIds []int64) ([]ListTicketConcessionItemsRow, error)
+	ListTicketConcessionQuantities(ctx context.Context, ticketID int64) ([]ListTicketConcessionQuantitiesRow, error)
 	ListTickets(ctx context.Context, arg ListTicketsParams) ([]Ticket, error)
+	ListTrendingMovies(ctx context.Context, limit int32) ([]MovieTrending, error)
+	ListUnnotifiedScreenings(ctx context.Context, limit int32) ([]Screening, error)
+	ListUserPurchases(ctx context.Context, ticketOwner string) ([]ListUserPurchasesRow, error)
+	ListUserRecommendations(ctx context.Context, arg ListUserRecommendationsParams) ([]UserRecommendation, error)
+	ListVenueAuditoriums(ctx context.Context, venueID int64) ([]Auditorium, error)
+	ListVenueHours(ctx context.Context, venueIds []int64) ([]VenueHour, error)
+	ListVenuePricingRules(ctx context.Context, venueID sql.NullInt64) ([]PricingRule, error)
+	ListVenuePrivateBookings(ctx context.Context, arg ListVenuePrivateBookingsParams) ([]PrivateBooking, error)
+	ListVenueScreenings(ctx context.Context, arg ListVenueScreeningsParams) ([]Screening, error)
+	ListVenueStaff(ctx context.Context, venueID int64) ([]VenueStaff, error)
+	ListVenues(ctx context.Context, arg ListVenuesParams) ([]Venue, error)
+	ListWatchers(ctx context.Context, movieIds []int64) ([]WatchlistItem, error)
+	ListWatchlist(ctx context.Context, arg ListWatchlistParams) ([]WatchlistItem, error)
+	LockAuditorium(ctx context.Context, id int64) (Auditorium, error)
+	MarkMembershipPastDue(ctx context.Context, id int64) (int64, error)
+	MarkScreeningsNotified(ctx context.Context, ids []int64) error
+	OpenCashShift(ctx context.Context, arg OpenCashShiftParams) (CashShift, error)
+	PauseMembership(ctx context.Context, arg PauseMembershipParams) (Membership, error)
+	PayPrivateBookingInvoice(ctx context.Context, arg PayPrivateBookingInvoiceParams) (PrivateBookingInvoice, error)
+	PublishScreening(ctx context.Context, id int64) (Screening, error)
+	QuotePrivateBooking(ctx context.Context, arg QuotePrivateBookingParams) (PrivateBooking, error)
+	RefreshDirectorOscars(ctx context.Context, personID int64) error
+	RefreshMovieDailySales(ctx context.Context) error
+	RefreshMovieTrending(ctx context.Context) error
+	ReleaseMembershipAllowance(ctx context.Context, arg ReleaseMembershipAllowanceParams) error
+	ReleaseScreening(ctx context.Context, privateBookingID sql.NullInt64) error
+	RemoveWatchlistItem(ctx context.Context, arg RemoveWatchlistItemParams) (int64, error)
+	RenewMembership(ctx context.Context, arg RenewMembershipParams) (Membership, error)
+	ResolveReviewReports(ctx context.Context, reviewID int64) error
+	ResumeMembership(ctx context.Context, arg ResumeMembershipParams) (Membership, error)
+	RevokePasswordResets(ctx context.Context, username string) error
+	SearchMovies(ctx context.Context, arg SearchMoviesParams) ([]SearchMoviesRow, error)
+	SoftDeleteMovie(ctx context.Context, id int64) (Movie, error)
+	SummarizeCashShift(ctx context.Context, shiftID int64) ([]SummarizeCashShiftRow, error)
+	UpdateDirector(ctx context.Context, arg UpdateDirectorParams) (Director, error)
+	UpdateMovie(ctx context.Context, arg UpdateMovieParams) (Movie, error)
+	UpdatePerson(ctx context.Context, arg UpdatePersonParams) (Person, error)
+	UpdateReviewStatus(ctx context.Context, arg UpdateReviewStatusParams) (Review, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	UpsertScreeningFormat(ctx context.Context, arg UpsertScreeningFormatParams) (ScreeningFormat, error)
+	UpsertVenueStaff(ctx context.Context, arg UpsertVenueStaffParams) (VenueStaff, error)
+	UsePasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error)
+	VerifyReviews(ctx context.Context, arg VerifyReviewsParams) error
+	VoidPrivateBookingInvoice(ctx context.Context, bookingID int64) (PrivateBookingInvoice, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// buyTicket creates a ticket of given user for given movie
+func buyTicket(t *testing.T, u User, m Movie) Ticket {
+	ticket, err := testQueries.CreateTicket(context.Background(), CreateTicketParams{
+		TicketOwner:   u.Username,
+		MovieID:       m.ID,
+		Adult:         1,
+		Total:         10,
+		Currency:      "USD",
+		PaymentMethod: "card",
+	})
+	require.NoError(t, err)
+
+	return ticket
+}
+
+// TestListUserPurchases tests ListUserPurchases DB operation
+func TestListUserPurchases(t *testing.T) {
+	u := createRandomUser(t)
+	m := createRandomMovie(t)
+	buyTicket(t, u, m)
+	buyTicket(t, u, m)
+
+	purchases, err := testQueries.ListUserPurchases(context.Background(), u.Username)
+	require.NoError(t, err)
+	require.Len(t, purchases, 1)
+	require.Equal(t, m.ID, purchases[0].MovieID)
+	require.Equal(t, m.DirectorID, purchases[0].DirectorID)
+	require.Equal(t, int64(2), purchases[0].Tickets)
+}
+
+// TestListNowShowingMovies tests ListNowShowingMovies DB operation
+func TestListNowShowingMovies(t *testing.T) {
+	showing := createRandomMovie(t)
+	notPublished := createRandomMovie(t)
+
+	s := createRandomScreening(t, showing)
+	_, err := testQueries.PublishScreening(context.Background(), s.ID)
+	require.NoError(t, err)
+	createRandomScreening(t, notPublished)
+
+	movies, err := testQueries.ListNowShowingMovies(context.Background())
+	require.NoError(t, err)
+
+	ids := make(map[int64]bool)
+	for _, m := range movies {
+		ids[m.ID] = true
+	}
+	require.True(t, ids[showing.ID])
+	require.False(t, ids[notPublished.ID])
+}
+
+// TestListGenresOfMovies tests ListGenresOfMovies DB operation
+func TestListGenresOfMovies(t *testing.T) {
+	m1 := createRandomMovie(t)
+	m2 := createRandomMovie(t)
+	g := createRandomGenre(t)
+
+	for _, m := range []Movie{m1, m2} {
+		err := testQueries.AddMovieGenre(context.Background(), AddMovieGenreParams{MovieID: m.ID, GenreID: g.ID})
+		require.NoError(t, err)
+	}
+
+	genres, err := testQueries.ListGenresOfMovies(context.Background(), []int64{m1.ID, m2.ID})
+	require.NoError(t, err)
+	require.Equal(t, []MovieGenre{{MovieID: m1.ID, GenreID: g.ID}, {MovieID: m2.ID, GenreID: g.ID}}, genres)
+}
+
+// TestListCoPurchases tests ListCoPurchases DB operation
+func TestListCoPurchases(t *testing.T) {
+	me := createRandomUser(t)
+	peer1 := createRandomUser(t)
+	peer2 := createRandomUser(t)
+	shared := createRandomMovie(t)
+	other := createRandomMovie(t)
+
+	buyTicket(t, me, shared)
+	buyTicket(t, peer1, shared)
+	buyTicket(t, peer2, shared)
+	buyTicket(t, peer1, other)
+	buyTicket(t, peer2, other)
+	buyTicket(t, peer2, other)
+
+	// the movies i bought aren't co-purchases and every peer is counted once
+	rows, err := testQueries.ListCoPurchases(context.Background(), me.Username)
+	require.NoError(t, err)
+	require.Equal(t, []ListCoPurchasesRow{{MovieID: other.ID, Buyers: 2}}, rows)
+
+	buyers, err := testQueries.ListBuyers(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, buyers, me.Username)
+}
+
+// TestUserRecommendations tests the precomputed recommendation DB operations
+func TestUserRecommendations(t *testing.T) {
+	u := createRandomUser(t)
+	m1 := createRandomMovie(t)
+	m2 := createRandomMovie(t)
+
+	// only the now-showing movies are listed
+	s := createRandomScreening(t, m1)
+	_, err := testQueries.PublishScreening(context.Background(), s.ID)
+	require.NoError(t, err)
+
+	err = testStore.ReplaceUserRecommendationsTx(context.Background(), ReplaceUserRecommendationsTxParams{
+		Username: u.Username,
+		Recommendations: []CreateUserRecommendationParams{
+			{MovieID: m1.ID, Score: 0.5, Reasons: []string{"genre"}},
+			{MovieID: m2.ID, Score: 0.9, Reasons: []string{"director"}},
+		},
+	})
+	require.NoError(t, err)
+
+	arg := ListUserRecommendationsParams{
+		Username:      u.Username,
+		ComputedAfter: time.Now().Add(-time.Hour),
+		Limit:         10,
+	}
+
+	recommendations, err := testQueries.ListUserRecommendations(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, recommendations, 1)
+	require.Equal(t, m1.ID, recommendations[0].MovieID)
+	require.Equal(t, []string{"genre"}, recommendations[0].Reasons)
+
+	// the stale recommendations aren't listed
+	arg.ComputedAfter = time.Now().Add(time.Hour)
+	recommendations, err = testQueries.ListUserRecommendations(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, recommendations)
+
+	// a replacement removes the old recommendations
+	err = testStore.ReplaceUserRecommendationsTx(context.Background(), ReplaceUserRecommendationsTxParams{Username: u.Username})
+	require.NoError(t, err)
+
+	arg.ComputedAfter = time.Now().Add(-time.Hour)
+	recommendations, err = testQueries.ListUserRecommendations(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, recommendations)
+}
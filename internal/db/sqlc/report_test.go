@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestListDailySales tests ListDailySales DB operation
+func TestListDailySales(t *testing.T) {
+	u := createRandomUser(t)
+	m := createRandomMovie(t)
+	s := createRandomScreening(t, m)
+
+	ticket, err := testQueries.CreateTicket(context.Background(), CreateTicketParams{
+		TicketOwner:   u.Username,
+		MovieID:       m.ID,
+		Adult:         2,
+		Child:         1,
+		Total:         301,
+		Currency:      "USD",
+		PaymentMethod: "card",
+		ScreeningID:   sql.NullInt64{Int64: s.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "card", ticket.PaymentMethod)
+	require.Equal(t, s.ID, ticket.ScreeningID.Int64)
+
+	sale := createRandomPosSale(t, createRandomCashShift(t), "cash")
+
+	now := time.Now()
+	rows, err := testQueries.ListDailySales(context.Background(), ListDailySalesParams{
+		CreatedFrom: now.Add(-time.Hour),
+		CreatedTo:   now.Add(time.Hour),
+		TimeZone:    "UTC",
+	})
+	require.NoError(t, err)
+
+	var online, boxOffice []ListDailySalesRow
+	for _, r := range rows {
+		switch r.MovieID {
+		case m.ID:
+			online = append(online, r)
+		case sale.MovieID:
+			boxOffice = append(boxOffice, r)
+		}
+	}
+
+	// the adults get the remainder of the split
+	require.Len(t, online, 2)
+	require.Equal(t, "adult", online[0].TicketType)
+	require.Equal(t, int64(2), online[0].Quantity)
+	require.Equal(t, "USD", online[0].Currency)
+	require.Equal(t, int64(201), online[0].Gross)
+	require.Equal(t, int64(201), online[0].Net)
+	require.Equal(t, "child", online[1].TicketType)
+	require.Equal(t, int64(1), online[1].Quantity)
+	require.Equal(t, int64(100), online[1].Gross)
+	for _, r := range online {
+		require.Equal(t, m.Title, r.Title)
+		require.Equal(t, s.ID, r.ScreeningID.Int64)
+		require.Equal(t, "card", r.PaymentMethod)
+		require.Equal(t, "online", r.Channel)
+	}
+
+	var gross int64
+	for _, r := range boxOffice {
+		require.False(t, r.ScreeningID.Valid)
+		require.Equal(t, "cash", r.PaymentMethod)
+		require.Equal(t, "box_office", r.Channel)
+		gross += r.Gross
+	}
+	require.Equal(t, sale.Total, gross)
+
+	// the sales are out of a range that ends before them
+	rows, err = testQueries.ListDailySales(context.Background(), ListDailySalesParams{
+		CreatedFrom: now.Add(-2 * time.Hour),
+		CreatedTo:   now.Add(-time.Hour),
+		TimeZone:    "UTC",
+	})
+	require.NoError(t, err)
+	for _, r := range rows {
+		require.NotEqual(t, m.ID, r.MovieID)
+	}
+}
+
+// TestStreamDailySales tests that StreamDailySales passes the rows of ListDailySales in the same order
+func TestStreamDailySales(t *testing.T) {
+	createRandomPosSale(t, createRandomCashShift(t), "cash")
+	createRandomPosSale(t, createRandomCashShift(t), "card")
+
+	now := time.Now()
+	arg := ListDailySalesParams{
+		CreatedFrom: now.Add(-time.Hour),
+		CreatedTo:   now.Add(time.Hour),
+		TimeZone:    "UTC",
+	}
+
+	rows, err := testQueries.ListDailySales(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+
+	var streamed []ListDailySalesRow
+	err = testQueries.StreamDailySales(context.Background(), arg, func(r ListDailySalesRow) error {
+		streamed = append(streamed, r)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, rows, streamed)
+
+	// the reading stops at the first error of the callback
+	calls := 0
+	err = testQueries.StreamDailySales(context.Background(), arg, func(r ListDailySalesRow) error {
+		calls++
+		return sql.ErrConnDone
+	})
+	require.EqualError(t, err, sql.ErrConnDone.Error())
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomReview creates a random approved review of a new user for given movie
+func createRandomReview(t *testing.T, m Movie) Review {
+	u := createRandomUser(t)
+	arg := CreateReviewParams{
+		MovieID:  m.ID,
+		Username: u.Username,
+		Rating:   int16(util.RandomInt(1, 5)),
+		Body:     util.RandomString(20),
+		Status:   ReviewApproved,
+	}
+
+	review, err := testQueries.CreateReview(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, review)
+
+	require.Equal(t, arg.MovieID, review.MovieID)
+	require.Equal(t, arg.Username, review.Username)
+	require.Equal(t, arg.Rating, review.Rating)
+	require.Equal(t, arg.Body, review.Body)
+	require.Equal(t, arg.Status, review.Status)
+	require.NotZero(t, review.ID)
+	require.NotZero(t, review.CreatedAt)
+
+	return review
+}
+
+// TestCreateReview tests CreateReview DB operation
+func TestCreateReview(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+	require.False(t, review.Verified)
+
+	// a second review of the same user for the same movie is rejected
+	_, err := testQueries.CreateReview(context.Background(), CreateReviewParams{
+		MovieID:  review.MovieID,
+		Username: review.Username,
+		Rating:   3,
+		Status:   ReviewPending,
+	})
+	require.Error(t, err)
+}
+
+// TestCreateVerifiedReview tests that reviews of checked in viewers are verified
+func TestCreateVerifiedReview(t *testing.T) {
+	ticket := createRandomTicket(t)
+
+	_, err := testQueries.CheckInTicket(context.Background(), ticket.ID)
+	require.NoError(t, err)
+
+	review, err := testQueries.CreateReview(context.Background(), CreateReviewParams{
+		MovieID:  ticket.MovieID,
+		Username: ticket.TicketOwner,
+		Rating:   5,
+		Status:   ReviewPending,
+	})
+	require.NoError(t, err)
+	require.True(t, review.Verified)
+}
+
+// TestVerifyReviews tests VerifyReviews DB operation
+func TestVerifyReviews(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+
+	err := testQueries.VerifyReviews(context.Background(), VerifyReviewsParams{
+		MovieID:  review.MovieID,
+		Username: review.Username,
+	})
+	require.NoError(t, err)
+
+	r2, err := testQueries.GetReview(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.True(t, r2.Verified)
+}
+
+// TestListMovieReviews tests ListMovieReviews DB operation
+func TestListMovieReviews(t *testing.T) {
+	m := createRandomMovie(t)
+	var reviews []Review
+	for i := 0; i < 3; i++ {
+		reviews = append(reviews, createRandomReview(t, m))
+	}
+
+	// rejected reviews are not listed
+	_, err := testQueries.UpdateReviewStatus(context.Background(), UpdateReviewStatusParams{
+		ID:     reviews[2].ID,
+		Status: ReviewRejected,
+	})
+	require.NoError(t, err)
+
+	list, err := testQueries.ListMovieReviews(context.Background(), ListMovieReviewsParams{
+		MovieID: m.ID,
+		Limit:   5,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, reviews[1].ID, list[0].ID)
+	require.Equal(t, reviews[0].ID, list[1].ID)
+
+	list, err = testQueries.ListMovieReviews(context.Background(), ListMovieReviewsParams{
+		MovieID:  m.ID,
+		BeforeID: sql.NullInt64{Int64: reviews[1].ID, Valid: true},
+		Limit:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, reviews[0].ID, list[0].ID)
+
+	list, err = testQueries.ListReviewsByStatus(context.Background(), ListReviewsByStatusParams{
+		Status: ReviewRejected,
+		Limit:  5,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	require.Equal(t, reviews[2].ID, list[0].ID)
+}
+
+// TestGetMovieReviewStats tests GetMovieReviewStats DB operation
+func TestGetMovieReviewStats(t *testing.T) {
+	m := createRandomMovie(t)
+
+	stats, err := testQueries.GetMovieReviewStats(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Zero(t, stats.Count)
+	require.Zero(t, stats.Average)
+
+	var sum int64
+	histogram := make(map[int16]int64)
+	for i := 0; i < 4; i++ {
+		r := createRandomReview(t, m)
+		sum += int64(r.Rating)
+		histogram[r.Rating]++
+	}
+
+	stats, err = testQueries.GetMovieReviewStats(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats.Count)
+	require.InDelta(t, float64(sum)/4, stats.Average, 0.001)
+	require.Equal(t, histogram[1], stats.OneStar)
+	require.Equal(t, histogram[5], stats.FiveStars)
+}
+
+// TestDeleteReview tests DeleteReview DB operation
+func TestDeleteReview(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+
+	err := testQueries.DeleteReview(context.Background(), review.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetReview(context.Background(), review.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
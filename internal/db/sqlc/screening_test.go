@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomScreening creates a random unpublished screening of given movie
+func createRandomScreening(t *testing.T, m Movie) Screening {
+	startsAt := time.Now().Add(time.Duration(util.RandomInt(1, 100)) * time.Hour)
+	arg := CreateScreeningParams{
+		MovieID:        m.ID,
+		StartsAt:       startsAt,
+		TrailerMinutes: 15,
+		EndsAt:         startsAt.Add(2 * time.Hour),
+		BlockedUntil:   startsAt.Add(2*time.Hour + 15*time.Minute),
+		Format:         "2d",
+		AudioLanguage:  "en",
+	}
+
+	screening, err := testQueries.CreateScreening(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, screening.ID)
+	require.Equal(t, arg.MovieID, screening.MovieID)
+	require.WithinDuration(t, arg.StartsAt, screening.StartsAt, time.Second)
+	require.False(t, screening.PublishedAt.Valid)
+	require.False(t, screening.WatchersNotifiedAt.Valid)
+	require.Equal(t, arg.TrailerMinutes, screening.TrailerMinutes)
+	require.WithinDuration(t, arg.EndsAt, screening.EndsAt, time.Second)
+	require.WithinDuration(t, arg.BlockedUntil, screening.BlockedUntil, time.Second)
+	require.Equal(t, arg.Format, screening.Format)
+	require.Equal(t, arg.AudioLanguage, screening.AudioLanguage)
+	require.False(t, screening.SubtitleLanguage.Valid)
+
+	return screening
+}
+
+// TestCreateScreening tests CreateScreening DB operation
+func TestCreateScreening(t *testing.T) {
+	createRandomScreening(t, createRandomMovie(t))
+}
+
+// TestPublishScreening tests PublishScreening DB operation
+func TestPublishScreening(t *testing.T) {
+	s1 := createRandomScreening(t, createRandomMovie(t))
+
+	s2, err := testQueries.PublishScreening(context.Background(), s1.ID)
+	require.NoError(t, err)
+	require.True(t, s2.PublishedAt.Valid)
+
+	// a screening is published only once
+	_, err = testQueries.PublishScreening(context.Background(), s1.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	s3, err := testQueries.GetScreening(context.Background(), s1.ID)
+	require.NoError(t, err)
+	require.Equal(t, s2.PublishedAt, s3.PublishedAt)
+}
+
+// TestListMovieScreenings tests ListMovieScreenings DB operation
+func TestListMovieScreenings(t *testing.T) {
+	m := createRandomMovie(t)
+	s1 := createRandomScreening(t, m)
+	createRandomScreening(t, m)
+
+	_, err := testQueries.PublishScreening(context.Background(), s1.ID)
+	require.NoError(t, err)
+
+	// only the published screenings are on sale
+	screenings, err := testQueries.ListMovieScreenings(context.Background(), ListMovieScreeningsParams{MovieID: m.ID})
+	require.NoError(t, err)
+	require.Len(t, screenings, 1)
+	require.Equal(t, s1.ID, screenings[0].ID)
+}
+
+// TestListMovieScreeningsFilters tests the filters of ListMovieScreenings DB operation
+func TestListMovieScreeningsFilters(t *testing.T) {
+	m := createRandomMovie(t)
+	startsAt := time.Now().Add(time.Hour)
+
+	create := func(format string, subtitles string, relaxed bool) Screening {
+		s, err := testQueries.CreateScreening(context.Background(), CreateScreeningParams{
+			MovieID:          m.ID,
+			StartsAt:         startsAt,
+			EndsAt:           startsAt.Add(2 * time.Hour),
+			BlockedUntil:     startsAt.Add(2 * time.Hour),
+			Format:           format,
+			AudioLanguage:    "en",
+			SubtitleLanguage: sql.NullString{String: subtitles, Valid: subtitles != ""},
+			Relaxed:          relaxed,
+		})
+		require.NoError(t, err)
+
+		s, err = testQueries.PublishScreening(context.Background(), s.ID)
+		require.NoError(t, err)
+
+		return s
+	}
+
+	plain := create("2d", "", false)
+	imax := create("imax", "tr", false)
+	relaxed := create("3d", "", true)
+
+	testCases := []struct {
+		name string
+		arg  ListMovieScreeningsParams
+		want []int64
+	}{
+		{
+			name: "All",
+			arg:  ListMovieScreeningsParams{MovieID: m.ID},
+			want: []int64{plain.ID, imax.ID, relaxed.ID},
+		},
+		{
+			name: "Format",
+			arg:  ListMovieScreeningsParams{MovieID: m.ID, Format: sql.NullString{String: "imax", Valid: true}},
+			want: []int64{imax.ID},
+		},
+		{
+			name: "Subtitled",
+			arg:  ListMovieScreeningsParams{MovieID: m.ID, Subtitled: sql.NullBool{Bool: true, Valid: true}},
+			want: []int64{imax.ID},
+		},
+		{
+			name: "Not Subtitled",
+			arg:  ListMovieScreeningsParams{MovieID: m.ID, Subtitled: sql.NullBool{Bool: false, Valid: true}},
+			want: []int64{plain.ID, relaxed.ID},
+		},
+		{
+			name: "Relaxed",
+			arg:  ListMovieScreeningsParams{MovieID: m.ID, Relaxed: sql.NullBool{Bool: true, Valid: true}},
+			want: []int64{relaxed.ID},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			screenings, err := testQueries.ListMovieScreenings(context.Background(), tc.arg)
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(screenings))
+			for _, s := range screenings {
+				ids = append(ids, s.ID)
+			}
+			require.ElementsMatch(t, tc.want, ids)
+		})
+	}
+}
+
+// TestScreeningFormats tests ListScreeningFormats, GetScreeningFormat and UpsertScreeningFormat DB operations
+func TestScreeningFormats(t *testing.T) {
+	code := util.RandomString(8)
+
+	f, err := testQueries.UpsertScreeningFormat(context.Background(), UpsertScreeningFormatParams{
+		Code:      code,
+		Name:      util.RandomName(),
+		Surcharge: 400,
+	})
+	require.NoError(t, err)
+	require.Equal(t, code, f.Code)
+	require.Equal(t, int64(400), f.Surcharge)
+
+	// upserting again changes the surcharge of the same format
+	f, err = testQueries.UpsertScreeningFormat(context.Background(), UpsertScreeningFormatParams{Code: code, Name: f.Name, Surcharge: 600})
+	require.NoError(t, err)
+
+	got, err := testQueries.GetScreeningFormat(context.Background(), code)
+	require.NoError(t, err)
+	require.Equal(t, int64(600), got.Surcharge)
+
+	formats, err := testQueries.ListScreeningFormats(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, formats)
+
+	// the default format is seeded by the migration
+	_, err = testQueries.GetScreeningFormat(context.Background(), "2d")
+	require.NoError(t, err)
+}
+
+// TestUnnotifiedScreenings tests ListUnnotifiedScreenings and MarkScreeningsNotified DB operations
+func TestUnnotifiedScreenings(t *testing.T) {
+	s := createRandomScreening(t, createRandomMovie(t))
+
+	_, err := testQueries.PublishScreening(context.Background(), s.ID)
+	require.NoError(t, err)
+
+	screenings, err := testQueries.ListUnnotifiedScreenings(context.Background(), 1000)
+	require.NoError(t, err)
+	require.True(t, containsScreening(screenings, s.ID))
+
+	err = testQueries.MarkScreeningsNotified(context.Background(), []int64{s.ID})
+	require.NoError(t, err)
+
+	screenings, err = testQueries.ListUnnotifiedScreenings(context.Background(), 1000)
+	require.NoError(t, err)
+	require.False(t, containsScreening(screenings, s.ID))
+}
+
+// containsScreening checks if the screening with given ID is in the list
+func containsScreening(screenings []Screening, id int64) bool {
+	for _, s := range screenings {
+		if s.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createSearchableMovie creates a movie with a unique title and summary so it can be searched for
+func createSearchableMovie(t *testing.T) (Movie, Director) {
+	director := createRandomDirector(t)
+	arg := CreateMovieParams{
+		Title:         "Zephyr " + util.RandomString(8),
+		DirectorID:    director.ID,
+		Rating:        int16(util.RandomInt(6, 10)),
+		Poster:        util.RandomString(10),
+		Summary:       "a lighthouse keeper finds " + util.RandomString(8),
+		Certification: "PG",
+		Tags:          []string{},
+	}
+
+	m, err := testQueries.CreateMovie(context.Background(), arg)
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateMovieCredit(context.Background(), CreateMovieCreditParams{
+		MovieID:  m.ID,
+		PersonID: director.PersonID,
+		Role:     "director",
+	})
+	require.NoError(t, err)
+
+	return m, director
+}
+
+// containsSearchResult reports whether the given movie is in the search results
+func containsSearchResult(results []SearchMoviesRow, id int64) bool {
+	for _, r := range results {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// TestSearchMovies tests SearchMovies DB operation
+func TestSearchMovies(t *testing.T) {
+	m, director := createSearchableMovie(t)
+
+	// full text match on the title comes with a highlighted snippet
+	results, err := testQueries.SearchMovies(context.Background(), SearchMoviesParams{Q: m.Title, Count: 20})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, m.ID, results[0].ID)
+	require.Greater(t, results[0].Rank, float32(0))
+
+	results, err = testQueries.SearchMovies(context.Background(), SearchMoviesParams{Q: m.Title + " lighthouse", Count: 20})
+	require.NoError(t, err)
+	require.True(t, containsSearchResult(results, m.ID))
+	require.Contains(t, results[0].Snippet, "<b>")
+
+	// the movie can be found by its director
+	results, err = testQueries.SearchMovies(context.Background(), SearchMoviesParams{Q: director.FirstName + " " + director.LastName, Count: 20})
+	require.NoError(t, err)
+	require.True(t, containsSearchResult(results, m.ID))
+
+	// typos are tolerated by the trigram similarity
+	typo := m.Title[:len(m.Title)-1] + "x"
+	results, err = testQueries.SearchMovies(context.Background(), SearchMoviesParams{Q: typo, Count: 20})
+	require.NoError(t, err)
+	require.True(t, containsSearchResult(results, m.ID))
+
+	// deleted movies aren't found
+	_, err = testQueries.SoftDeleteMovie(context.Background(), m.ID)
+	require.NoError(t, err)
+
+	results, err = testQueries.SearchMovies(context.Background(), SearchMoviesParams{Q: m.Title, Count: 20})
+	require.NoError(t, err)
+	require.False(t, containsSearchResult(results, m.ID))
+}
+
+// TestAutocompleteMovies tests AutocompleteMovies DB operation
+func TestAutocompleteMovies(t *testing.T) {
+	m, _ := createSearchableMovie(t)
+	prefix := m.Title[:len(m.Title)-3]
+
+	suggestions, err := testQueries.AutocompleteMovies(context.Background(), AutocompleteMoviesParams{Prefix: prefix, Count: 5})
+	require.NoError(t, err)
+	require.NotEmpty(t, suggestions)
+	require.Equal(t, m.ID, suggestions[0].ID)
+	require.Equal(t, m.Title, suggestions[0].Title)
+}
+
+// TestSearchMoviesEscapesSnippet tests that the markup of a summary is escaped in the snippet
+func TestSearchMoviesEscapesSnippet(t *testing.T) {
+	m, _ := createSearchableMovie(t)
+	word := util.RandomString(10)
+
+	_, err := testQueries.UpdateMovie(context.Background(), UpdateMovieParams{
+		ID:      m.ID,
+		Summary: sql.NullString{String: `<img src=x onerror="alert(1)"> the ` + word + ` & co`, Valid: true},
+	})
+	require.NoError(t, err)
+
+	results, err := testQueries.SearchMovies(context.Background(), SearchMoviesParams{Q: word, Count: 20})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, m.ID, results[0].ID)
+
+	snippet := results[0].Snippet
+	require.Contains(t, snippet, "<b>"+word+"</b>")
+	require.NotContains(t, snippet, "<img")
+	require.Contains(t, snippet, "&lt;img")
+	require.Contains(t, snippet, "&amp;")
+}
+
+// TestAutocompleteMoviesWildcards tests that a wildcard prefix doesn't match every title
+func TestAutocompleteMoviesWildcards(t *testing.T) {
+	createSearchableMovie(t)
+
+	suggestions, err := testQueries.AutocompleteMovies(context.Background(), AutocompleteMoviesParams{Prefix: "%", Count: 5})
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+}
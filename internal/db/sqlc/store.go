@@ -1,20 +1,1174 @@
 package db
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/schedule"
+)
+
+var (
+	ErrOutOfStock       = errors.New("concession item is out of stock")
+	ErrScheduleConflict = errors.New("screenings conflict with the schedule of the auditorium")
+	ErrScreeningBooked  = errors.New("screening is booked privately")
+	ErrScreeningSold    = errors.New("screening already has sold seats")
+	ErrScreeningFull    = errors.New("screening doesn't have enough seats left")
+	ErrInvoicePaid      = errors.New("invoice of the booking is already paid")
+)
+
+// statuses of the reviews in the moderation queue
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
 
 // Store provides all DB functions
 type Store interface {
 	Querier
+	PurchaseTicketTx(ctx context.Context, arg PurchaseTicketTxParams) (PurchaseTicketTxResult, error)
+	OrderConcessionsTx(ctx context.Context, arg OrderConcessionsTxParams) (ConcessionOrderTxResult, error)
+	DeleteTicketTx(ctx context.Context, id int64) error
+	CreateDirectorTx(ctx context.Context, arg CreateDirectorTxParams) (Director, error)
+	UpdateDirectorTx(ctx context.Context, arg UpdateDirectorParams) (Director, error)
+	CreateMovieTx(ctx context.Context, arg CreateMovieTxParams) (Movie, error)
+	UpdateMovieTx(ctx context.Context, arg UpdateMovieTxParams) (Movie, error)
+	CreateAwardTx(ctx context.Context, arg CreateAwardParams) (Award, error)
+	DeleteAwardTx(ctx context.Context, id int64) (Award, error)
+	CheckInTicketTx(ctx context.Context, id int64) (Ticket, error)
+	CreateReviewTx(ctx context.Context, arg CreateReviewTxParams) (Review, error)
+	ModerateReviewTx(ctx context.Context, arg ModerateReviewTxParams) (Review, error)
+	ReportReviewTx(ctx context.Context, arg ReportReviewTxParams) (ReportReviewTxResult, error)
+	ReplaceUserRecommendationsTx(ctx context.Context, arg ReplaceUserRecommendationsTxParams) error
+	CreateVenueTx(ctx context.Context, arg CreateVenueTxParams) (CreateVenueTxResult, error)
+	ScheduleScreeningsTx(ctx context.Context, arg ScheduleScreeningsTxParams) (ScheduleScreeningsTxResult, error)
+	CreateMembershipTx(ctx context.Context, arg CreateMembershipTxParams) (MembershipTxResult, error)
+	RenewMembershipTx(ctx context.Context, arg RenewMembershipTxParams) (MembershipTxResult, error)
+	ChangeMembershipPlanTx(ctx context.Context, arg ChangeMembershipPlanTxParams) (MembershipTxResult, error)
+	CancelMembershipTx(ctx context.Context, arg CancelMembershipTxParams) (MembershipTxResult, error)
+	ConfirmPrivateBookingTx(ctx context.Context, arg ConfirmPrivateBookingTxParams) (PrivateBookingTxResult, error)
+	CancelPrivateBookingTx(ctx context.Context, arg CancelPrivateBookingParams) (PrivateBookingTxResult, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error)
+	StreamDailySales(ctx context.Context, arg ListDailySalesParams, fn func(ListDailySalesRow) error) error
 }
 
 // Store provides all DB functions
 type SQLStore struct {
 	*Queries
+	db *sql.DB
 }
 
 // NewStore creates a new store instance
 func NewStore(db *sql.DB) Store {
 	return &SQLStore{
 		Queries: New(db),
+		db:      db,
+	}
+}
+
+// execTx executes given function within a DB transaction
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	q := New(tx)
+	err = fn(q)
+
+	// if fn fails we rollback the transaction and return both errors if rollback fails as well
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ConcessionLine holds an item and the quantity of it in a concession order
+type ConcessionLine struct {
+	ItemID   int64 `json:"item_id"`
+	Quantity int32 `json:"quantity"`
+}
+
+// ConcessionOrderTxResult holds the created concession order and its items
+type ConcessionOrderTxResult struct {
+	Order ConcessionOrder       `json:"order"`
+	Items []ConcessionOrderItem `json:"items"`
+}
+
+// PurchaseTicketTxParams holds the input of the ticket purchase transaction
+type PurchaseTicketTxParams struct {
+	CreateTicketParams
+	Concessions []ConcessionLine `json:"concessions"`
+}
+
+// PurchaseTicketTxResult holds the result of the ticket purchase transaction,
+// the membership is given if the seats are covered by its allowance
+type PurchaseTicketTxResult struct {
+	Ticket      Ticket                   `json:"ticket"`
+	Concessions *ConcessionOrderTxResult `json:"concessions"`
+	Membership  *Membership              `json:"membership"`
+}
+
+// PurchaseTicketTx creates a ticket and its concession order in a single transaction,
+// so the stock is only decremented if the ticket is created. The seats are covered by the membership of the owner
+// instead of a payment while its allowance has enough seats left, the price of the seats is the discount of the ticket then.
+// The seats of a screening that is booked privately can't be sold, it returns ErrScreeningBooked for them,
+// and it returns ErrScreeningFull if the auditorium of the screening doesn't have enough seats left
+func (store *SQLStore) PurchaseTicketTx(ctx context.Context, arg PurchaseTicketTxParams) (PurchaseTicketTxResult, error) {
+	var result PurchaseTicketTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// the screening is locked until the ticket is created, so a booking can't be confirmed
+		// and the seats can't be sold by another purchase in between
+		if arg.ScreeningID.Valid {
+			s, err := q.GetScreeningForSale(ctx, arg.ScreeningID.Int64)
+			if err != nil {
+				return err
+			}
+
+			if s.PrivateBookingID.Valid {
+				return ErrScreeningBooked
+			}
+
+			if s.AuditoriumID.Valid {
+				a, err := q.GetAuditorium(ctx, s.AuditoriumID.Int64)
+				if err != nil {
+					return err
+				}
+
+				sold, err := q.CountScreeningSeats(ctx, arg.ScreeningID)
+				if err != nil {
+					return err
+				}
+
+				if sold+int64(arg.Adult)+int64(arg.Child) > int64(a.Seats) {
+					return ErrScreeningFull
+				}
+			}
+		}
+
+		m, err := q.ConsumeMembershipAllowance(ctx, ConsumeMembershipAllowanceParams{
+			Seats:    int32(arg.Adult) + int32(arg.Child),
+			Username: arg.TicketOwner,
+		})
+
+		switch {
+		case err == nil:
+			arg.Discount += arg.Total
+			arg.Total = 0
+			arg.Tax = 0
+			arg.PaymentMethod = MembershipPaymentMethod
+			arg.MembershipID = sql.NullInt64{Int64: m.ID, Valid: true}
+			result.Membership = &m
+		case err != sql.ErrNoRows:
+			return err
+		}
+
+		result.Ticket, err = q.CreateTicket(ctx, arg.CreateTicketParams)
+		if err != nil {
+			return err
+		}
+
+		if len(arg.Concessions) == 0 {
+			return nil
+		}
+
+		order, err := addConcessionOrder(ctx, q, result.Ticket.ID, result.Ticket.TicketOwner, arg.Concessions)
+		if err != nil {
+			return err
+		}
+
+		result.Concessions = &order
+		return nil
+	})
+
+	return result, err
+}
+
+// OrderConcessionsTxParams holds the input of the concession order transaction
+type OrderConcessionsTxParams struct {
+	TicketID    int64            `json:"ticket_id"`
+	OrderOwner  string           `json:"order_owner"`
+	Concessions []ConcessionLine `json:"concessions"`
+}
+
+// OrderConcessionsTx creates a concession order for an existing ticket, it is picked up at the ticket's screening
+func (store *SQLStore) OrderConcessionsTx(ctx context.Context, arg OrderConcessionsTxParams) (ConcessionOrderTxResult, error) {
+	var result ConcessionOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = addConcessionOrder(ctx, q, arg.TicketID, arg.OrderOwner, arg.Concessions)
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteTicketTx deletes a ticket and gives the items of its concession orders back to the stock and
+// the seats of a membership ticket back to its allowance, it returns sql.ErrNoRows if the ticket doesn't exist
+func (store *SQLStore) DeleteTicketTx(ctx context.Context, id int64) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		// we lock the ticket so concurrent deletes don't restore the stock twice
+		ticket, err := q.GetTicketForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if ticket.PaymentMethod == MembershipPaymentMethod && ticket.MembershipID.Valid {
+			err = q.ReleaseMembershipAllowance(ctx, ReleaseMembershipAllowanceParams{
+				Seats:    int32(ticket.Adult) + int32(ticket.Child),
+				ID:       ticket.MembershipID.Int64,
+				BoughtAt: ticket.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// the quantities are in item ID order like the orders, so the stock rows are locked in the same order
+		quantities, err := q.ListTicketConcessionQuantities(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range quantities {
+			_, err = q.AddConcessionStock(ctx, AddConcessionStockParams{Amount: item.Quantity, ID: item.ItemID})
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.DeleteTicket(ctx, id)
+	})
+}
+
+// addConcessionOrder decrements the stock of every line and records the order with the current prices
+func addConcessionOrder(ctx context.Context, q *Queries, ticketID int64, owner string, lines []ConcessionLine) (ConcessionOrderTxResult, error) {
+	var result ConcessionOrderTxResult
+
+	// we merge the duplicate items and lock the rows in ID order to avoid deadlocks between orders
+	quantities := make(map[int64]int32)
+	for _, l := range lines {
+		quantities[l.ItemID] += l.Quantity
+	}
+
+	ids := make([]int64, 0, len(quantities))
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	items := make([]ConcessionItem, 0, len(ids))
+	var total int64
+
+	for _, id := range ids {
+		// first we make sure the item exists so a missing item isn't reported as out of stock
+		_, err := q.GetConcessionItem(ctx, id)
+		if err != nil {
+			return result, err
+		}
+
+		item, err := q.DecrementConcessionStock(ctx, DecrementConcessionStockParams{
+			ID:       id,
+			Quantity: quantities[id],
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return result, ErrOutOfStock
+			}
+			return result, err
+		}
+
+		items = append(items, item)
+		total += item.Price * int64(quantities[id])
+	}
+
+	var err error
+	result.Order, err = q.CreateConcessionOrder(ctx, CreateConcessionOrderParams{
+		TicketID:   ticketID,
+		OrderOwner: owner,
+		Total:      total,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Items = make([]ConcessionOrderItem, 0, len(items))
+	for _, item := range items {
+		orderItem, err := q.CreateConcessionOrderItem(ctx, CreateConcessionOrderItemParams{
+			OrderID:   result.Order.ID,
+			ItemID:    item.ID,
+			Name:      item.Name,
+			Quantity:  quantities[item.ID],
+			UnitPrice: item.Price,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Items = append(result.Items, orderItem)
+	}
+
+	return result, nil
+}
+
+// CreateDirectorTxParams holds the input of the director creation transaction
+type CreateDirectorTxParams struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// CreateDirectorTx creates the person of a director and the director itself in a single transaction
+func (store *SQLStore) CreateDirectorTx(ctx context.Context, arg CreateDirectorTxParams) (Director, error) {
+	var result Director
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		p, err := q.CreatePerson(ctx, CreatePersonParams{
+			FirstName: arg.FirstName,
+			LastName:  arg.LastName,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = q.CreateDirector(ctx, CreateDirectorParams{
+			FirstName: arg.FirstName,
+			LastName:  arg.LastName,
+			PersonID:  p.ID,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateDirectorTx updates a director and keeps the name of its person in sync
+func (store *SQLStore) UpdateDirectorTx(ctx context.Context, arg UpdateDirectorParams) (Director, error) {
+	var result Director
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.UpdateDirector(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.UpdatePerson(ctx, UpdatePersonParams{
+			ID:        result.PersonID,
+			FirstName: arg.FirstName,
+			LastName:  arg.LastName,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// CreateMovieTxParams holds the input of the movie creation transaction
+type CreateMovieTxParams struct {
+	CreateMovieParams
+	GenreIDs []int64 `json:"genre_ids"`
+}
+
+// CreateMovieTx creates a movie with its genres and credits its director in a single transaction
+func (store *SQLStore) CreateMovieTx(ctx context.Context, arg CreateMovieTxParams) (Movie, error) {
+	var result Movie
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		d, err := q.GetDirector(ctx, arg.DirectorID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.CreateMovie(ctx, arg.CreateMovieParams)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateMovieCredit(ctx, CreateMovieCreditParams{
+			MovieID:  result.ID,
+			PersonID: d.PersonID,
+			Role:     "director",
+		})
+		if err != nil {
+			return err
+		}
+
+		return addMovieGenres(ctx, q, result.ID, arg.GenreIDs)
+	})
+
+	return result, err
+}
+
+// UpdateMovieTxParams holds the input of the movie update transaction,
+// genres are replaced only if GenreIDs is not nil
+type UpdateMovieTxParams struct {
+	UpdateMovieParams
+	GenreIDs []int64 `json:"genre_ids"`
+}
+
+// UpdateMovieTx updates a movie and replaces its genres in a single transaction
+func (store *SQLStore) UpdateMovieTx(ctx context.Context, arg UpdateMovieTxParams) (Movie, error) {
+	var result Movie
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.UpdateMovie(ctx, arg.UpdateMovieParams)
+		if err != nil {
+			return err
+		}
+
+		if arg.GenreIDs == nil {
+			return nil
+		}
+
+		err = q.DeleteMovieGenres(ctx, result.ID)
+		if err != nil {
+			return err
+		}
+
+		return addMovieGenres(ctx, q, result.ID, arg.GenreIDs)
+	})
+
+	return result, err
+}
+
+// addMovieGenres adds the given genres to a movie
+func addMovieGenres(ctx context.Context, q *Queries, movieID int64, genreIDs []int64) error {
+	for _, id := range genreIDs {
+		err := q.AddMovieGenre(ctx, AddMovieGenreParams{
+			MovieID: movieID,
+			GenreID: id,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateAwardTx creates an award and refreshes the oscars of the awarded director
+func (store *SQLStore) CreateAwardTx(ctx context.Context, arg CreateAwardParams) (Award, error) {
+	var result Award
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateAward(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return refreshOscars(ctx, q, result)
+	})
+
+	return result, err
+}
+
+// DeleteAwardTx deletes an award and refreshes the oscars of the awarded director
+func (store *SQLStore) DeleteAwardTx(ctx context.Context, id int64) (Award, error) {
+	var result Award
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.DeleteAward(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return refreshOscars(ctx, q, result)
+	})
+
+	return result, err
+}
+
+// refreshOscars recounts the oscars of the director of the awarded person, if the person is a director
+func refreshOscars(ctx context.Context, q *Queries, award Award) error {
+	if !award.PersonID.Valid {
+		return nil
 	}
+
+	return q.RefreshDirectorOscars(ctx, award.PersonID.Int64)
+}
+
+// CheckInTicketTx checks in a ticket and marks the reviews of its owner for its movie as verified
+func (store *SQLStore) CheckInTicketTx(ctx context.Context, id int64) (Ticket, error) {
+	var result Ticket
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CheckInTicket(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return q.VerifyReviews(ctx, VerifyReviewsParams{
+			MovieID:  result.MovieID,
+			Username: result.TicketOwner,
+		})
+	})
+
+	return result, err
+}
+
+// CreateReviewTxParams holds the input of the review creation transaction,
+// FilterReason is the reason of the automatic filter if it didn't leave the review pending
+type CreateReviewTxParams struct {
+	CreateReviewParams
+	FilterReason string `json:"filter_reason"`
+}
+
+// CreateReviewTx creates a review and records the decision of the automatic filter in the audit trail
+func (store *SQLStore) CreateReviewTx(ctx context.Context, arg CreateReviewTxParams) (Review, error) {
+	var result Review
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateReview(ctx, arg.CreateReviewParams)
+		if err != nil {
+			return err
+		}
+
+		// every review starts in the queue, so only a filter decision is an event
+		if result.Status == ReviewPending {
+			return nil
+		}
+
+		_, err = q.CreateModerationEvent(ctx, CreateModerationEventParams{
+			ReviewID:   result.ID,
+			FromStatus: ReviewPending,
+			ToStatus:   result.Status,
+			Reason:     arg.FilterReason,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ModerateReviewTxParams holds the input of the moderation transaction
+type ModerateReviewTxParams struct {
+	ID        int64  `json:"id"`
+	Moderator string `json:"moderator"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+}
+
+// ModerateReviewTx changes the status of a review and records the decision of the moderator in the audit trail,
+// the reports of the review are resolved by the decision so only the later reports send it back to the queue
+func (store *SQLStore) ModerateReviewTx(ctx context.Context, arg ModerateReviewTxParams) (Review, error) {
+	var result Review
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// we lock the review so concurrent decisions are recorded with the right previous status
+		review, err := q.GetReviewForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpdateReviewStatus(ctx, UpdateReviewStatusParams{
+			ID:     review.ID,
+			Status: arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = q.ResolveReviewReports(ctx, review.ID); err != nil {
+			return err
+		}
+
+		_, err = q.CreateModerationEvent(ctx, CreateModerationEventParams{
+			ReviewID:   review.ID,
+			Moderator:  sql.NullString{String: arg.Moderator, Valid: true},
+			FromStatus: review.Status,
+			ToStatus:   result.Status,
+			Reason:     arg.Reason,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ReportReviewTxParams holds the input of the report transaction,
+// an approved review goes back to the queue when its reports reach the Threshold
+type ReportReviewTxParams struct {
+	CreateReviewReportParams
+	Threshold int64 `json:"threshold"`
+}
+
+// ReportReviewTxResult holds the created report and the reported review
+type ReportReviewTxResult struct {
+	Report ReviewReport `json:"report"`
+	Review Review       `json:"review"`
+}
+
+// ReportReviewTx reports a review and sends it back to the moderation queue if it is reported too often
+func (store *SQLStore) ReportReviewTx(ctx context.Context, arg ReportReviewTxParams) (ReportReviewTxResult, error) {
+	var result ReportReviewTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		// we lock the review so concurrent reports don't requeue it twice
+		result.Review, err = q.GetReviewForUpdate(ctx, arg.ReviewID)
+		if err != nil {
+			return err
+		}
+
+		result.Report, err = q.CreateReviewReport(ctx, arg.CreateReviewReportParams)
+		if err != nil {
+			return err
+		}
+
+		if result.Review.Status != ReviewApproved || arg.Threshold <= 0 {
+			return nil
+		}
+
+		count, err := q.CountReviewReports(ctx, arg.ReviewID)
+		if err != nil {
+			return err
+		}
+
+		if count < arg.Threshold {
+			return nil
+		}
+
+		result.Review, err = q.UpdateReviewStatus(ctx, UpdateReviewStatusParams{
+			ID:     arg.ReviewID,
+			Status: ReviewPending,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateModerationEvent(ctx, CreateModerationEventParams{
+			ReviewID:   arg.ReviewID,
+			FromStatus: ReviewApproved,
+			ToStatus:   ReviewPending,
+			Reason:     fmt.Sprintf("reported by %d users", count),
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ReplaceUserRecommendationsTxParams holds the input of the recommendation replacement transaction
+type ReplaceUserRecommendationsTxParams struct {
+	Username        string                           `json:"username"`
+	Recommendations []CreateUserRecommendationParams `json:"recommendations"`
+}
+
+// ReplaceUserRecommendationsTx replaces the precomputed recommendations of a user in a single transaction,
+// so the user never sees a half written list
+func (store *SQLStore) ReplaceUserRecommendationsTx(ctx context.Context, arg ReplaceUserRecommendationsTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteUserRecommendations(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range arg.Recommendations {
+			r.Username = arg.Username
+			err = q.CreateUserRecommendation(ctx, r)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// CreateVenueTxParams holds the input of the venue creation transaction
+type CreateVenueTxParams struct {
+	CreateVenueParams
+	Hours []CreateVenueHoursParams `json:"hours"`
+}
+
+// CreateVenueTxResult holds the result of the venue creation transaction
+type CreateVenueTxResult struct {
+	Venue Venue       `json:"venue"`
+	Hours []VenueHour `json:"hours"`
+}
+
+// CreateVenueTx creates a venue with its opening hours in a single transaction
+func (store *SQLStore) CreateVenueTx(ctx context.Context, arg CreateVenueTxParams) (CreateVenueTxResult, error) {
+	var result CreateVenueTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Venue, err = q.CreateVenue(ctx, arg.CreateVenueParams)
+		if err != nil {
+			return err
+		}
+
+		result.Hours = make([]VenueHour, 0, len(arg.Hours))
+		for _, h := range arg.Hours {
+			h.VenueID = result.Venue.ID
+			hours, err := q.CreateVenueHours(ctx, h)
+			if err != nil {
+				return err
+			}
+			result.Hours = append(result.Hours, hours)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// ScheduleScreeningsTxParams holds the input of the screening scheduling transaction,
+// the screenings must have their ends and the time their auditorium is blocked until
+type ScheduleScreeningsTxParams struct {
+	AuditoriumID int64                   `json:"auditorium_id"`
+	Screenings   []CreateScreeningParams `json:"screenings"`
+	DryRun       bool                    `json:"dry_run"`
+}
+
+// ScreeningConflict holds a screening of the schedule and the screening it overlaps with, the other screening is either
+// already in the auditorium or another screening of the same schedule
+type ScreeningConflict struct {
+	Index      int                   `json:"index"`
+	Screening  CreateScreeningParams `json:"screening"`
+	Existing   *Screening            `json:"existing,omitempty"`
+	OtherIndex *int                  `json:"other_index,omitempty"`
+}
+
+// ScheduleScreeningsTxResult holds the result of the screening scheduling transaction
+type ScheduleScreeningsTxResult struct {
+	Screenings []Screening         `json:"screenings"`
+	Conflicts  []ScreeningConflict `json:"conflicts"`
+}
+
+// screeningSlot returns the time the screening holds its auditorium
+func screeningSlot(s CreateScreeningParams) schedule.Slot {
+	return schedule.Slot{StartsAt: s.StartsAt, EndsAt: s.EndsAt, BlockedUntil: s.BlockedUntil}
+}
+
+// ScheduleScreeningsTx creates the screenings of an auditorium if none of them overlaps with another, it returns
+// ErrScheduleConflict with the conflicts otherwise. A dry run only finds the conflicts and creates nothing
+func (store *SQLStore) ScheduleScreeningsTx(ctx context.Context, arg ScheduleScreeningsTxParams) (ScheduleScreeningsTxResult, error) {
+	result := ScheduleScreeningsTxResult{
+		Screenings: []Screening{},
+		Conflicts:  []ScreeningConflict{},
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// the auditorium is locked so the concurrent schedules of it can't miss each other
+		if _, err := q.LockAuditorium(ctx, arg.AuditoriumID); err != nil {
+			return err
+		}
+
+		auditoriumID := sql.NullInt64{Int64: arg.AuditoriumID, Valid: true}
+
+		for i, s := range arg.Screenings {
+			s.AuditoriumID = auditoriumID
+
+			existing, err := q.ListOverlappingScreenings(ctx, ListOverlappingScreeningsParams{
+				AuditoriumID: auditoriumID,
+				BlockedUntil: s.BlockedUntil,
+				StartsAt:     s.StartsAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			for j := range existing {
+				result.Conflicts = append(result.Conflicts, ScreeningConflict{
+					Index:     i,
+					Screening: s,
+					Existing:  &existing[j],
+				})
+			}
+
+			// then i check the earlier screenings of the schedule
+			slot := screeningSlot(s)
+			for j := 0; j < i; j++ {
+				if slot.Overlaps(screeningSlot(arg.Screenings[j])) {
+					otherIndex := j
+					result.Conflicts = append(result.Conflicts, ScreeningConflict{
+						Index:      i,
+						Screening:  s,
+						OtherIndex: &otherIndex,
+					})
+				}
+			}
+		}
+
+		if len(result.Conflicts) > 0 {
+			if arg.DryRun {
+				return nil
+			}
+			return ErrScheduleConflict
+		}
+
+		for _, s := range arg.Screenings {
+			s.AuditoriumID = auditoriumID
+
+			// a dry run returns the screenings as they would be created
+			if arg.DryRun {
+				result.Screenings = append(result.Screenings, Screening{
+					MovieID:        s.MovieID,
+					StartsAt:       s.StartsAt,
+					AuditoriumID:   s.AuditoriumID,
+					TrailerMinutes: s.TrailerMinutes,
+					EndsAt:         s.EndsAt,
+					BlockedUntil:   s.BlockedUntil,
+				})
+				continue
+			}
+
+			screening, err := q.CreateScreening(ctx, s)
+			if err != nil {
+				return err
+			}
+			result.Screenings = append(result.Screenings, screening)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// MembershipPaymentMethod is the payment method of the tickets that are covered by the allowance of a membership
+const MembershipPaymentMethod = "membership"
+
+// MembershipPaymentParams holds a payment of a membership that is taken or refunded by the payment gateway
+type MembershipPaymentParams struct {
+	Kind      string `json:"kind"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Reference string `json:"reference"`
+}
+
+// MembershipRefundParams holds an amount to give back of the charges of a membership
+type MembershipRefundParams struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MembershipTxResult holds the membership and its payment of the membership transactions,
+// the payment is nil if nothing is paid. The refunds are pending until they are given back through the payment gateway
+type MembershipTxResult struct {
+	Membership Membership          `json:"membership"`
+	Payment    *MembershipPayment  `json:"payment"`
+	Refunds    []MembershipPayment `json:"refunds"`
+}
+
+// CreateMembershipTxParams holds the input of the membership creation transaction
+type CreateMembershipTxParams struct {
+	CreateMembershipParams
+	Payment *MembershipPaymentParams `json:"payment"`
+}
+
+// CreateMembershipTx creates a membership and records the payment of its first period in a single transaction
+func (store *SQLStore) CreateMembershipTx(ctx context.Context, arg CreateMembershipTxParams) (MembershipTxResult, error) {
+	var result MembershipTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Membership, err = q.CreateMembership(ctx, arg.CreateMembershipParams)
+		if err != nil {
+			return err
+		}
+
+		result.Payment, err = addMembershipPayment(ctx, q, result.Membership, arg.Payment)
+		return err
+	})
+
+	return result, err
+}
+
+// RenewMembershipTxParams holds the input of the membership renewal transaction
+type RenewMembershipTxParams struct {
+	RenewMembershipParams
+	Payment *MembershipPaymentParams `json:"payment"`
+}
+
+// RenewMembershipTx starts the next period of a membership and records its payment in a single transaction,
+// it returns sql.ErrNoRows if the period is already renewed or the membership is cancelled meanwhile
+func (store *SQLStore) RenewMembershipTx(ctx context.Context, arg RenewMembershipTxParams) (MembershipTxResult, error) {
+	var result MembershipTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Membership, err = q.RenewMembership(ctx, arg.RenewMembershipParams)
+		if err != nil {
+			return err
+		}
+
+		result.Payment, err = addMembershipPayment(ctx, q, result.Membership, arg.Payment)
+		return err
+	})
+
+	return result, err
+}
+
+// ChangeMembershipPlanTxParams holds the input of the plan change transaction, an upgrade is charged
+// before the plan is changed and a downgrade is refunded after it
+type ChangeMembershipPlanTxParams struct {
+	ChangeMembershipPlanParams
+	Payment *MembershipPaymentParams `json:"payment"`
+	Refund  *MembershipRefundParams  `json:"refund"`
+}
+
+// ChangeMembershipPlanTx changes the plan of a membership and records the prorated payment or the pending refund
+// in a single transaction, it returns sql.ErrNoRows if the membership isn't active or more seats are used than the new allowance
+func (store *SQLStore) ChangeMembershipPlanTx(ctx context.Context, arg ChangeMembershipPlanTxParams) (MembershipTxResult, error) {
+	var result MembershipTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Membership, err = q.ChangeMembershipPlan(ctx, arg.ChangeMembershipPlanParams)
+		if err != nil {
+			return err
+		}
+
+		result.Payment, err = addMembershipPayment(ctx, q, result.Membership, arg.Payment)
+		if err != nil {
+			return err
+		}
+
+		result.Refunds, err = addMembershipRefunds(ctx, q, result.Membership, arg.Refund)
+		return err
+	})
+
+	return result, err
+}
+
+// CancelMembershipTxParams holds the input of the membership cancellation transaction
+type CancelMembershipTxParams struct {
+	ID     int64                   `json:"id"`
+	Refund *MembershipRefundParams `json:"refund"`
+}
+
+// CancelMembershipTx cancels a membership and records its pending refund in a single transaction,
+// it returns sql.ErrNoRows if the membership is already cancelled
+func (store *SQLStore) CancelMembershipTx(ctx context.Context, arg CancelMembershipTxParams) (MembershipTxResult, error) {
+	var result MembershipTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Membership, err = q.CancelMembership(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Refunds, err = addMembershipRefunds(ctx, q, result.Membership, arg.Refund)
+		return err
+	})
+
+	return result, err
+}
+
+// addMembershipPayment records a payment of the current period of a membership, it records nothing if the payment is nil
+func addMembershipPayment(ctx context.Context, q *Queries, m Membership, arg *MembershipPaymentParams) (*MembershipPayment, error) {
+	if arg == nil {
+		return nil, nil
+	}
+
+	p, err := q.CreateMembershipPayment(ctx, CreateMembershipPaymentParams{
+		MembershipID: m.ID,
+		PeriodStart:  m.PeriodStart,
+		Kind:         arg.Kind,
+		Amount:       arg.Amount,
+		Currency:     arg.Currency,
+		Reference:    sql.NullString{String: arg.Reference, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// addMembershipRefunds records the pending refunds of an amount of a membership. The amount is given back from
+// the charges of the current period that aren't refunded yet from the latest, so it can't be more than what is paid
+// for the period. It records nothing if the refund is nil or the period is never charged
+func addMembershipRefunds(ctx context.Context, q *Queries, m Membership, arg *MembershipRefundParams) ([]MembershipPayment, error) {
+	if arg == nil {
+		return nil, nil
+	}
+
+	charges, err := q.ListRefundableMembershipCharges(ctx, ListRefundableMembershipChargesParams{
+		MembershipID: m.ID,
+		PeriodStart:  m.PeriodStart,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var refunds []MembershipPayment
+	remaining := arg.Amount
+	for _, c := range charges {
+		if remaining <= 0 {
+			break
+		}
+
+		amount := c.Refundable
+		if amount > remaining {
+			amount = remaining
+		}
+
+		p, err := q.CreateMembershipPayment(ctx, CreateMembershipPaymentParams{
+			MembershipID: m.ID,
+			PeriodStart:  m.PeriodStart,
+			Kind:         "refund",
+			Amount:       -amount,
+			Currency:     arg.Currency,
+			RefundOf:     sql.NullInt64{Int64: c.ID, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		refunds = append(refunds, p)
+		remaining -= amount
+	}
+
+	return refunds, nil
+}
+
+// PrivateBookingConfirmed is the status of the private bookings whose screenings are blocked for them
+const PrivateBookingConfirmed = "confirmed"
+
+// PrivateBookingTxResult holds the booking and its invoice of the private booking transactions,
+// the invoice is nil if the booking has none
+type PrivateBookingTxResult struct {
+	Booking PrivateBooking         `json:"booking"`
+	Invoice *PrivateBookingInvoice `json:"invoice"`
+}
+
+// ConfirmPrivateBookingTxParams holds the input of the booking confirmation transaction,
+// the booking of the invoice is set by the transaction
+type ConfirmPrivateBookingTxParams struct {
+	ConfirmPrivateBookingParams
+	Invoice CreatePrivateBookingInvoiceParams `json:"invoice"`
+}
+
+// ConfirmPrivateBookingTx confirms an accepted booking, blocks all the seats of its screening and issues its invoice
+// in a single transaction. It returns sql.ErrNoRows if the booking isn't accepted, ErrScreeningBooked if the screening
+// is booked by another booking and ErrScreeningSold if seats of the screening are already sold
+func (store *SQLStore) ConfirmPrivateBookingTx(ctx context.Context, arg ConfirmPrivateBookingTxParams) (PrivateBookingTxResult, error) {
+	var result PrivateBookingTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Booking, err = q.ConfirmPrivateBooking(ctx, arg.ConfirmPrivateBookingParams)
+		if err != nil {
+			return err
+		}
+
+		// the screening is locked by the update, the tickets that are sold before it are counted after it
+		_, err = q.BlockScreening(ctx, BlockScreeningParams{
+			PrivateBookingID: sql.NullInt64{Int64: result.Booking.ID, Valid: true},
+			ID:               result.Booking.ScreeningID,
+		})
+		if err == sql.ErrNoRows {
+			return ErrScreeningBooked
+		}
+		if err != nil {
+			return err
+		}
+
+		sold, err := q.CountScreeningSeats(ctx, sql.NullInt64{Int64: result.Booking.ScreeningID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if sold > 0 {
+			return ErrScreeningSold
+		}
+
+		arg.Invoice.BookingID = result.Booking.ID
+		invoice, err := q.CreatePrivateBookingInvoice(ctx, arg.Invoice)
+		if err != nil {
+			return err
+		}
+
+		result.Invoice = &invoice
+		return nil
+	})
+
+	return result, err
+}
+
+// CancelPrivateBookingTx cancels or rejects a booking in a single transaction. A confirmed booking releases
+// the seats of its screening and its invoice is voided, it returns ErrInvoicePaid if the invoice is already paid.
+// It returns sql.ErrNoRows if the status of the booking is changed meanwhile
+func (store *SQLStore) CancelPrivateBookingTx(ctx context.Context, arg CancelPrivateBookingParams) (PrivateBookingTxResult, error) {
+	var result PrivateBookingTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Booking, err = q.CancelPrivateBooking(ctx, arg)
+		if err != nil || arg.PreviousStatus != PrivateBookingConfirmed {
+			return err
+		}
+
+		invoice, err := q.VoidPrivateBookingInvoice(ctx, result.Booking.ID)
+		if err == sql.ErrNoRows {
+			return ErrInvoicePaid
+		}
+		if err != nil {
+			return err
+		}
+		result.Invoice = &invoice
+
+		return q.ReleaseScreening(ctx, sql.NullInt64{Int64: result.Booking.ID, Valid: true})
+	})
+
+	return result, err
+}
+
+// ResetPasswordTxParams holds the input of the password reset transaction, the password is hashed by the caller
+type ResetPasswordTxParams struct {
+	TokenHash         string    `json:"token_hash"`
+	HashedPassword    string    `json:"hashed_password"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+}
+
+// ResetPasswordTx uses a reset token and sets the new password of its user in a single transaction,
+// the other reset tokens of the user are revoked. It returns sql.ErrNoRows if the token is used or expired
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error) {
+	var u User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		reset, err := q.UsePasswordReset(ctx, arg.TokenHash)
+		if err != nil {
+			return err
+		}
+
+		u, err = q.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+			HashedPassword:    arg.HashedPassword,
+			PasswordChangedAt: arg.PasswordChangedAt,
+			Username:          reset.Username,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.RevokePasswordResets(ctx, reset.Username)
+	})
+
+	return u, err
 }
@@ -0,0 +1,578 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPurchaseTicketTx tests PurchaseTicketTx DB transaction
+func TestPurchaseTicketTx(t *testing.T) {
+	u := createRandomUser(t)
+	m := createRandomMovie(t)
+	popcorn := createRandomConcessionItem(t, 10)
+	drink := createRandomConcessionItem(t, 10)
+
+	arg := PurchaseTicketTxParams{
+		CreateTicketParams: CreateTicketParams{
+			MovieID:       m.ID,
+			TicketOwner:   u.Username,
+			Adult:         2,
+			Total:         util.RandomInt(20, 500),
+			Currency:      "USD",
+			PaymentMethod: "card",
+		},
+		Concessions: []ConcessionLine{
+			{ItemID: popcorn.ID, Quantity: 1},
+			{ItemID: drink.ID, Quantity: 2},
+			{ItemID: popcorn.ID, Quantity: 1},
+		},
+	}
+
+	result, err := testStore.PurchaseTicketTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, result.Ticket.ID)
+	require.NotNil(t, result.Concessions)
+
+	order := result.Concessions
+	require.Equal(t, result.Ticket.ID, order.Order.TicketID)
+	require.Equal(t, popcorn.Price*2+drink.Price*2, order.Order.Total)
+	require.Len(t, order.Items, 2)
+
+	// the stock is decremented with the ticket
+	popcorn2, err := testQueries.GetConcessionItem(context.Background(), popcorn.ID)
+	require.NoError(t, err)
+	require.Equal(t, popcorn.Stock-2, popcorn2.Stock)
+
+	items, err := testQueries.ListConcessionOrderItems(context.Background(), order.Order.ID)
+	require.NoError(t, err)
+	require.Equal(t, order.Items, items)
+}
+
+// TestPurchaseTicketTxOutOfStock tests that nothing is created when an item is out of stock
+func TestPurchaseTicketTxOutOfStock(t *testing.T) {
+	u := createRandomUser(t)
+	m := createRandomMovie(t)
+	popcorn := createRandomConcessionItem(t, 1)
+
+	arg := PurchaseTicketTxParams{
+		CreateTicketParams: CreateTicketParams{
+			MovieID:       m.ID,
+			TicketOwner:   u.Username,
+			Adult:         1,
+			Total:         util.RandomInt(20, 500),
+			Currency:      "USD",
+			PaymentMethod: "card",
+		},
+		Concessions: []ConcessionLine{{ItemID: popcorn.ID, Quantity: 2}},
+	}
+
+	_, err := testStore.PurchaseTicketTx(context.Background(), arg)
+	require.EqualError(t, err, ErrOutOfStock.Error())
+
+	tickets, err := testQueries.ListTickets(context.Background(), ListTicketsParams{TicketOwner: u.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, tickets)
+}
+
+// TestPurchaseTicketTxScreeningFull tests that the seats of a screening can't be sold over the seats of its auditorium
+func TestPurchaseTicketTxScreeningFull(t *testing.T) {
+	u := createRandomUser(t)
+	m := createRandomMovie(t)
+	v := createRandomVenue(t)
+
+	a, err := testQueries.CreateAuditorium(context.Background(), CreateAuditoriumParams{
+		VenueID:         v.ID,
+		Name:            util.RandomName(),
+		Seats:           3,
+		CleaningMinutes: 15,
+	})
+	require.NoError(t, err)
+
+	startsAt := time.Now().Add(time.Duration(util.RandomInt(1, 100)) * time.Hour)
+	s, err := testQueries.CreateScreening(context.Background(), CreateScreeningParams{
+		MovieID:        m.ID,
+		StartsAt:       startsAt,
+		AuditoriumID:   sql.NullInt64{Int64: a.ID, Valid: true},
+		TrailerMinutes: 15,
+		EndsAt:         startsAt.Add(2 * time.Hour),
+		BlockedUntil:   startsAt.Add(2*time.Hour + 15*time.Minute),
+		Format:         "2d",
+		AudioLanguage:  "en",
+	})
+	require.NoError(t, err)
+
+	arg := PurchaseTicketTxParams{
+		CreateTicketParams: CreateTicketParams{
+			MovieID:       m.ID,
+			TicketOwner:   u.Username,
+			Adult:         2,
+			Total:         util.RandomInt(20, 500),
+			Currency:      "USD",
+			PaymentMethod: "card",
+			ScreeningID:   sql.NullInt64{Int64: s.ID, Valid: true},
+		},
+	}
+
+	_, err = testStore.PurchaseTicketTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// only one of the three seats is left
+	_, err = testStore.PurchaseTicketTx(context.Background(), arg)
+	require.EqualError(t, err, ErrScreeningFull.Error())
+
+	arg.Adult = 1
+	_, err = testStore.PurchaseTicketTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	sold, err := testQueries.CountScreeningSeats(context.Background(), arg.ScreeningID)
+	require.NoError(t, err)
+	require.Equal(t, int64(a.Seats), sold)
+}
+
+// TestOrderConcessionsTx tests OrderConcessionsTx DB transaction
+func TestOrderConcessionsTx(t *testing.T) {
+	ticket := createRandomTicket(t)
+	drink := createRandomConcessionItem(t, 10)
+
+	result, err := testStore.OrderConcessionsTx(context.Background(), OrderConcessionsTxParams{
+		TicketID:    ticket.ID,
+		OrderOwner:  ticket.TicketOwner,
+		Concessions: []ConcessionLine{{ItemID: drink.ID, Quantity: 3}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ticket.ID, result.Order.TicketID)
+	require.Equal(t, drink.Price*3, result.Order.Total)
+	require.Len(t, result.Items, 1)
+	require.Equal(t, drink.Name, result.Items[0].Name)
+}
+
+// TestDeleteTicketTx tests that the concession stock of a ticket is restored when it's deleted
+func TestDeleteTicketTx(t *testing.T) {
+	ticket := createRandomTicket(t)
+	popcorn := createRandomConcessionItem(t, 10)
+	drink := createRandomConcessionItem(t, 10)
+
+	for i := 0; i < 2; i++ {
+		_, err := testStore.OrderConcessionsTx(context.Background(), OrderConcessionsTxParams{
+			TicketID:    ticket.ID,
+			OrderOwner:  ticket.TicketOwner,
+			Concessions: []ConcessionLine{{ItemID: popcorn.ID, Quantity: 2}, {ItemID: drink.ID, Quantity: 1}},
+		})
+		require.NoError(t, err)
+	}
+
+	items, err := testQueries.ListTicketConcessionItems(context.Background(), []int64{ticket.ID})
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+
+	err = testStore.DeleteTicketTx(context.Background(), ticket.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetTicket(context.Background(), ticket.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	// the ordered items are put back on the stock
+	popcorn2, err := testQueries.GetConcessionItem(context.Background(), popcorn.ID)
+	require.NoError(t, err)
+	require.Equal(t, popcorn.Stock, popcorn2.Stock)
+
+	drink2, err := testQueries.GetConcessionItem(context.Background(), drink.ID)
+	require.NoError(t, err)
+	require.Equal(t, drink.Stock, drink2.Stock)
+
+	err = testStore.DeleteTicketTx(context.Background(), ticket.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestCreateDirectorTx tests CreateDirectorTx DB transaction
+func TestCreateDirectorTx(t *testing.T) {
+	arg := CreateDirectorTxParams{
+		FirstName: util.RandomName(),
+		LastName:  util.RandomName(),
+	}
+
+	d, err := testStore.CreateDirectorTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, d.ID)
+
+	p, err := testQueries.GetPerson(context.Background(), d.PersonID)
+	require.NoError(t, err)
+	require.Equal(t, arg.FirstName, p.FirstName)
+	require.Equal(t, arg.LastName, p.LastName)
+
+	// the person is renamed with the director
+	arg2 := UpdateDirectorParams{
+		ID:        d.ID,
+		FirstName: sql.NullString{String: util.RandomName(), Valid: true},
+	}
+
+	d2, err := testStore.UpdateDirectorTx(context.Background(), arg2)
+	require.NoError(t, err)
+	require.Equal(t, arg2.FirstName.String, d2.FirstName)
+
+	p, err = testQueries.GetPerson(context.Background(), d.PersonID)
+	require.NoError(t, err)
+	require.Equal(t, arg2.FirstName.String, p.FirstName)
+	require.Equal(t, arg.LastName, p.LastName)
+}
+
+// TestCreateMovieTx tests that the director of a new movie is credited
+func TestCreateMovieTx(t *testing.T) {
+	d := createRandomDirector(t)
+	g := createRandomGenre(t)
+	arg := CreateMovieTxParams{
+		CreateMovieParams: CreateMovieParams{
+			Title:         util.RandomName(),
+			DirectorID:    d.ID,
+			Rating:        int16(util.RandomInt(6, 10)),
+			Poster:        util.RandomString(10),
+			Summary:       util.RandomString(10),
+			Certification: "G",
+			Tags:          []string{},
+		},
+		GenreIDs: []int64{g.ID},
+	}
+
+	m, err := testStore.CreateMovieTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	genres, err := testQueries.ListMovieGenres(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Len(t, genres, 1)
+	require.Equal(t, g.ID, genres[0].ID)
+
+	credits, err := testQueries.ListMovieCredits(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Len(t, credits, 1)
+	require.Equal(t, d.PersonID, credits[0].PersonID)
+	require.Equal(t, "director", credits[0].Role)
+
+	// unknown directors are rejected
+	arg.DirectorID = d.ID + 1000000
+	_, err = testStore.CreateMovieTx(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestAwardTx tests that the oscars of a director are derived from the awards
+func TestAwardTx(t *testing.T) {
+	d := createRandomDirector(t)
+	m := createRandomMovie(t)
+
+	oscar := CreateAwardParams{
+		Name:     "Academy Awards",
+		Kind:     "oscar",
+		Year:     sql.NullInt32{Int32: 2020, Valid: true},
+		Category: "Best Director",
+		Winner:   true,
+		PersonID: sql.NullInt64{Int64: d.PersonID, Valid: true},
+		MovieID:  sql.NullInt64{Int64: m.ID, Valid: true},
+	}
+
+	a1, err := testStore.CreateAwardTx(context.Background(), oscar)
+	require.NoError(t, err)
+
+	// nominations and other awards are not counted
+	nomination := oscar
+	nomination.Winner = false
+	_, err = testStore.CreateAwardTx(context.Background(), nomination)
+	require.NoError(t, err)
+
+	other := oscar
+	other.Name = "Golden Globe Awards"
+	other.Kind = "golden_globe"
+	_, err = testStore.CreateAwardTx(context.Background(), other)
+	require.NoError(t, err)
+
+	// the oscars are counted by their kind, not by their name
+	renamed := oscar
+	renamed.Name = "Oscars"
+	a2, err := testStore.CreateAwardTx(context.Background(), renamed)
+	require.NoError(t, err)
+
+	d2, err := testQueries.GetDirector(context.Background(), d.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), d2.Oscars)
+
+	_, err = testStore.DeleteAwardTx(context.Background(), a2.ID)
+	require.NoError(t, err)
+
+	_, err = testStore.DeleteAwardTx(context.Background(), a1.ID)
+	require.NoError(t, err)
+
+	d2, err = testQueries.GetDirector(context.Background(), d.ID)
+	require.NoError(t, err)
+	require.Zero(t, d2.Oscars)
+}
+
+// TestUpdateMovieTx tests that the genres of a movie are replaced only when they are given
+func TestUpdateMovieTx(t *testing.T) {
+	m := createRandomMovie(t)
+	g1 := createRandomGenre(t)
+	g2 := createRandomGenre(t)
+
+	err := testQueries.AddMovieGenre(context.Background(), AddMovieGenreParams{MovieID: m.ID, GenreID: g1.ID})
+	require.NoError(t, err)
+
+	// nil genres are kept
+	arg := UpdateMovieTxParams{
+		UpdateMovieParams: UpdateMovieParams{
+			ID:            m.ID,
+			Certification: sql.NullString{String: "R", Valid: true},
+		},
+	}
+
+	m2, err := testStore.UpdateMovieTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "R", m2.Certification)
+	require.Equal(t, m.Tags, m2.Tags)
+
+	genres, err := testQueries.ListMovieGenres(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Len(t, genres, 1)
+
+	arg.GenreIDs = []int64{g2.ID}
+	_, err = testStore.UpdateMovieTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	genres, err = testQueries.ListMovieGenres(context.Background(), m.ID)
+	require.NoError(t, err)
+	require.Len(t, genres, 1)
+	require.Equal(t, g2.ID, genres[0].ID)
+}
+
+// TestCheckInTicketTx tests CheckInTicketTx DB transaction
+func TestCheckInTicketTx(t *testing.T) {
+	ticket := createRandomTicket(t)
+
+	review, err := testQueries.CreateReview(context.Background(), CreateReviewParams{
+		MovieID:  ticket.MovieID,
+		Username: ticket.TicketOwner,
+		Rating:   4,
+		Status:   ReviewPending,
+	})
+	require.NoError(t, err)
+	require.False(t, review.Verified)
+
+	result, err := testStore.CheckInTicketTx(context.Background(), ticket.ID)
+	require.NoError(t, err)
+	require.True(t, result.CheckedInAt.Valid)
+
+	review, err = testQueries.GetReview(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.True(t, review.Verified)
+}
+
+// TestCreateReviewTx tests CreateReviewTx DB transaction
+func TestCreateReviewTx(t *testing.T) {
+	m := createRandomMovie(t)
+	u1 := createRandomUser(t)
+	u2 := createRandomUser(t)
+
+	// a pending review has no event
+	review, err := testStore.CreateReviewTx(context.Background(), CreateReviewTxParams{
+		CreateReviewParams: CreateReviewParams{MovieID: m.ID, Username: u1.Username, Rating: 4, Status: ReviewPending},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ReviewPending, review.Status)
+
+	events, err := testQueries.ListModerationEvents(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	// a review rejected by the filter is recorded without a moderator
+	review, err = testStore.CreateReviewTx(context.Background(), CreateReviewTxParams{
+		CreateReviewParams: CreateReviewParams{MovieID: m.ID, Username: u2.Username, Rating: 1, Status: ReviewRejected},
+		FilterReason:       "blocked word",
+	})
+	require.NoError(t, err)
+
+	events, err = testQueries.ListModerationEvents(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.False(t, events[0].Moderator.Valid)
+	require.Equal(t, ReviewPending, events[0].FromStatus)
+	require.Equal(t, ReviewRejected, events[0].ToStatus)
+	require.Equal(t, "blocked word", events[0].Reason)
+}
+
+// TestModerateReviewTx tests ModerateReviewTx DB transaction
+func TestModerateReviewTx(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+	moderator := createRandomUser(t)
+
+	arg := ModerateReviewTxParams{
+		ID:        review.ID,
+		Moderator: moderator.Username,
+		Status:    ReviewRejected,
+		Reason:    util.RandomString(10),
+	}
+
+	result, err := testStore.ModerateReviewTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ReviewRejected, result.Status)
+
+	events, err := testQueries.ListModerationEvents(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, moderator.Username, events[0].Moderator.String)
+	require.Equal(t, ReviewApproved, events[0].FromStatus)
+	require.Equal(t, ReviewRejected, events[0].ToStatus)
+	require.Equal(t, arg.Reason, events[0].Reason)
+
+	arg.ID = review.ID + 1000000
+	_, err = testStore.ModerateReviewTx(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+// TestReportReviewTx tests that a review goes back to the queue when its reports reach the threshold
+func TestReportReviewTx(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+
+	for i := 1; i <= 2; i++ {
+		u := createRandomUser(t)
+		result, err := testStore.ReportReviewTx(context.Background(), ReportReviewTxParams{
+			CreateReviewReportParams: CreateReviewReportParams{ReviewID: review.ID, Reporter: u.Username, Reason: "spam"},
+			Threshold:                2,
+		})
+		require.NoError(t, err)
+		require.Equal(t, u.Username, result.Report.Reporter)
+
+		if i == 1 {
+			require.Equal(t, ReviewApproved, result.Review.Status)
+		} else {
+			require.Equal(t, ReviewPending, result.Review.Status)
+		}
+	}
+
+	events, err := testQueries.ListModerationEvents(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, ReviewPending, events[0].ToStatus)
+}
+
+// TestReportReviewTxAfterApproval tests that the reports before a review is approved again don't requeue it
+func TestReportReviewTxAfterApproval(t *testing.T) {
+	review := createRandomReview(t, createRandomMovie(t))
+
+	report := func() ReportReviewTxResult {
+		result, err := testStore.ReportReviewTx(context.Background(), ReportReviewTxParams{
+			CreateReviewReportParams: CreateReviewReportParams{ReviewID: review.ID, Reporter: createRandomUser(t).Username, Reason: "spam"},
+			Threshold:                2,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	report()
+	require.Equal(t, ReviewPending, report().Review.Status)
+
+	approved, err := testStore.ModerateReviewTx(context.Background(), ModerateReviewTxParams{
+		ID:        review.ID,
+		Moderator: createRandomUser(t).Username,
+		Status:    ReviewApproved,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ReviewApproved, approved.Status)
+
+	count, err := testQueries.CountReviewReports(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	// one new report isn't enough to hide the approved review again
+	require.Equal(t, ReviewApproved, report().Review.Status)
+	require.Equal(t, ReviewPending, report().Review.Status)
+}
+
+// TestCreateVenueTx tests that a venue isn't created if one of its hours is invalid
+func TestCreateVenueTx(t *testing.T) {
+	arg := CreateVenueTxParams{
+		CreateVenueParams: CreateVenueParams{Name: util.RandomName() + util.RandomString(6), Address: util.RandomString(20), TimeZone: "UTC"},
+		Hours: []CreateVenueHoursParams{
+			{Weekday: 0, Opens: 600, Closes: 1380},
+			{Weekday: 1, Opens: 600, Closes: 1500},
+		},
+	}
+
+	result, err := testStore.CreateVenueTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, result.Venue.Name)
+	require.Len(t, result.Hours, 2)
+	require.Equal(t, result.Venue.ID, result.Hours[1].VenueID)
+
+	// the second weekday closes before it opens, so the venue is rolled back
+	arg.Name = util.RandomName() + util.RandomString(6)
+	arg.Hours[1].Closes = 500
+	_, err = testStore.CreateVenueTx(context.Background(), arg)
+	require.Error(t, err)
+
+	venues, err := testQueries.ListVenues(context.Background(), ListVenuesParams{AfterID: result.Venue.ID, Limit: 50})
+	require.NoError(t, err)
+	for _, v := range venues {
+		require.NotEqual(t, arg.Name, v.Name)
+	}
+}
+
+// TestScheduleScreeningsTx tests ScheduleScreeningsTx DB transaction
+func TestScheduleScreeningsTx(t *testing.T) {
+	a := createRandomAuditorium(t, createRandomVenue(t))
+	m := createRandomMovie(t)
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+
+	screening := func(startsAt time.Time) CreateScreeningParams {
+		return CreateScreeningParams{
+			MovieID:        m.ID,
+			StartsAt:       startsAt,
+			TrailerMinutes: 15,
+			EndsAt:         startsAt.Add(2 * time.Hour),
+			BlockedUntil:   startsAt.Add(2*time.Hour + 15*time.Minute),
+			Format:         "2d",
+			AudioLanguage:  "en",
+		}
+	}
+
+	arg := ScheduleScreeningsTxParams{
+		AuditoriumID: a.ID,
+		Screenings:   []CreateScreeningParams{screening(startsAt), screening(startsAt.Add(3 * time.Hour))},
+	}
+
+	// a dry run finds no conflicts and creates nothing
+	arg.DryRun = true
+	result, err := testStore.ScheduleScreeningsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, result.Conflicts)
+	require.Len(t, result.Screenings, 2)
+	require.Zero(t, result.Screenings[0].ID)
+
+	arg.DryRun = false
+	result, err = testStore.ScheduleScreeningsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, result.Conflicts)
+	require.Len(t, result.Screenings, 2)
+	require.NotZero(t, result.Screenings[0].ID)
+	require.Equal(t, a.ID, result.Screenings[1].AuditoriumID.Int64)
+
+	// the first screening starts while the second created one is cleaned, the second starts during the first one
+	arg.Screenings = []CreateScreeningParams{screening(startsAt.Add(5 * time.Hour)), screening(startsAt.Add(6 * time.Hour))}
+
+	result, err = testStore.ScheduleScreeningsTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrScheduleConflict)
+	require.Len(t, result.Conflicts, 2)
+	require.Equal(t, 0, result.Conflicts[0].Index)
+	require.NotNil(t, result.Conflicts[0].Existing)
+	require.Equal(t, 1, result.Conflicts[1].Index)
+	require.NotNil(t, result.Conflicts[1].OtherIndex)
+	require.Equal(t, 0, *result.Conflicts[1].OtherIndex)
+
+	screenings, err := testQueries.ListOverlappingScreenings(context.Background(), ListOverlappingScreeningsParams{
+		AuditoriumID: sql.NullInt64{Int64: a.ID, Valid: true},
+		StartsAt:     startsAt,
+		BlockedUntil: startsAt.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, screenings, 2)
+}
@@ -0,0 +1,43 @@
+package db
+
+import "context"
+
+// StreamDailySales runs the ListDailySales query and passes its rows to fn one by one as they are read,
+// so a long report is never held in memory. Reading stops at the first error of fn and it is returned
+func (q *Queries) StreamDailySales(ctx context.Context, arg ListDailySalesParams, fn func(ListDailySalesRow) error) error {
+	rows, err := q.db.QueryContext(ctx, listDailySales, arg.CreatedFrom, arg.CreatedTo, arg.TimeZone)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var i ListDailySalesRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.MovieID,
+			&i.Title,
+			&i.ScreeningID,
+			&i.TicketType,
+			&i.PaymentMethod,
+			&i.Channel,
+			&i.Currency,
+			&i.Quantity,
+			&i.Gross,
+			&i.Discount,
+			&i.Tax,
+			&i.Net,
+		); err != nil {
+			return err
+		}
+
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
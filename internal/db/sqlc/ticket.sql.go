@@ -7,20 +7,60 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const checkInTicket = `-- name: CheckInTicket :one
+UPDATE tickets
+SET checked_in_at = now()
+WHERE id = $1 AND checked_in_at IS NULL
+RETURNING id, movie_id, ticket_owner, child, adult, total, created_at, checked_in_at, screening_id, payment_method, discount, tax, surcharge, currency, membership_id
+`
+
+func (q *Queries) CheckInTicket(ctx context.Context, id int64) (Ticket, error) {
+	row := q.db.QueryRowContext(ctx, checkInTicket, id)
+	var i Ticket
+	err := row.Scan(
+		&i.ID,
+		&i.MovieID,
+		&i.TicketOwner,
+		&i.Child,
+		&i.Adult,
+		&i.Total,
+		&i.CreatedAt,
+		&i.CheckedInAt,
+		&i.ScreeningID,
+		&i.PaymentMethod,
+		&i.Discount,
+		&i.Tax,
+		&i.Surcharge,
+		&i.Currency,
+		&i.MembershipID,
+	)
+	return i, err
+}
+
 const createTicket = `-- name: CreateTicket :one
-INSERT INTO tickets(movie_id, ticket_owner, child, adult, total)
-VALUES($1, $2, $3, $4, $5)
-RETURNING id, movie_id, ticket_owner, child, adult, total, created_at
+INSERT INTO tickets(
+  movie_id, ticket_owner, child, adult, total, screening_id, surcharge, tax, currency, discount, payment_method, membership_id
+)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, movie_id, ticket_owner, child, adult, total, created_at, checked_in_at, screening_id, payment_method, discount, tax, surcharge, currency, membership_id
 `
 
 type CreateTicketParams struct {
-	MovieID     int64  `json:"movie_id"`
-	TicketOwner string `json:"ticket_owner"`
-	Child       int16  `json:"child"`
-	Adult       int16  `json:"adult"`
-	Total       int64  `json:"total"`
+	MovieID       int64         `json:"movie_id"`
+	TicketOwner   string        `json:"ticket_owner"`
+	Child         int16         `json:"child"`
+	Adult         int16         `json:"adult"`
+	Total         int64         `json:"total"`
+	ScreeningID   sql.NullInt64 `json:"screening_id"`
+	Surcharge     int64         `json:"surcharge"`
+	Tax           int64         `json:"tax"`
+	Currency      string        `json:"currency"`
+	Discount      int64         `json:"discount"`
+	PaymentMethod string        `json:"payment_method"`
+	MembershipID  sql.NullInt64 `json:"membership_id"`
 }
 
 func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Ticket, error) {
@@ -30,6 +70,13 @@ func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Tic
 		arg.Child,
 		arg.Adult,
 		arg.Total,
+		arg.ScreeningID,
+		arg.Surcharge,
+		arg.Tax,
+		arg.Currency,
+		arg.Discount,
+		arg.PaymentMethod,
+		arg.MembershipID,
 	)
 	var i Ticket
 	err := row.Scan(
@@ -40,6 +87,14 @@ func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Tic
 		&i.Adult,
 		&i.Total,
 		&i.CreatedAt,
+		&i.CheckedInAt,
+		&i.ScreeningID,
+		&i.PaymentMethod,
+		&i.Discount,
+		&i.Tax,
+		&i.Surcharge,
+		&i.Currency,
+		&i.MembershipID,
 	)
 	return i, err
 }
@@ -55,7 +110,7 @@ func (q *Queries) DeleteTicket(ctx context.Context, id int64) error {
 }
 
 const getTicket = `-- name: GetTicket :one
-SELECT id, movie_id, ticket_owner, child, adult, total, created_at
+SELECT id, movie_id, ticket_owner, child, adult, total, created_at, checked_in_at, screening_id, payment_method, discount, tax, surcharge, currency, membership_id
 FROM tickets
 WHERE id = $1
 LIMIT 1
@@ -72,27 +127,65 @@ func (q *Queries) GetTicket(ctx context.Context, id int64) (Ticket, error) {
 		&i.Adult,
 		&i.Total,
 		&i.CreatedAt,
+		&i.CheckedInAt,
+		&i.ScreeningID,
+		&i.PaymentMethod,
+		&i.Discount,
+		&i.Tax,
+		&i.Surcharge,
+		&i.Currency,
+		&i.MembershipID,
+	)
+	return i, err
+}
+
+const getTicketForUpdate = `-- name: GetTicketForUpdate :one
+SELECT id, movie_id, ticket_owner, child, adult, total, created_at, checked_in_at, screening_id, payment_method, discount, tax, surcharge, currency, membership_id
+FROM tickets
+WHERE id = $1
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetTicketForUpdate(ctx context.Context, id int64) (Ticket, error) {
+	row := q.db.QueryRowContext(ctx, getTicketForUpdate, id)
+	var i Ticket
+	err := row.Scan(
+		&i.ID,
+		&i.MovieID,
+		&i.TicketOwner,
+		&i.Child,
+		&i.Adult,
+		&i.Total,
+		&i.CreatedAt,
+		&i.CheckedInAt,
+		&i.ScreeningID,
+		&i.PaymentMethod,
+		&i.Discount,
+		&i.Tax,
+		&i.Surcharge,
+		&i.Currency,
+		&i.MembershipID,
 	)
 	return i, err
 }
 
 const listTickets = `-- name: ListTickets :many
-SELECT id, movie_id, ticket_owner, child, adult, total, created_at
+SELECT id, movie_id, ticket_owner, child, adult, total, created_at, checked_in_at, screening_id, payment_method, discount, tax, surcharge, currency, membership_id
 FROM tickets
-WHERE ticket_owner = $1
+WHERE ticket_owner = $1 AND id > $2
 ORDER BY id
-LIMIT $2
-OFFSET $3
+LIMIT $3
 `
 
 type ListTicketsParams struct {
 	TicketOwner string `json:"ticket_owner"`
+	AfterID     int64  `json:"after_id"`
 	Limit       int32  `json:"limit"`
-	Offset      int32  `json:"offset"`
 }
 
 func (q *Queries) ListTickets(ctx context.Context, arg ListTicketsParams) ([]Ticket, error) {
-	rows, err := q.db.QueryContext(ctx, listTickets, arg.TicketOwner, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listTickets, arg.TicketOwner, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +201,14 @@ func (q *Queries) ListTickets(ctx context.Context, arg ListTicketsParams) ([]Tic
 			&i.Adult,
 			&i.Total,
 			&i.CreatedAt,
+			&i.CheckedInAt,
+			&i.ScreeningID,
+			&i.PaymentMethod,
+			&i.Discount,
+			&i.Tax,
+			&i.Surcharge,
+			&i.Currency,
+			&i.MembershipID,
 		); err != nil {
 			return nil, err
 		}
@@ -15,11 +15,13 @@ func createRandomTicket(t *testing.T) Ticket {
 	u := createRandomUser(t)
 	m := createRandomMovie(t)
 	arg := CreateTicketParams{
-		TicketOwner: u.Username,
-		MovieID:     m.ID,
-		Child:       int16(util.RandomInt(1, 5)),
-		Adult:       int16(util.RandomInt(1, 5)),
-		Total:       util.RandomInt(20, 500),
+		TicketOwner:   u.Username,
+		MovieID:       m.ID,
+		Child:         int16(util.RandomInt(1, 5)),
+		Adult:         int16(util.RandomInt(1, 5)),
+		Total:         util.RandomInt(20, 500),
+		Currency:      "USD",
+		PaymentMethod: "card",
 	}
 
 	ticket, err := testQueries.CreateTicket(context.Background(), arg)
@@ -31,6 +33,7 @@ func createRandomTicket(t *testing.T) Ticket {
 	require.Equal(t, arg.Adult, ticket.Adult)
 	require.Equal(t, arg.TicketOwner, ticket.TicketOwner)
 	require.Equal(t, arg.Total, ticket.Total)
+	require.Equal(t, arg.Currency, ticket.Currency)
 	require.NotZero(t, ticket.CreatedAt)
 	require.NotZero(t, ticket.ID)
 
@@ -69,7 +72,7 @@ func TestListTickets(t *testing.T) {
 
 	arg := ListTicketsParams{
 		TicketOwner: ticket.TicketOwner,
-		Offset:      0,
+		AfterID:     0,
 		Limit:       1,
 	}
 
@@ -80,6 +83,12 @@ func TestListTickets(t *testing.T) {
 	for _, v := range tickets {
 		require.NotEmpty(t, v)
 	}
+
+	// there is nothing after the last ticket of the owner
+	arg.AfterID = tickets[0].ID
+	tickets, err = testQueries.ListTickets(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, tickets)
 }
 
 // TestDeleteTicket tests DeleteTicket DB operation
@@ -93,3 +102,17 @@ func TestDeleteTicket(t *testing.T) {
 	require.EqualError(t, err, sql.ErrNoRows.Error())
 	require.Empty(t, t2)
 }
+
+// TestCheckInTicket tests CheckInTicket DB operation
+func TestCheckInTicket(t *testing.T) {
+	t1 := createRandomTicket(t)
+	require.False(t, t1.CheckedInAt.Valid)
+
+	t2, err := testQueries.CheckInTicket(context.Background(), t1.ID)
+	require.NoError(t, err)
+	require.True(t, t2.CheckedInAt.Valid)
+
+	// a ticket can't be checked in twice
+	_, err = testQueries.CheckInTicket(context.Background(), t1.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
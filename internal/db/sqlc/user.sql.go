@@ -7,12 +7,13 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 const createUser = `-- name: CreateUser :one
 INSERT INTO users(username, hashed_password, email, access_level)
 VALUES($1, $2, $3, $4)
-RETURNING username, hashed_password, email, access_level, created_at
+RETURNING username, hashed_password, email, access_level, created_at, password_changed_at
 `
 
 type CreateUserParams struct {
@@ -36,12 +37,27 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.AccessLevel,
 		&i.CreatedAt,
+		&i.PasswordChangedAt,
 	)
 	return i, err
 }
 
+const getPasswordChangedAt = `-- name: GetPasswordChangedAt :one
+SELECT password_changed_at
+FROM users
+WHERE username = $1
+LIMIT 1
+`
+
+func (q *Queries) GetPasswordChangedAt(ctx context.Context, username string) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, getPasswordChangedAt, username)
+	var password_changed_at time.Time
+	err := row.Scan(&password_changed_at)
+	return password_changed_at, err
+}
+
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, email, access_level, created_at
+SELECT username, hashed_password, email, access_level, created_at, password_changed_at
 FROM users
 WHERE username = $1
 LIMIT 1
@@ -56,6 +72,55 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Email,
 		&i.AccessLevel,
 		&i.CreatedAt,
+		&i.PasswordChangedAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT username, hashed_password, email, access_level, created_at, password_changed_at
+FROM users
+WHERE email = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.Email,
+		&i.AccessLevel,
+		&i.CreatedAt,
+		&i.PasswordChangedAt,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users
+SET hashed_password = $1, password_changed_at = $2
+WHERE username = $3
+RETURNING username, hashed_password, email, access_level, created_at, password_changed_at
+`
+
+type UpdateUserPasswordParams struct {
+	HashedPassword    string    `json:"hashed_password"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+	Username          string    `json:"username"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPassword, arg.HashedPassword, arg.PasswordChangedAt, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.Email,
+		&i.AccessLevel,
+		&i.CreatedAt,
+		&i.PasswordChangedAt,
 	)
 	return i, err
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -55,3 +56,42 @@ func TestGetUser(t *testing.T) {
 	require.Equal(t, u1.HashedPassword, u2.HashedPassword)
 	require.WithinDuration(t, u1.CreatedAt, u2.CreatedAt, time.Second)
 }
+
+// TestGetUserByEmail tests GetUserByEmail DB operation
+func TestGetUserByEmail(t *testing.T) {
+	u1 := createRandomUser(t)
+
+	u2, err := testQueries.GetUserByEmail(context.Background(), u1.Email)
+	require.NoError(t, err)
+	require.Equal(t, u1.Username, u2.Username)
+
+	_, err = testQueries.GetUserByEmail(context.Background(), util.RandomEmail())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// TestUpdateUserPassword tests UpdateUserPassword and GetPasswordChangedAt DB operations
+func TestUpdateUserPassword(t *testing.T) {
+	u1 := createRandomUser(t)
+
+	// a new user never changed the password
+	changedAt, err := testQueries.GetPasswordChangedAt(context.Background(), u1.Username)
+	require.NoError(t, err)
+	require.True(t, changedAt.Before(u1.CreatedAt))
+
+	hashedPassword, err := util.HashPassword(util.RandomString(8))
+	require.NoError(t, err)
+
+	now := time.Now()
+	u2, err := testQueries.UpdateUserPassword(context.Background(), UpdateUserPasswordParams{
+		HashedPassword:    hashedPassword,
+		PasswordChangedAt: now,
+		Username:          u1.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, u2.HashedPassword)
+	require.WithinDuration(t, now, u2.PasswordChangedAt, time.Millisecond)
+
+	changedAt, err = testQueries.GetPasswordChangedAt(context.Background(), u1.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, now, changedAt, time.Millisecond)
+}
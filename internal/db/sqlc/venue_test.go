@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomVenue(t *testing.T) Venue {
+	arg := CreateVenueParams{
+		Name:       util.RandomName() + util.RandomString(6),
+		Address:    util.RandomString(20),
+		TimeZone:   "Europe/Istanbul",
+		AdultPrice: util.RandomInt(500, 1500),
+		ChildPrice: util.RandomInt(300, 900),
+		TaxRate:    int32(util.RandomInt(0, 2500)),
+	}
+
+	v, err := testQueries.CreateVenue(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, v.ID)
+	require.Equal(t, arg.Name, v.Name)
+	require.Equal(t, arg.Address, v.Address)
+	require.Equal(t, arg.TimeZone, v.TimeZone)
+	require.Equal(t, arg.AdultPrice, v.AdultPrice)
+	require.Equal(t, arg.ChildPrice, v.ChildPrice)
+	require.Equal(t, arg.TaxRate, v.TaxRate)
+	require.NotZero(t, v.CreatedAt)
+
+	return v
+}
+
+func createRandomAuditorium(t *testing.T, v Venue) Auditorium {
+	arg := CreateAuditoriumParams{
+		VenueID: v.ID,
+		Name:    util.RandomName(),
+		Seats:   int32(util.RandomInt(50, 300)),
+		// CleaningMinutes is 15 like the default of the table
+		CleaningMinutes: 15,
+	}
+
+	a, err := testQueries.CreateAuditorium(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, a.ID)
+	require.Equal(t, arg.VenueID, a.VenueID)
+	require.Equal(t, arg.Name, a.Name)
+	require.Equal(t, arg.Seats, a.Seats)
+	require.Equal(t, arg.CleaningMinutes, a.CleaningMinutes)
+
+	return a
+}
+
+// TestCreateVenue tests CreateVenue DB operation
+func TestCreateVenue(t *testing.T) {
+	createRandomVenue(t)
+}
+
+// TestListVenues tests ListVenues DB operation
+func TestListVenues(t *testing.T) {
+	v1 := createRandomVenue(t)
+	v2 := createRandomVenue(t)
+
+	venues, err := testQueries.ListVenues(context.Background(), ListVenuesParams{AfterID: v1.ID - 1, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, venues, 2)
+	require.Equal(t, v1, venues[0])
+	require.Equal(t, v2, venues[1])
+}
+
+// TestVenueHours tests CreateVenueHours and ListVenueHours DB operations
+func TestVenueHours(t *testing.T) {
+	v := createRandomVenue(t)
+
+	_, err := testQueries.CreateVenueHours(context.Background(), CreateVenueHoursParams{VenueID: v.ID, Weekday: 6, Opens: 600, Closes: 1500})
+	require.NoError(t, err)
+	_, err = testQueries.CreateVenueHours(context.Background(), CreateVenueHoursParams{VenueID: v.ID, Weekday: 1, Opens: 600, Closes: 1380})
+	require.NoError(t, err)
+
+	// a venue can't close before it opens
+	_, err = testQueries.CreateVenueHours(context.Background(), CreateVenueHoursParams{VenueID: v.ID, Weekday: 2, Opens: 600, Closes: 500})
+	require.Error(t, err)
+
+	hours, err := testQueries.ListVenueHours(context.Background(), []int64{v.ID})
+	require.NoError(t, err)
+	require.Len(t, hours, 2)
+	require.Equal(t, int16(1), hours[0].Weekday)
+	require.Equal(t, int16(6), hours[1].Weekday)
+	require.Equal(t, int16(1500), hours[1].Closes)
+}
+
+// TestListVenueAuditoriums tests ListVenueAuditoriums DB operation
+func TestListVenueAuditoriums(t *testing.T) {
+	v := createRandomVenue(t)
+	a := createRandomAuditorium(t, v)
+
+	// the auditorium names are unique in a venue
+	_, err := testQueries.CreateAuditorium(context.Background(), CreateAuditoriumParams{VenueID: v.ID, Name: a.Name, Seats: 10})
+	require.Error(t, err)
+
+	auditoriums, err := testQueries.ListVenueAuditoriums(context.Background(), v.ID)
+	require.NoError(t, err)
+	require.Equal(t, []Auditorium{a}, auditoriums)
+}
+
+// TestListVenueScreenings tests ListVenueScreenings DB operation
+func TestListVenueScreenings(t *testing.T) {
+	v := createRandomVenue(t)
+	a := createRandomAuditorium(t, v)
+	m := createRandomMovie(t)
+
+	create := func(startsAt time.Time, publish bool) Screening {
+		s, err := testQueries.CreateScreening(context.Background(), CreateScreeningParams{
+			MovieID:       m.ID,
+			StartsAt:      startsAt,
+			AuditoriumID:  sql.NullInt64{Int64: a.ID, Valid: true},
+			EndsAt:        startsAt.Add(2 * time.Hour),
+			BlockedUntil:  startsAt.Add(2 * time.Hour),
+			Format:        "2d",
+			AudioLanguage: "en",
+		})
+		require.NoError(t, err)
+
+		if publish {
+			s, err = testQueries.PublishScreening(context.Background(), s.ID)
+			require.NoError(t, err)
+		}
+
+		return s
+	}
+
+	soon := create(time.Now().Add(time.Hour), true)
+	create(time.Now().Add(2*time.Hour), false)
+	create(time.Now().Add(10*24*time.Hour), true)
+
+	screenings, err := testQueries.ListVenueScreenings(context.Background(), ListVenueScreeningsParams{
+		VenueID:      v.ID,
+		StartsBefore: time.Now().Add(7 * 24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, screenings, 1)
+	require.Equal(t, soon.ID, screenings[0].ID)
+	require.Equal(t, a.ID, screenings[0].AuditoriumID.Int64)
+}
+
+// TestVenueStaff tests the venue staff DB operations
+func TestVenueStaff(t *testing.T) {
+	v := createRandomVenue(t)
+	u := createRandomUser(t)
+	arg := UpsertVenueStaffParams{Username: u.Username, VenueID: v.ID, Role: "staff"}
+
+	staff, err := testQueries.UpsertVenueStaff(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "staff", staff.Role)
+
+	// the role of a user is changed by upserting it again
+	arg.Role = "manager"
+	staff, err = testQueries.UpsertVenueStaff(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "manager", staff.Role)
+
+	got, err := testQueries.GetVenueStaff(context.Background(), GetVenueStaffParams{Username: u.Username, VenueID: v.ID})
+	require.NoError(t, err)
+	require.Equal(t, staff, got)
+
+	list, err := testQueries.ListVenueStaff(context.Background(), v.ID)
+	require.NoError(t, err)
+	require.Equal(t, []VenueStaff{staff}, list)
+
+	n, err := testQueries.DeleteVenueStaff(context.Background(), DeleteVenueStaffParams{Username: u.Username, VenueID: v.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	_, err = testQueries.GetVenueStaff(context.Background(), GetVenueStaffParams{Username: u.Username, VenueID: v.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
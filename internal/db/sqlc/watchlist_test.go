@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// addRandomWatchlistItem adds given movie to the watchlist of given user
+func addRandomWatchlistItem(t *testing.T, u User, m Movie, notify bool) WatchlistItem {
+	arg := AddWatchlistItemParams{
+		Username: u.Username,
+		MovieID:  m.ID,
+		Notify:   notify,
+	}
+
+	item, err := testQueries.AddWatchlistItem(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, item.Username)
+	require.Equal(t, arg.MovieID, item.MovieID)
+	require.Equal(t, arg.Notify, item.Notify)
+	require.NotZero(t, item.CreatedAt)
+
+	return item
+}
+
+// TestAddWatchlistItem tests AddWatchlistItem DB operation
+func TestAddWatchlistItem(t *testing.T) {
+	u := createRandomUser(t)
+	m := createRandomMovie(t)
+	i1 := addRandomWatchlistItem(t, u, m, true)
+
+	// adding the movie again only updates the notify flag
+	i2 := addRandomWatchlistItem(t, u, m, false)
+	require.Equal(t, i1.CreatedAt, i2.CreatedAt)
+}
+
+// TestListWatchlist tests ListWatchlist DB operation
+func TestListWatchlist(t *testing.T) {
+	u := createRandomUser(t)
+	var items []WatchlistItem
+	for i := 0; i < 3; i++ {
+		items = append(items, addRandomWatchlistItem(t, u, createRandomMovie(t), true))
+	}
+
+	list, err := testQueries.ListWatchlist(context.Background(), ListWatchlistParams{
+		Username: u.Username,
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, items[0].MovieID, list[0].MovieID)
+
+	list, err = testQueries.ListWatchlist(context.Background(), ListWatchlistParams{
+		Username: u.Username,
+		AfterID:  list[1].MovieID,
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, items[2].MovieID, list[0].MovieID)
+}
+
+// TestRemoveWatchlistItem tests RemoveWatchlistItem DB operation
+func TestRemoveWatchlistItem(t *testing.T) {
+	u := createRandomUser(t)
+	item := addRandomWatchlistItem(t, u, createRandomMovie(t), true)
+
+	arg := RemoveWatchlistItemParams{Username: u.Username, MovieID: item.MovieID}
+
+	rows, err := testQueries.RemoveWatchlistItem(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	rows, err = testQueries.RemoveWatchlistItem(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
+
+// TestListWatchers tests ListWatchers DB operation
+func TestListWatchers(t *testing.T) {
+	m := createRandomMovie(t)
+	u1 := createRandomUser(t)
+	u2 := createRandomUser(t)
+	addRandomWatchlistItem(t, u1, m, true)
+	addRandomWatchlistItem(t, u2, m, false)
+
+	// only the watchers who want notifications are listed
+	watchers, err := testQueries.ListWatchers(context.Background(), []int64{m.ID})
+	require.NoError(t, err)
+	require.Len(t, watchers, 1)
+	require.Equal(t, u1.Username, watchers[0].Username)
+}
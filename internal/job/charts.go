@@ -0,0 +1,47 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+)
+
+// ChartsJob refreshes the materialized views the box-office and trending charts are read from
+type ChartsJob struct {
+	store db.Store
+}
+
+// NewChartsJob creates a new charts job with given store
+func NewChartsJob(store db.Store) *ChartsJob {
+	return &ChartsJob{store: store}
+}
+
+// Run refreshes the views at the start and then every interval until the context is done,
+// the errors are logged and retried at the next run
+func (job *ChartsJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.RunOnce(ctx); err != nil {
+			log.Println("charts job failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce refreshes the chart views, they are refreshed concurrently so the charts stay readable meanwhile
+func (job *ChartsJob) RunOnce(ctx context.Context) error {
+	if err := job.store.RefreshMovieDailySales(ctx); err != nil {
+		return err
+	}
+
+	return job.store.RefreshMovieTrending(ctx)
+}
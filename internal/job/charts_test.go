@@ -0,0 +1,57 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChartsJob tests RunOnce of the charts job
+func TestChartsJob(t *testing.T) {
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		wantErr    error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RefreshMovieDailySales(gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().RefreshMovieTrending(gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "Daily Sales Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RefreshMovieDailySales(gomock.Any()).Times(1).Return(sql.ErrConnDone)
+				store.EXPECT().RefreshMovieTrending(gomock.Any()).Times(0)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+		{
+			name: "Trending Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RefreshMovieDailySales(gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().RefreshMovieTrending(gomock.Any()).Times(1).Return(sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			err := NewChartsJob(store).RunOnce(context.Background())
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/membership"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+)
+
+// MembershipsJob renews the memberships whose periods are over, it charges the next period through the payment gateway.
+// It gives back the refunds that are still pending since the gateway failed when they were made as well
+type MembershipsJob struct {
+	store     db.Store
+	payments  payment.Gateway
+	batchSize int32
+	now       func() time.Time
+}
+
+// NewMembershipsJob creates a new memberships job with given store and payment gateway
+func NewMembershipsJob(store db.Store, payments payment.Gateway) *MembershipsJob {
+	return &MembershipsJob{store: store, payments: payments, batchSize: defaultBatchSize, now: time.Now}
+}
+
+// Run runs the job every interval until the context is done, the errors are logged and retried at the next run
+func (job *MembershipsJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := job.RunOnce(ctx)
+			if err != nil {
+				log.Println("memberships job failed:", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("memberships job renewed %d memberships", n)
+			}
+		}
+	}
+}
+
+// RunOnce renews a batch of the due memberships and returns the count of the renewed ones.
+// The memberships that are cancelled at the end of their period are cancelled instead, and the ones whose payment
+// is declined are past due until a later run takes it. The charges have an idempotency key of the period,
+// so a period that is charged by a failed run isn't charged twice
+func (job *MembershipsJob) RunOnce(ctx context.Context) (int, error) {
+	now := job.now()
+
+	if err := job.refundPending(ctx); err != nil {
+		return 0, err
+	}
+
+	memberships, err := job.store.ListDueMemberships(ctx, db.ListDueMembershipsParams{DueAt: now, Limit: job.batchSize})
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, m := range memberships {
+		if m.CancelAtPeriodEnd {
+			_, err = job.store.CancelMembershipTx(ctx, db.CancelMembershipTxParams{ID: m.ID})
+			if err != nil && err != sql.ErrNoRows {
+				return count, err
+			}
+			continue
+		}
+
+		renewed, err := job.renew(ctx, m, now)
+		if err != nil {
+			return count, err
+		}
+		if renewed {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// renew charges and starts the next period of a membership, it reports whether the membership is renewed.
+// The next period of a past due membership starts once it is paid instead of the end of its last period,
+// so does the next period of a membership that is missed by the job for a whole period
+func (job *MembershipsJob) renew(ctx context.Context, m db.Membership, now time.Time) (bool, error) {
+	plan, err := job.store.GetMembershipPlan(ctx, m.PlanID)
+	if err != nil {
+		return false, err
+	}
+
+	start := m.PeriodEnd
+	if m.Status == "past_due" || start.Before(now.AddDate(0, -1, 0)) {
+		start = now
+	}
+	period := membership.NewPeriod(start)
+
+	// the retries of a past due membership are new charges since the declined one can't be taken again
+	var p *db.MembershipPaymentParams
+	if plan.Price > 0 {
+		receipt, err := job.payments.Charge(ctx, payment.Charge{
+			Username:       m.Username,
+			Amount:         util.NewMoney(plan.Price, plan.Currency),
+			Description:    fmt.Sprintf("%s membership until %s", plan.Name, period.End.Format("2006-01-02")),
+			IdempotencyKey: fmt.Sprintf("membership-%d-%d", m.ID, period.Start.Unix()),
+		})
+
+		if err == payment.ErrDeclined {
+			_, err = job.store.MarkMembershipPastDue(ctx, m.ID)
+			return false, err
+		}
+		if err != nil {
+			return false, err
+		}
+
+		p = &db.MembershipPaymentParams{
+			Kind:      "renewal",
+			Amount:    receipt.Amount.Amount,
+			Currency:  receipt.Amount.Currency,
+			Reference: receipt.Reference,
+		}
+	}
+
+	_, err = job.store.RenewMembershipTx(ctx, db.RenewMembershipTxParams{
+		RenewMembershipParams: db.RenewMembershipParams{
+			PeriodStart:       period.Start,
+			PeriodEnd:         period.End,
+			Allowance:         plan.Allowance,
+			ID:                m.ID,
+			PreviousPeriodEnd: m.PeriodEnd,
+		},
+		Payment: p,
+	})
+
+	if err == sql.ErrNoRows {
+		return false, job.refundUnrenewed(ctx, m, p)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// refundUnrenewed refunds the charge of a membership that isn't renewed because it is changed meanwhile,
+// a membership that is renewed by another run keeps the charge since the charges of a period are the same charge
+func (job *MembershipsJob) refundUnrenewed(ctx context.Context, m db.Membership, p *db.MembershipPaymentParams) error {
+	if p == nil {
+		return nil
+	}
+
+	current, err := job.store.GetMembership(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+
+	if !current.PeriodEnd.Equal(m.PeriodEnd) {
+		return nil
+	}
+
+	_, err = job.payments.Refund(ctx, payment.Refund{
+		Username:    m.Username,
+		Amount:      util.NewMoney(p.Amount, p.Currency),
+		Description: "membership isn't renewed",
+		Reference:   p.Reference,
+	})
+
+	return err
+}
+
+// refundPending gives back a batch of the pending refunds, a refund that fails again is retried at the next run
+func (job *MembershipsJob) refundPending(ctx context.Context) error {
+	refunds, err := job.store.ListPendingMembershipRefunds(ctx, job.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range refunds {
+		m, err := job.store.GetMembership(ctx, p.MembershipID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := membership.Refund(ctx, job.store, job.payments, m.Username, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
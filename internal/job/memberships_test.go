@@ -0,0 +1,266 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingGateway is a payment gateway that keeps the payments in memory for the tests
+type recordingGateway struct {
+	charges []payment.Charge
+	refunds []payment.Refund
+	err     error
+}
+
+// Charge records the charge or returns the error of the gateway
+func (g *recordingGateway) Charge(ctx context.Context, c payment.Charge) (payment.Receipt, error) {
+	if g.err != nil {
+		return payment.Receipt{}, g.err
+	}
+	g.charges = append(g.charges, c)
+	return payment.Receipt{Reference: "ch_" + c.IdempotencyKey, Amount: c.Amount}, nil
+}
+
+// Refund records the refund or returns the error of the gateway
+func (g *recordingGateway) Refund(ctx context.Context, r payment.Refund) (payment.Receipt, error) {
+	if g.err != nil {
+		return payment.Receipt{}, g.err
+	}
+	g.refunds = append(g.refunds, r)
+	return payment.Receipt{Reference: "re_1", Amount: r.Amount}, nil
+}
+
+// TestMembershipsJob tests RunOnce of the memberships job
+func TestMembershipsJob(t *testing.T) {
+	now := time.Date(2030, 5, 10, 12, 0, 0, 0, time.UTC)
+	plan := db.MembershipPlan{ID: util.RandomInt(1, 1000), Name: "4 movies", Allowance: 4, Price: 1999, Currency: "USD"}
+
+	due := db.Membership{
+		ID:          util.RandomInt(1, 1000),
+		Username:    "alice",
+		PlanID:      plan.ID,
+		Status:      "active",
+		PeriodStart: time.Date(2030, 4, 10, 9, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2030, 5, 10, 9, 0, 0, 0, time.UTC),
+		Allowance:   4,
+		Used:        3,
+	}
+	next := due.PeriodEnd.AddDate(0, 1, 0)
+
+	pastDue := due
+	pastDue.Status = "past_due"
+
+	cancelled := due
+	cancelled.CancelAtPeriodEnd = true
+
+	listArg := db.ListDueMembershipsParams{DueAt: now, Limit: defaultBatchSize}
+
+	testCases := []struct {
+		name          string
+		gatewayErr    error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, count int, err error, gateway *recordingGateway)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RenewMembershipTxParams{
+					RenewMembershipParams: db.RenewMembershipParams{
+						PeriodStart:       due.PeriodEnd,
+						PeriodEnd:         next,
+						Allowance:         plan.Allowance,
+						ID:                due.ID,
+						PreviousPeriodEnd: due.PeriodEnd,
+					},
+					Payment: &db.MembershipPaymentParams{
+						Kind:      "renewal",
+						Amount:    plan.Price,
+						Currency:  plan.Currency,
+						Reference: fmt.Sprintf("ch_membership-%d-%d", due.ID, due.PeriodEnd.Unix()),
+					},
+				}
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(nil, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Eq(listArg)).Times(1).Return([]db.Membership{due}, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().RenewMembershipTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.MembershipTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Equal(t, 1, count)
+				require.Len(t, gateway.charges, 1)
+				require.Equal(t, "alice", gateway.charges[0].Username)
+				require.Equal(t, util.NewMoney(plan.Price, plan.Currency), gateway.charges[0].Amount)
+				require.Equal(t, "4 movies membership until 2030-06-10", gateway.charges[0].Description)
+			},
+		},
+		{
+			name: "Cancel At Period End",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(nil, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Eq(listArg)).Times(1).Return([]db.Membership{cancelled}, nil)
+				store.EXPECT().CancelMembershipTx(gomock.Any(), gomock.Eq(db.CancelMembershipTxParams{ID: cancelled.ID})).Times(1).
+					Return(db.MembershipTxResult{}, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().RenewMembershipTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+				require.Empty(t, gateway.charges)
+			},
+		},
+		{
+			name:       "Declined",
+			gatewayErr: payment.ErrDeclined,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(nil, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Eq(listArg)).Times(1).Return([]db.Membership{due}, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().MarkMembershipPastDue(gomock.Any(), gomock.Eq(due.ID)).Times(1).Return(int64(1), nil)
+				store.EXPECT().RenewMembershipTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+			},
+		},
+		{
+			name: "Past Due Starts Now",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(nil, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Eq(listArg)).Times(1).Return([]db.Membership{pastDue}, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().RenewMembershipTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RenewMembershipTxParams) (db.MembershipTxResult, error) {
+						require.Equal(t, now, arg.PeriodStart)
+						require.Equal(t, now.AddDate(0, 1, 0), arg.PeriodEnd)
+						require.Equal(t, pastDue.PeriodEnd, arg.PreviousPeriodEnd)
+						return db.MembershipTxResult{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Equal(t, 1, count)
+			},
+		},
+		{
+			name: "Free Plan",
+			buildStubs: func(store *mockdb.MockStore) {
+				free := plan
+				free.Price = 0
+
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(nil, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Eq(listArg)).Times(1).Return([]db.Membership{due}, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(free, nil)
+				store.EXPECT().RenewMembershipTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RenewMembershipTxParams) (db.MembershipTxResult, error) {
+						require.Nil(t, arg.Payment)
+						return db.MembershipTxResult{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Equal(t, 1, count)
+				require.Empty(t, gateway.charges)
+			},
+		},
+		{
+			name: "Renewed By Another Run",
+			buildStubs: func(store *mockdb.MockStore) {
+				renewed := due
+				renewed.PeriodStart, renewed.PeriodEnd = due.PeriodEnd, next
+
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(nil, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Eq(listArg)).Times(1).Return([]db.Membership{due}, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().RenewMembershipTx(gomock.Any(), gomock.Any()).Times(1).Return(db.MembershipTxResult{}, sql.ErrNoRows)
+				store.EXPECT().GetMembership(gomock.Any(), gomock.Eq(due.ID)).Times(1).Return(renewed, nil)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+		{
+			name: "Cancelled Meanwhile",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(nil, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Eq(listArg)).Times(1).Return([]db.Membership{due}, nil)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Eq(plan.ID)).Times(1).Return(plan, nil)
+				store.EXPECT().RenewMembershipTx(gomock.Any(), gomock.Any()).Times(1).Return(db.MembershipTxResult{}, sql.ErrNoRows)
+				store.EXPECT().GetMembership(gomock.Any(), gomock.Eq(due.ID)).Times(1).Return(cancelled, nil)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, util.NewMoney(plan.Price, plan.Currency), gateway.refunds[0].Amount)
+			},
+		},
+		{
+			name: "Pending Refund",
+			buildStubs: func(store *mockdb.MockStore) {
+				charge := db.MembershipPayment{ID: 1, MembershipID: due.ID, Kind: "renewal", Amount: plan.Price, Currency: plan.Currency, Reference: sql.NullString{String: "ch_1", Valid: true}}
+				pending := db.MembershipPayment{ID: 2, MembershipID: due.ID, Kind: "refund", Amount: -500, Currency: plan.Currency, RefundOf: sql.NullInt64{Int64: charge.ID, Valid: true}}
+				done := pending
+				done.Reference = sql.NullString{String: "re_1", Valid: true}
+
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return([]db.MembershipPayment{pending}, nil)
+				store.EXPECT().GetMembership(gomock.Any(), gomock.Eq(due.ID)).Times(1).Return(due, nil)
+				store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Eq(charge.ID)).Times(1).Return(charge, nil)
+				store.EXPECT().CompleteMembershipRefund(gomock.Any(), gomock.Eq(db.CompleteMembershipRefundParams{ID: pending.ID, Reference: done.Reference})).
+					Times(1).Return(done, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Eq(listArg)).Times(1).Return(nil, nil)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "alice", gateway.refunds[0].Username)
+				require.Equal(t, util.NewMoney(500, plan.Currency), gateway.refunds[0].Amount)
+				require.Equal(t, "ch_1", gateway.refunds[0].Reference)
+				require.Equal(t, "membership-refund-2", gateway.refunds[0].IdempotencyKey)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingMembershipRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(nil, nil)
+				store.EXPECT().ListDueMemberships(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().GetMembershipPlan(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.Zero(t, count)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			gateway := &recordingGateway{err: tt.gatewayErr}
+			job := NewMembershipsJob(store, gateway)
+			job.now = func() time.Time { return now }
+
+			count, err := job.RunOnce(context.Background())
+			tt.checkResponse(t, count, err, gateway)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/booking"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+)
+
+// PrivateBookingsJob gives back the deposits of the closed private bookings whose refunds are still pending
+// since the gateway failed when the bookings were closed
+type PrivateBookingsJob struct {
+	store     db.Store
+	payments  payment.Gateway
+	batchSize int32
+}
+
+// NewPrivateBookingsJob creates a new private bookings job with given store and payment gateway
+func NewPrivateBookingsJob(store db.Store, payments payment.Gateway) *PrivateBookingsJob {
+	return &PrivateBookingsJob{store: store, payments: payments, batchSize: defaultBatchSize}
+}
+
+// Run runs the job every interval until the context is done, the errors are logged and retried at the next run
+func (job *PrivateBookingsJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := job.RunOnce(ctx)
+			if err != nil {
+				log.Println("private bookings job failed:", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("private bookings job refunded %d deposits", n)
+			}
+		}
+	}
+}
+
+// RunOnce refunds a batch of the pending deposits and returns the count of the refunded ones,
+// a refund that fails again is retried at the next run
+func (job *PrivateBookingsJob) RunOnce(ctx context.Context) (int, error) {
+	bookings, err := job.store.ListPendingPrivateBookingRefunds(ctx, job.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, b := range bookings {
+		if _, err := booking.RefundDeposit(ctx, job.store, job.payments, b); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,102 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrivateBookingsJob tests RunOnce of the private bookings job
+func TestPrivateBookingsJob(t *testing.T) {
+	closed := db.PrivateBooking{
+		ID:               util.RandomInt(1, 1000),
+		Organizer:        "alice",
+		Status:           "rejected",
+		Deposit:          sql.NullInt64{Int64: 20000, Valid: true},
+		Currency:         sql.NullString{String: "USD", Valid: true},
+		DepositReference: sql.NullString{String: "ch_1", Valid: true},
+		CancelledAt:      sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	refunded := closed
+	refunded.DepositRefundReference = sql.NullString{String: "re_1", Valid: true}
+
+	testCases := []struct {
+		name          string
+		gatewayErr    error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, count int, err error, gateway *recordingGateway)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CompletePrivateBookingRefundParams{ID: closed.ID, DepositRefundReference: refunded.DepositRefundReference}
+				store.EXPECT().ListPendingPrivateBookingRefunds(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return([]db.PrivateBooking{closed}, nil)
+				store.EXPECT().CompletePrivateBookingRefund(gomock.Any(), gomock.Eq(arg)).Times(1).Return(refunded, nil)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Equal(t, 1, count)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "ch_1", gateway.refunds[0].Reference)
+				require.Equal(t, util.NewMoney(20000, "USD"), gateway.refunds[0].Amount)
+			},
+		},
+		{
+			name: "Nothing Pending",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingPrivateBookingRefunds(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+			},
+		},
+		{
+			name:       "Gateway Error",
+			gatewayErr: errors.New("gateway is down"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingPrivateBookingRefunds(gomock.Any(), gomock.Any()).Times(1).Return([]db.PrivateBooking{closed}, nil)
+				store.EXPECT().CompletePrivateBookingRefund(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.Error(t, err)
+				require.Zero(t, count)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingPrivateBookingRefunds(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, count int, err error, gateway *recordingGateway) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			gateway := &recordingGateway{err: tt.gatewayErr}
+			job := NewPrivateBookingsJob(store, gateway)
+
+			count, err := job.RunOnce(context.Background())
+			tt.checkResponse(t, count, err, gateway)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/recommend"
+)
+
+// RecommendationJob precomputes the recommendations of every buyer, so the endpoint doesn't compute them per request
+type RecommendationJob struct {
+	store  db.Store
+	engine *recommend.Engine
+}
+
+// NewRecommendationJob creates a new recommendation job with given store and engine
+func NewRecommendationJob(store db.Store, engine *recommend.Engine) *RecommendationJob {
+	return &RecommendationJob{store: store, engine: engine}
+}
+
+// Run runs the job every day at the given time of day until the context is done
+func (job *RecommendationJob) Run(ctx context.Context, at time.Duration) {
+	for {
+		timer := time.NewTimer(time.Until(nextDailyRun(time.Now(), at)))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			n, err := job.RunOnce(ctx)
+			if err != nil {
+				log.Println("recommendation job failed:", err)
+			}
+			log.Printf("recommendation job precomputed %d users", n)
+		}
+	}
+}
+
+// RunOnce precomputes the recommendations of every user with a ticket and returns the count of the users.
+// A failing user doesn't stop the others, the last error is returned
+func (job *RecommendationJob) RunOnce(ctx context.Context) (int, error) {
+	buyers, err := job.store.ListBuyers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	var lastErr error
+	for _, username := range buyers {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		if err := job.engine.Precompute(ctx, username); err != nil {
+			lastErr = err
+			continue
+		}
+		count++
+	}
+
+	return count, lastErr
+}
+
+// nextDailyRun returns the next time after now that is at the given offset from the midnight
+func nextDailyRun(now time.Time, at time.Duration) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	next := midnight.Add(at)
+	if !next.After(now) {
+		next = midnight.AddDate(0, 0, 1).Add(at)
+	}
+
+	return next
+}
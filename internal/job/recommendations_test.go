@@ -0,0 +1,70 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/recommend"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecommendationJob tests RunOnce of the recommendation job
+func TestRecommendationJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListBuyers(gomock.Any()).Times(1).Return([]string{"alice", "bob"}, nil)
+
+	// alice fails and bob is still precomputed
+	store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(1).Return([]db.Movie{}, sql.ErrConnDone)
+	store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(1).Return([]db.Movie{}, nil)
+	store.EXPECT().ReplaceUserRecommendationsTx(gomock.Any(), gomock.Eq(db.ReplaceUserRecommendationsTxParams{
+		Username:        "bob",
+		Recommendations: []db.CreateUserRecommendationParams{},
+	})).Times(1).Return(nil)
+
+	job := NewRecommendationJob(store, recommend.NewEngine(store))
+
+	n, err := job.RunOnce(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Equal(t, 1, n)
+}
+
+// TestNextDailyRun tests nextDailyRun
+func TestNextDailyRun(t *testing.T) {
+	at := 3 * time.Hour
+
+	testCases := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "Before",
+			now:  time.Date(2030, 1, 31, 1, 0, 0, 0, time.UTC),
+			want: time.Date(2030, 1, 31, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "At",
+			now:  time.Date(2030, 1, 31, 3, 0, 0, 0, time.UTC),
+			want: time.Date(2030, 2, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "After",
+			now:  time.Date(2030, 12, 31, 22, 0, 0, 0, time.UTC),
+			want: time.Date(2031, 1, 1, 3, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, nextDailyRun(tt.now, at))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/notify"
+)
+
+// defaultBatchSize is the count of the screenings a single run handles
+const defaultBatchSize = 100
+
+// screeningTimeLayout is the layout of the start times in the notifications
+const screeningTimeLayout = "Mon Jan 2 15:04"
+
+// WatchlistJob notifies the watchers of the movies whose screenings are newly published
+type WatchlistJob struct {
+	store     db.Store
+	notifier  notify.Notifier
+	batchSize int32
+}
+
+// NewWatchlistJob creates a new watchlist job with given store and notifier
+func NewWatchlistJob(store db.Store, notifier notify.Notifier) *WatchlistJob {
+	return &WatchlistJob{store: store, notifier: notifier, batchSize: defaultBatchSize}
+}
+
+// Run runs the job every interval until the context is done, the errors are logged and retried at the next run
+func (job *WatchlistJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := job.RunOnce(ctx)
+			if err != nil {
+				log.Println("watchlist job failed:", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("watchlist job enqueued %d notifications", n)
+			}
+		}
+	}
+}
+
+// RunOnce matches a batch of the newly published screenings to the watchlists and enqueues a notification
+// for every watcher of a movie, it returns the count of the enqueued notifications.
+// The screenings are marked only after every notification is enqueued, so a failed run is retried
+// and a watcher may be notified twice but never missed
+func (job *WatchlistJob) RunOnce(ctx context.Context) (int, error) {
+	screenings, err := job.store.ListUnnotifiedScreenings(ctx, job.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(screenings) == 0 {
+		return 0, nil
+	}
+
+	// first i group the screenings by movie so a watcher gets a single notification per movie
+	byMovie := make(map[int64][]db.Screening)
+	movieIDs := make([]int64, 0)
+	screeningIDs := make([]int64, 0, len(screenings))
+	for _, s := range screenings {
+		if _, ok := byMovie[s.MovieID]; !ok {
+			movieIDs = append(movieIDs, s.MovieID)
+		}
+		byMovie[s.MovieID] = append(byMovie[s.MovieID], s)
+		screeningIDs = append(screeningIDs, s.ID)
+	}
+
+	for _, group := range byMovie {
+		sort.Slice(group, func(i, j int) bool { return group[i].StartsAt.Before(group[j].StartsAt) })
+	}
+
+	movies, err := job.store.ListMoviesByIDs(ctx, movieIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	titles := make(map[int64]string)
+	for _, m := range movies {
+		// deleted movies aren't on sale anymore
+		if !m.DeletedAt.Valid {
+			titles[m.ID] = m.Title
+		}
+	}
+
+	watchers, err := job.store.ListWatchers(ctx, movieIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, w := range watchers {
+		title, ok := titles[w.MovieID]
+		if !ok {
+			continue
+		}
+
+		err = job.notifier.Enqueue(ctx, newOnSaleNotification(w.Username, title, byMovie[w.MovieID]))
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	err = job.store.MarkScreeningsNotified(ctx, screeningIDs)
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// newOnSaleNotification creates the notification of the screenings of a movie on the watchlist of a user
+func newOnSaleNotification(username, title string, screenings []db.Screening) notify.Notification {
+	times := make([]string, 0, len(screenings))
+	for _, s := range screenings {
+		times = append(times, s.StartsAt.Format(screeningTimeLayout))
+	}
+
+	return notify.Notification{
+		Username: username,
+		Subject:  fmt.Sprintf("%s is on sale", title),
+		Body:     fmt.Sprintf("Tickets for %s are on sale for the screenings on %s.", title, strings.Join(times, ", ")),
+	}
+}
@@ -0,0 +1,135 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/notify"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier is a notifier that keeps the notifications in memory for the tests
+type recordingNotifier struct {
+	notifications []notify.Notification
+	err           error
+}
+
+// Enqueue records the notification or returns the error of the notifier
+func (n *recordingNotifier) Enqueue(ctx context.Context, notification notify.Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+// TestWatchlistJob tests RunOnce of the watchlist job
+func TestWatchlistJob(t *testing.T) {
+	movie := db.Movie{ID: util.RandomInt(1, 1000), Title: util.RandomName()}
+	deleted := db.Movie{ID: movie.ID + 1, Title: util.RandomName(), DeletedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+
+	start := time.Date(2030, 5, 10, 20, 30, 0, 0, time.UTC)
+	screenings := []db.Screening{
+		{ID: 1, MovieID: movie.ID, StartsAt: start.Add(24 * time.Hour)},
+		{ID: 2, MovieID: movie.ID, StartsAt: start},
+		{ID: 3, MovieID: deleted.ID, StartsAt: start},
+	}
+	movieIDs := []int64{movie.ID, deleted.ID}
+	screeningIDs := []int64{1, 2, 3}
+
+	watchers := []db.WatchlistItem{
+		{Username: "alice", MovieID: movie.ID, Notify: true},
+		{Username: "bob", MovieID: movie.ID, Notify: true},
+		{Username: "carol", MovieID: deleted.ID, Notify: true},
+	}
+
+	testCases := []struct {
+		name          string
+		notifierErr   error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, count int, err error, notifier *recordingNotifier)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUnnotifiedScreenings(gomock.Any(), gomock.Eq(int32(defaultBatchSize))).Times(1).Return(screenings, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq(movieIDs)).Times(1).Return([]db.Movie{movie, deleted}, nil)
+				store.EXPECT().ListWatchers(gomock.Any(), gomock.Eq(movieIDs)).Times(1).Return(watchers, nil)
+				store.EXPECT().MarkScreeningsNotified(gomock.Any(), gomock.Eq(screeningIDs)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, count int, err error, notifier *recordingNotifier) {
+				require.NoError(t, err)
+				require.Equal(t, 2, count)
+				require.Len(t, notifier.notifications, 2)
+
+				n := notifier.notifications[0]
+				require.Equal(t, "alice", n.Username)
+				require.Equal(t, movie.Title+" is on sale", n.Subject)
+				require.Contains(t, n.Body, "Fri May 10 20:30, Sat May 11 20:30")
+				require.Equal(t, "bob", notifier.notifications[1].Username)
+			},
+		},
+		{
+			name: "No Screenings",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUnnotifiedScreenings(gomock.Any(), gomock.Any()).Times(1).Return([]db.Screening{}, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListWatchers(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().MarkScreeningsNotified(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error, notifier *recordingNotifier) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+			},
+		},
+		{
+			name:        "Notifier Error",
+			notifierErr: errors.New("queue is full"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUnnotifiedScreenings(gomock.Any(), gomock.Any()).Times(1).Return(screenings, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq(movieIDs)).Times(1).Return([]db.Movie{movie, deleted}, nil)
+				store.EXPECT().ListWatchers(gomock.Any(), gomock.Eq(movieIDs)).Times(1).Return(watchers, nil)
+				store.EXPECT().MarkScreeningsNotified(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error, notifier *recordingNotifier) {
+				require.Error(t, err)
+				require.Zero(t, count)
+			},
+		},
+		{
+			name: "Store Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUnnotifiedScreenings(gomock.Any(), gomock.Any()).Times(1).Return(screenings, nil)
+				store.EXPECT().ListMoviesByIDs(gomock.Any(), gomock.Eq(movieIDs)).Times(1).Return([]db.Movie{movie, deleted}, nil)
+				store.EXPECT().ListWatchers(gomock.Any(), gomock.Any()).Times(1).Return([]db.WatchlistItem{}, sql.ErrConnDone)
+				store.EXPECT().MarkScreeningsNotified(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error, notifier *recordingNotifier) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			notifier := &recordingNotifier{err: tt.notifierErr}
+			job := NewWatchlistJob(store, notifier)
+
+			count, err := job.RunOnce(context.Background())
+			tt.checkResponse(t, count, err, notifier)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileMailer is a mailer that appends the messages to a local file, it implements Mailer interface.
+// It is useful on a development machine until a real mail provider is wired
+type FileMailer struct {
+	path string
+	mu   *sync.Mutex
+}
+
+// NewFileMailer creates a new FileMailer that writes to the file at given path, the file is created if it doesn't exist
+func NewFileMailer(path string) Mailer {
+	return FileMailer{path: path, mu: &sync.Mutex{}}
+}
+
+// Send appends the message to the file with its headers
+func (m FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// the messages of concurrent requests aren't interleaved
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
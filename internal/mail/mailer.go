@@ -0,0 +1,16 @@
+package mail
+
+import "context"
+
+// Message holds an email to a single recipient
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer interface will be our email sender which lets us to implement and switch between providers
+type Mailer interface {
+	// Send delivers the message, it doesn't wait for the recipient to read it
+	Send(ctx context.Context, m Message) error
+}
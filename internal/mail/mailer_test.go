@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileMailer tests FileMailer
+func TestFileMailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.txt")
+	m := NewFileMailer(path)
+
+	err := m.Send(context.Background(), Message{To: "john@example.com", Subject: "reset", Body: "your token"})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), Message{To: "jane@example.com", Subject: "reset", Body: "her token"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "To: john@example.com\nSubject: reset\n\nyour token\n")
+	require.Contains(t, string(data), "To: jane@example.com\nSubject: reset\n\nher token\n")
+
+	// a cancelled context isn't written
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = m.Send(ctx, Message{To: "john@example.com"})
+	require.Error(t, err)
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, after)
+}
+
+// TestMemoryMailer tests MemoryMailer
+func TestMemoryMailer(t *testing.T) {
+	m := NewMemoryMailer()
+	require.Empty(t, m.Messages())
+
+	msg := Message{To: "john@example.com", Subject: "reset", Body: "your token"}
+	err := m.Send(context.Background(), msg)
+	require.NoError(t, err)
+	require.Equal(t, []Message{msg}, m.Messages())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = m.Send(ctx, Message{To: "jane@example.com"})
+	require.Error(t, err)
+	require.Len(t, m.Messages(), 1)
+}
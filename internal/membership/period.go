@@ -0,0 +1,96 @@
+package membership
+
+import (
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+)
+
+// Period is a monthly billing period of a membership, it starts at Start and ends before End
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewPeriod creates the period that starts at given time and ends a month later. The periods that start
+// at the end of a month end at the end of the shorter months instead of running over to the next month
+func NewPeriod(start time.Time) Period {
+	y, m, d := start.Date()
+
+	// the day 0 of a month is the last day of the month before it
+	if last := time.Date(y, m+2, 0, 0, 0, 0, 0, start.Location()).Day(); d > last {
+		d = last
+	}
+
+	end := time.Date(y, m+1, d, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	return Period{Start: start, End: end}
+}
+
+// Remaining returns the part of the period that is left at given time
+func (p Period) Remaining(now time.Time) time.Duration {
+	switch {
+	case now.Before(p.Start):
+		return p.End.Sub(p.Start)
+	case now.After(p.End):
+		return 0
+	default:
+		return p.End.Sub(now)
+	}
+}
+
+// Prorate returns the part of a price of the period that is left at given time, rounded half up.
+// The period is prorated by the second so a plan changed on the last day costs a day
+func (p Period) Prorate(price util.Money, now time.Time) util.Money {
+	left, length := p.seconds(now)
+	return price.Share(left, length, util.RoundHalfUp)
+}
+
+// ProrateAllowance returns the part of an allowance of the period that is left at given time, rounded half up
+func (p Period) ProrateAllowance(allowance int32, now time.Time) int32 {
+	left, length := p.seconds(now)
+	return int32((2*int64(allowance)*left + length) / (2 * length))
+}
+
+// seconds returns the seconds left of the period at given time and the seconds of the whole period
+func (p Period) seconds(now time.Time) (int64, int64) {
+	return int64(p.Remaining(now) / time.Second), int64(p.End.Sub(p.Start) / time.Second)
+}
+
+// Resume returns the period of a membership that is resumed after a pause, the paused time is added to its end
+func (p Period) Resume(pausedAt, now time.Time) Period {
+	if now.Before(pausedAt) {
+		return p
+	}
+	return Period{Start: p.Start, End: p.End.Add(now.Sub(pausedAt))}
+}
+
+// Plan holds the allowance and the price of a membership plan for a period
+type Plan struct {
+	Allowance int32
+	Price     util.Money
+}
+
+// PlanChange holds the change of the plan of a membership in the middle of a period
+type PlanChange struct {
+	// Charge is the price of the new plan for the rest of the period less the price of the old plan for it,
+	// it is negative if the user is refunded
+	Charge util.Money
+	// Allowance is the allowance of the period with the new plan, the seats that are already used aren't taken back
+	Allowance int32
+}
+
+// ChangePlan prorates the change of a plan at given time, allowance and used are the allowance of the period
+// and the seats that are used from it. The plans must be priced in the same currency
+func (p Period) ChangePlan(from, to Plan, allowance, used int32, now time.Time) (PlanChange, error) {
+	charge, err := p.Prorate(to.Price, now).Sub(p.Prorate(from.Price, now))
+	if err != nil {
+		return PlanChange{}, err
+	}
+
+	allowance += p.ProrateAllowance(to.Allowance, now) - p.ProrateAllowance(from.Allowance, now)
+	if allowance < used {
+		allowance = used
+	}
+
+	return PlanChange{Charge: charge, Allowance: allowance}, nil
+}
@@ -0,0 +1,158 @@
+package membership
+
+import (
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewPeriod tests the ends of the monthly periods
+func TestNewPeriod(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+	}{
+		{
+			name:  "Middle Of Month",
+			start: time.Date(2030, 3, 15, 10, 30, 0, 0, time.UTC),
+			end:   time.Date(2030, 4, 15, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "End Of Year",
+			start: time.Date(2030, 12, 20, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2031, 1, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Shorter Month",
+			start: time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC),
+			end:   time.Date(2030, 2, 28, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Leap Year",
+			start: time.Date(2032, 1, 30, 9, 0, 0, 0, time.UTC),
+			end:   time.Date(2032, 2, 29, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Time Zone",
+			start: time.Date(2030, 8, 31, 23, 0, 0, 0, istanbul),
+			end:   time.Date(2030, 9, 30, 23, 0, 0, 0, istanbul),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPeriod(tt.start)
+			require.Equal(t, tt.start, p.Start)
+			require.True(t, tt.end.Equal(p.End), "got %v", p.End)
+		})
+	}
+}
+
+// TestPeriodProrate tests the prorated prices and allowances
+func TestPeriodProrate(t *testing.T) {
+	// april has 30 days
+	p := NewPeriod(time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC))
+	price := util.NewMoney(3000, "USD")
+
+	require.Equal(t, price, p.Prorate(price, p.Start.Add(-time.Hour)))
+	require.Equal(t, util.NewMoney(2000, "USD"), p.Prorate(price, p.Start.AddDate(0, 0, 10)))
+	require.Equal(t, util.NewMoney(100, "USD"), p.Prorate(price, p.End.AddDate(0, 0, -1)))
+	require.True(t, p.Prorate(price, p.End.Add(time.Hour)).IsZero())
+
+	require.Equal(t, int32(4), p.ProrateAllowance(4, p.Start))
+	require.Equal(t, int32(2), p.ProrateAllowance(4, p.Start.AddDate(0, 0, 15)))
+	// 4 seats for 20 of the 30 days is 2.67 seats
+	require.Equal(t, int32(3), p.ProrateAllowance(4, p.Start.AddDate(0, 0, 10)))
+	require.Zero(t, p.ProrateAllowance(4, p.End))
+}
+
+// TestPeriodResume tests that the paused time is added to the end of the period
+func TestPeriodResume(t *testing.T) {
+	p := NewPeriod(time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC))
+	pausedAt := p.Start.AddDate(0, 0, 5)
+
+	got := p.Resume(pausedAt, pausedAt.AddDate(0, 0, 12))
+	require.Equal(t, p.Start, got.Start)
+	require.Equal(t, time.Date(2030, 5, 13, 0, 0, 0, 0, time.UTC), got.End)
+
+	require.Equal(t, p, p.Resume(pausedAt, pausedAt.Add(-time.Minute)))
+}
+
+// TestPeriodChangePlan tests the prorated changes of the plans
+func TestPeriodChangePlan(t *testing.T) {
+	p := NewPeriod(time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC))
+	basic := Plan{Allowance: 2, Price: util.NewMoney(1500, "USD")}
+	premium := Plan{Allowance: 8, Price: util.NewMoney(4500, "USD")}
+
+	testCases := []struct {
+		name      string
+		from      Plan
+		to        Plan
+		allowance int32
+		used      int32
+		now       time.Time
+		check     func(t *testing.T, got PlanChange, err error)
+	}{
+		{
+			name:      "Upgrade",
+			from:      basic,
+			to:        premium,
+			allowance: 2,
+			used:      1,
+			now:       p.Start.AddDate(0, 0, 15),
+			check: func(t *testing.T, got PlanChange, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.NewMoney(1500, "USD"), got.Charge)
+				require.Equal(t, int32(2-1+4), got.Allowance)
+			},
+		},
+		{
+			name:      "Downgrade",
+			from:      premium,
+			to:        basic,
+			allowance: 8,
+			used:      1,
+			now:       p.Start.AddDate(0, 0, 15),
+			check: func(t *testing.T, got PlanChange, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.NewMoney(-1500, "USD"), got.Charge)
+				require.Equal(t, int32(8-4+1), got.Allowance)
+			},
+		},
+		{
+			name:      "Used Seats Are Kept",
+			from:      premium,
+			to:        basic,
+			allowance: 8,
+			used:      7,
+			now:       p.Start,
+			check: func(t *testing.T, got PlanChange, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(7), got.Allowance)
+			},
+		},
+		{
+			name:      "Currency Mismatch",
+			from:      basic,
+			to:        Plan{Allowance: 2, Price: util.NewMoney(1500, "EUR")},
+			allowance: 2,
+			now:       p.Start,
+			check: func(t *testing.T, got PlanChange, err error) {
+				require.ErrorIs(t, err, util.ErrCurrencyMismatch)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.ChangePlan(tt.from, tt.to, tt.allowance, tt.used, tt.now)
+			tt.check(t, got, err)
+		})
+	}
+}
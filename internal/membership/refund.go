@@ -0,0 +1,44 @@
+package membership
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+)
+
+// Refund gives back a pending refund of a membership through the payment gateway and records its reference.
+// The refund is retried with the same idempotency key, so a refund that is retried after a failure or
+// by another caller at the same time is given back once
+func Refund(ctx context.Context, store db.Store, payments payment.Gateway, username string, p db.MembershipPayment) (db.MembershipPayment, error) {
+	charge, err := store.GetMembershipPayment(ctx, p.RefundOf.Int64)
+	if err != nil {
+		return p, err
+	}
+
+	receipt, err := payments.Refund(ctx, payment.Refund{
+		Username:       username,
+		Amount:         util.NewMoney(-p.Amount, p.Currency),
+		Description:    "membership refund",
+		Reference:      charge.Reference.String,
+		IdempotencyKey: fmt.Sprintf("membership-refund-%d", p.ID),
+	})
+	if err != nil {
+		return p, err
+	}
+
+	done, err := store.CompleteMembershipRefund(ctx, db.CompleteMembershipRefundParams{
+		ID:        p.ID,
+		Reference: sql.NullString{String: receipt.Reference, Valid: true},
+	})
+
+	// the refund is completed by another caller meanwhile
+	if err == sql.ErrNoRows {
+		return store.GetMembershipPayment(ctx, p.ID)
+	}
+
+	return done, err
+}
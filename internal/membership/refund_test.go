@@ -0,0 +1,118 @@
+package membership
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/burakkarasel/Theatre-API/internal/payment"
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// refundGateway is a payment gateway that records the refunds for the tests
+type refundGateway struct {
+	refunds []payment.Refund
+	err     error
+}
+
+// Charge isn't used by the refunds
+func (g *refundGateway) Charge(ctx context.Context, c payment.Charge) (payment.Receipt, error) {
+	return payment.Receipt{}, errors.New("unexpected charge")
+}
+
+// Refund records the refund or returns the error of the gateway
+func (g *refundGateway) Refund(ctx context.Context, r payment.Refund) (payment.Receipt, error) {
+	if g.err != nil {
+		return payment.Receipt{}, g.err
+	}
+	g.refunds = append(g.refunds, r)
+	return payment.Receipt{Reference: "re_" + r.IdempotencyKey, Amount: r.Amount}, nil
+}
+
+// TestRefund tests Refund
+func TestRefund(t *testing.T) {
+	charge := db.MembershipPayment{ID: 1, MembershipID: 7, Kind: "renewal", Amount: 2000, Currency: "USD", Reference: sql.NullString{String: "ch_1", Valid: true}}
+	pending := db.MembershipPayment{ID: 2, MembershipID: 7, Kind: "refund", Amount: -500, Currency: "USD", RefundOf: sql.NullInt64{Int64: charge.ID, Valid: true}}
+
+	done := pending
+	done.Reference = sql.NullString{String: "re_membership-refund-2", Valid: true}
+
+	testCases := []struct {
+		name       string
+		gatewayErr error
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got db.MembershipPayment, err error, gateway *refundGateway)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Eq(charge.ID)).Times(1).Return(charge, nil)
+				store.EXPECT().CompleteMembershipRefund(gomock.Any(), gomock.Eq(db.CompleteMembershipRefundParams{ID: pending.ID, Reference: done.Reference})).
+					Times(1).Return(done, nil)
+			},
+			check: func(t *testing.T, got db.MembershipPayment, err error, gateway *refundGateway) {
+				require.NoError(t, err)
+				require.Equal(t, done, got)
+				require.Len(t, gateway.refunds, 1)
+				require.Equal(t, "alice", gateway.refunds[0].Username)
+				require.Equal(t, util.NewMoney(500, "USD"), gateway.refunds[0].Amount)
+				require.Equal(t, "ch_1", gateway.refunds[0].Reference)
+				require.Equal(t, "membership-refund-2", gateway.refunds[0].IdempotencyKey)
+			},
+		},
+		{
+			name: "Completed Meanwhile",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Eq(charge.ID)).Times(1).Return(charge, nil)
+				store.EXPECT().CompleteMembershipRefund(gomock.Any(), gomock.Any()).Times(1).Return(db.MembershipPayment{}, sql.ErrNoRows)
+				store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(done, nil)
+			},
+			check: func(t *testing.T, got db.MembershipPayment, err error, gateway *refundGateway) {
+				require.NoError(t, err)
+				require.Equal(t, done, got)
+			},
+		},
+		{
+			name:       "Gateway Error",
+			gatewayErr: errors.New("gateway is down"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Eq(charge.ID)).Times(1).Return(charge, nil)
+				store.EXPECT().CompleteMembershipRefund(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, got db.MembershipPayment, err error, gateway *refundGateway) {
+				require.Error(t, err)
+				require.Equal(t, pending, got)
+			},
+		},
+		{
+			name: "Internal Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMembershipPayment(gomock.Any(), gomock.Eq(charge.ID)).Times(1).Return(db.MembershipPayment{}, sql.ErrConnDone)
+				store.EXPECT().CompleteMembershipRefund(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, got db.MembershipPayment, err error, gateway *refundGateway) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.Empty(t, gateway.refunds)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			gateway := &refundGateway{err: tt.gatewayErr}
+			got, err := Refund(context.Background(), store, gateway, "alice", pending)
+			tt.check(t, got, err, gateway)
+		})
+	}
+}
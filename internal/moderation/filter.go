@@ -0,0 +1,33 @@
+package moderation
+
+// Verdict holds the decision of a filter about a text
+type Verdict struct {
+	Flagged bool
+	// Reason explains why the text is flagged, it is recorded in the audit trail
+	Reason string
+}
+
+// Filter interface will be our automatic review filter which lets us to implement and switch between filters
+type Filter interface {
+	// Check inspects the text and flags it if it shouldn't be published
+	Check(text string) Verdict
+}
+
+// chainFilter runs a list of filters and returns the first flag
+type chainFilter []Filter
+
+// NewChainFilter creates a filter that flags a text if any of the given filters flags it
+func NewChainFilter(filters ...Filter) Filter {
+	return chainFilter(filters)
+}
+
+// Check returns the verdict of the first filter that flags the text
+func (c chainFilter) Check(text string) Verdict {
+	for _, f := range c {
+		if v := f.Check(text); v.Flagged {
+			return v
+		}
+	}
+
+	return Verdict{}
+}
@@ -0,0 +1,19 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestChainFilter tests that a chain flags a text if any of its filters flags it
+func TestChainFilter(t *testing.T) {
+	re, err := NewRegexFilter([]string{`https?://`})
+	require.NoError(t, err)
+
+	f := NewChainFilter(NewWordlistFilter([]string{"scam"}), re)
+
+	require.False(t, f.Check("a great movie").Flagged)
+	require.True(t, f.Check("what a scam").Flagged)
+	require.True(t, f.Check("see https://example.com").Flagged)
+}
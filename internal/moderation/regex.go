@@ -0,0 +1,41 @@
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RegexFilter is a filter that flags the texts matching a pattern, it implements Filter interface
+type RegexFilter struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRegexFilter creates a new RegexFilter, it returns an error if any of the patterns is invalid
+func NewRegexFilter(patterns []string) (Filter, error) {
+	f := RegexFilter{patterns: make([]*regexp.Regexp, 0, len(patterns))}
+	for _, p := range patterns {
+		// an empty pattern matches every text
+		if p == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		f.patterns = append(f.patterns, re)
+	}
+
+	return f, nil
+}
+
+// Check flags the text if it matches any of the patterns
+func (f RegexFilter) Check(text string) Verdict {
+	for _, re := range f.patterns {
+		if re.MatchString(text) {
+			return Verdict{Flagged: true, Reason: fmt.Sprintf("matches pattern %q", re.String())}
+		}
+	}
+
+	return Verdict{}
+}
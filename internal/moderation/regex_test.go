@@ -0,0 +1,28 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegexFilter tests RegexFilter
+func TestRegexFilter(t *testing.T) {
+	f, err := NewRegexFilter([]string{`https?://`, ``, `(?i)buy\s+now`})
+	require.NoError(t, err)
+
+	require.False(t, f.Check("a great movie").Flagged)
+
+	v := f.Check("visit http://example.com")
+	require.True(t, v.Flagged)
+	require.Contains(t, v.Reason, "https?://")
+
+	require.True(t, f.Check("BUY   now").Flagged)
+}
+
+// TestInvalidRegexFilter tests that an invalid pattern is rejected
+func TestInvalidRegexFilter(t *testing.T) {
+	f, err := NewRegexFilter([]string{`(`})
+	require.Error(t, err)
+	require.Nil(t, f)
+}
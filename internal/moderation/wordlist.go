@@ -0,0 +1,45 @@
+package moderation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// WordlistFilter is a filter that flags the texts with a blocked word, it implements Filter interface
+type WordlistFilter struct {
+	words map[string]bool
+}
+
+// NewWordlistFilter creates a new WordlistFilter, the words are matched as whole words ignoring case
+func NewWordlistFilter(words []string) Filter {
+	f := WordlistFilter{words: make(map[string]bool)}
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w != "" {
+			f.words[w] = true
+		}
+	}
+
+	return f
+}
+
+// Check flags the text if any of its words is blocked
+func (f WordlistFilter) Check(text string) Verdict {
+	if len(f.words) == 0 {
+		return Verdict{}
+	}
+
+	// we split on everything but letters and digits so punctuation can't hide a word
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, w := range fields {
+		if f.words[w] {
+			return Verdict{Flagged: true, Reason: fmt.Sprintf("blocked word %q", w)}
+		}
+	}
+
+	return Verdict{}
+}
@@ -0,0 +1,39 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestWordlistFilter tests WordlistFilter
+func TestWordlistFilter(t *testing.T) {
+	f := NewWordlistFilter([]string{"Spoiler", " scam ", ""})
+
+	testCases := []struct {
+		name    string
+		text    string
+		flagged bool
+	}{
+		{name: "Clean", text: "a great movie with a great cast", flagged: false},
+		{name: "Blocked Word", text: "huge SPOILER: he dies", flagged: true},
+		{name: "Punctuation", text: "what a scam!", flagged: true},
+		{name: "Part Of Word", text: "scampi was better than this movie", flagged: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v := f.Check(tt.text)
+			require.Equal(t, tt.flagged, v.Flagged)
+			if tt.flagged {
+				require.NotEmpty(t, v.Reason)
+			}
+		})
+	}
+}
+
+// TestEmptyWordlistFilter tests that an empty wordlist never flags a text
+func TestEmptyWordlistFilter(t *testing.T) {
+	f := NewWordlistFilter(nil)
+	require.False(t, f.Check("anything goes").Flagged)
+}
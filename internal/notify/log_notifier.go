@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier is a notifier that writes the notifications to a logger, it implements Notifier interface.
+// It is useful until a real channel like email or push is wired
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a new LogNotifier that writes to given logger
+func NewLogNotifier(logger *log.Logger) Notifier {
+	return LogNotifier{logger: logger}
+}
+
+// Enqueue writes the notification to the logger
+func (n LogNotifier) Enqueue(ctx context.Context, notification Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	n.logger.Printf("notification to %s: %s: %s", notification.Username, notification.Subject, notification.Body)
+	return nil
+}
@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogNotifier tests LogNotifier
+func TestLogNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLogNotifier(log.New(&buf, "", 0))
+
+	err := n.Enqueue(context.Background(), Notification{Username: "john", Subject: "on sale", Body: "tickets are on sale"})
+	require.NoError(t, err)
+	require.Equal(t, "notification to john: on sale: tickets are on sale\n", buf.String())
+
+	// a cancelled context isn't written
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	buf.Reset()
+	err = n.Enqueue(ctx, Notification{Username: "john"})
+	require.Error(t, err)
+	require.Empty(t, buf.String())
+}
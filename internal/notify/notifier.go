@@ -0,0 +1,16 @@
+package notify
+
+import "context"
+
+// Notification holds a message for a user
+type Notification struct {
+	Username string `json:"username"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+}
+
+// Notifier interface will be our notification sender which lets us to implement and switch between channels
+type Notifier interface {
+	// Enqueue queues the notification for the delivery, it doesn't wait for the user to receive it
+	Enqueue(ctx context.Context, n Notification) error
+}
@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+)
+
+var ErrDeclined = errors.New("payment is declined")
+
+// Charge holds a payment to take from a user
+type Charge struct {
+	Username    string
+	Amount      util.Money
+	Description string
+	// IdempotencyKey makes the retries of a charge take the payment once, the charges without it aren't deduplicated
+	IdempotencyKey string
+}
+
+// Refund holds a payment to give back to a user for an earlier charge
+type Refund struct {
+	Username    string
+	Amount      util.Money
+	Description string
+	// Reference is the reference of the charge that is refunded
+	Reference string
+	// IdempotencyKey makes the retries of a refund give it back once, the refunds without it aren't deduplicated
+	IdempotencyKey string
+}
+
+// Receipt holds a completed payment, the reference is given by the gateway
+type Receipt struct {
+	Reference string
+	Amount    util.Money
+}
+
+// Gateway interface will be our payment provider which lets us to implement and switch between providers
+type Gateway interface {
+	// Charge takes the payment from the user, it returns ErrDeclined if the payment is declined
+	Charge(ctx context.Context, c Charge) (Receipt, error)
+	// Refund gives the amount back to the user, it can't be more than the refunded charge
+	Refund(ctx context.Context, r Refund) (Receipt, error)
+}
@@ -0,0 +1,65 @@
+package payment
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+)
+
+// LogGateway is a gateway that approves every payment and writes it to a logger, it implements Gateway interface.
+// It is useful until a real payment provider is wired
+type LogGateway struct {
+	logger *log.Logger
+}
+
+// NewLogGateway creates a new LogGateway that writes to given logger
+func NewLogGateway(logger *log.Logger) Gateway {
+	return LogGateway{logger: logger}
+}
+
+// Charge writes the charge to the logger, the retries of a charge with an idempotency key get the same reference
+func (g LogGateway) Charge(ctx context.Context, c Charge) (Receipt, error) {
+	if err := ctx.Err(); err != nil {
+		return Receipt{}, err
+	}
+
+	reference := "ch_" + c.IdempotencyKey
+	if c.IdempotencyKey == "" {
+		var err error
+		if reference, err = newReference("ch_"); err != nil {
+			return Receipt{}, err
+		}
+	}
+
+	g.logger.Printf("charged %s from %s: %s (%s)", c.Amount, c.Username, c.Description, reference)
+	return Receipt{Reference: reference, Amount: c.Amount}, nil
+}
+
+// Refund writes the refund to the logger, the retries of a refund with an idempotency key get the same reference
+func (g LogGateway) Refund(ctx context.Context, r Refund) (Receipt, error) {
+	if err := ctx.Err(); err != nil {
+		return Receipt{}, err
+	}
+
+	reference := "re_" + r.IdempotencyKey
+	if r.IdempotencyKey == "" {
+		var err error
+		if reference, err = newReference("re_"); err != nil {
+			return Receipt{}, err
+		}
+	}
+
+	g.logger.Printf("refunded %s of %s to %s: %s (%s)", r.Amount, r.Reference, r.Username, r.Description, reference)
+	return Receipt{Reference: reference, Amount: r.Amount}, nil
+}
+
+// newReference creates a random reference with given prefix
+func newReference(prefix string) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return prefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,51 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogGateway tests LogGateway
+func TestLogGateway(t *testing.T) {
+	var buf bytes.Buffer
+	g := NewLogGateway(log.New(&buf, "", 0))
+	amount := util.NewMoney(1999, "USD")
+
+	receipt, err := g.Charge(context.Background(), Charge{Username: "john", Amount: amount, Description: "membership", IdempotencyKey: "m-1"})
+	require.NoError(t, err)
+	require.Equal(t, "ch_m-1", receipt.Reference)
+	require.Equal(t, amount, receipt.Amount)
+	require.Equal(t, "charged 19.99 USD from john: membership (ch_m-1)\n", buf.String())
+
+	// the charges without an idempotency key get a random reference
+	other, err := g.Charge(context.Background(), Charge{Username: "john", Amount: amount})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(other.Reference, "ch_"))
+	require.Len(t, other.Reference, 19)
+
+	buf.Reset()
+	refund, err := g.Refund(context.Background(), Refund{Username: "john", Amount: util.NewMoney(500, "USD"), Reference: receipt.Reference})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(refund.Reference, "re_"))
+	require.Contains(t, buf.String(), "refunded 5.00 USD of ch_m-1 to john")
+
+	// the retries of a refund with an idempotency key get the same reference
+	keyed, err := g.Refund(context.Background(), Refund{Username: "john", Amount: util.NewMoney(500, "USD"), Reference: receipt.Reference, IdempotencyKey: "r-1"})
+	require.NoError(t, err)
+	require.Equal(t, "re_r-1", keyed.Reference)
+
+	// a cancelled context isn't charged
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	buf.Reset()
+	_, err = g.Charge(ctx, Charge{Username: "john", Amount: amount})
+	require.Error(t, err)
+	require.Empty(t, buf.String())
+}
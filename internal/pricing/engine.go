@@ -0,0 +1,197 @@
+package pricing
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+)
+
+// Clock returns the current time, the engine takes it so the prices can be tested at a fixed time
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the clock of the host
+type SystemClock struct{}
+
+// Now returns the current time of the host
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// kindOrder is the order the kinds of rules are explained in the breakdown
+var kindOrder = map[string]int{
+	RuleHoliday:   0,
+	RuleWeekday:   1,
+	RuleMatinee:   2,
+	RuleOccupancy: 3,
+}
+
+// Request holds everything the price of a purchase depends on, so the same request is always priced the same at the same time
+type Request struct {
+	StartsAt time.Time
+	// Location is the time zone of the venue, the rules match the local time of the screening
+	Location *time.Location
+	Adult    int16
+	Child    int16
+	// the prices and the surcharge are net minor units of the currency, the tax is added with TaxRate in basis points
+	Currency   string
+	TaxRate    int32
+	AdultPrice int64
+	ChildPrice int64
+	// FormatName and Surcharge are the format of the screening and its surcharge for every seat
+	FormatName string
+	Surcharge  int64
+	// Seats and Sold are the capacity of the auditorium and the seats already sold for the screening
+	Seats int32
+	Sold  int64
+	Rules []Rule
+}
+
+// Line is a line of the price breakdown, its amount is net of tax
+type Line struct {
+	Description string     `json:"description"`
+	RuleID      int64      `json:"rule_id,omitempty"`
+	Amount      util.Money `json:"amount"`
+}
+
+// Breakdown is the price of a purchase explained line by line. Net is the sum of the lines,
+// the tax is computed on it separately and Total is the sum of both. Discount is what the rules took off the net
+type Breakdown struct {
+	Lines     []Line     `json:"lines"`
+	Surcharge util.Money `json:"surcharge"`
+	Discount  util.Money `json:"discount"`
+	Net       util.Money `json:"net"`
+	TaxRate   int32      `json:"tax_rate"`
+	Tax       util.Money `json:"tax"`
+	Total     util.Money `json:"total"`
+	Occupancy int32      `json:"occupancy"`
+	QuotedAt  time.Time  `json:"quoted_at"`
+}
+
+// Engine prices the seats of the screenings with the pricing rules
+type Engine struct {
+	clock Clock
+}
+
+// NewEngine creates a new pricing engine with given clock
+func NewEngine(clock Clock) *Engine {
+	return &Engine{clock: clock}
+}
+
+// Quote prices the request. The percents of the matching rules are applied to the price of the seats without compounding,
+// a holiday replaces the weekday tiers of its date and the total is never negative
+func (engine *Engine) Quote(req Request) Breakdown {
+	now := engine.clock.Now()
+	local := req.StartsAt.In(req.Location)
+	seats := int64(req.Adult) + int64(req.Child)
+
+	var occupancy int32
+	if req.Seats > 0 {
+		occupancy = int32(req.Sold * 100 / int64(req.Seats))
+	}
+
+	b := Breakdown{
+		Lines:     []Line{},
+		Surcharge: util.NewMoney(0, req.Currency),
+		Discount:  util.NewMoney(0, req.Currency),
+		TaxRate:   req.TaxRate,
+		Occupancy: occupancy,
+		QuotedAt:  now,
+	}
+	adultPrice := util.NewMoney(req.AdultPrice, req.Currency)
+	childPrice := util.NewMoney(req.ChildPrice, req.Currency)
+
+	if req.Adult > 0 {
+		b.Lines = append(b.Lines, Line{
+			Description: fmt.Sprintf("%d adult x %s", req.Adult, adultPrice),
+			Amount:      adultPrice.Times(int64(req.Adult)),
+		})
+	}
+	if req.Child > 0 {
+		b.Lines = append(b.Lines, Line{
+			Description: fmt.Sprintf("%d child x %s", req.Child, childPrice),
+			Amount:      childPrice.Times(int64(req.Child)),
+		})
+	}
+
+	base := util.NewMoney(int64(req.Adult)*req.AdultPrice+int64(req.Child)*req.ChildPrice, req.Currency)
+
+	// the percents of the rules are whole, the money takes them in basis points
+	var discount int64
+	for _, r := range matchingRules(req.Rules, now, local, occupancy) {
+		amount := base.Percent(int64(r.Percent)*100, util.RoundHalfUp)
+		if amount.Amount < 0 {
+			discount -= amount.Amount
+		}
+
+		b.Lines = append(b.Lines, Line{
+			Description: fmt.Sprintf("%s (%s %+d%%)", r.Name, r.Kind, r.Percent),
+			RuleID:      r.ID,
+			Amount:      amount,
+		})
+	}
+
+	if req.Surcharge > 0 && seats > 0 {
+		surcharge := util.NewMoney(req.Surcharge, req.Currency)
+		b.Surcharge = surcharge.Times(seats)
+		b.Lines = append(b.Lines, Line{
+			Description: fmt.Sprintf("%s surcharge %d x %s", req.FormatName, seats, surcharge),
+			Amount:      b.Surcharge,
+		})
+	}
+
+	var net int64
+	for _, l := range b.Lines {
+		net += l.Amount.Amount
+	}
+
+	// the discounts can't make the seats free of charge below zero
+	if net < 0 {
+		b.Lines = append(b.Lines, Line{Description: "total can't be negative", Amount: util.NewMoney(-net, req.Currency)})
+		discount += net
+		net = 0
+	}
+
+	b.Discount = util.NewMoney(discount, req.Currency)
+	b.Net = util.NewMoney(net, req.Currency)
+	b.Tax = b.Net.Tax(req.TaxRate)
+	b.Total = util.NewMoney(net+b.Tax.Amount, req.Currency)
+
+	return b
+}
+
+// matchingRules returns the rules that apply in the order they are explained
+func matchingRules(rules []Rule, now time.Time, local time.Time, occupancy int32) []Rule {
+	matched := make([]Rule, 0, len(rules))
+	holiday := false
+
+	for _, r := range rules {
+		if !r.valid(now) || !r.matches(local, occupancy) {
+			continue
+		}
+		if r.Kind == RuleHoliday {
+			holiday = true
+		}
+		matched = append(matched, r)
+	}
+
+	result := matched[:0]
+	for _, r := range matched {
+		if holiday && r.Kind == RuleWeekday {
+			continue
+		}
+		result = append(result, r)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if kindOrder[result[i].Kind] != kindOrder[result[j].Kind] {
+			return kindOrder[result[i].Kind] < kindOrder[result[j].Kind]
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
@@ -0,0 +1,237 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/burakkarasel/Theatre-API/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClock always returns the same time
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestQuote(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	require.NoError(t, err)
+
+	now := time.Date(2022, 10, 1, 9, 0, 0, 0, time.UTC)
+	engine := NewEngine(fixedClock(now))
+
+	matinee := Rule{ID: 1, Name: "Matinee", Kind: RuleMatinee, Percent: -20, StartsBefore: 15 * 60}
+	tuesday := Rule{ID: 2, Name: "Cheap Tuesday", Kind: RuleWeekday, Percent: -30, Weekdays: []time.Weekday{time.Tuesday}}
+	weekend := Rule{ID: 3, Name: "Weekend", Kind: RuleWeekday, Percent: 10, Weekdays: []time.Weekday{time.Saturday, time.Sunday}}
+	republicDay := Rule{ID: 4, Name: "Republic Day", Kind: RuleHoliday, Percent: 25, Date: time.Date(2022, 10, 29, 0, 0, 0, 0, time.UTC)}
+	surge := Rule{ID: 5, Name: "Almost Full", Kind: RuleOccupancy, Percent: 15, MinOccupancy: 80}
+	rules := []Rule{surge, republicDay, weekend, tuesday, matinee}
+
+	request := func(startsAt time.Time, sold int64) Request {
+		return Request{
+			StartsAt:   startsAt,
+			Location:   istanbul,
+			Adult:      2,
+			Child:      1,
+			Currency:   "TRY",
+			AdultPrice: 1000,
+			ChildPrice: 700,
+			FormatName: "IMAX",
+			Surcharge:  500,
+			Seats:      100,
+			Sold:       sold,
+			Rules:      rules,
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		req            Request
+		ruleIDs        []int64
+		total          int64
+		discount       int64
+		checkBreakdown func(t *testing.T, b Breakdown)
+	}{
+		{
+			// wednesday 20:00 in Istanbul
+			name:    "No Rules",
+			req:     request(time.Date(2022, 10, 5, 17, 0, 0, 0, time.UTC), 10),
+			ruleIDs: []int64{},
+			total:   2700 + 1500,
+		},
+		{
+			// tuesday 13:00 in Istanbul is 10:00 in UTC, both the matinee and the tuesday apply to the seats
+			name:     "Matinee On Tuesday",
+			req:      request(time.Date(2022, 10, 4, 10, 0, 0, 0, time.UTC), 10),
+			ruleIDs:  []int64{2, 1},
+			total:    2700 - 810 - 540 + 1500,
+			discount: 810 + 540,
+		},
+		{
+			// 12:30 in UTC is 15:30 in Istanbul, so it isn't a matinee in the venue
+			name:    "Local Time",
+			req:     request(time.Date(2022, 10, 5, 12, 30, 0, 0, time.UTC), 10),
+			ruleIDs: []int64{},
+			total:   2700 + 1500,
+		},
+		{
+			// the holiday is a saturday, the weekend tier doesn't apply on it
+			name:    "Holiday Replaces Weekday",
+			req:     request(time.Date(2022, 10, 29, 17, 0, 0, 0, time.UTC), 10),
+			ruleIDs: []int64{4},
+			total:   2700 + 675 + 1500,
+		},
+		{
+			name:    "Weekend",
+			req:     request(time.Date(2022, 10, 22, 17, 0, 0, 0, time.UTC), 10),
+			ruleIDs: []int64{3},
+			total:   2700 + 270 + 1500,
+		},
+		{
+			name:    "Occupancy Surge",
+			req:     request(time.Date(2022, 10, 5, 17, 0, 0, 0, time.UTC), 80),
+			ruleIDs: []int64{5},
+			total:   2700 + 405 + 1500,
+			checkBreakdown: func(t *testing.T, b Breakdown) {
+				require.Equal(t, int32(80), b.Occupancy)
+			},
+		},
+		{
+			name:    "Below Surge",
+			req:     request(time.Date(2022, 10, 5, 17, 0, 0, 0, time.UTC), 79),
+			ruleIDs: []int64{},
+			total:   2700 + 1500,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			b := engine.Quote(tc.req)
+
+			require.Equal(t, util.NewMoney(tc.total, "TRY"), b.Total)
+			require.Equal(t, util.NewMoney(1500, "TRY"), b.Surcharge)
+			require.Equal(t, util.NewMoney(tc.discount, "TRY"), b.Discount)
+			require.Equal(t, now, b.QuotedAt)
+
+			ids := []int64{}
+			var sum int64
+			for _, l := range b.Lines {
+				require.Equal(t, "TRY", l.Amount.Currency)
+				sum += l.Amount.Amount
+				if l.RuleID != 0 {
+					ids = append(ids, l.RuleID)
+				}
+			}
+			require.Equal(t, tc.ruleIDs, ids)
+			require.Equal(t, b.Net.Amount, sum)
+			require.Equal(t, b.Total, b.Net)
+
+			if tc.checkBreakdown != nil {
+				tc.checkBreakdown(t, b)
+			}
+		})
+	}
+}
+
+func TestQuoteIsDeterministic(t *testing.T) {
+	now := time.Date(2022, 10, 1, 9, 0, 0, 0, time.UTC)
+	engine := NewEngine(fixedClock(now))
+
+	req := Request{
+		StartsAt:   time.Date(2022, 10, 4, 10, 0, 0, 0, time.UTC),
+		Location:   time.UTC,
+		Adult:      1,
+		AdultPrice: 999,
+		Rules: []Rule{
+			{ID: 2, Name: "B", Kind: RuleMatinee, Percent: -15, StartsBefore: 12 * 60},
+			{ID: 1, Name: "A", Kind: RuleMatinee, Percent: -10, StartsBefore: 12 * 60},
+		},
+	}
+
+	first := engine.Quote(req)
+	require.Equal(t, first, engine.Quote(req))
+
+	// the rules of the same kind are explained by their IDs regardless of their order
+	require.Equal(t, int64(1), first.Lines[1].RuleID)
+	require.Equal(t, int64(-100), first.Lines[1].Amount.Amount)
+	require.Equal(t, int64(2), first.Lines[2].RuleID)
+	require.Equal(t, int64(-150), first.Lines[2].Amount.Amount)
+	require.Equal(t, int64(999-100-150), first.Total.Amount)
+}
+
+func TestQuoteValidity(t *testing.T) {
+	from := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	rule := Rule{ID: 1, Name: "October", Kind: RuleWeekday, Percent: -50, ValidFrom: &from, ValidUntil: &until,
+		Weekdays: []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}}
+
+	req := Request{
+		StartsAt:   time.Date(2022, 12, 4, 18, 0, 0, 0, time.UTC),
+		Location:   time.UTC,
+		Adult:      1,
+		AdultPrice: 1000,
+		Rules:      []Rule{rule},
+	}
+
+	// the rule is evaluated at the time of the purchase, not the time of the screening
+	require.Equal(t, int64(1000), NewEngine(fixedClock(from.Add(-time.Second))).Quote(req).Total.Amount)
+	require.Equal(t, int64(500), NewEngine(fixedClock(from)).Quote(req).Total.Amount)
+	require.Equal(t, int64(500), NewEngine(fixedClock(until.Add(-time.Second))).Quote(req).Total.Amount)
+	require.Equal(t, int64(1000), NewEngine(fixedClock(until)).Quote(req).Total.Amount)
+}
+
+func TestQuoteNeverNegative(t *testing.T) {
+	engine := NewEngine(fixedClock(time.Date(2022, 10, 1, 9, 0, 0, 0, time.UTC)))
+
+	req := Request{
+		StartsAt:   time.Date(2022, 10, 4, 10, 0, 0, 0, time.UTC),
+		Location:   time.UTC,
+		Child:      2,
+		ChildPrice: 700,
+		Rules: []Rule{
+			{ID: 1, Name: "Matinee", Kind: RuleMatinee, Percent: -80, StartsBefore: 12 * 60},
+			{ID: 2, Name: "Tuesday", Kind: RuleWeekday, Percent: -50, Weekdays: []time.Weekday{time.Tuesday}},
+		},
+	}
+
+	b := engine.Quote(req)
+	require.True(t, b.Total.IsZero())
+	// the discount is capped at the price of the seats
+	require.Equal(t, int64(1400), b.Discount.Amount)
+
+	var sum int64
+	for _, l := range b.Lines {
+		sum += l.Amount.Amount
+	}
+	require.Zero(t, sum)
+}
+
+func TestQuoteTax(t *testing.T) {
+	engine := NewEngine(fixedClock(time.Date(2022, 10, 1, 9, 0, 0, 0, time.UTC)))
+
+	req := Request{
+		StartsAt:   time.Date(2022, 10, 5, 18, 0, 0, 0, time.UTC),
+		Location:   time.UTC,
+		Currency:   "EUR",
+		TaxRate:    700,
+		Adult:      1,
+		Child:      1,
+		AdultPrice: 1250,
+		ChildPrice: 825,
+		Rules:      []Rule{{ID: 1, Name: "Launch", Kind: RuleWeekday, Percent: -10, Weekdays: []time.Weekday{time.Wednesday}}},
+	}
+
+	b := engine.Quote(req)
+
+	// the lines are net, the tax is computed once on their sum: 2075 - 208 = 1867 and 7% of it is 130.69
+	require.Equal(t, util.NewMoney(1867, "EUR"), b.Net)
+	require.Equal(t, util.NewMoney(131, "EUR"), b.Tax)
+	require.Equal(t, util.NewMoney(1998, "EUR"), b.Total)
+	require.Equal(t, util.NewMoney(208, "EUR"), b.Discount)
+	require.Equal(t, int32(700), b.TaxRate)
+	require.Equal(t, "1 adult x 12.50 EUR", b.Lines[0].Description)
+}
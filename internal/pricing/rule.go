@@ -0,0 +1,68 @@
+package pricing
+
+import "time"
+
+// kinds of the pricing rules
+const (
+	// RuleMatinee adjusts the screenings that start before a time of the day
+	RuleMatinee = "matinee"
+	// RuleWeekday adjusts the screenings on some days of the week, like a cheaper tuesday
+	RuleWeekday = "weekday"
+	// RuleHoliday adjusts the screenings on a date, the weekday tiers don't apply on that date
+	RuleHoliday = "holiday"
+	// RuleOccupancy surges the price when the screening is filling up
+	RuleOccupancy = "occupancy"
+)
+
+// Rule adjusts the price of the seats by a percent, it is a discount when the percent is negative.
+// Only the fields of its kind are used to match a screening
+type Rule struct {
+	ID      int64
+	Name    string
+	Kind    string
+	Percent int32
+	// StartsBefore is in minutes after the midnight of the venue, for matinee rules
+	StartsBefore int32
+	// Weekdays are the days of the week of the weekday rules, sunday is 0
+	Weekdays []time.Weekday
+	// Date is the day of a holiday rule in the venue, only its year, month and day are used
+	Date time.Time
+	// MinOccupancy is the percent of the sold seats that triggers an occupancy rule
+	MinOccupancy int32
+	// the rule is evaluated only between ValidFrom and ValidUntil if they are given
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+}
+
+// valid reports whether the rule is in effect at the given time
+func (r Rule) valid(now time.Time) bool {
+	if r.ValidFrom != nil && now.Before(*r.ValidFrom) {
+		return false
+	}
+	if r.ValidUntil != nil && !now.Before(*r.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+// matches reports whether the rule applies to a screening that starts at the given local time with the given occupancy
+func (r Rule) matches(local time.Time, occupancy int32) bool {
+	switch r.Kind {
+	case RuleMatinee:
+		return int32(local.Hour()*60+local.Minute()) < r.StartsBefore
+	case RuleWeekday:
+		for _, d := range r.Weekdays {
+			if d == local.Weekday() {
+				return true
+			}
+		}
+		return false
+	case RuleHoliday:
+		y, m, d := r.Date.Date()
+		ly, lm, ld := local.Date()
+		return y == ly && m == lm && d == ld
+	case RuleOccupancy:
+		return occupancy >= r.MinOccupancy
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows a number of requests for every key in a fixed window, like the password resets of an email.
+// The counts are kept in memory so every instance of the app limits its own requests
+type Limiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	counts    map[string]*count
+	lastSweep time.Time
+	// now is the clock of the limiter, the tests replace it
+	now func() time.Time
+}
+
+// count holds the requests of a key in the window that starts at start
+type count struct {
+	start    time.Time
+	requests int
+}
+
+// NewLimiter creates a new limiter that allows limit requests for every key in every window
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:  limit,
+		window: window,
+		counts: make(map[string]*count),
+		now:    time.Now,
+	}
+}
+
+// Allow counts a request of the key and reports whether it is within the limit,
+// the requests that are over the limit are not counted
+func (l *Limiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	c, ok := l.counts[key]
+	if !ok || !now.Before(c.start.Add(l.window)) {
+		c = &count{start: now}
+		l.counts[key] = c
+	}
+
+	if c.requests >= l.limit {
+		return false
+	}
+
+	c.requests++
+	return true
+}
+
+// sweep drops the counts of the ended windows once in a window, so the keys that aren't seen again are not kept
+func (l *Limiter) sweep(now time.Time) {
+	if now.Before(l.lastSweep.Add(l.window)) {
+		return
+	}
+
+	for key, c := range l.counts {
+		if !now.Before(c.start.Add(l.window)) {
+			delete(l.counts, key)
+		}
+	}
+	l.lastSweep = now
+}
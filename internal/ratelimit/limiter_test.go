@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestLimiter tests that the requests of a key are limited in its window
+func TestLimiter(t *testing.T) {
+	now := time.Date(2022, 5, 20, 20, 0, 0, 0, time.UTC)
+
+	l := NewLimiter(2, time.Hour)
+	l.now = func() time.Time { return now }
+
+	require.True(t, l.Allow("john@example.com"))
+	require.True(t, l.Allow("john@example.com"))
+	require.False(t, l.Allow("john@example.com"))
+
+	// the other keys have their own counts
+	require.True(t, l.Allow("jane@example.com"))
+
+	// a new window starts when the window of the key ends
+	now = now.Add(time.Hour)
+	require.True(t, l.Allow("john@example.com"))
+	require.True(t, l.Allow("john@example.com"))
+	require.False(t, l.Allow("john@example.com"))
+}
+
+// TestLimiterSweep tests that the counts of the ended windows are dropped
+func TestLimiterSweep(t *testing.T) {
+	now := time.Date(2022, 5, 20, 20, 0, 0, 0, time.UTC)
+
+	l := NewLimiter(1, time.Minute)
+	l.now = func() time.Time { return now }
+
+	require.True(t, l.Allow("10.0.0.1"))
+	require.True(t, l.Allow("10.0.0.2"))
+	require.Len(t, l.counts, 2)
+
+	now = now.Add(2 * time.Minute)
+	require.True(t, l.Allow("10.0.0.3"))
+	require.Len(t, l.counts, 1)
+}
@@ -0,0 +1,119 @@
+package recommend
+
+import (
+	"context"
+
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+)
+
+// PrecomputeLimit is the count of the recommendations stored for a user by the precompute
+const PrecomputeLimit = 50
+
+// Engine computes the recommendations of the users in-process from the data in the DB
+type Engine struct {
+	store db.Store
+}
+
+// NewEngine creates a new recommendation engine with given store
+func NewEngine(store db.Store) *Engine {
+	return &Engine{store: store}
+}
+
+// Compute returns at most limit now-showing movies for the user, best first
+func (engine *Engine) Compute(ctx context.Context, username string, limit int) ([]Recommendation, error) {
+	movies, err := engine.store.ListNowShowingMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(movies) == 0 {
+		return []Recommendation{}, nil
+	}
+
+	rows, err := engine.store.ListUserPurchases(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// then i get the genres of the bought and the now-showing movies at once
+	ids := make([]int64, 0, len(rows)+len(movies))
+	seen := make(map[int64]bool)
+	for _, r := range rows {
+		ids = append(ids, r.MovieID)
+		seen[r.MovieID] = true
+	}
+	for _, m := range movies {
+		if !seen[m.ID] {
+			ids = append(ids, m.ID)
+		}
+	}
+
+	movieGenres, err := engine.store.ListGenresOfMovies(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	genres := make(map[int64][]int64)
+	for _, mg := range movieGenres {
+		genres[mg.MovieID] = append(genres[mg.MovieID], mg.GenreID)
+	}
+
+	// a user without tickets has no co-purchases
+	coPurchases := make(map[int64]int64)
+	if len(rows) > 0 {
+		co, err := engine.store.ListCoPurchases(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range co {
+			coPurchases[c.MovieID] = c.Buyers
+		}
+	}
+
+	purchases := make([]Purchase, 0, len(rows))
+	for _, r := range rows {
+		purchases = append(purchases, Purchase{
+			MovieID:    r.MovieID,
+			DirectorID: r.DirectorID,
+			GenreIDs:   genres[r.MovieID],
+			Tickets:    r.Tickets,
+		})
+	}
+
+	candidates := make([]Candidate, 0, len(movies))
+	for _, m := range movies {
+		candidates = append(candidates, Candidate{
+			MovieID:    m.ID,
+			DirectorID: m.DirectorID,
+			GenreIDs:   genres[m.ID],
+			Rating:     m.Rating,
+		})
+	}
+
+	return Rank(purchases, candidates, coPurchases, limit), nil
+}
+
+// Precompute computes the recommendations of the user and replaces the stored ones
+func (engine *Engine) Precompute(ctx context.Context, username string) error {
+	recommendations, err := engine.Compute(ctx, username, PrecomputeLimit)
+	if err != nil {
+		return err
+	}
+
+	arg := db.ReplaceUserRecommendationsTxParams{
+		Username:        username,
+		Recommendations: make([]db.CreateUserRecommendationParams, 0, len(recommendations)),
+	}
+
+	for _, r := range recommendations {
+		arg.Recommendations = append(arg.Recommendations, db.CreateUserRecommendationParams{
+			Username: username,
+			MovieID:  r.MovieID,
+			Score:    r.Score,
+			Reasons:  r.Reasons,
+		})
+	}
+
+	return engine.store.ReplaceUserRecommendationsTx(ctx, arg)
+}
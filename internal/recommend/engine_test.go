@@ -0,0 +1,119 @@
+package recommend
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/burakkarasel/Theatre-API/internal/db/mock"
+	db "github.com/burakkarasel/Theatre-API/internal/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEngineCompute tests Compute of the engine
+func TestEngineCompute(t *testing.T) {
+	username := "john"
+	movies := []db.Movie{
+		{ID: 1, DirectorID: 1, Rating: 5},
+		{ID: 2, DirectorID: 2, Rating: 7},
+	}
+	purchases := []db.ListUserPurchasesRow{{MovieID: 3, DirectorID: 1, Tickets: 2}}
+	genres := []db.MovieGenre{{MovieID: 2, GenreID: 10}, {MovieID: 3, GenreID: 10}}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, got []Recommendation, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(1).Return(movies, nil)
+				store.EXPECT().ListUserPurchases(gomock.Any(), gomock.Eq(username)).Times(1).Return(purchases, nil)
+				store.EXPECT().ListGenresOfMovies(gomock.Any(), gomock.Eq([]int64{3, 1, 2})).Times(1).Return(genres, nil)
+				store.EXPECT().ListCoPurchases(gomock.Any(), gomock.Eq(username)).Times(1).Return([]db.ListCoPurchasesRow{{MovieID: 2, Buyers: 4}}, nil)
+			},
+			checkResponse: func(t *testing.T, got []Recommendation, err error) {
+				require.NoError(t, err)
+				require.Len(t, got, 2)
+
+				// the same genre and the co-purchases beat the same director
+				require.Equal(t, int64(2), got[0].MovieID)
+				require.Equal(t, []string{ReasonGenre, ReasonCoPurchase}, got[0].Reasons)
+				require.Equal(t, int64(1), got[1].MovieID)
+				require.Equal(t, []string{ReasonDirector}, got[1].Reasons)
+			},
+		},
+		{
+			name: "Without History",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(1).Return(movies, nil)
+				store.EXPECT().ListUserPurchases(gomock.Any(), gomock.Eq(username)).Times(1).Return([]db.ListUserPurchasesRow{}, nil)
+				store.EXPECT().ListGenresOfMovies(gomock.Any(), gomock.Eq([]int64{1, 2})).Times(1).Return(genres[:1], nil)
+				store.EXPECT().ListCoPurchases(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, got []Recommendation, err error) {
+				require.NoError(t, err)
+				require.Len(t, got, 2)
+				require.Equal(t, int64(2), got[0].MovieID)
+			},
+		},
+		{
+			name: "Nothing Showing",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(1).Return([]db.Movie{}, nil)
+				store.EXPECT().ListUserPurchases(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, got []Recommendation, err error) {
+				require.NoError(t, err)
+				require.Empty(t, got)
+			},
+		},
+		{
+			name: "Store Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(1).Return(movies, nil)
+				store.EXPECT().ListUserPurchases(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListUserPurchasesRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, got []Recommendation, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tt.buildStubs(store)
+
+			got, err := NewEngine(store).Compute(context.Background(), username, 10)
+			tt.checkResponse(t, got, err)
+		})
+	}
+}
+
+// TestEnginePrecompute tests that Precompute replaces the stored recommendations of the user
+func TestEnginePrecompute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListNowShowingMovies(gomock.Any()).Times(1).Return([]db.Movie{{ID: 1, Rating: 5}}, nil)
+	store.EXPECT().ListUserPurchases(gomock.Any(), gomock.Eq("john")).Times(1).Return([]db.ListUserPurchasesRow{}, nil)
+	store.EXPECT().ListGenresOfMovies(gomock.Any(), gomock.Any()).Times(1).Return([]db.MovieGenre{}, nil)
+
+	arg := db.ReplaceUserRecommendationsTxParams{
+		Username: "john",
+		Recommendations: []db.CreateUserRecommendationParams{
+			{Username: "john", MovieID: 1, Reasons: []string{ReasonTopRated}},
+		},
+	}
+	store.EXPECT().ReplaceUserRecommendationsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil)
+
+	err := NewEngine(store).Precompute(context.Background(), "john")
+	require.NoError(t, err)
+}
@@ -0,0 +1,147 @@
+package recommend
+
+import "sort"
+
+// weights of the signals in the score, they add up to 1 so a score is between 0 and 1
+const (
+	directorWeight   = 0.4
+	genreWeight      = 0.35
+	coPurchaseWeight = 0.25
+)
+
+// reasons of a recommendation
+const (
+	ReasonDirector   = "director"
+	ReasonGenre      = "genre"
+	ReasonCoPurchase = "co_purchase"
+	// ReasonTopRated is given when there is no signal, e.g. for a user without any tickets
+	ReasonTopRated = "top_rated"
+)
+
+// Purchase holds a movie the user bought tickets for
+type Purchase struct {
+	MovieID    int64
+	DirectorID int64
+	GenreIDs   []int64
+	Tickets    int64
+}
+
+// Candidate holds a now-showing movie that can be recommended
+type Candidate struct {
+	MovieID    int64
+	DirectorID int64
+	GenreIDs   []int64
+	Rating     int16
+}
+
+// Recommendation holds a recommended movie with its score and the reasons of the score
+type Recommendation struct {
+	MovieID int64    `json:"movie_id"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// Rank scores the candidates by the director and genre affinity of the purchases and by the count of the other
+// buyers of the same movies who bought them, it skips the bought movies and returns at most limit recommendations
+func Rank(purchases []Purchase, candidates []Candidate, coPurchases map[int64]int64, limit int) []Recommendation {
+	// first i find the share of the tickets of every director and genre in the history
+	var total int64
+	bought := make(map[int64]bool)
+	for _, p := range purchases {
+		total += p.Tickets
+		bought[p.MovieID] = true
+	}
+
+	directors := make(map[int64]float64)
+	genres := make(map[int64]float64)
+	for _, p := range purchases {
+		share := float64(p.Tickets) / float64(total)
+		directors[p.DirectorID] += share
+		for _, g := range p.GenreIDs {
+			genres[g] += share
+		}
+	}
+
+	var maxBuyers int64
+	for _, c := range candidates {
+		if coPurchases[c.MovieID] > maxBuyers {
+			maxBuyers = coPurchases[c.MovieID]
+		}
+	}
+
+	type scored struct {
+		Recommendation
+		rating int16
+	}
+
+	result := make([]scored, 0, len(candidates))
+	for _, c := range candidates {
+		if bought[c.MovieID] {
+			continue
+		}
+
+		r := scored{Recommendation: Recommendation{MovieID: c.MovieID, Reasons: []string{}}, rating: c.Rating}
+
+		if d := directors[c.DirectorID]; d > 0 {
+			r.Score += directorWeight * d
+			r.Reasons = append(r.Reasons, ReasonDirector)
+		}
+
+		if g := genreAffinity(genres, c.GenreIDs); g > 0 {
+			r.Score += genreWeight * g
+			r.Reasons = append(r.Reasons, ReasonGenre)
+		}
+
+		if b := coPurchases[c.MovieID]; b > 0 {
+			r.Score += coPurchaseWeight * float64(b) / float64(maxBuyers)
+			r.Reasons = append(r.Reasons, ReasonCoPurchase)
+		}
+
+		if len(r.Reasons) == 0 {
+			r.Reasons = append(r.Reasons, ReasonTopRated)
+		}
+
+		result = append(result, r)
+	}
+
+	// the rating breaks the ties, so the users without history get the top rated movies
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if result[i].rating != result[j].rating {
+			return result[i].rating > result[j].rating
+		}
+		return result[i].MovieID < result[j].MovieID
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	recommendations := make([]Recommendation, 0, len(result))
+	for _, r := range result {
+		recommendations = append(recommendations, r.Recommendation)
+	}
+
+	return recommendations
+}
+
+// genreAffinity is the mean affinity of the user to the genres of a movie
+func genreAffinity(affinity map[int64]float64, genreIDs []int64) float64 {
+	if len(genreIDs) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, g := range genreIDs {
+		// a movie can have many genres, so the share of a genre can't be more than 1
+		if a := affinity[g]; a < 1 {
+			sum += a
+		} else {
+			sum++
+		}
+	}
+
+	return sum / float64(len(genreIDs))
+}
@@ -0,0 +1,76 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRank tests the scores and the order of Rank
+func TestRank(t *testing.T) {
+	// the user bought 3 tickets for a drama of director 1 and 1 ticket for a comedy of director 2
+	purchases := []Purchase{
+		{MovieID: 1, DirectorID: 1, GenreIDs: []int64{10}, Tickets: 3},
+		{MovieID: 2, DirectorID: 2, GenreIDs: []int64{20}, Tickets: 1},
+	}
+
+	candidates := []Candidate{
+		{MovieID: 1, DirectorID: 1, GenreIDs: []int64{10}, Rating: 9},
+		{MovieID: 3, DirectorID: 1, GenreIDs: []int64{30}, Rating: 5},
+		{MovieID: 4, DirectorID: 3, GenreIDs: []int64{10}, Rating: 5},
+		{MovieID: 5, DirectorID: 3, GenreIDs: []int64{30}, Rating: 5},
+		{MovieID: 6, DirectorID: 3, GenreIDs: []int64{30}, Rating: 8},
+		{MovieID: 7, DirectorID: 3, GenreIDs: nil, Rating: 2},
+	}
+
+	coPurchases := map[int64]int64{5: 2, 7: 1}
+
+	got := Rank(purchases, candidates, coPurchases, 10)
+
+	// the bought movie isn't recommended
+	require.Len(t, got, 5)
+
+	require.Equal(t, int64(3), got[0].MovieID)
+	require.InDelta(t, directorWeight*0.75, got[0].Score, 1e-9)
+	require.Equal(t, []string{ReasonDirector}, got[0].Reasons)
+
+	require.Equal(t, int64(4), got[1].MovieID)
+	require.InDelta(t, genreWeight*0.75, got[1].Score, 1e-9)
+	require.Equal(t, []string{ReasonGenre}, got[1].Reasons)
+
+	require.Equal(t, int64(5), got[2].MovieID)
+	require.InDelta(t, coPurchaseWeight, got[2].Score, 1e-9)
+	require.Equal(t, []string{ReasonCoPurchase}, got[2].Reasons)
+
+	require.Equal(t, int64(7), got[3].MovieID)
+	require.InDelta(t, coPurchaseWeight/2, got[3].Score, 1e-9)
+
+	require.Equal(t, int64(6), got[4].MovieID)
+	require.Zero(t, got[4].Score)
+	require.Equal(t, []string{ReasonTopRated}, got[4].Reasons)
+
+	// the limit is applied after the order
+	got = Rank(purchases, candidates, coPurchases, 2)
+	require.Len(t, got, 2)
+	require.Equal(t, int64(3), got[0].MovieID)
+}
+
+// TestRankWithoutHistory tests that a user without tickets gets the top rated movies
+func TestRankWithoutHistory(t *testing.T) {
+	candidates := []Candidate{
+		{MovieID: 1, Rating: 5},
+		{MovieID: 2, Rating: 9},
+		{MovieID: 3, Rating: 9},
+	}
+
+	got := Rank(nil, candidates, nil, 10)
+	require.Len(t, got, 3)
+	require.Equal(t, int64(2), got[0].MovieID)
+	require.Equal(t, int64(3), got[1].MovieID)
+	require.Equal(t, int64(1), got[2].MovieID)
+
+	for _, r := range got {
+		require.Zero(t, r.Score)
+		require.Equal(t, []string{ReasonTopRated}, r.Reasons)
+	}
+}
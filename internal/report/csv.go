@@ -0,0 +1,36 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVWriter writes a report as CSV
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter creates a new CSV writer that writes to w
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// WriteRow writes a CSV record, the records are buffered until they are flushed by Close
+func (writer *CSVWriter) WriteRow(cells ...interface{}) error {
+	record := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		s, err := formatCell(cell)
+		if err != nil {
+			return err
+		}
+		record = append(record, s)
+	}
+
+	return writer.w.Write(record)
+}
+
+// Close flushes the buffered records
+func (writer *CSVWriter) Close() error {
+	writer.w.Flush()
+	return writer.w.Error()
+}
@@ -0,0 +1,29 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCSVWriter tests CSVWriter
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+
+	require.NoError(t, w.WriteRow("title", "gross", "net"))
+	require.NoError(t, w.WriteRow("Heat, Director's Cut", int64(1200), Decimal("12.50")))
+	require.NoError(t, w.Close())
+
+	require.Equal(t, "title,gross,net\n\"Heat, Director's Cut\",1200,12.50\n", buf.String())
+}
+
+// TestCSVWriterUnsupportedCell tests that the cells other than strings and int64s are rejected
+func TestCSVWriterUnsupportedCell(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+
+	err := w.WriteRow("title", 1.5)
+	require.ErrorIs(t, err, ErrUnsupportedCell)
+}
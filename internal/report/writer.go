@@ -0,0 +1,52 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnsupportedCell = errors.New("unsupported report cell")
+var ErrInvalidDecimal = errors.New("invalid decimal report cell")
+
+// Decimal is a number cell in its decimal text, like the 12.50 of an amount of money.
+// It is kept as text so the amounts aren't rounded by a float, the spreadsheets still read it as a number
+type Decimal string
+
+// Writer writes a report table row by row, so the report is streamed instead of built in memory
+type Writer interface {
+	// WriteRow writes a row, the cells are strings, int64s or Decimals
+	WriteRow(cells ...interface{}) error
+	// Close flushes the rows and finishes the report
+	Close() error
+}
+
+// formatCell returns the text of a cell
+func formatCell(cell interface{}) (string, error) {
+	switch v := cell.(type) {
+	case string:
+		return v, nil
+	case int64:
+		return fmt.Sprint(v), nil
+	case Decimal:
+		// the decimal is written as is, so i make sure it is a plain number
+		if !isDecimal(string(v)) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidDecimal, string(v))
+		}
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedCell, cell)
+	}
+}
+
+// isDecimal reports whether s is a decimal number like -12.50, without an exponent or a sign of plus
+func isDecimal(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	whole, fraction, found := strings.Cut(s, ".")
+
+	if whole == "" || (found && fraction == "") {
+		return false
+	}
+
+	return strings.Trim(whole+fraction, "0123456789") == ""
+}
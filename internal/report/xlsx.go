@@ -0,0 +1,133 @@
+package report
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// the parts of the workbook, the sheet is the only part that grows with the rows
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// XLSXWriter writes a report as a single sheet XLSX workbook, the strings are written inline
+// so the rows don't have to be kept for a shared strings table
+type XLSXWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+// NewXLSXWriter creates a new XLSX writer that writes to w, the sheet gets given name
+func NewXLSXWriter(w io.Writer, sheetName string) (*XLSXWriter, error) {
+	zw := zip.NewWriter(w)
+
+	var name strings.Builder
+	if err := xml.EscapeText(&name, []byte(sheetName)); err != nil {
+		return nil, err
+	}
+
+	// first i write the fixed parts, the sheet has to be the last part since it is written row by row
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, name.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := bufio.NewWriter(f)
+	if _, err := sheet.WriteString(xlsxSheetStart); err != nil {
+		return nil, err
+	}
+
+	return &XLSXWriter{zw: zw, sheet: sheet}, nil
+}
+
+// WriteRow writes a row to the sheet, the int64s and the Decimals are written as numbers
+func (writer *XLSXWriter) WriteRow(cells ...interface{}) error {
+	writer.rows++
+	fmt.Fprintf(writer.sheet, `<row r="%d">`, writer.rows)
+
+	for i, cell := range cells {
+		ref := columnName(i) + strconv.Itoa(writer.rows)
+
+		switch v := cell.(type) {
+		case int64:
+			fmt.Fprintf(writer.sheet, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case Decimal:
+			number, err := formatCell(v)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(writer.sheet, `<c r="%s"><v>%s</v></c>`, ref, number)
+		case string:
+			fmt.Fprintf(writer.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(writer.sheet, []byte(v)); err != nil {
+				return err
+			}
+			writer.sheet.WriteString(`</t></is></c>`)
+		default:
+			_, err := formatCell(cell)
+			return err
+		}
+	}
+
+	_, err := writer.sheet.WriteString(`</row>`)
+	return err
+}
+
+// Close finishes the sheet and the workbook
+func (writer *XLSXWriter) Close() error {
+	if _, err := writer.sheet.WriteString(xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	if err := writer.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return writer.zw.Close()
+}
+
+// columnName returns the letters of the column at given zero based index, A to Z and then AA
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
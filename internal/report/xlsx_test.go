@@ -0,0 +1,66 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestXLSXWriter tests XLSXWriter
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewXLSXWriter(&buf, "Sales & Tax")
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteRow("title", "gross", "net"))
+	require.NoError(t, w.WriteRow("<Heat>", int64(1200), Decimal("-12.50")))
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+
+		parts[f.Name] = string(data)
+	}
+
+	require.Len(t, parts, 5)
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts["xl/workbook.xml"], `name="Sales &amp; Tax"`)
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	require.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">title</t></is></c>`)
+	require.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">&lt;Heat&gt;</t></is></c><c r="B2"><v>1200</v></c><c r="C2"><v>-12.50</v></c></row>`)
+	require.Contains(t, sheet, `</sheetData></worksheet>`)
+}
+
+// TestXLSXWriterInvalidDecimal tests that a decimal that isn't a plain number isn't written into the sheet
+func TestXLSXWriterInvalidDecimal(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewXLSXWriter(&buf, "Sales")
+	require.NoError(t, err)
+
+	for _, d := range []Decimal{"", "12.", ".5", "1e5", "NaN", "</v>"} {
+		err := w.WriteRow(d)
+		require.ErrorIs(t, err, ErrInvalidDecimal)
+	}
+}
+
+// TestColumnName tests columnName
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", columnName(0))
+	require.Equal(t, "Z", columnName(25))
+	require.Equal(t, "AA", columnName(26))
+	require.Equal(t, "AZ", columnName(51))
+	require.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,20 @@
+package schedule
+
+import "time"
+
+// Weekly returns the start and its repetitions for the given count of weeks, the first of them is the start itself.
+// The weeks are added on the wall clock of loc, so a screening at 20:00 stays at 20:00 when the clocks change
+func Weekly(startsAt time.Time, loc *time.Location, weeks int) []time.Time {
+	if weeks < 1 {
+		return []time.Time{}
+	}
+
+	local := startsAt.In(loc)
+
+	starts := make([]time.Time, 0, weeks)
+	for i := 0; i < weeks; i++ {
+		starts = append(starts, local.AddDate(0, 0, 7*i).In(startsAt.Location()))
+	}
+
+	return starts
+}
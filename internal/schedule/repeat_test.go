@@ -0,0 +1,42 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeekly(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Istanbul")
+	require.NoError(t, err)
+
+	startsAt := time.Date(2022, 5, 20, 17, 0, 0, 0, time.UTC)
+
+	starts := Weekly(startsAt, loc, 3)
+	require.Equal(t, []time.Time{
+		startsAt,
+		time.Date(2022, 5, 27, 17, 0, 0, 0, time.UTC),
+		time.Date(2022, 6, 3, 17, 0, 0, 0, time.UTC),
+	}, starts)
+
+	require.Empty(t, Weekly(startsAt, loc, 0))
+}
+
+func TestWeeklyKeepsWallClock(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// the clocks go forward on 27 March 2022 in Berlin
+	startsAt := time.Date(2022, 3, 20, 20, 0, 0, 0, loc)
+
+	starts := Weekly(startsAt.UTC(), loc, 2)
+	require.Len(t, starts, 2)
+
+	require.Equal(t, time.Date(2022, 3, 20, 19, 0, 0, 0, time.UTC), starts[0])
+	require.Equal(t, time.Date(2022, 3, 27, 18, 0, 0, 0, time.UTC), starts[1])
+
+	for _, s := range starts {
+		require.Equal(t, 20, s.In(loc).Hour())
+	}
+}
@@ -0,0 +1,27 @@
+package schedule
+
+import "time"
+
+// Slot is the time a screening holds its auditorium, the trailers run from the start and the movie ends at EndsAt.
+// The auditorium is cleaned until BlockedUntil, so no other screening can start before it
+type Slot struct {
+	StartsAt     time.Time
+	EndsAt       time.Time
+	BlockedUntil time.Time
+}
+
+// NewSlot computes the slot of a screening from its start and the minutes of its trailers, movie and cleaning
+func NewSlot(startsAt time.Time, trailerMinutes, runtime, cleaningMinutes int32) Slot {
+	endsAt := startsAt.Add(time.Duration(trailerMinutes+runtime) * time.Minute)
+
+	return Slot{
+		StartsAt:     startsAt,
+		EndsAt:       endsAt,
+		BlockedUntil: endsAt.Add(time.Duration(cleaningMinutes) * time.Minute),
+	}
+}
+
+// Overlaps reports whether two slots hold the auditorium at the same time, the slots touching each other don't overlap
+func (s Slot) Overlaps(other Slot) bool {
+	return s.StartsAt.Before(other.BlockedUntil) && other.StartsAt.Before(s.BlockedUntil)
+}
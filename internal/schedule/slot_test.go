@@ -0,0 +1,70 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSlot(t *testing.T) {
+	startsAt := time.Date(2022, 5, 20, 20, 0, 0, 0, time.UTC)
+
+	s := NewSlot(startsAt, 15, 120, 20)
+
+	require.Equal(t, startsAt, s.StartsAt)
+	require.Equal(t, time.Date(2022, 5, 20, 22, 15, 0, 0, time.UTC), s.EndsAt)
+	require.Equal(t, time.Date(2022, 5, 20, 22, 35, 0, 0, time.UTC), s.BlockedUntil)
+}
+
+func TestSlotOverlaps(t *testing.T) {
+	base := NewSlot(time.Date(2022, 5, 20, 20, 0, 0, 0, time.UTC), 15, 120, 20)
+
+	testCases := []struct {
+		name     string
+		startsAt time.Time
+		overlaps bool
+	}{
+		{
+			name:     "Before",
+			startsAt: time.Date(2022, 5, 20, 16, 0, 0, 0, time.UTC),
+			overlaps: false,
+		},
+		{
+			name:     "CleaningOverlapsStart",
+			startsAt: time.Date(2022, 5, 20, 17, 30, 0, 0, time.UTC),
+			overlaps: true,
+		},
+		{
+			name:     "TouchesStart",
+			startsAt: time.Date(2022, 5, 20, 17, 25, 0, 0, time.UTC),
+			overlaps: false,
+		},
+		{
+			name:     "StartsInTrailers",
+			startsAt: time.Date(2022, 5, 20, 20, 10, 0, 0, time.UTC),
+			overlaps: true,
+		},
+		{
+			name:     "StartsInCleaning",
+			startsAt: time.Date(2022, 5, 20, 22, 30, 0, 0, time.UTC),
+			overlaps: true,
+		},
+		{
+			name:     "StartsAfterCleaning",
+			startsAt: time.Date(2022, 5, 20, 22, 35, 0, 0, time.UTC),
+			overlaps: false,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			other := NewSlot(tc.startsAt, 15, 120, 20)
+
+			require.Equal(t, tc.overlaps, base.Overlaps(other))
+			require.Equal(t, tc.overlaps, other.Overlaps(base))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// ticketCodeAlphabet leaves out characters that are easy to misread on a printed ticket
+const ticketCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const ticketCodeLength = 10
+
+// NewTicketCode generates a random ticket code which is printed on box office tickets
+func NewTicketCode() (string, error) {
+	var sb strings.Builder
+	k := big.NewInt(int64(len(ticketCodeAlphabet)))
+
+	for i := 0; i < ticketCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, k)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(ticketCodeAlphabet[n.Int64()])
+	}
+
+	return sb.String(), nil
+}
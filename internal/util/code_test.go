@@ -0,0 +1,23 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewTicketCode tests NewTicketCode
+func TestNewTicketCode(t *testing.T) {
+	code1, err := NewTicketCode()
+	require.NoError(t, err)
+	require.Len(t, code1, ticketCodeLength)
+
+	for _, c := range code1 {
+		require.True(t, strings.ContainsRune(ticketCodeAlphabet, c))
+	}
+
+	code2, err := NewTicketCode()
+	require.NoError(t, err)
+	require.NotEqual(t, code1, code2)
+}
@@ -13,6 +13,34 @@ type Config struct {
 	DBSource            string        `mapstructure:"DB_SOURCE"`
 	TokenSymmetricKey   string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	// the cursors of the lists are signed with the cursor key, it is derived from the token key if it isn't set
+	CursorKey string `mapstructure:"CURSOR_KEY"`
+	// the automatic review filter flags the words and patterns, the lists are comma separated
+	ModerationWordlist []string `mapstructure:"MODERATION_WORDLIST"`
+	ModerationPatterns []string `mapstructure:"MODERATION_PATTERNS"`
+	// the watchlist job doesn't run if its interval is zero
+	WatchlistJobInterval time.Duration `mapstructure:"WATCHLIST_JOB_INTERVAL"`
+	// the recommendations are computed per request unless they are precomputed nightly, at the given time after midnight
+	RecommendationPrecompute   bool          `mapstructure:"RECOMMENDATION_PRECOMPUTE"`
+	RecommendationPrecomputeAt time.Duration `mapstructure:"RECOMMENDATION_PRECOMPUTE_AT"`
+	// the chart views are refreshed every interval, they aren't refreshed if it is zero
+	ChartsRefreshInterval time.Duration `mapstructure:"CHARTS_REFRESH_INTERVAL"`
+	// Currency is the ISO 4217 currency of the prices and the sales, it is USD if it isn't set
+	Currency string `mapstructure:"CURRENCY"`
+	// TaxRate is the tax rate in basis points of the sales that aren't in a venue, like the box office sales
+	TaxRate int32 `mapstructure:"TAX_RATE"`
+	// the due memberships are renewed every interval, they aren't renewed if it is zero
+	MembershipRenewalInterval time.Duration `mapstructure:"MEMBERSHIP_RENEWAL_INTERVAL"`
+	// the pending deposit refunds of the closed private bookings are retried every interval, they aren't if it is zero
+	PrivateBookingRefundInterval time.Duration `mapstructure:"PRIVATE_BOOKING_REFUND_INTERVAL"`
+	// the emails are appended to the mailer file, they are kept in memory if it isn't set
+	MailerFile string `mapstructure:"MAILER_FILE"`
+	// a password reset token can be used until its duration passes, it is an hour if it isn't set
+	PasswordResetDuration time.Duration `mapstructure:"PASSWORD_RESET_DURATION"`
+	// the password reset requests are limited per email and per IP in every window, the defaults are used if they aren't set
+	PasswordResetEmailLimit int           `mapstructure:"PASSWORD_RESET_EMAIL_LIMIT"`
+	PasswordResetIPLimit    int           `mapstructure:"PASSWORD_RESET_IP_LIMIT"`
+	PasswordResetWindow     time.Duration `mapstructure:"PASSWORD_RESET_WINDOW"`
 }
 
 // LoadConfig loads the env variables from app.env
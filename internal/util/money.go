@@ -0,0 +1,165 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrCurrencyMismatch    = errors.New("amounts are in different currencies")
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+)
+
+// DefaultCurrency is the currency of the app if the config doesn't set one
+const DefaultCurrency = "USD"
+
+// basisPoints is one whole in basis points, the percents and the tax rates are given in basis points so 1800 is 18%
+const basisPoints = 10000
+
+// currencyExponents holds the ISO 4217 currencies the app supports with the digits of their minor units
+var currencyExponents = map[string]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"TRY": 2,
+	"CHF": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"BHD": 3,
+}
+
+// IsSupportedCurrency reports whether the currency is an ISO 4217 code the app supports
+func IsSupportedCurrency(currency string) bool {
+	_, ok := currencyExponents[currency]
+	return ok
+}
+
+// RoundingMode tells how a fraction of a minor unit is rounded
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds the halves away from zero, it is the rounding of the prices
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds the halves to the even minor unit, so the halves don't add up on large sums
+	RoundHalfEven
+	// RoundDown drops the fraction
+	RoundDown
+)
+
+// Money is an amount in the minor units of its currency, like cents of a dollar.
+// The amounts are never floats so they add up exactly
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney creates a new money with given minor units and currency
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Add returns the sum of the amounts, they must be in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of the amounts, they must be in the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+// Times returns the amount multiplied by n, like the price of n seats
+func (m Money) Times(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Percent returns the share of the amount in basis points rounded with given mode
+func (m Money) Percent(bp int64, mode RoundingMode) Money {
+	return Money{Amount: divRound(m.Amount*bp, basisPoints, mode), Currency: m.Currency}
+}
+
+// Share returns the part of the amount for num out of den rounded with given mode, like the unused days of a month.
+// den must be positive
+func (m Money) Share(num, den int64, mode RoundingMode) Money {
+	return Money{Amount: divRound(m.Amount*num, den, mode), Currency: m.Currency}
+}
+
+// Tax returns the tax of a net amount with given rate in basis points, rounded half up
+func (m Money) Tax(rate int32) Money {
+	return m.Percent(int64(rate), RoundHalfUp)
+}
+
+// SplitTax splits a gross amount that includes the tax into its net amount and tax
+func (m Money) SplitTax(rate int32) (net Money, tax Money) {
+	net = Money{Amount: divRound(m.Amount*basisPoints, basisPoints+int64(rate), RoundHalfUp), Currency: m.Currency}
+	tax = Money{Amount: m.Amount - net.Amount, Currency: m.Currency}
+	return net, tax
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// String formats the money in its major units, like 12.50 USD
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Decimal formats the money in its major units without the currency, like 12.50
+func (m Money) Decimal() string {
+	exp := currencyExponents[m.Currency]
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	if exp == 0 {
+		return fmt.Sprintf("%s%d", sign, amount)
+	}
+
+	unit := int64(1)
+	for i := 0; i < exp; i++ {
+		unit *= 10
+	}
+
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/unit, exp, amount%unit)
+}
+
+// divRound divides n by a positive d and rounds the quotient with given mode
+func divRound(n, d int64, mode RoundingMode) int64 {
+	q, r := n/d, n%d
+	if r == 0 || mode == RoundDown {
+		return q
+	}
+
+	// the quotient is truncated toward zero, the remainder has the sign of n
+	if r < 0 {
+		r = -r
+	}
+	step := int64(1)
+	if n < 0 {
+		step = -1
+	}
+
+	switch {
+	case 2*r > d:
+		return q + step
+	case 2*r < d:
+		return q
+	case mode == RoundHalfEven && q%2 == 0:
+		return q
+	default:
+		return q + step
+	}
+}
@@ -0,0 +1,105 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMoneyArithmetic tests the sums of the amounts
+func TestMoneyArithmetic(t *testing.T) {
+	a := NewMoney(1250, "USD")
+
+	sum, err := a.Add(NewMoney(750, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(2000, "USD"), sum)
+
+	diff, err := a.Sub(NewMoney(1500, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(-250, "USD"), diff)
+
+	_, err = a.Add(NewMoney(750, "EUR"))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = a.Sub(NewMoney(750, "EUR"))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	require.Equal(t, NewMoney(3750, "USD"), a.Times(3))
+	require.True(t, NewMoney(0, "USD").IsZero())
+}
+
+// TestMoneyPercent tests the rounding modes
+func TestMoneyPercent(t *testing.T) {
+	testCases := []struct {
+		name   string
+		amount int64
+		bp     int64
+		mode   RoundingMode
+		want   int64
+	}{
+		{name: "Exact", amount: 1000, bp: 2000, mode: RoundHalfUp, want: 200},
+		{name: "Half Up", amount: 25, bp: 1000, mode: RoundHalfUp, want: 3},
+		{name: "Half Up Negative", amount: -25, bp: 1000, mode: RoundHalfUp, want: -3},
+		{name: "Half Even Down", amount: 25, bp: 1000, mode: RoundHalfEven, want: 2},
+		{name: "Half Even Up", amount: 35, bp: 1000, mode: RoundHalfEven, want: 4},
+		{name: "Half Even Negative", amount: -25, bp: 1000, mode: RoundHalfEven, want: -2},
+		{name: "Below Half", amount: 24, bp: 1000, mode: RoundHalfUp, want: 2},
+		{name: "Above Half", amount: 26, bp: 1000, mode: RoundHalfEven, want: 3},
+		{name: "Down", amount: 29, bp: 1000, mode: RoundDown, want: 2},
+		{name: "Down Negative", amount: -29, bp: 1000, mode: RoundDown, want: -2},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMoney(tt.amount, "EUR").Percent(tt.bp, tt.mode)
+			require.Equal(t, NewMoney(tt.want, "EUR"), got)
+		})
+	}
+}
+
+// TestMoneyShare tests the shares of the amounts
+func TestMoneyShare(t *testing.T) {
+	m := NewMoney(1000, "USD")
+
+	// 10 of the 30 days of a month
+	require.Equal(t, NewMoney(333, "USD"), m.Share(10, 30, RoundHalfUp))
+	require.Equal(t, NewMoney(667, "USD"), m.Share(20, 30, RoundHalfUp))
+	require.Equal(t, NewMoney(666, "USD"), m.Share(20, 30, RoundDown))
+	require.Equal(t, m, m.Share(30, 30, RoundHalfUp))
+	require.True(t, m.Share(0, 30, RoundHalfUp).IsZero())
+	require.Equal(t, NewMoney(-500, "USD"), NewMoney(-1000, "USD").Share(1, 2, RoundHalfEven))
+}
+
+// TestMoneyTax tests the tax of the net amounts and the split of the gross amounts
+func TestMoneyTax(t *testing.T) {
+	net := NewMoney(1999, "TRY")
+	tax := net.Tax(1800)
+	require.Equal(t, NewMoney(360, "TRY"), tax)
+
+	gross, err := net.Add(tax)
+	require.NoError(t, err)
+
+	splitNet, splitTax := gross.SplitTax(1800)
+	require.Equal(t, net, splitNet)
+	require.Equal(t, tax, splitTax)
+
+	// a zero rate has no tax
+	require.True(t, net.Tax(0).IsZero())
+	splitNet, splitTax = net.SplitTax(0)
+	require.Equal(t, net, splitNet)
+	require.True(t, splitTax.IsZero())
+}
+
+// TestMoneyString tests the formatting of the minor units
+func TestMoneyString(t *testing.T) {
+	require.Equal(t, "12.50 USD", NewMoney(1250, "USD").String())
+	require.Equal(t, "0.05 EUR", NewMoney(5, "EUR").String())
+	require.Equal(t, "-3.07 GBP", NewMoney(-307, "GBP").String())
+	require.Equal(t, "1500 JPY", NewMoney(1500, "JPY").String())
+	require.Equal(t, "1.005 KWD", NewMoney(1005, "KWD").String())
+	require.Equal(t, "12.50", NewMoney(1250, "USD").Decimal())
+
+	require.True(t, IsSupportedCurrency("TRY"))
+	require.False(t, IsSupportedCurrency("XXX"))
+	require.False(t, IsSupportedCurrency("usd"))
+}
@@ -0,0 +1,37 @@
+package util
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewResetToken tests NewResetToken
+func TestNewResetToken(t *testing.T) {
+	token1, err := NewResetToken()
+	require.NoError(t, err)
+
+	b, err := base64.RawURLEncoding.DecodeString(token1)
+	require.NoError(t, err)
+	require.Len(t, b, resetTokenBytes)
+
+	token2, err := NewResetToken()
+	require.NoError(t, err)
+	require.NotEqual(t, token1, token2)
+}
+
+// TestHashResetToken tests HashResetToken
+func TestHashResetToken(t *testing.T) {
+	token, err := NewResetToken()
+	require.NoError(t, err)
+
+	hash := HashResetToken(token)
+	require.Len(t, hash, 64)
+	require.NotEqual(t, token, hash)
+	require.Equal(t, hash, HashResetToken(token))
+
+	other, err := NewResetToken()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, HashResetToken(other))
+}